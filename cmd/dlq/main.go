@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"service-courier/internal/app/deliveryopts"
+	dlqGateway "service-courier/internal/gateway/dlq"
+	orderGateway "service-courier/internal/gateway/order"
+	"service-courier/internal/model/dlq"
+	"service-courier/internal/pkg/db"
+	courierRepo "service-courier/internal/repository/courier"
+	deliveryRepo "service-courier/internal/repository/delivery"
+	inboxRepo "service-courier/internal/repository/inbox"
+	offerRepo "service-courier/internal/repository/offer"
+	outboxRepo "service-courier/internal/repository/outbox"
+	pendingRepo "service-courier/internal/repository/pending"
+	zoneRepo "service-courier/internal/repository/zone"
+	deliveryService "service-courier/internal/service/delivery"
+	orderChangedUC "service-courier/internal/service/order/changed"
+	"service-courier/internal/service/order/replay"
+	zoneService "service-courier/internal/service/zone"
+
+	"github.com/IBM/sarama"
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
+	"github.com/joho/godotenv"
+	flag "github.com/spf13/pflag"
+)
+
+const usage = `Usage:
+  dlq list                          показать сообщения из dead-letter топика
+  dlq replay --all                  повторно обработать все сообщения
+  dlq replay --ref 0:12 --ref 1:3   повторно обработать выбранные (partition:offset)`
+
+func main() {
+	err := godotenv.Load()
+	if err != nil {
+		log.Printf("Error loading .env file: %s", err.Error())
+	}
+
+	if len(os.Args) < 2 {
+		fmt.Println(usage)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	switch os.Args[1] {
+	case "list":
+		err = runList(ctx)
+	case "replay":
+		err = runReplay(ctx, os.Args[2:])
+	default:
+		fmt.Println(usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		log.Fatalf("dlq %s: %v", os.Args[1], err)
+	}
+}
+
+func runList(ctx context.Context) error {
+	reader, closeReader, err := newReader()
+	if err != nil {
+		return err
+	}
+	defer closeReader()
+
+	messages, err := replay.NewService(reader, nil, nil).List(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "REF\tORIGINAL\tFAILED_AT\tKEY\tERROR\tVALUE")
+	for _, m := range messages {
+		failedAt := "-"
+		if !m.FailedAt.IsZero() {
+			failedAt = m.FailedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%d:%d\t%s/%d/%d\t%s\t%s\t%s\t%s\n",
+			m.Partition, m.Offset,
+			m.Original.Topic, m.Original.Partition, m.Original.Offset,
+			failedAt, m.Key, m.Error, string(m.Value))
+	}
+
+	return w.Flush()
+}
+
+func runReplay(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("replay", flag.ContinueOnError)
+	all := fs.Bool("all", false, "повторно обработать все сообщения")
+	rawRefs := fs.StringSlice("ref", nil, "позиция сообщения partition:offset")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	refs, err := parseRefs(*rawRefs)
+	if err != nil {
+		return err
+	}
+	if len(refs) == 0 && !*all {
+		return fmt.Errorf("specify --ref or --all")
+	}
+
+	reader, closeReader, err := newReader()
+	if err != nil {
+		return err
+	}
+	defer closeReader()
+
+	usecase, orders, closeUsecase, err := newUsecase()
+	if err != nil {
+		return err
+	}
+	defer closeUsecase()
+
+	results, err := replay.NewService(reader, usecase, orders).Replay(ctx, refs)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+			log.Printf("replay %d:%d failed: %v", r.Message.Partition, r.Message.Offset, r.Err)
+			continue
+		}
+		log.Printf("replay %d:%d ok", r.Message.Partition, r.Message.Offset)
+	}
+
+	log.Printf("replayed %d messages, %d failed", len(results)-failed, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d messages failed", failed)
+	}
+	return nil
+}
+
+func parseRefs(raw []string) ([]dlq.Ref, error) {
+	refs := make([]dlq.Ref, 0, len(raw))
+	for _, r := range raw {
+		partition, offset, ok := strings.Cut(r, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid ref %q, expected partition:offset", r)
+		}
+		p, err := strconv.ParseInt(partition, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid partition in ref %q", r)
+		}
+		o, err := strconv.ParseInt(offset, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid offset in ref %q", r)
+		}
+		refs = append(refs, dlq.Ref{Partition: int32(p), Offset: o})
+	}
+	return refs, nil
+}
+
+func newReader() (*dlqGateway.Reader, func(), error) {
+	config := sarama.NewConfig()
+	config.Version = sarama.V2_1_0_0
+
+	client, err := sarama.NewClient([]string{resolveBroker()}, config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create kafka client: %w", err)
+	}
+
+	consumer, err := sarama.NewConsumerFromClient(client)
+	if err != nil {
+		_ = client.Close()
+		return nil, nil, fmt.Errorf("create kafka consumer: %w", err)
+	}
+
+	closeFn := func() {
+		if err := consumer.Close(); err != nil {
+			log.Printf("kafka consumer close error: %v", err)
+		}
+		if err := client.Close(); err != nil {
+			log.Printf("kafka client close error: %v", err)
+		}
+	}
+
+	return dlqGateway.NewReader(client, consumer, resolveDLQTopic()), closeFn, nil
+}
+
+// newUsecase собирает обработку order.changed и возвращает шлюз сервиса заказов, по которому replay сверяет статус
+func newUsecase() (*orderChangedUC.Usecase, *orderGateway.Gateway, func(), error) {
+	dbPool := db.MustInitDB()
+	ctxGetter := trmpgx.DefaultCtxGetter
+
+	courierRepository := courierRepo.NewCourierRepository(dbPool, ctxGetter)
+	deliveryRepository := deliveryRepo.NewDeliveryRepository(dbPool, ctxGetter)
+	outboxRepository := outboxRepo.NewOutboxRepository(dbPool, ctxGetter)
+	inboxRepository := inboxRepo.NewInboxRepository(dbPool, ctxGetter)
+
+	txManager := manager.Must(trmpgx.NewDefaultFactory(dbPool))
+
+	pendingRepository := pendingRepo.NewPendingRepository(dbPool, ctxGetter)
+	offerRepository := offerRepo.NewOfferRepository(dbPool, ctxGetter)
+	zoneRepository := zoneRepo.NewZoneRepository(dbPool, ctxGetter)
+	zoneSvc := zoneService.NewZoneService(zoneRepository, courierRepository, txManager)
+
+	orderClient, err := orderGateway.NewClient(orderGateway.LoadConfig())
+	if err != nil {
+		dbPool.Close()
+		return nil, nil, nil, fmt.Errorf("init order gateway: %w", err)
+	}
+
+	deliverySvc := deliveryService.NewDeliveryService(
+		deliveryRepository,
+		courierRepository,
+		deliveryService.NewTransportFactoryFromConfig(deliveryService.LoadTransportConfig()),
+		txManager,
+		deliveryService.RealClock{},
+		deliveryopts.Options(orderClient.Gateway, outboxRepository, pendingRepository, offerRepository, zoneSvc)...,
+	)
+
+	closeFn := func() {
+		if err := orderClient.Close(); err != nil {
+			log.Printf("order client close error: %v", err)
+		}
+		dbPool.Close()
+	}
+
+	return orderChangedUC.NewUsecase(deliverySvc, inboxRepository, txManager), orderClient.Gateway, closeFn, nil
+}
+
+func resolveBroker() string {
+	broker := os.Getenv("KAFKA_BROKER")
+	if broker == "" {
+		return "localhost:9092"
+	}
+	return broker
+}
+
+func resolveDLQTopic() string {
+	if topic := os.Getenv("KAFKA_ORDER_DLQ_TOPIC"); topic != "" {
+		return topic
+	}
+
+	topic := os.Getenv("KAFKA_ORDER_TOPIC")
+	if topic == "" {
+		topic = "order.status.changed"
+	}
+	return topic + ".dlq"
+}
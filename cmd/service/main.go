@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"log"
+	"net"
 	"net/http"
 	_ "net/http/pprof"
 	"os"
@@ -13,18 +14,31 @@ import (
 	"syscall"
 	"time"
 
+	"service-courier/internal/app/deliveryopts"
 	orderGateway "service-courier/internal/gateway/order"
 	"service-courier/internal/handler/common"
 	courierHandler "service-courier/internal/handler/courier"
 	deliveryHandler "service-courier/internal/handler/delivery"
+	rpcHandler "service-courier/internal/handler/rpc"
+	shiftHandler "service-courier/internal/handler/shift"
+	zoneHandler "service-courier/internal/handler/zone"
 	"service-courier/internal/metrics"
 	ratelimitMiddleware "service-courier/internal/middleware"
 	db "service-courier/internal/pkg/db"
 	"service-courier/internal/pkg/limiter"
+	courierPb "service-courier/internal/proto/courier"
 	courierRepo "service-courier/internal/repository/courier"
+	cursorRepo "service-courier/internal/repository/cursor"
 	deliveryRepo "service-courier/internal/repository/delivery"
+	offerRepo "service-courier/internal/repository/offer"
+	outboxRepo "service-courier/internal/repository/outbox"
+	pendingRepo "service-courier/internal/repository/pending"
+	shiftRepo "service-courier/internal/repository/shift"
+	zoneRepo "service-courier/internal/repository/zone"
 	courierService "service-courier/internal/service/courier"
 	deliveryService "service-courier/internal/service/delivery"
+	shiftService "service-courier/internal/service/shift"
+	zoneService "service-courier/internal/service/zone"
 
 	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
 	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
@@ -34,6 +48,10 @@ import (
 	"github.com/joho/godotenv"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	flag "github.com/spf13/pflag"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
 func main() {
@@ -44,26 +62,21 @@ func main() {
 
 	dbPool := db.MustInitDB()
 
-	courierRepository := courierRepo.NewCourierRepository(dbPool)
-	courierSvc := courierService.NewCourierService(courierRepository)
-	courier := courierHandler.NewCourierHandler(courierSvc)
-
 	ctxGetter := trmpgx.DefaultCtxGetter
+	courierRepository := courierRepo.NewCourierRepository(dbPool, ctxGetter)
 	deliveryRepository := deliveryRepo.NewDeliveryRepository(dbPool, ctxGetter)
-	deliveryTransportFactory := deliveryService.NewTransportFactory()
+	outboxRepository := outboxRepo.NewOutboxRepository(dbPool, ctxGetter)
+	deliveryTransportFactory := deliveryService.NewTransportFactoryFromConfig(deliveryService.LoadTransportConfig())
 
 	txManager := manager.Must(trmpgx.NewDefaultFactory(dbPool))
 
 	clock := deliveryService.RealClock{}
 
-	deliverySvc := deliveryService.NewDeliveryService(
-		deliveryRepository,
-		courierRepository,
-		deliveryTransportFactory,
-		txManager,
-		clock,
-	)
-	delivery := deliveryHandler.NewDeliveryHandler(deliverySvc)
+	pendingRepository := pendingRepo.NewPendingRepository(dbPool, ctxGetter)
+	offerRepository := offerRepo.NewOfferRepository(dbPool, ctxGetter)
+	zoneRepository := zoneRepo.NewZoneRepository(dbPool, ctxGetter)
+	zoneSvc := zoneService.NewZoneService(zoneRepository, courierRepository, txManager)
+	zone := zoneHandler.NewZoneHandler(zoneSvc)
 
 	orderCfg := orderGateway.LoadConfig()
 	orderClient, err := orderGateway.NewClient(orderCfg)
@@ -76,13 +89,33 @@ func main() {
 		}
 	}()
 
-	orderWorker := deliveryService.NewOrderWorker(deliverySvc, orderClient.Gateway, clock)
+	deliverySvc := deliveryService.NewDeliveryService(
+		deliveryRepository,
+		courierRepository,
+		deliveryTransportFactory,
+		txManager,
+		clock,
+		deliveryopts.Options(orderClient.Gateway, outboxRepository, pendingRepository, offerRepository, zoneSvc)...,
+	)
+	delivery := deliveryHandler.NewDeliveryHandler(deliverySvc)
+
+	courierSvc := courierService.NewCourierService(courierRepository, courierService.WithAvailabilityNotifier(deliverySvc))
+	courier := courierHandler.NewCourierHandler(courierSvc)
+
+	shiftRepository := shiftRepo.NewShiftRepository(dbPool, ctxGetter)
+	shiftSvc := shiftService.NewShiftService(shiftRepository, courierRepository, zoneRepository, clock)
+	shift := shiftHandler.NewShiftHandler(shiftSvc)
+
+	cursorRepository := cursorRepo.NewCursorRepository(dbPool)
+	orderWorker := deliveryService.NewOrderWorker(deliverySvc, orderClient.Gateway, cursorRepository, clock, orderCfg.Lookback)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	releaseInterval := resolveReleaseInterval()
 	worker := deliveryService.NewWorker(deliverySvc, releaseInterval)
+	pendingWorker := deliveryService.NewPendingWorker(deliverySvc, resolvePendingRetryInterval())
+	shiftScheduler := shiftService.NewScheduler(shiftSvc, resolveShiftSchedulerInterval())
 
 	var wg sync.WaitGroup
 	wg.Add(1)
@@ -95,25 +128,40 @@ func main() {
 		defer wg.Done()
 		orderWorker.Start(ctx)
 	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		shiftScheduler.Start(ctx)
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		pendingWorker.Start(ctx)
+	}()
 
 	limit := limiter.NewTokenBucket(10, 5)
 
 	srv := &http.Server{
 		Addr:    ":" + resolvePort(),
-		Handler: initRouter(courier, delivery, limit),
+		Handler: initRouter(courier, delivery, shift, zone, limit),
 	}
+	// SSE-подписки не завершаются сами, без этого Shutdown ждал бы их до таймаута
+	srv.RegisterOnShutdown(deliverySvc.StopEventStreams)
 
-	serverErr := make(chan error, 1)
+	// по месту на каждый сервер (HTTP, gRPC, pprof): ошибку читают один раз, остальные не должны блокировать
+	// отправителей, а закрывать канал нельзя - в него пишут несколько горутин
+	serverErr := make(chan error, 3)
 	go func() {
-		defer close(serverErr)
 		log.Printf("Server started on %s\n", srv.Addr)
 		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			serverErr <- err
 		}
 	}()
 
+	grpcSrv, grpcHealth := startGRPCServer(rpcHandler.NewServer(courierSvc, deliverySvc), serverErr)
+
 	startPprofServer(serverErr)
-	waitGracefulShutdown(cancel, srv, dbPool, serverErr, &wg)
+	waitGracefulShutdown(cancel, srv, grpcSrv, grpcHealth, dbPool, serverErr, &wg)
 
 	log.Println("Shutting down service-courier")
 }
@@ -138,6 +186,8 @@ func resolvePort() string {
 func waitGracefulShutdown(
 	cancel context.CancelFunc,
 	srv *http.Server,
+	grpcSrv *grpc.Server,
+	grpcHealth *health.Server,
 	dbPool *pgxpool.Pool,
 	serverErr <-chan error,
 	wg *sync.WaitGroup,
@@ -163,6 +213,8 @@ func waitGracefulShutdown(
 		log.Println("HTTP server stopped")
 	}
 
+	stopGRPCServer(shutdownCtx, grpcSrv, grpcHealth)
+
 	log.Println("Waiting for workers to stop...")
 	workerDone := make(chan struct{})
 	go func() {
@@ -185,6 +237,8 @@ func waitGracefulShutdown(
 func initRouter(
 	courier *courierHandler.Handler,
 	delivery *deliveryHandler.Handler,
+	shift *shiftHandler.Handler,
+	zone *zoneHandler.Handler,
 	limit *limiter.TokenBucket,
 ) *chi.Mux {
 	r := chi.NewRouter()
@@ -196,16 +250,65 @@ func initRouter(
 	r.Head("/healthcheck", common.HealthCheck)
 
 	r.Get("/couriers", courier.GetAll)
+	r.Post("/couriers/import", courier.Import)
+	r.Get("/couriers/export", courier.Export)
+	r.Get("/couriers/stats", delivery.Stats)
 
 	r.Route("/courier", func(r chi.Router) {
 		r.Get("/{id}", courier.Get)
 		r.Post("/", courier.Create)
 		r.Put("/", courier.Update)
+		r.Patch("/{id}", courier.Patch)
+		r.Delete("/{id}", courier.Delete)
+		r.Post("/{id}/deactivate", courier.Deactivate)
+		r.Post("/{id}/activate", courier.Activate)
+		r.Post("/{id}/erase", courier.Erase)
+		r.Put("/{id}/location", courier.UpdateLocation)
+		r.Get("/{id}/zones", zone.GetCourierZones)
+		r.Get("/{id}/offers", delivery.OfferStats)
+		r.Get("/{id}/stats", delivery.CourierStats)
+		r.Get("/{id}/events", delivery.CourierEvents)
+		r.Put("/{id}/zones", zone.SetCourierZones)
+	})
+
+	r.Get("/shifts", shift.List)
+
+	r.Route("/shift", func(r chi.Router) {
+		r.Get("/{id}", shift.Get)
+		r.Post("/", shift.Create)
+		r.Put("/{id}", shift.Update)
+		r.Delete("/{id}", shift.Delete)
+	})
+
+	r.Get("/zones", zone.List)
+	r.Get("/zones/geojson", zone.Export)
+	r.Post("/zones/geojson", zone.Import)
+
+	r.Route("/zone", func(r chi.Router) {
+		r.Get("/{id}", zone.Get)
+		r.Post("/", zone.Create)
+		r.Put("/{id}", zone.Update)
+		r.Delete("/{id}", zone.Delete)
 	})
 
+	r.Get("/deliveries", delivery.List)
+
 	r.Route("/delivery", func(r chi.Router) {
 		r.Post("/assign", delivery.Assign)
 		r.Post("/unassign", delivery.Unassign)
+
+		r.Route("/{order_id}", func(r chi.Router) {
+			r.Get("/", delivery.Get)
+			r.Get("/history", delivery.History)
+			r.Get("/events", delivery.Events)
+			r.Post("/accept", delivery.Accept)
+			r.Post("/decline", delivery.Decline)
+			r.Post("/pickup", delivery.PickUp)
+			r.Post("/in-transit", delivery.StartTransit)
+			r.Post("/deliver", delivery.Deliver)
+			r.Post("/fail", delivery.Fail)
+			r.Post("/return", delivery.Return)
+		})
 	})
 
 	r.Handle("/metrics", promhttp.Handler())
@@ -225,6 +328,84 @@ func resolveReleaseInterval() time.Duration {
 	return time.Duration(sec) * time.Second
 }
 
+func resolveShiftSchedulerInterval() time.Duration {
+	env := os.Getenv("SHIFT_SCHEDULER_INTERVAL_SECONDS")
+	if env == "" {
+		return 30 * time.Second
+	}
+	sec, err := strconv.Atoi(env)
+	if err != nil || sec <= 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(sec) * time.Second
+}
+
+func resolvePendingRetryInterval() time.Duration {
+	env := os.Getenv("PENDING_RETRY_INTERVAL_SECONDS")
+	if env == "" {
+		return 5 * time.Second
+	}
+	sec, err := strconv.Atoi(env)
+	if err != nil || sec <= 0 {
+		return 5 * time.Second
+	}
+	return time.Duration(sec) * time.Second
+}
+
+// startGRPCServer поднимает gRPC API на GRPC_PORT вместе с health и reflection
+func startGRPCServer(server *rpcHandler.Server, errChan chan error) (*grpc.Server, *health.Server) {
+	grpcSrv := grpc.NewServer()
+	courierPb.RegisterCourierServiceServer(grpcSrv, server)
+
+	healthSrv := health.NewServer()
+	healthSrv.SetServingStatus(courierPb.CourierService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(grpcSrv, healthSrv)
+
+	reflection.Register(grpcSrv)
+
+	addr := ":" + resolveGRPCPort()
+	go func() {
+		lis, err := net.Listen("tcp", addr)
+		if err != nil {
+			errChan <- err
+			return
+		}
+		log.Printf("gRPC server started on %s\n", addr)
+		if err := grpcSrv.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			errChan <- err
+		}
+	}()
+
+	return grpcSrv, healthSrv
+}
+
+// stopGRPCServer дожидается текущих вызовов, пока не истечет ctx, затем обрывает оставшиеся
+func stopGRPCServer(ctx context.Context, grpcSrv *grpc.Server, healthSrv *health.Server) {
+	healthSrv.Shutdown()
+
+	stopped := make(chan struct{})
+	go func() {
+		grpcSrv.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		log.Println("gRPC server stopped")
+	case <-ctx.Done():
+		grpcSrv.Stop()
+		log.Println("gRPC server shutdown timeout - connections closed")
+	}
+}
+
+func resolveGRPCPort() string {
+	port := os.Getenv("GRPC_PORT")
+	if port == "" {
+		return "50051"
+	}
+	return port
+}
+
 func startPprofServer(errChan chan error) *http.Server {
 	r := chi.NewRouter()
 
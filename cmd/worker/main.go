@@ -6,14 +6,22 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
+	"service-courier/internal/app/deliveryopts"
+	orderGateway "service-courier/internal/gateway/order"
 	orderChangedHandler "service-courier/internal/handler/queues/order/changed"
 	"service-courier/internal/pkg/db"
 	courierRepo "service-courier/internal/repository/courier"
 	deliveryRepo "service-courier/internal/repository/delivery"
+	inboxRepo "service-courier/internal/repository/inbox"
+	offerRepo "service-courier/internal/repository/offer"
+	outboxRepo "service-courier/internal/repository/outbox"
+	pendingRepo "service-courier/internal/repository/pending"
+	zoneRepo "service-courier/internal/repository/zone"
 	deliveryService "service-courier/internal/service/delivery"
 	orderChangedUC "service-courier/internal/service/order/changed"
+	outboxRelay "service-courier/internal/service/outbox"
+	zoneService "service-courier/internal/service/zone"
 
 	"github.com/IBM/sarama"
 	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
@@ -40,6 +48,11 @@ func main() {
 		topic = "order.status.changed"
 	}
 
+	dlqTopic := os.Getenv("KAFKA_ORDER_DLQ_TOPIC")
+	if dlqTopic == "" {
+		dlqTopic = topic + ".dlq"
+	}
+
 	groupID := os.Getenv("KAFKA_GROUP_ID")
 	if groupID == "" {
 		groupID = "my-group-id"
@@ -49,8 +62,8 @@ func main() {
 	config := sarama.NewConfig()
 	config.Version = sarama.V2_1_0_0
 	config.Consumer.Offsets.Initial = sarama.OffsetOldest
-	config.Consumer.Offsets.AutoCommit.Enable = true
-	config.Consumer.Offsets.AutoCommit.Interval = 1 * time.Second
+	// offset фиксируется вручную после успешной обработки или отправки в DLQ
+	config.Consumer.Offsets.AutoCommit.Enable = false
 
 	kafkaClient, err := sarama.NewConsumerGroup([]string{broker}, groupID, config)
 	if err != nil {
@@ -64,32 +77,70 @@ func main() {
 		}
 	}()
 
+	producerConfig := sarama.NewConfig()
+	producerConfig.Version = sarama.V2_1_0_0
+	producerConfig.Producer.RequiredAcks = sarama.WaitForAll
+	producerConfig.Producer.Idempotent = true
+	producerConfig.Producer.Return.Successes = true
+	producerConfig.Net.MaxOpenRequests = 1
+
+	producer, err := sarama.NewSyncProducer([]string{broker}, producerConfig)
+	if err != nil {
+		log.Printf("unable to create kafka producer: %v", err)
+		cancel()
+		return
+	}
+	defer func() {
+		if err := producer.Close(); err != nil {
+			log.Printf("kafka producer close error: %v", err)
+		}
+	}()
+
 	dbPool := db.MustInitDB()
 
 	ctxGetter := trmpgx.DefaultCtxGetter
 
-	courierRepository := courierRepo.NewCourierRepository(dbPool)
+	courierRepository := courierRepo.NewCourierRepository(dbPool, ctxGetter)
 
 	deliveryRepository := deliveryRepo.NewDeliveryRepository(dbPool, ctxGetter)
-	deliveryTransportFactory := deliveryService.NewTransportFactory()
+	outboxRepository := outboxRepo.NewOutboxRepository(dbPool, ctxGetter)
+	inboxRepository := inboxRepo.NewInboxRepository(dbPool, ctxGetter)
+	deliveryTransportFactory := deliveryService.NewTransportFactoryFromConfig(deliveryService.LoadTransportConfig())
 
 	txManager := manager.Must(trmpgx.NewDefaultFactory(dbPool))
 
+	pendingRepository := pendingRepo.NewPendingRepository(dbPool, ctxGetter)
+	offerRepository := offerRepo.NewOfferRepository(dbPool, ctxGetter)
+	zoneRepository := zoneRepo.NewZoneRepository(dbPool, ctxGetter)
+	zoneSvc := zoneService.NewZoneService(zoneRepository, courierRepository, txManager)
+
 	clock := deliveryService.RealClock{}
 
+	orderClient, err := orderGateway.NewClient(orderGateway.LoadConfig())
+	if err != nil {
+		log.Printf("unable to init order gateway: %v", err)
+		return
+	}
+	defer func() {
+		if err := orderClient.Close(); err != nil {
+			log.Printf("order client close error: %v", err)
+		}
+	}()
+
 	deliverySvc := deliveryService.NewDeliveryService(
 		deliveryRepository,
 		courierRepository,
 		deliveryTransportFactory,
 		txManager,
 		clock,
+		deliveryopts.Options(orderClient.Gateway, outboxRepository, pendingRepository, offerRepository, zoneSvc)...,
 	)
 
 	// usecase
-	orderChangedUsecase := orderChangedUC.NewUsecase(deliverySvc)
+	orderChangedUsecase := orderChangedUC.NewUsecase(deliverySvc, inboxRepository, txManager)
 
 	// handler
-	orderChangeHandler := orderChangedHandler.NewHandler(orderChangedUsecase)
+	orderChangeHandler := orderChangedHandler.NewHandler(orderChangedUsecase, producer, dlqTopic)
 
 	go func() {
 		for {
@@ -104,6 +155,9 @@ func main() {
 		}
 	}()
 
+	relay := outboxRelay.NewRelay(outboxRepository, txManager, producer, outboxRelay.LoadConfig())
+	go relay.Start(ctx)
+
 	log.Println("Assign worker started")
 
 	waitGracefulShutdown(cancel)
@@ -0,0 +1,104 @@
+// Package deliveryopts собирает настройки сервиса доставок из окружения,
+// общие для всех процессов: API, воркера и утилиты DLQ.
+package deliveryopts
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"service-courier/internal/gateway/geocoder"
+	orderGateway "service-courier/internal/gateway/order"
+	"service-courier/internal/pkg/geo"
+	offerRepo "service-courier/internal/repository/offer"
+	outboxRepo "service-courier/internal/repository/outbox"
+	pendingRepo "service-courier/internal/repository/pending"
+	deliveryService "service-courier/internal/service/delivery"
+)
+
+// Options возвращает опции сервиса доставок по переменным окружения
+func Options(
+	orders *orderGateway.Gateway,
+	outbox *outboxRepo.Repository,
+	pending *pendingRepo.Repository,
+	offers *offerRepo.Repository,
+	zones deliveryService.ZoneResolver,
+) []deliveryService.Option {
+	opts := []deliveryService.Option{
+		deliveryService.WithDistanceStrategy(geo.NewDistanceStrategy(os.Getenv("DISTANCE_STRATEGY"))),
+		deliveryService.WithOutbox(outbox, DeliveryTopic()),
+		deliveryService.WithPendingQueue(pending, PendingSLA(), AssignmentExpiredTopic()),
+		deliveryService.WithPriorityPolicies(deliveryService.LoadPriorityPolicies()),
+		deliveryService.WithAssignmentStrategy(deliveryService.LoadAssignmentStrategy(deliveryService.LoadTransportConfig())),
+		deliveryService.WithEventStream(deliveryService.LoadEventStreamConfig()),
+	}
+
+	// batch - заказы копятся в очереди и распределяются пачками
+	if os.Getenv("DISPATCH_MODE") == "batch" {
+		opts = append(opts, deliveryService.WithBatchDispatch(deliveryService.LoadBatchConfig()))
+	}
+
+	// on - курьер принимает или отклоняет заказ, прежде чем стать занятым
+	if os.Getenv("DELIVERY_OFFERS") == "on" {
+		opts = append(opts, deliveryService.WithOffers(offers, deliveryService.LoadOfferTimeout()))
+	}
+
+	geocoderCfg := geocoder.LoadConfig()
+	if geocoderCfg.URL == "" {
+		log.Println("GEOCODER_URL is not set, nearest courier assignment is disabled")
+		if enabled, _ := ZoneMatching(); enabled {
+			log.Println("GEOCODER_URL is not set, zone matching is disabled")
+		}
+		return opts
+	}
+
+	locator := deliveryService.NewOrderLocator(orders, geocoder.NewClient(geocoderCfg))
+	opts = append(opts, deliveryService.WithLocator(locator))
+
+	if enabled, fallback := ZoneMatching(); enabled {
+		opts = append(opts, deliveryService.WithZones(zones, fallback))
+	}
+	return opts
+}
+
+// ZoneMatching читает ZONE_MATCHING: off (по умолчанию), zone или neighbors
+func ZoneMatching() (enabled bool, fallbackToNeighbors bool) {
+	switch os.Getenv("ZONE_MATCHING") {
+	case "zone":
+		return true, false
+	case "neighbors":
+		return true, true
+	default:
+		return false, false
+	}
+}
+
+func DeliveryTopic() string {
+	topic := os.Getenv("KAFKA_DELIVERY_TOPIC")
+	if topic == "" {
+		return "delivery.status.changed"
+	}
+	return topic
+}
+
+// PendingSLA - сколько заказ ждет свободного курьера в очереди
+func PendingSLA() time.Duration {
+	env := os.Getenv("PENDING_ASSIGNMENT_SLA_MINUTES")
+	if env == "" {
+		return 15 * time.Minute
+	}
+	minutes, err := strconv.Atoi(env)
+	if err != nil || minutes <= 0 {
+		return 15 * time.Minute
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+func AssignmentExpiredTopic() string {
+	topic := os.Getenv("KAFKA_ASSIGNMENT_EXPIRED_TOPIC")
+	if topic == "" {
+		return "delivery.assignment.expired"
+	}
+	return topic
+}
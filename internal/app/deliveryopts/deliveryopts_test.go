@@ -0,0 +1,40 @@
+package deliveryopts_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"service-courier/internal/app/deliveryopts"
+)
+
+func TestZoneMatching(t *testing.T) {
+	for value, want := range map[string][2]bool{
+		"":          {false, false},
+		"off":       {false, false},
+		"zone":      {true, false},
+		"neighbors": {true, true},
+	} {
+		t.Setenv("ZONE_MATCHING", value)
+		enabled, fallback := deliveryopts.ZoneMatching()
+		assert.Equal(t, want, [2]bool{enabled, fallback}, "ZONE_MATCHING=%q", value)
+	}
+}
+
+func TestPendingSLA(t *testing.T) {
+	t.Setenv("PENDING_ASSIGNMENT_SLA_MINUTES", "30")
+	assert.Equal(t, 30*time.Minute, deliveryopts.PendingSLA())
+
+	// некорректное значение заменяется значением по умолчанию
+	t.Setenv("PENDING_ASSIGNMENT_SLA_MINUTES", "-1")
+	assert.Equal(t, 15*time.Minute, deliveryopts.PendingSLA())
+}
+
+func TestTopicsDefaults(t *testing.T) {
+	t.Setenv("KAFKA_DELIVERY_TOPIC", "")
+	t.Setenv("KAFKA_ASSIGNMENT_EXPIRED_TOPIC", "custom.expired")
+
+	assert.Equal(t, "delivery.status.changed", deliveryopts.DeliveryTopic())
+	assert.Equal(t, "custom.expired", deliveryopts.AssignmentExpiredTopic())
+}
@@ -0,0 +1,133 @@
+package courier
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"service-courier/internal/model/courier"
+	"strings"
+)
+
+const (
+	// MaxListLimit - наибольший размер страницы списка курьеров
+	MaxListLimit = 500
+	maxSearchLen = 100
+)
+
+// ListRequest - параметры списка курьеров, общие для HTTP и gRPC API
+type ListRequest struct {
+	Statuses   []string
+	Transports []string
+	// Search - подстрока имени или телефона
+	Search string
+	// ZoneID - 0 значит без фильтра по зоне
+	ZoneID int64
+	// Sort - id или name, пусто - id
+	Sort string
+	// Order - asc или desc, пусто - asc
+	Order string
+	// Limit - 0 значит размер страницы по умолчанию
+	Limit  uint64
+	Cursor string
+}
+
+// ToFilter проверяет параметры и переводит их в фильтр списка
+func (r ListRequest) ToFilter() (courier.ListFilter, error) {
+	filter := courier.ListFilter{Sort: courier.SortByID}
+
+	for _, item := range r.Statuses {
+		item = strings.TrimSpace(item)
+		if err := ValidateStatus(item); err != nil {
+			return filter, err
+		}
+		filter.Statuses = append(filter.Statuses, courier.CourierStatus(item))
+	}
+	for _, item := range r.Transports {
+		item = strings.TrimSpace(item)
+		if err := ValidateTransportType(item); err != nil {
+			return filter, err
+		}
+		filter.Transports = append(filter.Transports, courier.TransportType(item))
+	}
+	if v := strings.TrimSpace(r.Search); v != "" {
+		if len(v) > maxSearchLen {
+			return filter, errors.New("search query is too long")
+		}
+		filter.Search = v
+	}
+	if r.ZoneID < 0 {
+		return filter, errors.New("invalid zone_id")
+	}
+	if r.ZoneID > 0 {
+		id := r.ZoneID
+		filter.ZoneID = &id
+	}
+
+	if r.Sort != "" {
+		filter.Sort = courier.SortField(r.Sort)
+		if !filter.Sort.IsValid() {
+			return filter, errors.New("invalid sort, expected id or name")
+		}
+	}
+	switch r.Order {
+	case "", "asc":
+	case "desc":
+		filter.Desc = true
+	default:
+		return filter, errors.New("invalid order, expected asc or desc")
+	}
+
+	if r.Limit > MaxListLimit {
+		return filter, fmt.Errorf("invalid limit, expected 1..%d", MaxListLimit)
+	}
+	filter.Limit = r.Limit
+
+	if r.Cursor != "" {
+		after, err := decodeCursor(r.Cursor, filter.Sort, filter.Desc)
+		if err != nil {
+			return filter, err
+		}
+		filter.After = &after
+	}
+
+	return filter, nil
+}
+
+// NextCursor возвращает курсор следующей страницы для клиента, пусто - страница последняя
+func NextCursor(filter courier.ListFilter, page courier.Page) string {
+	if page.Next == nil {
+		return ""
+	}
+	return encodeCursor(filter.Sort, filter.Desc, *page.Next)
+}
+
+// pageCursor - курсор страницы для клиента. Сортировка зашита в курсор,
+// чтобы его нельзя было применить к списку с другим порядком.
+type pageCursor struct {
+	Sort courier.SortField `json:"s"`
+	Desc bool              `json:"d,omitempty"`
+	Name string            `json:"n,omitempty"`
+	ID   int64             `json:"id"`
+}
+
+func encodeCursor(sort courier.SortField, desc bool, c courier.Cursor) string {
+	pc := pageCursor{Sort: sort, Desc: desc, ID: c.ID}
+	if sort == courier.SortByName {
+		pc.Name = c.Name
+	}
+	data, _ := json.Marshal(pc)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(value string, sort courier.SortField, desc bool) (courier.Cursor, error) {
+	var pc pageCursor
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || json.Unmarshal(data, &pc) != nil {
+		return courier.Cursor{}, errors.New("invalid cursor")
+	}
+	if pc.Sort != sort || pc.Desc != desc {
+		return courier.Cursor{}, errors.New("cursor does not match sort and order")
+	}
+	return courier.Cursor{Name: pc.Name, ID: pc.ID}, nil
+}
@@ -0,0 +1,140 @@
+package courier
+
+import (
+	"fmt"
+	"service-courier/internal/model/courier"
+	"unicode"
+)
+
+// CreateRequest запрос на создание курьера
+type CreateRequest struct {
+	Name          string `json:"name"`
+	Phone         string `json:"phone"`
+	Status        string `json:"status"`
+	TransportType string `json:"transport_type"`
+}
+
+// UpdateRequest запрос на обновление курьера, пустые поля не меняются
+type UpdateRequest struct {
+	ID            int64  `json:"id"`
+	Name          string `json:"name"`
+	Phone         string `json:"phone"`
+	Status        string `json:"status"`
+	TransportType string `json:"transport_type"`
+}
+
+func (r CreateRequest) Validate() error {
+	if err := ValidateName(r.Name); err != nil {
+		return err
+	}
+	if err := ValidatePhone(r.Phone); err != nil {
+		return err
+	}
+	if err := ValidateStatus(r.Status); err != nil {
+		return err
+	}
+	if err := ValidateTransportType(r.TransportType); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r UpdateRequest) Validate() error {
+	if r.ID <= 0 {
+		return fmt.Errorf("invalid id")
+	}
+
+	if r.Name == "" && r.Phone == "" && r.Status == "" && r.TransportType == "" {
+		return fmt.Errorf("all fields are empty")
+	}
+
+	if r.Name != "" {
+		if err := ValidateName(r.Name); err != nil {
+			return err
+		}
+	}
+	if r.Phone != "" {
+		if err := ValidatePhone(r.Phone); err != nil {
+			return err
+		}
+	}
+	if r.Status != "" {
+		if err := ValidateStatus(r.Status); err != nil {
+			return err
+		}
+	}
+	if r.TransportType != "" {
+		if err := ValidateTransportType(r.TransportType); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r CreateRequest) ToModel() courier.Courier {
+	return courier.Courier{
+		Name:          r.Name,
+		Phone:         r.Phone,
+		Status:        courier.CourierStatus(r.Status),
+		TransportType: courier.TransportType(r.TransportType),
+	}
+}
+
+func (r UpdateRequest) ToModel() courier.Courier {
+	return courier.Courier{
+		ID:            r.ID,
+		Name:          r.Name,
+		Phone:         r.Phone,
+		Status:        courier.CourierStatus(r.Status),
+		TransportType: courier.TransportType(r.TransportType),
+	}
+}
+
+// ValidateName - имя курьера не пустое и не длиннее 100 байт
+func ValidateName(name string) error {
+	if name == "" || len(name) > 100 {
+		return fmt.Errorf("name is too long or empty")
+	}
+	return nil
+}
+
+// ValidatePhone - телефон из "+" и 11 цифр
+func ValidatePhone(phone string) error {
+	if phone == "" {
+		return fmt.Errorf("phone is empty")
+	}
+
+	if len(phone) != 12 || phone[0] != '+' {
+		return fmt.Errorf("invalid phone")
+	}
+
+	digits := phone[1:]
+	for _, digit := range digits {
+		if !unicode.IsDigit(digit) {
+			return fmt.Errorf("invalid phone")
+		}
+	}
+
+	return nil
+}
+
+// ValidateStatus - статус из жизненного цикла курьера
+func ValidateStatus(status string) error {
+	switch status {
+	case courier.StatusAvailable, courier.StatusBusy, courier.StatusPaused:
+		return nil
+	default:
+		return fmt.Errorf("invalid status")
+	}
+}
+
+// ValidateTransportType - известный вид транспорта
+func ValidateTransportType(transportType string) error {
+	switch transportType {
+	case courier.TransportOnFoot, courier.TransportScooter, courier.TransportCar:
+		return nil
+	default:
+		return fmt.Errorf("invalid transport type")
+	}
+}
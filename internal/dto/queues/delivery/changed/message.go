@@ -0,0 +1,12 @@
+package changed
+
+type Message struct {
+	DeliveryID     int64   `json:"delivery_id"`
+	OrderID        string  `json:"order_id"`
+	CourierID      int64   `json:"courier_id"`
+	PreviousStatus *string `json:"previous_status"`
+	Status         string  `json:"status"`
+	Actor          string  `json:"actor"`
+	Reason         string  `json:"reason"`
+	OccurredAt     string  `json:"occurred_at"`
+}
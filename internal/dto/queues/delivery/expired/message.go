@@ -0,0 +1,11 @@
+package expired
+
+// Message - заказ так и не получил курьера за отведенный SLA
+type Message struct {
+	OrderID   string `json:"order_id"`
+	Priority  string `json:"priority"`
+	Attempts  int    `json:"attempts"`
+	LastError string `json:"last_error"`
+	QueuedAt  string `json:"queued_at"`
+	ExpiredAt string `json:"expired_at"`
+}
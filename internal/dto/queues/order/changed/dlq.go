@@ -0,0 +1,10 @@
+package changed
+
+// Заголовки сообщения в dead-letter топике
+const (
+	HeaderError             = "x-error"
+	HeaderOriginalTopic     = "x-original-topic"
+	HeaderOriginalPartition = "x-original-partition"
+	HeaderOriginalOffset    = "x-original-offset"
+	HeaderFailedAt          = "x-failed-at"
+)
@@ -3,4 +3,6 @@ package changed
 type Message struct {
 	OrderID string `json:"order_id"`
 	Status  string `json:"status"`
+	// Priority - standard, express или vip, отсутствует у старых сообщений
+	Priority string `json:"priority,omitempty"`
 }
@@ -0,0 +1,120 @@
+package dlq
+
+import (
+	"context"
+	"fmt"
+	"service-courier/internal/dto/queues/order/changed"
+	"service-courier/internal/model/dlq"
+	"strconv"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+type offsetClient interface {
+	GetOffset(topic string, partition int32, time int64) (int64, error)
+}
+
+// Reader читает dead-letter топик целиком, не вступая в consumer group
+type Reader struct {
+	client   offsetClient
+	consumer sarama.Consumer
+	topic    string
+}
+
+func NewReader(client offsetClient, consumer sarama.Consumer, topic string) *Reader {
+	return &Reader{
+		client:   client,
+		consumer: consumer,
+		topic:    topic,
+	}
+}
+
+func (r *Reader) List(ctx context.Context) ([]dlq.Message, error) {
+	partitions, err := r.consumer.Partitions(r.topic)
+	if err != nil {
+		return nil, fmt.Errorf("get partitions: %w", err)
+	}
+
+	messages := make([]dlq.Message, 0)
+	for _, partition := range partitions {
+		msgs, err := r.readPartition(ctx, partition)
+		if err != nil {
+			return nil, fmt.Errorf("read partition %d: %w", partition, err)
+		}
+		messages = append(messages, msgs...)
+	}
+
+	return messages, nil
+}
+
+func (r *Reader) readPartition(ctx context.Context, partition int32) ([]dlq.Message, error) {
+	newest, err := r.client.GetOffset(r.topic, partition, sarama.OffsetNewest)
+	if err != nil {
+		return nil, fmt.Errorf("get newest offset: %w", err)
+	}
+	oldest, err := r.client.GetOffset(r.topic, partition, sarama.OffsetOldest)
+	if err != nil {
+		return nil, fmt.Errorf("get oldest offset: %w", err)
+	}
+	if newest <= oldest {
+		return nil, nil
+	}
+
+	pc, err := r.consumer.ConsumePartition(r.topic, partition, oldest)
+	if err != nil {
+		return nil, fmt.Errorf("consume partition: %w", err)
+	}
+	defer pc.AsyncClose()
+
+	messages := make([]dlq.Message, 0, newest-oldest)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case err := <-pc.Errors():
+			return nil, err
+		case msg := <-pc.Messages():
+			messages = append(messages, toMessage(msg))
+			if msg.Offset >= newest-1 {
+				return messages, nil
+			}
+		}
+	}
+}
+
+func toMessage(msg *sarama.ConsumerMessage) dlq.Message {
+	m := dlq.Message{
+		Partition: msg.Partition,
+		Offset:    msg.Offset,
+		Key:       string(msg.Key),
+		Value:     msg.Value,
+	}
+
+	for _, h := range msg.Headers {
+		if h == nil {
+			continue
+		}
+		value := string(h.Value)
+		switch string(h.Key) {
+		case changed.HeaderError:
+			m.Error = value
+		case changed.HeaderOriginalTopic:
+			m.Original.Topic = value
+		case changed.HeaderOriginalPartition:
+			if p, err := strconv.ParseInt(value, 10, 32); err == nil {
+				m.Original.Partition = int32(p)
+			}
+		case changed.HeaderOriginalOffset:
+			if o, err := strconv.ParseInt(value, 10, 64); err == nil {
+				m.Original.Offset = o
+			}
+		case changed.HeaderFailedAt:
+			if t, err := time.Parse(time.RFC3339, value); err == nil {
+				m.FailedAt = t
+			}
+		}
+	}
+
+	return m
+}
@@ -0,0 +1,69 @@
+package dlq_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+
+	dtoChanged "service-courier/internal/dto/queues/order/changed"
+	"service-courier/internal/gateway/dlq"
+)
+
+type stubOffsets struct {
+	newest map[int32]int64
+}
+
+func (s stubOffsets) GetOffset(_ string, partition int32, at int64) (int64, error) {
+	if at == sarama.OffsetOldest {
+		return 0, nil
+	}
+	return s.newest[partition], nil
+}
+
+func TestReader_List(t *testing.T) {
+	t.Parallel()
+
+	consumer := mocks.NewConsumer(t, nil)
+	consumer.SetTopicMetadata(map[string][]int32{"orders.dlq": {0, 1}})
+
+	consumer.ExpectConsumePartition("orders.dlq", 0, 0).
+		YieldMessage(&sarama.ConsumerMessage{
+			Key:   []byte("order-1"),
+			Value: []byte(`{"order_id":"order-1","status":"created"}`),
+			Headers: []*sarama.RecordHeader{
+				{Key: []byte(dtoChanged.HeaderError), Value: []byte("db timeout")},
+				{Key: []byte(dtoChanged.HeaderOriginalTopic), Value: []byte("orders")},
+				{Key: []byte(dtoChanged.HeaderOriginalPartition), Value: []byte("2")},
+				{Key: []byte(dtoChanged.HeaderOriginalOffset), Value: []byte("41")},
+				{Key: []byte(dtoChanged.HeaderFailedAt), Value: []byte("2024-01-01T12:00:00Z")},
+			},
+		}).
+		YieldMessage(&sarama.ConsumerMessage{Key: []byte("order-2"), Value: []byte(`bad`)})
+
+	reader := dlq.NewReader(stubOffsets{newest: map[int32]int64{0: 2, 1: 0}}, consumer, "orders.dlq")
+
+	messages, err := reader.List(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(messages) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(messages))
+	}
+
+	first := messages[0]
+	if first.Error != "db timeout" || first.Key != "order-1" {
+		t.Errorf("unexpected message %+v", first)
+	}
+	if first.Original.Topic != "orders" || first.Original.Partition != 2 || first.Original.Offset != 41 {
+		t.Errorf("unexpected original position %+v", first.Original)
+	}
+	if !first.FailedAt.Equal(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected failed_at %v", first.FailedAt)
+	}
+	if messages[1].Offset != 1 || messages[1].Error != "" {
+		t.Errorf("unexpected second message %+v", messages[1])
+	}
+}
@@ -0,0 +1,20 @@
+package geocoder
+
+import (
+	"os"
+	"time"
+)
+
+type Config struct {
+	URL     string
+	City    string
+	Timeout time.Duration
+}
+
+func LoadConfig() Config {
+	return Config{
+		URL:     os.Getenv("GEOCODER_URL"),
+		City:    os.Getenv("GEOCODER_CITY"),
+		Timeout: 2 * time.Second,
+	}
+}
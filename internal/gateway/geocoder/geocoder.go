@@ -0,0 +1,103 @@
+package geocoder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"service-courier/internal/model/order"
+	"service-courier/internal/pkg/geo"
+	"strconv"
+	"strings"
+)
+
+var ErrAddressNotFound = errors.New("address not found")
+
+// Client - клиент к Nominatim-совместимому API геокодирования
+type Client struct {
+	baseURL    string
+	city       string
+	httpClient *http.Client
+}
+
+func NewClient(cfg Config) *Client {
+	return &Client{
+		baseURL:    strings.TrimRight(cfg.URL, "/"),
+		city:       cfg.City,
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+type searchResult struct {
+	Lat string `json:"lat"`
+	Lon string `json:"lon"`
+}
+
+func (c *Client) Geocode(ctx context.Context, address order.Address) (*geo.Point, error) {
+	query := formatAddress(address, c.city)
+	if query == "" {
+		return nil, ErrAddressNotFound
+	}
+
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("format", "json")
+	params.Set("limit", "1")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/search?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("geocode request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Printf("geocoder: close response body: %v", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("geocode request: unexpected status %d", resp.StatusCode)
+	}
+
+	var results []searchResult
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+
+	if len(results) == 0 {
+		return nil, ErrAddressNotFound
+	}
+
+	lat, err := strconv.ParseFloat(results[0].Lat, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parse latitude: %w", err)
+	}
+	lon, err := strconv.ParseFloat(results[0].Lon, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parse longitude: %w", err)
+	}
+
+	return &geo.Point{Lat: lat, Lon: lon}, nil
+}
+
+func formatAddress(address order.Address, city string) string {
+	parts := make([]string, 0, 3)
+	if address.Street == "" {
+		return ""
+	}
+	parts = append(parts, address.Street)
+	if address.House != "" {
+		parts = append(parts, address.House)
+	}
+	if city != "" {
+		parts = append(parts, city)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,59 @@
+package geocoder_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"service-courier/internal/gateway/geocoder"
+	"service-courier/internal/model/order"
+)
+
+func TestGeocode_Success(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/search" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		if q := r.URL.Query().Get("q"); q != "Тверская, 1, Москва" {
+			t.Fatalf("unexpected query %q", q)
+		}
+		_, _ = w.Write([]byte(`[{"lat":"55.7575","lon":"37.6135"}]`))
+	}))
+	defer srv.Close()
+
+	client := geocoder.NewClient(geocoder.Config{URL: srv.URL, City: "Москва", Timeout: time.Second})
+
+	point, err := client.Geocode(context.Background(), order.Address{Street: "Тверская", House: "1"})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	if point.Lat != 55.7575 || point.Lon != 37.6135 {
+		t.Fatalf("unexpected point %+v", point)
+	}
+}
+
+func TestGeocode_NotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	client := geocoder.NewClient(geocoder.Config{URL: srv.URL, Timeout: time.Second})
+
+	_, err := client.Geocode(context.Background(), order.Address{Street: "Несуществующая"})
+	if !errors.Is(err, geocoder.ErrAddressNotFound) {
+		t.Fatalf("expected ErrAddressNotFound, got %v", err)
+	}
+}
+
+func TestGeocode_EmptyAddress(t *testing.T) {
+	client := geocoder.NewClient(geocoder.Config{URL: "http://unused", Timeout: time.Second})
+
+	_, err := client.Geocode(context.Background(), order.Address{})
+	if !errors.Is(err, geocoder.ErrAddressNotFound) {
+		t.Fatalf("expected ErrAddressNotFound, got %v", err)
+	}
+}
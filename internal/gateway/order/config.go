@@ -17,9 +17,15 @@ func LoadConfig() Config {
 		addr = "service-order:50051"
 	}
 
+	// максимальная глубина, на которую воркер опроса догоняет заказы после простоя
+	lookback := time.Hour
+	if d, err := time.ParseDuration(os.Getenv("ORDER_POLL_MAX_LOOKBACK")); err == nil && d > 0 {
+		lookback = d
+	}
+
 	return Config{
 		Addr:     addr,
 		Timeout:  3 * time.Second,
-		Lookback: 5 * time.Second,
+		Lookback: lookback,
 	}
 }
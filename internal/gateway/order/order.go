@@ -54,14 +54,64 @@ func (g *Gateway) GetOrders(ctx context.Context, from time.Time) ([]order.Order,
 	}
 	orders := make([]order.Order, 0, len(resp.Orders))
 	for _, o := range resp.Orders {
-		orders = append(orders, order.Order{
-			ID:        o.GetId(),
-			CreatedAt: o.GetCreatedAt().AsTime(),
-		})
+		orders = append(orders, toOrder(o))
 	}
 	return orders, nil
 }
 
+func (g *Gateway) GetOrderByID(ctx context.Context, id string) (*order.Order, error) {
+	pbReq := &pb.GetOrderByIdRequest{Id: id}
+	exec := retry.NewRetryExecutor(retry.RetryConfig{
+		MaxAttempts: 3,
+		Strategy:    retry.NewExponentialBackoff(100*time.Millisecond, 1*time.Second, 2.0),
+		ShouldRetry: isRetryable,
+	})
+
+	var resp *pb.GetOrderByIdResponse
+	err := exec.ExecuteWithCallback(
+		func() error {
+			r, err := g.client.GetOrderById(ctx, pbReq)
+			if err != nil {
+				return err
+			}
+			resp = r
+			return nil
+		},
+		func(attempt int, err error, delay time.Duration) {
+			metrics.GatewayRetriesTotal.Inc()
+		},
+	)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, order.ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("get order by id failed: %w", err)
+	}
+	if resp == nil || resp.GetOrder() == nil {
+		return nil, order.ErrOrderNotFound
+	}
+	o := toOrder(resp.GetOrder())
+	return &o, nil
+}
+
+// toOrder переводит заказ из gRPC-контракта сервиса заказов. Приоритета в контракте нет,
+// поэтому Priority остается пустым и заказ обслуживается как standard.
+func toOrder(o *pb.Order) order.Order {
+	address := o.GetAddress()
+	return order.Order{
+		ID:     o.GetId(),
+		Status: o.GetStatus(),
+		Address: order.Address{
+			Street:    address.GetStreet(),
+			House:     address.GetHouse(),
+			Apartment: address.GetApartment(),
+			Floor:     address.GetFloor(),
+			Comment:   address.GetComment(),
+		},
+		CreatedAt: o.GetCreatedAt().AsTime(),
+	}
+}
+
 func isRetryable(err error) bool {
 	st, ok := status.FromError(err)
 	if !ok {
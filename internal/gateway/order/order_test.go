@@ -2,11 +2,14 @@ package order_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	order "service-courier/internal/gateway/order"
 	"service-courier/internal/metrics"
+	modelDelivery "service-courier/internal/model/delivery"
+	modelOrder "service-courier/internal/model/order"
 	pb "service-courier/internal/proto"
 
 	"github.com/prometheus/client_golang/prometheus/testutil"
@@ -19,6 +22,8 @@ import (
 type stubClient struct {
 	responses []stubResponse
 	calls     int
+	orderByID *pb.GetOrderByIdResponse
+	byIDErr   error
 }
 
 type stubResponse struct {
@@ -37,7 +42,11 @@ func (s *stubClient) GetOrders(ctx context.Context, in *pb.GetOrdersRequest, opt
 }
 
 func (s *stubClient) GetOrderById(ctx context.Context, in *pb.GetOrderByIdRequest, opts ...grpc.CallOption) (*pb.GetOrderByIdResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "not used")
+	s.calls++
+	if s.orderByID == nil && s.byIDErr == nil {
+		return nil, status.Error(codes.Unimplemented, "not used")
+	}
+	return s.orderByID, s.byIDErr
 }
 
 func TestGatewayGetOrders_RetryOnTemporary(t *testing.T) {
@@ -93,3 +102,68 @@ func TestGatewayGetOrders_NoRetryOnPermanent(t *testing.T) {
 		t.Fatalf("expected retries metric to stay the same, before: %v, after: %v", before, after)
 	}
 }
+
+func TestGatewayGetOrderByID_MapsAddress(t *testing.T) {
+	client := &stubClient{
+		orderByID: &pb.GetOrderByIdResponse{
+			Order: &pb.Order{
+				Id:      "1",
+				Status:  "created",
+				Address: &pb.DeliveryAddress{Street: "Тверская", House: "1"},
+			},
+		},
+	}
+
+	gw := order.NewGateway(client)
+
+	o, err := gw.GetOrderByID(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	if o.Address.Street != "Тверская" || o.Address.House != "1" {
+		t.Fatalf("unexpected address: %+v", o.Address)
+	}
+}
+
+func TestGatewayGetOrders_PriorityIsStandard(t *testing.T) {
+	client := &stubClient{
+		responses: []stubResponse{
+			{resp: &pb.GetOrdersResponse{Orders: []*pb.Order{{Id: "1", Status: "created"}}}},
+		},
+	}
+
+	gw := order.NewGateway(client)
+
+	orders, err := gw.GetOrders(context.Background(), time.Now())
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	if len(orders) != 1 {
+		t.Fatalf("expected 1 order, got %d", len(orders))
+	}
+
+	// контракт не передает приоритет - пустое значение разбирается как standard
+	priority, err := modelDelivery.ParsePriority(orders[0].Priority)
+	if err != nil {
+		t.Fatalf("expected empty priority to parse, got error: %v", err)
+	}
+	if priority != modelDelivery.PriorityStandard {
+		t.Fatalf("expected standard priority, got %q", priority)
+	}
+}
+
+func TestGatewayGetOrderByID_NotFound(t *testing.T) {
+	client := &stubClient{
+		byIDErr: status.Error(codes.NotFound, "not found"),
+	}
+
+	gw := order.NewGateway(client)
+
+	_, err := gw.GetOrderByID(context.Background(), "1")
+	if !errors.Is(err, modelOrder.ErrOrderNotFound) {
+		t.Fatalf("expected ErrOrderNotFound, got %v", err)
+	}
+	if client.calls != 1 {
+		t.Fatalf("expected 1 call, got %d", client.calls)
+	}
+}
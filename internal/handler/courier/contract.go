@@ -4,11 +4,19 @@ package courier
 import (
 	"context"
 	"service-courier/internal/model/courier"
+	"service-courier/internal/pkg/geo"
 )
 
 type courierService interface {
 	GetCourier(ctx context.Context, id int64) (*courier.Courier, error)
-	GetAllCouriers(ctx context.Context) ([]courier.Courier, error)
+	ListCouriers(ctx context.Context, filter courier.ListFilter) (*courier.Page, error)
 	CreateCourier(ctx context.Context, courierData courier.Courier) (id int64, err error)
+	ImportCouriers(ctx context.Context, rows []courier.ImportRow, mode courier.ImportMode) (*courier.ImportReport, error)
 	UpdateCourier(ctx context.Context, courierData courier.Courier) error
+	PatchCourier(ctx context.Context, courierData courier.Courier, setLocation bool) (*courier.Courier, error)
+	UpdateLocation(ctx context.Context, id int64, point geo.Point) error
+	DeactivateCourier(ctx context.Context, id int64) error
+	ActivateCourier(ctx context.Context, id int64) error
+	DeleteCourier(ctx context.Context, id int64) error
+	EraseCourier(ctx context.Context, id int64) error
 }
@@ -1,32 +1,81 @@
 package courier
 
-import "service-courier/internal/model/courier"
+import (
+	"service-courier/internal/model/courier"
+	"service-courier/internal/pkg/geo"
+	"time"
+)
 
 func ModelToResponse(courier courier.Courier) Courier {
-	return Courier{
+	response := Courier{
 		ID:            courier.ID,
 		Name:          courier.Name,
 		Phone:         courier.Phone,
 		Status:        string(courier.Status),
 		TransportType: string(courier.TransportType),
 	}
+
+	if courier.DeactivatedAt != nil {
+		response.DeactivatedAt = courier.DeactivatedAt.Format(time.RFC3339)
+	}
+
+	if courier.Location != nil {
+		response.Location = &Location{
+			Lat:       courier.Location.Point.Lat,
+			Lon:       courier.Location.Point.Lon,
+			UpdatedAt: courier.Location.UpdatedAt.Format(time.RFC3339),
+		}
+	}
+
+	return response
 }
 
-func (r CreateRequest) ToModel() courier.Courier {
-	return courier.Courier{
-		Name:          r.Name,
-		Phone:         r.Phone,
-		Status:        courier.CourierStatus(r.Status),
-		TransportType: courier.TransportType(r.TransportType),
+// ToModel - курьер для сохранения патча; version - версия, к которой применялся патч
+func (d PatchDocument) ToModel(id, version int64) courier.Courier {
+	courierData := courier.Courier{
+		ID:            id,
+		Name:          *d.Name,
+		Phone:         *d.Phone,
+		Status:        courier.CourierStatus(*d.Status),
+		TransportType: courier.TransportType(*d.TransportType),
+		Version:       version,
+	}
+
+	if d.Location != nil {
+		courierData.Location = &courier.Location{
+			Point: geo.Point{Lat: *d.Location.Lat, Lon: *d.Location.Lon},
+		}
 	}
+
+	return courierData
+}
+
+func (r UpdateLocationRequest) ToPoint() geo.Point {
+	return geo.Point{Lat: *r.Lat, Lon: *r.Lon}
 }
 
-func (r UpdateRequest) ToModel() courier.Courier {
-	return courier.Courier{
-		ID:            r.ID,
-		Name:          r.Name,
-		Phone:         r.Phone,
-		Status:        courier.CourierStatus(r.Status),
-		TransportType: courier.TransportType(r.TransportType),
+func ReportToImportResponse(report courier.ImportReport) ImportResponse {
+	response := ImportResponse{
+		Mode:      string(report.Mode),
+		Committed: report.Committed,
+		Created:   report.Created,
+		Failed:    report.Failed,
+		Rows:      make([]ImportRowResponse, len(report.Rows)),
 	}
+
+	for i, row := range report.Rows {
+		item := ImportRowResponse{Line: row.Line, Phone: row.Phone, ID: row.ID}
+		switch {
+		case row.Err != nil:
+			item.Status = "failed"
+			item.Error = row.Err.Error()
+		case report.Committed:
+			item.Status = "created"
+		default:
+			item.Status = "skipped"
+		}
+		response.Rows[i] = item
+	}
+
+	return response
 }
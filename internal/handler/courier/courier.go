@@ -5,6 +5,7 @@ import (
 	"errors"
 	"log"
 	"net/http"
+	dto "service-courier/internal/dto/courier"
 	"service-courier/internal/model/courier"
 	"strconv"
 
@@ -36,27 +37,42 @@ func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	w.Header().Set("ETag", etag(courierData.Version))
 	h.writeJSON(w, http.StatusOK, ModelToResponse(*courierData))
 }
 
+// GetAll - страница курьеров с фильтрами. Тело - массив курьеров, общее число подходящих
+// курьеров - в заголовке X-Total-Count, курсор следующей страницы - в X-Next-Cursor.
 func (h *Handler) GetAll(w http.ResponseWriter, r *http.Request) {
-	couriers, err := h.service.GetAllCouriers(r.Context())
+	filter, err := parseListFilter(r)
 	if err != nil {
-		log.Printf("get all couriers: %v", err)
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	page, err := h.service.ListCouriers(r.Context(), filter)
+	if err != nil {
+		log.Printf("list couriers: %v", err)
 		h.writeError(w, err)
 		return
 	}
 
-	responseCouriers := make([]Courier, len(couriers))
-	for i, c := range couriers {
+	responseCouriers := make([]Courier, len(page.Couriers))
+	for i, c := range page.Couriers {
 		responseCouriers[i] = ModelToResponse(c)
 	}
 
+	w.Header().Set("X-Total-Count", strconv.FormatInt(page.Total, 10))
+	if next := dto.NextCursor(filter, *page); next != "" {
+		w.Header().Set("X-Next-Cursor", next)
+	}
 	h.writeJSON(w, http.StatusOK, responseCouriers)
 }
 
 func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
-	var req CreateRequest
+	var req dto.CreateRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		h.writeJSON(w, http.StatusBadRequest, map[string]string{
 			"error": "Invalid JSON",
@@ -84,8 +100,18 @@ func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// Update меняет непустые поля курьера. С заголовком If-Match курьер обновляется,
+// только если не менялся после этой версии, иначе 412.
 func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
-	var req UpdateRequest
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	var req dto.UpdateRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		h.writeJSON(w, http.StatusBadRequest, map[string]string{
 			"error": "Invalid JSON",
@@ -100,8 +126,9 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := h.service.UpdateCourier(r.Context(), req.ToModel())
-	if err != nil {
+	courierData := req.ToModel()
+	courierData.Version = version
+	if err := h.service.UpdateCourier(r.Context(), courierData); err != nil {
 		log.Printf("update courier: %v", err)
 		h.writeError(w, err)
 		return
@@ -112,6 +139,42 @@ func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (h *Handler) UpdateLocation(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "Invalid courier ID",
+		})
+		return
+	}
+
+	var req UpdateLocationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "Invalid JSON",
+		})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if err := h.service.UpdateLocation(r.Context(), id, req.ToPoint()); err != nil {
+		log.Printf("update courier location: %v", err)
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]string{
+		"message": "Courier location updated successfully",
+	})
+}
+
 func (h *Handler) writeJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -131,6 +194,12 @@ func (h *Handler) mapError(err error) (string, int) {
 		return "Courier not found", http.StatusNotFound
 	case errors.Is(err, courier.ErrPhoneExists):
 		return "Courier with this phone already exists", http.StatusConflict
+	case errors.Is(err, courier.ErrVersionMismatch):
+		return "Courier was modified, reload it and retry", http.StatusPreconditionFailed
+	case errors.Is(err, courier.ErrBusyStatusManaged):
+		return "Status busy is set by the system when a delivery is assigned", http.StatusUnprocessableEntity
+	case errors.Is(err, courier.ErrHasActiveDeliveries):
+		return "Courier has active deliveries, change the status after they are completed", http.StatusConflict
 	default:
 		return "Internal server error", http.StatusInternalServerError
 	}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/mock/gomock"
@@ -13,6 +14,7 @@ import (
 	courierHandler "service-courier/internal/handler/courier"
 	"service-courier/internal/handler/courier/mocks"
 	model "service-courier/internal/model/courier"
+	"service-courier/internal/pkg/geo"
 )
 
 func TestGetCourier_Success(t *testing.T) {
@@ -98,10 +100,13 @@ func TestGetAllCouriers_Success(t *testing.T) {
 	mockService := mocks.NewMockcourierService(ctrl)
 
 	mockService.EXPECT().
-		GetAllCouriers(gomock.Any()).
-		Return([]model.Courier{
-			{ID: 1, Name: "A"},
-			{ID: 2, Name: "B"},
+		ListCouriers(gomock.Any(), model.ListFilter{Sort: model.SortByID}).
+		Return(&model.Page{
+			Couriers: []model.Courier{
+				{ID: 1, Name: "A"},
+				{ID: 2, Name: "B"},
+			},
+			Total: 2,
 		}, nil)
 
 	h := courierHandler.NewCourierHandler(mockService)
@@ -117,6 +122,106 @@ func TestGetAllCouriers_Success(t *testing.T) {
 	if rr.Code != http.StatusOK {
 		t.Fatalf("expected 200 OK, got %d", rr.Code)
 	}
+	if total := rr.Header().Get("X-Total-Count"); total != "2" {
+		t.Fatalf("expected X-Total-Count=2, got %q", total)
+	}
+	if next := rr.Header().Get("X-Next-Cursor"); next != "" {
+		t.Fatalf("expected no next cursor on the last page, got %q", next)
+	}
+}
+
+func TestGetAllCouriers_FiltersAndCursor(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockcourierService(ctrl)
+
+	zoneID := int64(3)
+	filter := model.ListFilter{
+		Statuses:   []model.CourierStatus{model.StatusAvailable, model.StatusBusy},
+		Transports: []model.TransportType{model.TransportCar},
+		Search:     "Iva",
+		ZoneID:     &zoneID,
+		Sort:       model.SortByName,
+		Desc:       true,
+		Limit:      1,
+	}
+
+	mockService.EXPECT().
+		ListCouriers(gomock.Any(), filter).
+		Return(&model.Page{
+			Couriers: []model.Courier{{ID: 7, Name: "Ivan"}},
+			Total:    4,
+			Next:     &model.Cursor{Name: "Ivan", ID: 7},
+		}, nil)
+
+	h := courierHandler.NewCourierHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Get("/couriers", h.GetAll)
+
+	query := "status=available,busy&transport_type=car&q=Iva&zone_id=3&sort=name&order=desc&limit=1"
+	req := httptest.NewRequest("GET", "/couriers?"+query, nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", rr.Code, rr.Body.String())
+	}
+	next := rr.Header().Get("X-Next-Cursor")
+	if next == "" {
+		t.Fatal("expected next cursor")
+	}
+
+	filter.After = &model.Cursor{Name: "Ivan", ID: 7}
+	mockService.EXPECT().
+		ListCouriers(gomock.Any(), filter).
+		Return(&model.Page{Total: 4}, nil)
+
+	req = httptest.NewRequest("GET", "/couriers?"+query+"&cursor="+next, nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK for next page, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	// курсор от сортировки по имени не подходит к сортировке по id
+	req = httptest.NewRequest("GET", "/couriers?cursor="+next, nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 Bad Request for mismatched cursor, got %d", rr.Code)
+	}
+}
+
+func TestGetAllCouriers_InvalidQuery(t *testing.T) {
+	t.Parallel()
+
+	for _, query := range []string{
+		"status=sleeping",
+		"transport_type=bike",
+		"zone_id=abc",
+		"sort=phone",
+		"order=random",
+		"limit=0",
+		"limit=1000",
+	} {
+		ctrl := gomock.NewController(t)
+		h := courierHandler.NewCourierHandler(mocks.NewMockcourierService(ctrl))
+		r := chi.NewRouter()
+		r.Get("/couriers", h.GetAll)
+
+		req := httptest.NewRequest("GET", "/couriers?"+query, nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400 Bad Request, got %d", query, rr.Code)
+		}
+		ctrl.Finish()
+	}
 }
 
 func TestGetAllCouriers_ServiceError(t *testing.T) {
@@ -126,7 +231,7 @@ func TestGetAllCouriers_ServiceError(t *testing.T) {
 	mockService := mocks.NewMockcourierService(ctrl)
 
 	mockService.EXPECT().
-		GetAllCouriers(gomock.Any()).
+		ListCouriers(gomock.Any(), gomock.Any()).
 		Return(nil, errors.New("db error"))
 
 	h := courierHandler.NewCourierHandler(mockService)
@@ -333,3 +438,542 @@ func TestUpdateCourier_NotFound(t *testing.T) {
 		t.Fatalf("expected 404 Not Found, got %d", rr.Code)
 	}
 }
+
+func TestUpdateLocation_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockcourierService(ctrl)
+
+	mockService.EXPECT().
+		UpdateLocation(gomock.Any(), int64(1), geo.Point{Lat: 55.75, Lon: 37.61}).
+		Return(nil)
+
+	h := courierHandler.NewCourierHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Put("/courier/{id}/location", h.UpdateLocation)
+
+	body := `{"lat": 55.75, "lon": 37.61}`
+	req := httptest.NewRequest("PUT", "/courier/1/location", bytes.NewBufferString(body))
+	rr := httptest.NewRecorder()
+
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", rr.Code)
+	}
+}
+
+func TestUpdateLocation_InvalidCoordinates(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockcourierService(ctrl)
+
+	h := courierHandler.NewCourierHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Put("/courier/{id}/location", h.UpdateLocation)
+
+	body := `{"lat": 95, "lon": 37.61}`
+	req := httptest.NewRequest("PUT", "/courier/1/location", bytes.NewBufferString(body))
+	rr := httptest.NewRecorder()
+
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 Bad Request, got %d", rr.Code)
+	}
+}
+
+func TestUpdateLocation_MissingCoordinates(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockcourierService(ctrl)
+
+	h := courierHandler.NewCourierHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Put("/courier/{id}/location", h.UpdateLocation)
+
+	body := `{"lat": 55.75}`
+	req := httptest.NewRequest("PUT", "/courier/1/location", bytes.NewBufferString(body))
+	rr := httptest.NewRecorder()
+
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 Bad Request, got %d", rr.Code)
+	}
+}
+
+func TestUpdateLocation_NotFound(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockcourierService(ctrl)
+
+	mockService.EXPECT().
+		UpdateLocation(gomock.Any(), int64(55), gomock.Any()).
+		Return(model.ErrCourierNotFound)
+
+	h := courierHandler.NewCourierHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Put("/courier/{id}/location", h.UpdateLocation)
+
+	body := `{"lat": 55.75, "lon": 37.61}`
+	req := httptest.NewRequest("PUT", "/courier/55/location", bytes.NewBufferString(body))
+	rr := httptest.NewRecorder()
+
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 Not Found, got %d", rr.Code)
+	}
+}
+
+func TestImportCouriers_CSV(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockcourierService(ctrl)
+
+	body := "\ufeffid,phone,name,status,transport_type\n" +
+		"1,+79990000001,Ivan,available,car\n" +
+		"2,12345,Anna,available,car\n"
+
+	mockService.EXPECT().
+		ImportCouriers(gomock.Any(), gomock.Any(), model.ImportPartial).
+		DoAndReturn(func(_ any, rows []model.ImportRow, mode model.ImportMode) (*model.ImportReport, error) {
+			if len(rows) != 2 {
+				t.Fatalf("expected 2 rows, got %d", len(rows))
+			}
+			if rows[0].Line != 2 || rows[0].Err != nil || rows[0].Courier.Name != "Ivan" {
+				t.Errorf("unexpected first row %+v", rows[0])
+			}
+			if rows[1].Line != 3 || rows[1].Err == nil {
+				t.Errorf("expected invalid phone on line 3, got %+v", rows[1])
+			}
+			return &model.ImportReport{
+				Mode:      mode,
+				Committed: true,
+				Created:   1,
+				Failed:    1,
+				Rows: []model.ImportResult{
+					{Line: 2, Phone: "+79990000001", ID: 10},
+					{Line: 3, Phone: "12345", Err: rows[1].Err},
+				},
+			}, nil
+		})
+
+	h := courierHandler.NewCourierHandler(mockService)
+
+	req := httptest.NewRequest("POST", "/couriers/import?mode=partial", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "text/csv")
+	rr := httptest.NewRecorder()
+
+	h.Import(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if !bytes.Contains(rr.Body.Bytes(), []byte(`"status":"created","id":10`)) {
+		t.Errorf("expected created row in report, got %s", rr.Body.String())
+	}
+}
+
+func TestImportCouriers_AtomicAborted(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockcourierService(ctrl)
+
+	body := `{"name":"Ivan","phone":"+79990000001","status":"available","transport_type":"car"}` + "\n\n" +
+		"not json\n"
+
+	mockService.EXPECT().
+		ImportCouriers(gomock.Any(), gomock.Any(), model.ImportAtomic).
+		DoAndReturn(func(_ any, rows []model.ImportRow, mode model.ImportMode) (*model.ImportReport, error) {
+			if len(rows) != 2 || rows[1].Line != 3 || rows[1].Err == nil {
+				t.Fatalf("expected invalid JSON on line 3, got %+v", rows)
+			}
+			return &model.ImportReport{
+				Mode:   mode,
+				Failed: 1,
+				Rows: []model.ImportResult{
+					{Line: 1, Phone: "+79990000001"},
+					{Line: 3, Err: rows[1].Err},
+				},
+			}, nil
+		})
+
+	h := courierHandler.NewCourierHandler(mockService)
+
+	req := httptest.NewRequest("POST", "/couriers/import", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	rr := httptest.NewRecorder()
+
+	h.Import(rr, req)
+
+	if rr.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected status 422, got %d", rr.Code)
+	}
+	if !bytes.Contains(rr.Body.Bytes(), []byte(`"status":"skipped"`)) {
+		t.Errorf("expected skipped row in report, got %s", rr.Body.String())
+	}
+}
+
+func TestImportCouriers_BadRequest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		url         string
+		contentType string
+		body        string
+		want        int
+	}{
+		{"unsupported format", "/couriers/import", "application/json", "[]", http.StatusUnsupportedMediaType},
+		{"invalid mode", "/couriers/import?mode=all", "text/csv", "name,phone,status,transport_type\n", http.StatusBadRequest},
+		{"missing column", "/couriers/import", "text/csv", "name,phone\nIvan,+79990000001\n", http.StatusBadRequest},
+		{"no rows", "/couriers/import?format=ndjson", "", "\n\n", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			h := courierHandler.NewCourierHandler(mocks.NewMockcourierService(ctrl))
+
+			req := httptest.NewRequest("POST", tt.url, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			rr := httptest.NewRecorder()
+
+			h.Import(rr, req)
+
+			if rr.Code != tt.want {
+				t.Errorf("expected status %d, got %d", tt.want, rr.Code)
+			}
+		})
+	}
+}
+
+func TestExportCouriers_CSVPages(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockcourierService(ctrl)
+
+	next := &model.Cursor{ID: 1}
+	gomock.InOrder(
+		mockService.EXPECT().
+			ListCouriers(gomock.Any(), model.ListFilter{Sort: model.SortByID, Limit: 500}).
+			Return(&model.Page{
+				Couriers: []model.Courier{{ID: 1, Name: "Ivan", Phone: "+79990000001", Status: "available", TransportType: "car"}},
+				Total:    2,
+				Next:     next,
+			}, nil),
+		mockService.EXPECT().
+			ListCouriers(gomock.Any(), model.ListFilter{Sort: model.SortByID, Limit: 500, After: next}).
+			Return(&model.Page{
+				Couriers: []model.Courier{{ID: 2, Name: "Anna, Jr", Phone: "+79990000002", Status: "busy", TransportType: "on_foot"}},
+				Total:    2,
+			}, nil),
+	)
+
+	h := courierHandler.NewCourierHandler(mockService)
+
+	req := httptest.NewRequest("GET", "/couriers/export", nil)
+	rr := httptest.NewRecorder()
+
+	h.Export(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	want := "id,name,phone,status,transport_type\n" +
+		"1,Ivan,+79990000001,available,car\n" +
+		"2,\"Anna, Jr\",+79990000002,busy,on_foot\n"
+	if rr.Body.String() != want {
+		t.Errorf("unexpected export:\n%s", rr.Body.String())
+	}
+}
+
+func TestExportCouriers_InvalidFormat(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	h := courierHandler.NewCourierHandler(mocks.NewMockcourierService(ctrl))
+
+	req := httptest.NewRequest("GET", "/couriers/export?format=xlsx", nil)
+	rr := httptest.NewRecorder()
+
+	h.Export(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", rr.Code)
+	}
+}
+
+func TestUpdateCourier_StaleIfMatch(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockcourierService(ctrl)
+
+	mockService.EXPECT().
+		UpdateCourier(gomock.Any(), model.Courier{ID: 1, Status: model.StatusAvailable, Version: 3}).
+		Return(model.ErrVersionMismatch)
+
+	h := courierHandler.NewCourierHandler(mockService)
+
+	req := httptest.NewRequest("PUT", "/courier", bytes.NewBufferString(`{"id": 1, "status": "available"}`))
+	req.Header.Set("If-Match", `"3"`)
+	rr := httptest.NewRecorder()
+
+	h.Update(rr, req)
+
+	if rr.Code != http.StatusPreconditionFailed {
+		t.Fatalf("expected 412 Precondition Failed, got %d", rr.Code)
+	}
+}
+
+func patchRouter(h *courierHandler.Handler) *chi.Mux {
+	r := chi.NewRouter()
+	r.Patch("/courier/{id}", h.Patch)
+	return r
+}
+
+func currentCourier() *model.Courier {
+	deactivatedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	return &model.Courier{
+		ID:            1,
+		Name:          "Ivan",
+		Phone:         "+78005553535",
+		Status:        model.StatusAvailable,
+		TransportType: model.TransportCar,
+		Location:      &model.Location{Point: geo.Point{Lat: 55.75, Lon: 37.61}},
+		Version:       3,
+		DeactivatedAt: &deactivatedAt,
+	}
+}
+
+func TestPatchCourier_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockcourierService(ctrl)
+
+	mockService.EXPECT().GetCourier(gomock.Any(), int64(1)).Return(currentCourier(), nil)
+	mockService.EXPECT().
+		PatchCourier(gomock.Any(), model.Courier{
+			ID:            1,
+			Name:          "Ivan",
+			Phone:         "+78005553535",
+			Status:        model.StatusPaused,
+			TransportType: model.TransportCar,
+			Version:       3,
+		}, true).
+		DoAndReturn(func(_ any, c model.Courier, _ bool) (*model.Courier, error) {
+			c.Version = 4
+			return &c, nil
+		})
+
+	h := courierHandler.NewCourierHandler(mockService)
+
+	body := `{"status": "paused", "location": null}`
+	req := httptest.NewRequest("PATCH", "/courier/1", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	req.Header.Set("If-Match", `"3"`)
+	rr := httptest.NewRecorder()
+
+	patchRouter(h).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if got := rr.Header().Get("ETag"); got != `"4"` {
+		t.Errorf("expected ETag \"4\", got %s", got)
+	}
+}
+
+func TestPatchCourier_KeepsLocationWhenNotPatched(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockcourierService(ctrl)
+
+	mockService.EXPECT().GetCourier(gomock.Any(), int64(1)).Return(currentCourier(), nil)
+	// позиция из ответа GET могла устареть - без location в патче она не сохраняется
+	mockService.EXPECT().
+		PatchCourier(gomock.Any(), gomock.Any(), false).
+		DoAndReturn(func(_ any, c model.Courier, _ bool) (*model.Courier, error) {
+			c.Version = 4
+			return &c, nil
+		})
+
+	h := courierHandler.NewCourierHandler(mockService)
+
+	req := httptest.NewRequest("PATCH", "/courier/1", bytes.NewBufferString(`{"name": "Ivan Petrov"}`))
+	req.Header.Set("If-Match", `"3"`)
+	rr := httptest.NewRecorder()
+
+	patchRouter(h).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestPatchCourier_Preconditions(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockcourierService(ctrl)
+
+	mockService.EXPECT().GetCourier(gomock.Any(), int64(1)).Return(currentCourier(), nil)
+
+	h := courierHandler.NewCourierHandler(mockService)
+	r := patchRouter(h)
+
+	req := httptest.NewRequest("PATCH", "/courier/1", bytes.NewBufferString(`{"status": "paused"}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusPreconditionRequired {
+		t.Errorf("expected 428 without If-Match, got %d", rr.Code)
+	}
+
+	req = httptest.NewRequest("PATCH", "/courier/1", bytes.NewBufferString(`{"status": "paused"}`))
+	req.Header.Set("If-Match", `"2"`)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusPreconditionFailed {
+		t.Errorf("expected 412 for stale ETag, got %d", rr.Code)
+	}
+}
+
+func TestPatchCourier_InvalidPatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"remove required field", `{"name": null}`},
+		{"change id", `{"id": 2}`},
+		{"unknown field", `{"rating": 5}`},
+		{"partial location", `{"location": {"lat": null}}`},
+		{"not an object", `["status", "paused"]`},
+		{"invalid status", `{"status": "sleeping"}`},
+		{"reactivate", `{"deactivated_at": null}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockService := mocks.NewMockcourierService(ctrl)
+
+			mockService.EXPECT().GetCourier(gomock.Any(), int64(1)).Return(currentCourier(), nil)
+
+			h := courierHandler.NewCourierHandler(mockService)
+
+			req := httptest.NewRequest("PATCH", "/courier/1", bytes.NewBufferString(tt.body))
+			req.Header.Set("If-Match", "*")
+			rr := httptest.NewRecorder()
+
+			patchRouter(h).ServeHTTP(rr, req)
+
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("expected 400 Bad Request, got %d: %s", rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestDeleteCourier(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"deleted", nil, http.StatusOK},
+		{"active deliveries", model.ErrHasActiveDeliveries, http.StatusConflict},
+		{"not found", model.ErrCourierNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockService := mocks.NewMockcourierService(ctrl)
+
+			mockService.EXPECT().DeleteCourier(gomock.Any(), int64(1)).Return(tt.err)
+
+			h := courierHandler.NewCourierHandler(mockService)
+
+			r := chi.NewRouter()
+			r.Delete("/courier/{id}", h.Delete)
+
+			req := httptest.NewRequest("DELETE", "/courier/1", nil)
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			if rr.Code != tt.want {
+				t.Errorf("expected status %d, got %d", tt.want, rr.Code)
+			}
+		})
+	}
+}
+
+func TestEraseCourier_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockcourierService(ctrl)
+
+	mockService.EXPECT().EraseCourier(gomock.Any(), int64(7)).Return(nil)
+
+	h := courierHandler.NewCourierHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Post("/courier/{id}/erase", h.Erase)
+
+	req := httptest.NewRequest("POST", "/courier/7/erase", nil)
+	rr := httptest.NewRecorder()
+
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", rr.Code)
+	}
+}
+
+func TestDeactivateCourier_InvalidID(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h := courierHandler.NewCourierHandler(mocks.NewMockcourierService(ctrl))
+
+	r := chi.NewRouter()
+	r.Post("/courier/{id}/deactivate", h.Deactivate)
+
+	req := httptest.NewRequest("POST", "/courier/abc/deactivate", nil)
+	rr := httptest.NewRecorder()
+
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 Bad Request, got %d", rr.Code)
+	}
+}
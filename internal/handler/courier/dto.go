@@ -2,20 +2,62 @@ package courier
 
 // Courier - модель курьера для ответа
 type Courier struct {
-	ID            int64  `json:"id"`
-	Name          string `json:"name"`
-	Phone         string `json:"phone"`
-	Status        string `json:"status"`
-	TransportType string `json:"transport_type"`
+	ID            int64     `json:"id"`
+	Name          string    `json:"name"`
+	Phone         string    `json:"phone"`
+	Status        string    `json:"status"`
+	TransportType string    `json:"transport_type"`
+	Location      *Location `json:"location,omitempty"`
+	DeactivatedAt string    `json:"deactivated_at,omitempty"`
 }
 
-// CreateRequest запрос на создание курьера
-type CreateRequest struct {
-	Name          string `json:"name"`
-	Phone         string `json:"phone"`
-	Status        string `json:"status"`
-	TransportType string `json:"transport_type"`
+// Location последняя известная позиция курьера
+type Location struct {
+	Lat       float64 `json:"lat"`
+	Lon       float64 `json:"lon"`
+	UpdatedAt string  `json:"updated_at"`
 }
 
-// UpdateRequest запрос на обновление курьера
-type UpdateRequest Courier
+// PatchDocument - курьер после применения merge patch к его текущему представлению.
+// Отсутствующее поле означает, что патч его удалил.
+type PatchDocument struct {
+	ID            int64          `json:"id"`
+	Name          *string        `json:"name"`
+	Phone         *string        `json:"phone"`
+	Status        *string        `json:"status"`
+	TransportType *string        `json:"transport_type"`
+	Location      *PatchLocation `json:"location"`
+	DeactivatedAt string         `json:"deactivated_at,omitempty"`
+}
+
+// PatchLocation - позиция курьера в патче; updated_at выставляет сервер
+type PatchLocation struct {
+	Lat       *float64 `json:"lat"`
+	Lon       *float64 `json:"lon"`
+	UpdatedAt string   `json:"updated_at,omitempty"`
+}
+
+// UpdateLocationRequest запрос на обновление позиции курьера
+type UpdateLocationRequest struct {
+	Lat *float64 `json:"lat"`
+	Lon *float64 `json:"lon"`
+}
+
+// ImportResponse отчет об импорте курьеров
+type ImportResponse struct {
+	Mode      string              `json:"mode"`
+	Committed bool                `json:"committed"`
+	Created   int                 `json:"created"`
+	Failed    int                 `json:"failed"`
+	Rows      []ImportRowResponse `json:"rows"`
+}
+
+// ImportRowResponse итог по строке файла: created, failed или skipped, если
+// строка корректна, но импорт отменен из-за ошибок в других строках
+type ImportRowResponse struct {
+	Line   int    `json:"line"`
+	Phone  string `json:"phone,omitempty"`
+	Status string `json:"status"`
+	ID     int64  `json:"id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
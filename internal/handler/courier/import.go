@@ -0,0 +1,302 @@
+package courier
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	dto "service-courier/internal/dto/courier"
+	"service-courier/internal/model/courier"
+	"strconv"
+	"strings"
+)
+
+const (
+	formatCSV    = "csv"
+	formatNDJSON = "ndjson"
+
+	// maxImportSize и maxImportRows ограничивают один импорт: HR присылает сотни строк, не сотни тысяч
+	maxImportSize = 5 << 20
+	maxImportRows = 1000
+)
+
+var (
+	errTooManyRows = fmt.Errorf("too many rows, expected at most %d", maxImportRows)
+	errNoRows      = errors.New("no rows to import")
+
+	importColumns = []string{"name", "phone", "status", "transport_type"}
+	exportColumns = []string{"id", "name", "phone", "status", "transport_type"}
+)
+
+// Import создает курьеров из CSV с заголовком или NDJSON и отвечает отчетом по каждой строке.
+// mode=atomic (по умолчанию) не создает никого, если хотя бы одна строка с ошибкой,
+// mode=partial создает корректные строки.
+func (h *Handler) Import(w http.ResponseWriter, r *http.Request) {
+	format, ok := importFormat(r)
+	if !ok {
+		h.writeJSON(w, http.StatusUnsupportedMediaType, map[string]string{
+			"error": "unsupported format, expected text/csv or application/x-ndjson",
+		})
+		return
+	}
+
+	mode := courier.ImportAtomic
+	if v := r.URL.Query().Get("mode"); v != "" {
+		mode = courier.ImportMode(v)
+		if !mode.IsValid() {
+			h.writeJSON(w, http.StatusBadRequest, map[string]string{
+				"error": "invalid mode, expected atomic or partial",
+			})
+			return
+		}
+	}
+
+	body := http.MaxBytesReader(w, r.Body, maxImportSize)
+	var (
+		rows []courier.ImportRow
+		err  error
+	)
+	if format == formatCSV {
+		rows, err = readCSVRows(body)
+	} else {
+		rows, err = readNDJSONRows(body)
+	}
+	if err != nil {
+		status := http.StatusBadRequest
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		h.writeJSON(w, status, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	report, err := h.service.ImportCouriers(r.Context(), rows, mode)
+	if err != nil {
+		log.Printf("import couriers: %v", err)
+		h.writeError(w, err)
+		return
+	}
+
+	status := http.StatusOK
+	if !report.Committed && report.Failed > 0 {
+		status = http.StatusUnprocessableEntity
+	}
+	h.writeJSON(w, status, ReportToImportResponse(*report))
+}
+
+// Export отдает всех курьеров в CSV (по умолчанию) или NDJSON. Курьеры читаются и
+// отправляются постранично, так что выгрузка не собирается целиком в памяти.
+// CSV выгрузки можно загрузить обратно через Import: лишняя колонка id пропускается.
+func (h *Handler) Export(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = formatCSV
+	}
+	if format != formatCSV && format != formatNDJSON {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "invalid format, expected csv or ndjson",
+		})
+		return
+	}
+
+	filter := courier.ListFilter{Sort: courier.SortByID, Limit: dto.MaxListLimit}
+	page, err := h.service.ListCouriers(r.Context(), filter)
+	if err != nil {
+		log.Printf("export couriers: %v", err)
+		h.writeError(w, err)
+		return
+	}
+
+	if format == formatCSV {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	} else {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="couriers.%s"`, format))
+	w.Header().Set("X-Total-Count", strconv.FormatInt(page.Total, 10))
+	w.WriteHeader(http.StatusOK)
+
+	rc := http.NewResponseController(w)
+	csvWriter := csv.NewWriter(w)
+	encoder := json.NewEncoder(w)
+	if format == formatCSV {
+		_ = csvWriter.Write(exportColumns)
+	}
+
+	for {
+		for _, c := range page.Couriers {
+			if format == formatCSV {
+				err = csvWriter.Write([]string{
+					strconv.FormatInt(c.ID, 10), c.Name, c.Phone, string(c.Status), string(c.TransportType),
+				})
+			} else {
+				err = encoder.Encode(ModelToResponse(c))
+			}
+			if err != nil {
+				log.Printf("export couriers: %v", err)
+				return
+			}
+		}
+		csvWriter.Flush()
+		if err := csvWriter.Error(); err != nil {
+			log.Printf("export couriers: %v", err)
+			return
+		}
+		if err := rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			log.Printf("export couriers: %v", err)
+			return
+		}
+
+		if page.Next == nil {
+			return
+		}
+		filter.After = page.Next
+		if page, err = h.service.ListCouriers(r.Context(), filter); err != nil {
+			// заголовки уже отправлены, клиент увидит оборванный файл
+			log.Printf("export couriers: %v", err)
+			return
+		}
+	}
+}
+
+// importFormat - формат файла из параметра format или из Content-Type
+func importFormat(r *http.Request) (string, bool) {
+	switch r.URL.Query().Get("format") {
+	case formatCSV:
+		return formatCSV, true
+	case formatNDJSON:
+		return formatNDJSON, true
+	case "":
+	default:
+		return "", false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return "", false
+	}
+	switch mediaType {
+	case "text/csv":
+		return formatCSV, true
+	case "application/x-ndjson", "application/ndjson", "application/jsonl":
+		return formatNDJSON, true
+	default:
+		return "", false
+	}
+}
+
+// readCSVRows читает CSV с заголовком. Порядок колонок любой, неизвестные колонки пропускаются.
+// Ошибка строки попадает в ImportRow.Err, ошибка всего файла возвращается.
+func readCSVRows(body io.Reader) ([]courier.ImportRow, error) {
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errNoRows
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		// Excel сохраняет UTF-8 с BOM в начале файла
+		name = strings.TrimPrefix(name, "\ufeff")
+		index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range importColumns {
+		if _, ok := index[name]; !ok {
+			return nil, fmt.Errorf("CSV header must contain %s", strings.Join(importColumns, ", "))
+		}
+	}
+
+	rows := make([]courier.ImportRow, 0)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+		if len(rows) == maxImportRows {
+			return nil, errTooManyRows
+		}
+
+		line, _ := reader.FieldPos(0)
+		field := func(name string) string {
+			if i := index[name]; i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		rows = append(rows, importRow(line, dto.CreateRequest{
+			Name:          field("name"),
+			Phone:         field("phone"),
+			Status:        field("status"),
+			TransportType: field("transport_type"),
+		}))
+	}
+
+	if len(rows) == 0 {
+		return nil, errNoRows
+	}
+	return rows, nil
+}
+
+// readNDJSONRows читает по одному JSON-объекту курьера на строку, пустые строки пропускаются
+func readNDJSONRows(body io.Reader) ([]courier.ImportRow, error) {
+	scanner := bufio.NewScanner(body)
+	rows := make([]courier.ImportRow, 0)
+
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		if len(rows) == maxImportRows {
+			return nil, errTooManyRows
+		}
+
+		var req dto.CreateRequest
+		if err := json.Unmarshal(data, &req); err != nil {
+			rows = append(rows, courier.ImportRow{Line: line, Err: errors.New("invalid JSON")})
+			continue
+		}
+		rows = append(rows, importRow(line, req))
+	}
+	if err := scanner.Err(); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("invalid NDJSON: %w", err)
+	}
+
+	if len(rows) == 0 {
+		return nil, errNoRows
+	}
+	return rows, nil
+}
+
+// importRow проверяет строку так же, как Create
+func importRow(line int, req dto.CreateRequest) courier.ImportRow {
+	row := courier.ImportRow{Line: line, Courier: req.ToModel()}
+	row.Err = req.Validate()
+	return row
+}
@@ -0,0 +1,57 @@
+package courier
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Deactivate выключает курьера из назначения заказов, текущие доставки он завершает
+func (h *Handler) Deactivate(w http.ResponseWriter, r *http.Request) {
+	h.applyToCourier(w, r, "deactivate courier", h.service.DeactivateCourier, "Courier deactivated successfully")
+}
+
+// Activate возвращает курьера в назначение заказов
+func (h *Handler) Activate(w http.ResponseWriter, r *http.Request) {
+	h.applyToCourier(w, r, "activate courier", h.service.ActivateCourier, "Courier activated successfully")
+}
+
+// Delete мягко удаляет курьера; с незавершенными доставками - 409
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	h.applyToCourier(w, r, "delete courier", h.service.DeleteCourier, "Courier deleted successfully")
+}
+
+// Erase обезличивает курьера (GDPR): имя и телефон заменяются, позиция стирается, курьер удаляется.
+// Доставки курьера остаются и ссылаются на обезличенную запись.
+func (h *Handler) Erase(w http.ResponseWriter, r *http.Request) {
+	h.applyToCourier(w, r, "erase courier", h.service.EraseCourier, "Courier personal data erased successfully")
+}
+
+func (h *Handler) applyToCourier(
+	w http.ResponseWriter,
+	r *http.Request,
+	action string,
+	apply func(ctx context.Context, id int64) error,
+	message string,
+) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "Invalid courier ID",
+		})
+		return
+	}
+
+	if err := apply(r.Context(), id); err != nil {
+		log.Printf("%s: %v", action, err)
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]string{
+		"message": message,
+	})
+}
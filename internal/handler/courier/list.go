@@ -0,0 +1,44 @@
+package courier
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	dto "service-courier/internal/dto/courier"
+	"service-courier/internal/model/courier"
+	"strconv"
+	"strings"
+)
+
+func parseListFilter(r *http.Request) (courier.ListFilter, error) {
+	query := r.URL.Query()
+	req := dto.ListRequest{
+		Search: query.Get("q"),
+		Sort:   query.Get("sort"),
+		Order:  query.Get("order"),
+		Cursor: query.Get("cursor"),
+	}
+
+	if v := query.Get("status"); v != "" {
+		req.Statuses = strings.Split(v, ",")
+	}
+	if v := query.Get("transport_type"); v != "" {
+		req.Transports = strings.Split(v, ",")
+	}
+	if v := query.Get("zone_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			return courier.ListFilter{}, errors.New("invalid zone_id")
+		}
+		req.ZoneID = id
+	}
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.ParseUint(v, 10, 64)
+		if err != nil || limit == 0 {
+			return courier.ListFilter{}, fmt.Errorf("invalid limit, expected 1..%d", dto.MaxListLimit)
+		}
+		req.Limit = limit
+	}
+
+	return req.ToFilter()
+}
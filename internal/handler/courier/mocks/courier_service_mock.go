@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 	courier "service-courier/internal/model/courier"
+	geo "service-courier/internal/pkg/geo"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -41,6 +42,20 @@ func (m *MockcourierService) EXPECT() *MockcourierServiceMockRecorder {
 	return m.recorder
 }
 
+// ActivateCourier mocks base method.
+func (m *MockcourierService) ActivateCourier(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivateCourier", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ActivateCourier indicates an expected call of ActivateCourier.
+func (mr *MockcourierServiceMockRecorder) ActivateCourier(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateCourier", reflect.TypeOf((*MockcourierService)(nil).ActivateCourier), ctx, id)
+}
+
 // CreateCourier mocks base method.
 func (m *MockcourierService) CreateCourier(ctx context.Context, courierData courier.Courier) (int64, error) {
 	m.ctrl.T.Helper()
@@ -56,19 +71,46 @@ func (mr *MockcourierServiceMockRecorder) CreateCourier(ctx, courierData any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCourier", reflect.TypeOf((*MockcourierService)(nil).CreateCourier), ctx, courierData)
 }
 
-// GetAllCouriers mocks base method.
-func (m *MockcourierService) GetAllCouriers(ctx context.Context) ([]courier.Courier, error) {
+// DeactivateCourier mocks base method.
+func (m *MockcourierService) DeactivateCourier(ctx context.Context, id int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllCouriers", ctx)
-	ret0, _ := ret[0].([]courier.Courier)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "DeactivateCourier", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetAllCouriers indicates an expected call of GetAllCouriers.
-func (mr *MockcourierServiceMockRecorder) GetAllCouriers(ctx any) *gomock.Call {
+// DeactivateCourier indicates an expected call of DeactivateCourier.
+func (mr *MockcourierServiceMockRecorder) DeactivateCourier(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCouriers", reflect.TypeOf((*MockcourierService)(nil).GetAllCouriers), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateCourier", reflect.TypeOf((*MockcourierService)(nil).DeactivateCourier), ctx, id)
+}
+
+// DeleteCourier mocks base method.
+func (m *MockcourierService) DeleteCourier(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCourier", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCourier indicates an expected call of DeleteCourier.
+func (mr *MockcourierServiceMockRecorder) DeleteCourier(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCourier", reflect.TypeOf((*MockcourierService)(nil).DeleteCourier), ctx, id)
+}
+
+// EraseCourier mocks base method.
+func (m *MockcourierService) EraseCourier(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseCourier", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EraseCourier indicates an expected call of EraseCourier.
+func (mr *MockcourierServiceMockRecorder) EraseCourier(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseCourier", reflect.TypeOf((*MockcourierService)(nil).EraseCourier), ctx, id)
 }
 
 // GetCourier mocks base method.
@@ -86,6 +128,51 @@ func (mr *MockcourierServiceMockRecorder) GetCourier(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourier", reflect.TypeOf((*MockcourierService)(nil).GetCourier), ctx, id)
 }
 
+// ImportCouriers mocks base method.
+func (m *MockcourierService) ImportCouriers(ctx context.Context, rows []courier.ImportRow, mode courier.ImportMode) (*courier.ImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportCouriers", ctx, rows, mode)
+	ret0, _ := ret[0].(*courier.ImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportCouriers indicates an expected call of ImportCouriers.
+func (mr *MockcourierServiceMockRecorder) ImportCouriers(ctx, rows, mode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportCouriers", reflect.TypeOf((*MockcourierService)(nil).ImportCouriers), ctx, rows, mode)
+}
+
+// ListCouriers mocks base method.
+func (m *MockcourierService) ListCouriers(ctx context.Context, filter courier.ListFilter) (*courier.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCouriers", ctx, filter)
+	ret0, _ := ret[0].(*courier.Page)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCouriers indicates an expected call of ListCouriers.
+func (mr *MockcourierServiceMockRecorder) ListCouriers(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCouriers", reflect.TypeOf((*MockcourierService)(nil).ListCouriers), ctx, filter)
+}
+
+// PatchCourier mocks base method.
+func (m *MockcourierService) PatchCourier(ctx context.Context, courierData courier.Courier, setLocation bool) (*courier.Courier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchCourier", ctx, courierData, setLocation)
+	ret0, _ := ret[0].(*courier.Courier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchCourier indicates an expected call of PatchCourier.
+func (mr *MockcourierServiceMockRecorder) PatchCourier(ctx, courierData, setLocation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchCourier", reflect.TypeOf((*MockcourierService)(nil).PatchCourier), ctx, courierData, setLocation)
+}
+
 // UpdateCourier mocks base method.
 func (m *MockcourierService) UpdateCourier(ctx context.Context, courierData courier.Courier) error {
 	m.ctrl.T.Helper()
@@ -99,3 +186,17 @@ func (mr *MockcourierServiceMockRecorder) UpdateCourier(ctx, courierData any) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCourier", reflect.TypeOf((*MockcourierService)(nil).UpdateCourier), ctx, courierData)
 }
+
+// UpdateLocation mocks base method.
+func (m *MockcourierService) UpdateLocation(ctx context.Context, id int64, point geo.Point) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLocation", ctx, id, point)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLocation indicates an expected call of UpdateLocation.
+func (mr *MockcourierServiceMockRecorder) UpdateLocation(ctx, id, point any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLocation", reflect.TypeOf((*MockcourierService)(nil).UpdateLocation), ctx, id, point)
+}
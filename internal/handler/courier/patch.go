@@ -0,0 +1,149 @@
+package courier
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"service-courier/internal/model/courier"
+	"service-courier/internal/pkg/mergepatch"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const maxPatchSize = 64 << 10
+
+var errIfMatchRequired = errors.New("If-Match header is required")
+
+// Patch меняет курьера по JSON Merge Patch (RFC 7396): переданные поля заменяются,
+// null удаляет поле (из полей курьера удалить можно только location). Обязателен
+// заголовок If-Match с ETag из GET: если курьера с тех пор изменили, ответ 412.
+func (h *Handler) Patch(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "Invalid courier ID",
+		})
+		return
+	}
+
+	if r.Header.Get("If-Match") == "" {
+		h.writeJSON(w, http.StatusPreconditionRequired, map[string]string{
+			"error": errIfMatchRequired.Error(),
+		})
+		return
+	}
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	patch, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPatchSize))
+	if err != nil {
+		h.writeJSON(w, http.StatusRequestEntityTooLarge, map[string]string{
+			"error": "Request body is too large",
+		})
+		return
+	}
+
+	current, err := h.service.GetCourier(r.Context(), id)
+	if err != nil {
+		log.Printf("patch courier: %v", err)
+		h.writeError(w, err)
+		return
+	}
+	// If-Match: * - подходит любая версия, но сохранение все равно сверяется с прочитанной
+	if version != 0 && version != current.Version {
+		h.writeError(w, courier.ErrVersionMismatch)
+		return
+	}
+
+	currentResponse := ModelToResponse(*current)
+	target, err := json.Marshal(currentResponse)
+	if err != nil {
+		log.Printf("patch courier: %v", err)
+		h.writeError(w, err)
+		return
+	}
+	merged, err := mergepatch.Apply(target, patch)
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// позиция меняется отдельным потоком без версии, поэтому пишется, только если патч ее задает или удаляет
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &fields); err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+	_, setLocation := fields["location"]
+
+	var doc PatchDocument
+	decoder := json.NewDecoder(bytes.NewReader(merged))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&doc); err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": fmt.Sprintf("invalid patch: %v", err),
+		})
+		return
+	}
+	if doc.DeactivatedAt != currentResponse.DeactivatedAt {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "deactivated_at is changed via deactivate and activate",
+		})
+		return
+	}
+	if err := doc.Validate(id); err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	updated, err := h.service.PatchCourier(r.Context(), doc.ToModel(id, current.Version), setLocation)
+	if err != nil {
+		log.Printf("patch courier: %v", err)
+		h.writeError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", etag(updated.Version))
+	h.writeJSON(w, http.StatusOK, ModelToResponse(*updated))
+}
+
+// etag - сильный ETag курьера по его версии
+func etag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// ifMatchVersion - версия курьера из заголовка If-Match; 0, если заголовка нет или он равен "*".
+// Слабый ETag (W/"...") для If-Match не подходит по RFC 9110.
+func ifMatchVersion(r *http.Request) (int64, error) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" || value == "*" {
+		return 0, nil
+	}
+
+	unquoted, err := strconv.Unquote(value)
+	if err != nil || !strings.HasPrefix(value, `"`) {
+		return 0, errors.New("invalid If-Match, expected a single ETag")
+	}
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, errors.New("invalid If-Match, expected a single ETag")
+	}
+	return version, nil
+}
@@ -2,52 +2,36 @@ package courier
 
 import (
 	"fmt"
-	"service-courier/internal/model/courier"
-	"unicode"
+	dto "service-courier/internal/dto/courier"
 )
 
-func (r CreateRequest) Validate() error {
-	if err := validateName(r.Name); err != nil {
-		return err
-	}
-	if err := validatePhone(r.Phone); err != nil {
-		return err
-	}
-	if err := validateStatus(r.Status); err != nil {
-		return err
-	}
-	if err := validateTransportType(r.TransportType); err != nil {
-		return err
-	}
-	return nil
-}
-
-func (r UpdateRequest) Validate() error {
-	if r.ID <= 0 {
-		return fmt.Errorf("invalid id")
+func (d PatchDocument) Validate(id int64) error {
+	if d.ID != id {
+		return fmt.Errorf("id cannot be changed")
 	}
 
-	if r.Name == "" && r.Phone == "" && r.Status == "" && r.TransportType == "" {
-		return fmt.Errorf("all fields are empty")
+	fields := []struct {
+		name     string
+		value    *string
+		validate func(string) error
+	}{
+		{"name", d.Name, dto.ValidateName},
+		{"phone", d.Phone, dto.ValidatePhone},
+		{"status", d.Status, dto.ValidateStatus},
+		{"transport_type", d.TransportType, dto.ValidateTransportType},
 	}
-
-	if r.Name != "" {
-		if err := validateName(r.Name); err != nil {
-			return err
+	for _, field := range fields {
+		if field.value == nil {
+			return fmt.Errorf("%s cannot be removed", field.name)
 		}
-	}
-	if r.Phone != "" {
-		if err := validatePhone(r.Phone); err != nil {
+		if err := field.validate(*field.value); err != nil {
 			return err
 		}
 	}
-	if r.Status != "" {
-		if err := validateStatus(r.Status); err != nil {
-			return err
-		}
-	}
-	if r.TransportType != "" {
-		if err := validateTransportType(r.TransportType); err != nil {
+
+	if d.Location != nil {
+		location := UpdateLocationRequest{Lat: d.Location.Lat, Lon: d.Location.Lon}
+		if err := location.Validate(); err != nil {
 			return err
 		}
 	}
@@ -55,46 +39,12 @@ func (r UpdateRequest) Validate() error {
 	return nil
 }
 
-func validateName(name string) error {
-	if name == "" || len(name) > 100 {
-		return fmt.Errorf("name is too long or empty")
+func (r UpdateLocationRequest) Validate() error {
+	if r.Lat == nil || r.Lon == nil {
+		return fmt.Errorf("lat and lon are required")
 	}
-	return nil
-}
-
-func validatePhone(phone string) error {
-	if phone == "" {
-		return fmt.Errorf("phone is empty")
-	}
-
-	if len(phone) != 12 || phone[0] != '+' {
-		return fmt.Errorf("invalid phone")
-	}
-
-	digits := phone[1:]
-	for _, digit := range digits {
-		if !unicode.IsDigit(digit) {
-			return fmt.Errorf("invalid phone")
-		}
+	if !r.ToPoint().Valid() {
+		return fmt.Errorf("invalid coordinates")
 	}
-
 	return nil
 }
-
-func validateStatus(status string) error {
-	switch status {
-	case courier.StatusAvailable, courier.StatusBusy, courier.StatusPaused:
-		return nil
-	default:
-		return fmt.Errorf("invalid status")
-	}
-}
-
-func validateTransportType(transportType string) error {
-	switch transportType {
-	case courier.TransportOnFoot, courier.TransportScooter, courier.TransportCar:
-		return nil
-	default:
-		return fmt.Errorf("invalid transport type")
-	}
-}
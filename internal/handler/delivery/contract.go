@@ -4,10 +4,21 @@ package delivery
 
 import (
 	"context"
+	modelDelivery "service-courier/internal/model/delivery"
 	"service-courier/internal/service/delivery"
 )
 
 type deliveryService interface {
-	AssignCourier(ctx context.Context, orderID string) (*delivery.AssignResult, error)
+	AssignCourier(ctx context.Context, orderID string, priority modelDelivery.Priority) (*delivery.AssignResult, error)
 	UnassignCourier(ctx context.Context, orderID string) (*delivery.UnassignResult, error)
+	TransitionDelivery(ctx context.Context, orderID string, to modelDelivery.DeliveryStatus) (*delivery.TransitionResult, error)
+	GetDeliveryHistory(ctx context.Context, orderID string) ([]modelDelivery.Event, error)
+	GetDelivery(ctx context.Context, orderID string) (*modelDelivery.Delivery, error)
+	GetLatestDelivery(ctx context.Context, orderID string) (*modelDelivery.Delivery, error)
+	ListDeliveries(ctx context.Context, filter modelDelivery.ListFilter) (*modelDelivery.Page, error)
+	DeclineOffer(ctx context.Context, orderID string) (*delivery.DeclineResult, error)
+	GetCourierOfferStats(ctx context.Context, courierID int64) (*modelDelivery.OfferStats, error)
+	GetCourierStats(ctx context.Context, courierID int64, filter modelDelivery.StatsFilter) (*modelDelivery.CourierStats, error)
+	ListCourierStats(ctx context.Context, filter modelDelivery.StatsFilter) ([]modelDelivery.CourierStats, error)
+	WatchEvents(ctx context.Context, filter modelDelivery.EventFilter, send func([]modelDelivery.Event) error) error
 }
@@ -1,17 +1,86 @@
 package delivery
 
 import (
+	modelDelivery "service-courier/internal/model/delivery"
 	"service-courier/internal/service/delivery"
 	"time"
 )
 
 func ResultToAssignResponse(res delivery.AssignResult) AssignResponse {
-	return AssignResponse{
+	resp := AssignResponse{
 		CourierID:     res.CourierID,
 		OrderID:       res.OrderID,
 		TransportType: string(res.TransportType),
 		Deadline:      res.Deadline.Format(time.RFC3339),
+		Status:        string(res.Status),
 	}
+	if res.OfferExpiresAt != nil {
+		expiresAt := res.OfferExpiresAt.Format(time.RFC3339)
+		resp.OfferExpiresAt = &expiresAt
+	}
+	return resp
+}
+
+func ResultToDeclineResponse(res delivery.DeclineResult) DeclineResponse {
+	resp := DeclineResponse{
+		OrderID:           res.OrderID,
+		DeclinedCourierID: res.CourierID,
+		Status:            modelDelivery.StatusUnassigned,
+	}
+
+	switch {
+	case res.Next != nil:
+		next := ResultToAssignResponse(*res.Next)
+		resp.Next = &next
+		resp.Status = next.Status
+	case res.Queued:
+		resp.Status = "pending"
+	}
+	return resp
+}
+
+func StatsToOfferStatsResponse(stats modelDelivery.OfferStats) OfferStatsResponse {
+	return OfferStatsResponse{
+		CourierID:      stats.CourierID,
+		Offered:        stats.Offered,
+		Accepted:       stats.Accepted,
+		Declined:       stats.Declined,
+		Expired:        stats.Expired,
+		AcceptanceRate: stats.AcceptanceRate(),
+	}
+}
+
+func StatsToCourierStatsResponse(stats modelDelivery.CourierStats) CourierStatsResponse {
+	return CourierStatsResponse{
+		CourierID:          stats.CourierID,
+		TransportType:      stats.TransportType,
+		Assigned:           stats.Assigned,
+		Delivered:          stats.Delivered,
+		OnTime:             stats.OnTime,
+		Unassigned:         stats.Unassigned,
+		Expired:            stats.Expired,
+		Returned:           stats.Returned,
+		OnTimeRate:         stats.OnTimeRate(),
+		AvgDeliverySeconds: int64(stats.AvgDeliveryTime().Seconds()),
+		BusySeconds:        int64(stats.BusyTime.Seconds()),
+		ShiftSeconds:       int64(stats.ShiftTime.Seconds()),
+		Utilisation:        stats.Utilisation(),
+	}
+}
+
+func StatsToStatsListResponse(filter modelDelivery.StatsFilter, stats []modelDelivery.CourierStats) StatsListResponse {
+	resp := StatsListResponse{
+		From:  filter.From.Format(time.RFC3339),
+		To:    filter.To.Format(time.RFC3339),
+		Stats: make([]CourierStatsResponse, len(stats)),
+	}
+	if filter.ByTransport {
+		resp.GroupBy = groupByTransport
+	}
+	for i, s := range stats {
+		resp.Stats[i] = StatsToCourierStatsResponse(s)
+	}
+	return resp
 }
 
 func ResultToUnassignResponse(res delivery.UnassignResult) UnassignResponse {
@@ -21,3 +90,86 @@ func ResultToUnassignResponse(res delivery.UnassignResult) UnassignResponse {
 		CourierID: res.CourierID,
 	}
 }
+
+func ResultToTransitionResponse(res delivery.TransitionResult) TransitionResponse {
+	return TransitionResponse{
+		OrderID:        res.OrderID,
+		CourierID:      res.CourierID,
+		PreviousStatus: string(res.From),
+		Status:         string(res.To),
+	}
+}
+
+func EventsToHistoryResponse(orderID string, events []modelDelivery.Event) HistoryResponse {
+	resp := HistoryResponse{
+		OrderID: orderID,
+		Events:  make([]HistoryEventResponse, 0, len(events)),
+	}
+
+	for _, e := range events {
+		var from *string
+		if e.FromStatus != "" {
+			status := string(e.FromStatus)
+			from = &status
+		}
+		resp.Events = append(resp.Events, HistoryEventResponse{
+			DeliveryID: e.DeliveryID,
+			CourierID:  e.CourierID,
+			FromStatus: from,
+			ToStatus:   string(e.ToStatus),
+			Actor:      string(e.Actor),
+			Reason:     e.Reason,
+			CreatedAt:  e.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return resp
+}
+
+func EventToResponse(e modelDelivery.Event) EventResponse {
+	var from *string
+	if e.FromStatus != "" {
+		status := string(e.FromStatus)
+		from = &status
+	}
+	return EventResponse{
+		ID:         e.ID,
+		Type:       e.Kind(),
+		OrderID:    e.OrderID,
+		DeliveryID: e.DeliveryID,
+		CourierID:  e.CourierID,
+		FromStatus: from,
+		ToStatus:   string(e.ToStatus),
+		Actor:      string(e.Actor),
+		Reason:     e.Reason,
+		CreatedAt:  e.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func ModelToDeliveryResponse(d modelDelivery.Delivery) DeliveryResponse {
+	resp := DeliveryResponse{
+		ID:         d.ID,
+		OrderID:    d.OrderID,
+		CourierID:  d.CourierID,
+		Status:     string(d.Status),
+		Priority:   string(d.Priority),
+		AssignedAt: d.AssignedAt.Format(time.RFC3339),
+		Deadline:   d.Deadline.Format(time.RFC3339),
+	}
+	if d.DeletedAt != nil {
+		deletedAt := d.DeletedAt.Format(time.RFC3339)
+		resp.DeletedAt = &deletedAt
+	}
+	return resp
+}
+
+func PageToListResponse(page modelDelivery.Page, nextCursor string) ListResponse {
+	resp := ListResponse{
+		Deliveries: make([]DeliveryResponse, len(page.Deliveries)),
+		NextCursor: nextCursor,
+	}
+	for i, d := range page.Deliveries {
+		resp.Deliveries[i] = ModelToDeliveryResponse(d)
+	}
+	return resp
+}
@@ -3,11 +3,16 @@ package delivery
 import (
 	"encoding/json"
 	"errors"
+	"io"
 	"log"
 	"net/http"
 	"service-courier/internal/model/courier"
 	"service-courier/internal/model/delivery"
+	"service-courier/internal/model/zone"
+	serviceDelivery "service-courier/internal/service/delivery"
+	"strconv"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 )
 
@@ -35,7 +40,24 @@ func (h *Handler) Assign(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, err := h.service.AssignCourier(r.Context(), req.OrderID)
+	priority, err := delivery.ParsePriority(req.Priority)
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "Invalid priority",
+		})
+		return
+	}
+
+	ctx := serviceDelivery.WithChangeSource(r.Context(), delivery.ActorHTTP, "")
+	result, err := h.service.AssignCourier(ctx, req.OrderID, priority)
+	if errors.Is(err, delivery.ErrAssignmentPending) {
+		h.writeJSON(w, http.StatusAccepted, map[string]string{
+			"order_id": req.OrderID,
+			"status":   "pending",
+			"message":  "No available couriers, order is queued for assignment",
+		})
+		return
+	}
 	if err != nil {
 		log.Printf("assign courier: %v", err)
 		h.writeError(w, err)
@@ -61,7 +83,8 @@ func (h *Handler) Unassign(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, err := h.service.UnassignCourier(r.Context(), req.OrderID)
+	ctx := serviceDelivery.WithChangeSource(r.Context(), delivery.ActorHTTP, req.Reason)
+	result, err := h.service.UnassignCourier(ctx, req.OrderID)
 	if err != nil {
 		log.Printf("unassign courier: %v", err)
 		h.writeError(w, err)
@@ -71,6 +94,126 @@ func (h *Handler) Unassign(w http.ResponseWriter, r *http.Request) {
 	h.writeJSON(w, http.StatusOK, ResultToUnassignResponse(*result))
 }
 
+func (h *Handler) Accept(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, delivery.StatusAccepted)
+}
+
+// Decline - курьер отказывается от предложенного заказа, заказ предлагается следующему курьеру
+func (h *Handler) Decline(w http.ResponseWriter, r *http.Request) {
+	orderID := chi.URLParam(r, "order_id")
+	if _, err := uuid.Parse(orderID); err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "Invalid order_id",
+		})
+		return
+	}
+
+	var req TransitionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "Invalid JSON",
+		})
+		return
+	}
+
+	ctx := serviceDelivery.WithChangeSource(r.Context(), delivery.ActorHTTP, req.Reason)
+	result, err := h.service.DeclineOffer(ctx, orderID)
+	if err != nil {
+		log.Printf("decline offer: %v", err)
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, ResultToDeclineResponse(*result))
+}
+
+func (h *Handler) PickUp(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, delivery.StatusPickedUp)
+}
+
+func (h *Handler) StartTransit(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, delivery.StatusInTransit)
+}
+
+func (h *Handler) Deliver(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, delivery.StatusDelivered)
+}
+
+func (h *Handler) Fail(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, delivery.StatusFailed)
+}
+
+func (h *Handler) Return(w http.ResponseWriter, r *http.Request) {
+	h.transition(w, r, delivery.StatusReturned)
+}
+
+func (h *Handler) transition(w http.ResponseWriter, r *http.Request, to delivery.DeliveryStatus) {
+	orderID := chi.URLParam(r, "order_id")
+	if _, err := uuid.Parse(orderID); err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "Invalid order_id",
+		})
+		return
+	}
+
+	var req TransitionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "Invalid JSON",
+		})
+		return
+	}
+
+	ctx := serviceDelivery.WithChangeSource(r.Context(), delivery.ActorHTTP, req.Reason)
+	result, err := h.service.TransitionDelivery(ctx, orderID, to)
+	if err != nil {
+		log.Printf("transition delivery to %s: %v", to, err)
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, ResultToTransitionResponse(*result))
+}
+
+func (h *Handler) History(w http.ResponseWriter, r *http.Request) {
+	orderID := chi.URLParam(r, "order_id")
+	if _, err := uuid.Parse(orderID); err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "Invalid order_id",
+		})
+		return
+	}
+
+	events, err := h.service.GetDeliveryHistory(r.Context(), orderID)
+	if err != nil {
+		log.Printf("get delivery history: %v", err)
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, EventsToHistoryResponse(orderID, events))
+}
+
+// OfferStats - сколько заказов курьеру предлагали и какую долю он принял
+func (h *Handler) OfferStats(w http.ResponseWriter, r *http.Request) {
+	courierID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "Invalid courier ID",
+		})
+		return
+	}
+
+	stats, err := h.service.GetCourierOfferStats(r.Context(), courierID)
+	if err != nil {
+		log.Printf("get courier offer stats: %v", err)
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, StatsToOfferStatsResponse(*stats))
+}
+
 func (h *Handler) writeJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -90,8 +233,20 @@ func (h *Handler) mapError(err error) (string, int) {
 		return "Delivery not found", http.StatusNotFound
 	case errors.Is(err, delivery.ErrOrderAlreadyAssigned):
 		return "Order already assigned", http.StatusConflict
+	case errors.Is(err, delivery.ErrInvalidTransition):
+		return "Invalid delivery status transition", http.StatusConflict
+	case errors.Is(err, delivery.ErrOfferExpired):
+		return "Delivery offer expired", http.StatusConflict
+	case errors.Is(err, delivery.ErrCourierUnavailable):
+		return "Courier can no longer take the delivery", http.StatusConflict
+	case errors.Is(err, delivery.ErrTooManyStreams):
+		return "Too many event streams", http.StatusServiceUnavailable
+	case errors.Is(err, courier.ErrCourierNotFound):
+		return "Courier not found", http.StatusNotFound
 	case errors.Is(err, courier.ErrNoAvailableCouriers):
 		return "No available couriers", http.StatusConflict
+	case errors.Is(err, zone.ErrOutsideZones):
+		return "Order location is outside of delivery zones", http.StatusUnprocessableEntity
 	default:
 		return "Internal server error", http.StatusInternalServerError
 	}
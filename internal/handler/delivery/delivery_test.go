@@ -2,9 +2,11 @@ package delivery_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,6 +17,7 @@ import (
 	"service-courier/internal/handler/delivery/mocks"
 	modelCourier "service-courier/internal/model/courier"
 	modelDelivery "service-courier/internal/model/delivery"
+	modelZone "service-courier/internal/model/zone"
 	dtoDelivery "service-courier/internal/service/delivery"
 )
 
@@ -26,7 +29,7 @@ func TestAssignCourier_Success(t *testing.T) {
 	mockService := mocks.NewMockdeliveryService(ctrl)
 
 	mockService.EXPECT().
-		AssignCourier(gomock.Any(), "f819526d-6a7c-48eb-b535-43989469d1ca").
+		AssignCourier(gomock.Any(), "f819526d-6a7c-48eb-b535-43989469d1ca", modelDelivery.PriorityStandard).
 		Return(&dtoDelivery.AssignResult{
 			OrderID:       "f819526d-6a7c-48eb-b535-43989469d1ca",
 			CourierID:     10,
@@ -84,6 +87,49 @@ func TestAssignCourier_InvalidJSON(t *testing.T) {
 	}
 }
 
+func TestAssignCourier_WithPriority(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockdeliveryService(ctrl)
+
+	mockService.EXPECT().
+		AssignCourier(gomock.Any(), "f819526d-6a7c-48eb-b535-43989469d1ca", modelDelivery.PriorityExpress).
+		Return(&dtoDelivery.AssignResult{OrderID: "f819526d-6a7c-48eb-b535-43989469d1ca", CourierID: 10}, nil)
+
+	h := deliveryHandler.NewDeliveryHandler(mockService)
+	r := chi.NewRouter()
+	r.Post("/delivery/assign", h.Assign)
+
+	body := `{"order_id":"f819526d-6a7c-48eb-b535-43989469d1ca","priority":"express"}`
+	req := httptest.NewRequest("POST", "/delivery/assign", bytes.NewBufferString(body))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", rr.Code)
+	}
+}
+
+func TestAssignCourier_InvalidPriority(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockdeliveryService(ctrl)
+	h := deliveryHandler.NewDeliveryHandler(mockService)
+	r := chi.NewRouter()
+	r.Post("/delivery/assign", h.Assign)
+
+	body := `{"order_id":"f819526d-6a7c-48eb-b535-43989469d1ca","priority":"urgent"}`
+	req := httptest.NewRequest("POST", "/delivery/assign", bytes.NewBufferString(body))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 Bad Request, got %d", rr.Code)
+	}
+}
+
 func TestAssignCourier_EmptyOrderID(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
@@ -109,7 +155,7 @@ func TestAssignCourier_OrderAlreadyAssigned(t *testing.T) {
 	defer ctrl.Finish()
 	mockService := mocks.NewMockdeliveryService(ctrl)
 	mockService.EXPECT().
-		AssignCourier(gomock.Any(), "f819526d-6a7c-48eb-b535-43989469d1ca").
+		AssignCourier(gomock.Any(), "f819526d-6a7c-48eb-b535-43989469d1ca", modelDelivery.PriorityStandard).
 		Return(nil, modelDelivery.ErrOrderAlreadyAssigned)
 
 	h := deliveryHandler.NewDeliveryHandler(mockService)
@@ -126,13 +172,59 @@ func TestAssignCourier_OrderAlreadyAssigned(t *testing.T) {
 	}
 }
 
+func TestAssignCourier_Queued(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockdeliveryService(ctrl)
+	mockService.EXPECT().
+		AssignCourier(gomock.Any(), "f819526d-6a7c-48eb-b535-43989469d1ca", modelDelivery.PriorityStandard).
+		Return(nil, modelDelivery.ErrAssignmentPending)
+
+	h := deliveryHandler.NewDeliveryHandler(mockService)
+	r := chi.NewRouter()
+	r.Post("/delivery/assign", h.Assign)
+
+	body := `{"order_id":"f819526d-6a7c-48eb-b535-43989469d1ca"}`
+	req := httptest.NewRequest("POST", "/delivery/assign", bytes.NewBufferString(body))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusAccepted {
+		t.Fatalf("expected 202 Accepted, got %d", rr.Code)
+	}
+}
+
+func TestAssignCourier_OutsideZones(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockdeliveryService(ctrl)
+	mockService.EXPECT().
+		AssignCourier(gomock.Any(), "f819526d-6a7c-48eb-b535-43989469d1ca", modelDelivery.PriorityStandard).
+		Return(nil, modelZone.ErrOutsideZones)
+
+	h := deliveryHandler.NewDeliveryHandler(mockService)
+	r := chi.NewRouter()
+	r.Post("/delivery/assign", h.Assign)
+
+	body := `{"order_id":"f819526d-6a7c-48eb-b535-43989469d1ca"}`
+	req := httptest.NewRequest("POST", "/delivery/assign", bytes.NewBufferString(body))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422 Unprocessable Entity, got %d", rr.Code)
+	}
+}
+
 func TestAssignCourier_NoAvailableCouriers(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	mockService := mocks.NewMockdeliveryService(ctrl)
 	mockService.EXPECT().
-		AssignCourier(gomock.Any(), "f819526d-6a7c-48eb-b535-43989469d1ca").
+		AssignCourier(gomock.Any(), "f819526d-6a7c-48eb-b535-43989469d1ca", modelDelivery.PriorityStandard).
 		Return(nil, modelCourier.ErrNoAvailableCouriers)
 
 	h := deliveryHandler.NewDeliveryHandler(mockService)
@@ -249,3 +341,756 @@ func TestUnassignCourier_DeliveryNotFound(t *testing.T) {
 		t.Fatalf("expected 404 Not Found, got %d", rr.Code)
 	}
 }
+
+func TestAcceptDelivery_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockdeliveryService(ctrl)
+
+	mockService.EXPECT().
+		TransitionDelivery(gomock.Any(), "f819526d-6a7c-48eb-b535-43989469d1ca", modelDelivery.DeliveryStatus(modelDelivery.StatusAccepted)).
+		Return(&dtoDelivery.TransitionResult{
+			OrderID:   "f819526d-6a7c-48eb-b535-43989469d1ca",
+			CourierID: 10,
+			From:      modelDelivery.StatusAssigned,
+			To:        modelDelivery.StatusAccepted,
+		}, nil)
+
+	h := deliveryHandler.NewDeliveryHandler(mockService)
+	r := chi.NewRouter()
+	r.Post("/delivery/{order_id}/accept", h.Accept)
+
+	req := httptest.NewRequest("POST", "/delivery/f819526d-6a7c-48eb-b535-43989469d1ca/accept", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", rr.Code)
+	}
+
+	var resp deliveryHandler.TransitionResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != modelDelivery.StatusAccepted {
+		t.Fatalf("expected status=accepted, got %s", resp.Status)
+	}
+	if resp.PreviousStatus != modelDelivery.StatusAssigned {
+		t.Fatalf("expected previous_status=assigned, got %s", resp.PreviousStatus)
+	}
+}
+
+func TestDeclineOffer_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockdeliveryService(ctrl)
+
+	expiresAt := time.Date(2025, 11, 30, 11, 1, 0, 0, time.UTC)
+	mockService.EXPECT().
+		DeclineOffer(gomock.Any(), "f819526d-6a7c-48eb-b535-43989469d1ca").
+		Return(&dtoDelivery.DeclineResult{
+			OrderID:   "f819526d-6a7c-48eb-b535-43989469d1ca",
+			CourierID: 10,
+			Next: &dtoDelivery.AssignResult{
+				OrderID:        "f819526d-6a7c-48eb-b535-43989469d1ca",
+				CourierID:      11,
+				TransportType:  "car",
+				Deadline:       time.Date(2025, 11, 30, 12, 0, 0, 0, time.UTC),
+				Status:         modelDelivery.StatusOffered,
+				OfferExpiresAt: &expiresAt,
+			},
+		}, nil)
+
+	h := deliveryHandler.NewDeliveryHandler(mockService)
+	r := chi.NewRouter()
+	r.Post("/delivery/{order_id}/decline", h.Decline)
+
+	body := `{"reason":"scooter cannot carry the order"}`
+	req := httptest.NewRequest("POST", "/delivery/f819526d-6a7c-48eb-b535-43989469d1ca/decline", bytes.NewBufferString(body))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", rr.Code)
+	}
+
+	var resp deliveryHandler.DeclineResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.DeclinedCourierID != 10 || resp.Status != modelDelivery.StatusOffered {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	if resp.Next == nil || resp.Next.CourierID != 11 || resp.Next.OfferExpiresAt == nil {
+		t.Fatalf("expected offer to courier 11, got %+v", resp.Next)
+	}
+}
+
+func TestDeclineOffer_NotOffered(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockdeliveryService(ctrl)
+
+	mockService.EXPECT().
+		DeclineOffer(gomock.Any(), "f819526d-6a7c-48eb-b535-43989469d1ca").
+		Return(nil, &modelDelivery.TransitionError{From: modelDelivery.StatusAccepted, To: modelDelivery.StatusDeclined})
+
+	h := deliveryHandler.NewDeliveryHandler(mockService)
+	r := chi.NewRouter()
+	r.Post("/delivery/{order_id}/decline", h.Decline)
+
+	req := httptest.NewRequest("POST", "/delivery/f819526d-6a7c-48eb-b535-43989469d1ca/decline", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusConflict {
+		t.Fatalf("expected 409 Conflict, got %d", rr.Code)
+	}
+}
+
+func TestCourierOfferStats_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockdeliveryService(ctrl)
+
+	mockService.EXPECT().
+		GetCourierOfferStats(gomock.Any(), int64(10)).
+		Return(&modelDelivery.OfferStats{CourierID: 10, Offered: 5, Accepted: 4, Expired: 1}, nil)
+
+	h := deliveryHandler.NewDeliveryHandler(mockService)
+	r := chi.NewRouter()
+	r.Get("/courier/{id}/offers", h.OfferStats)
+
+	req := httptest.NewRequest("GET", "/courier/10/offers", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", rr.Code)
+	}
+
+	var resp deliveryHandler.OfferStatsResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.AcceptanceRate != 0.8 {
+		t.Fatalf("expected acceptance_rate=0.8, got %v", resp.AcceptanceRate)
+	}
+}
+
+func TestCourierOfferStats_CourierNotFound(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockdeliveryService(ctrl)
+
+	mockService.EXPECT().
+		GetCourierOfferStats(gomock.Any(), int64(99)).
+		Return(nil, modelCourier.ErrCourierNotFound)
+
+	h := deliveryHandler.NewDeliveryHandler(mockService)
+	r := chi.NewRouter()
+	r.Get("/courier/{id}/offers", h.OfferStats)
+
+	req := httptest.NewRequest("GET", "/courier/99/offers", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 Not Found, got %d", rr.Code)
+	}
+}
+
+func TestDeliverDelivery_InvalidTransition(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockdeliveryService(ctrl)
+
+	mockService.EXPECT().
+		TransitionDelivery(gomock.Any(), "f819526d-6a7c-48eb-b535-43989469d1ca", modelDelivery.DeliveryStatus(modelDelivery.StatusDelivered)).
+		Return(nil, &modelDelivery.TransitionError{From: modelDelivery.StatusAssigned, To: modelDelivery.StatusDelivered})
+
+	h := deliveryHandler.NewDeliveryHandler(mockService)
+	r := chi.NewRouter()
+	r.Post("/delivery/{order_id}/deliver", h.Deliver)
+
+	req := httptest.NewRequest("POST", "/delivery/f819526d-6a7c-48eb-b535-43989469d1ca/deliver", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusConflict {
+		t.Fatalf("expected 409 Conflict, got %d", rr.Code)
+	}
+}
+
+func TestPickUpDelivery_InvalidOrderID(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockdeliveryService(ctrl)
+
+	h := deliveryHandler.NewDeliveryHandler(mockService)
+	r := chi.NewRouter()
+	r.Post("/delivery/{order_id}/pickup", h.PickUp)
+
+	req := httptest.NewRequest("POST", "/delivery/not-a-uuid/pickup", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 Bad Request, got %d", rr.Code)
+	}
+}
+
+func TestFailDelivery_NotFound(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockdeliveryService(ctrl)
+
+	mockService.EXPECT().
+		TransitionDelivery(gomock.Any(), "f819526d-6a7c-48eb-b535-43989469d1ca", modelDelivery.DeliveryStatus(modelDelivery.StatusFailed)).
+		Return(nil, modelDelivery.ErrDeliveryNotFound)
+
+	h := deliveryHandler.NewDeliveryHandler(mockService)
+	r := chi.NewRouter()
+	r.Post("/delivery/{order_id}/fail", h.Fail)
+
+	req := httptest.NewRequest("POST", "/delivery/f819526d-6a7c-48eb-b535-43989469d1ca/fail", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 Not Found, got %d", rr.Code)
+	}
+}
+
+func TestFailDelivery_InvalidJSON(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockdeliveryService(ctrl)
+
+	h := deliveryHandler.NewDeliveryHandler(mockService)
+	r := chi.NewRouter()
+	r.Post("/delivery/{order_id}/fail", h.Fail)
+
+	req := httptest.NewRequest("POST", "/delivery/f819526d-6a7c-48eb-b535-43989469d1ca/fail", bytes.NewBufferString("{"))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 Bad Request, got %d", rr.Code)
+	}
+}
+
+func TestDeliveryHistory_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockdeliveryService(ctrl)
+
+	orderID := "f819526d-6a7c-48eb-b535-43989469d1ca"
+	createdAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	mockService.EXPECT().
+		GetDeliveryHistory(gomock.Any(), orderID).
+		Return([]modelDelivery.Event{
+			{ID: 1, DeliveryID: 5, OrderID: orderID, CourierID: 10, ToStatus: modelDelivery.StatusAssigned, Actor: modelDelivery.ActorKafka, Reason: "order created", CreatedAt: createdAt},
+			{ID: 2, DeliveryID: 5, OrderID: orderID, CourierID: 10, FromStatus: modelDelivery.StatusAssigned, ToStatus: modelDelivery.StatusAccepted, Actor: modelDelivery.ActorHTTP, CreatedAt: createdAt.Add(time.Minute)},
+		}, nil)
+
+	h := deliveryHandler.NewDeliveryHandler(mockService)
+	r := chi.NewRouter()
+	r.Get("/delivery/{order_id}/history", h.History)
+
+	req := httptest.NewRequest("GET", "/delivery/"+orderID+"/history", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", rr.Code)
+	}
+
+	var resp deliveryHandler.HistoryResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(resp.Events))
+	}
+	if resp.Events[0].FromStatus != nil {
+		t.Fatalf("expected empty from_status for first event, got %s", *resp.Events[0].FromStatus)
+	}
+	if resp.Events[1].FromStatus == nil || *resp.Events[1].FromStatus != modelDelivery.StatusAssigned {
+		t.Fatalf("expected from_status=assigned for second event")
+	}
+	if resp.Events[0].Actor != string(modelDelivery.ActorKafka) {
+		t.Fatalf("expected actor=kafka_worker, got %s", resp.Events[0].Actor)
+	}
+}
+
+func TestDeliveryHistory_NotFound(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockdeliveryService(ctrl)
+
+	mockService.EXPECT().
+		GetDeliveryHistory(gomock.Any(), "f819526d-6a7c-48eb-b535-43989469d1ca").
+		Return(nil, modelDelivery.ErrDeliveryNotFound)
+
+	h := deliveryHandler.NewDeliveryHandler(mockService)
+	r := chi.NewRouter()
+	r.Get("/delivery/{order_id}/history", h.History)
+
+	req := httptest.NewRequest("GET", "/delivery/f819526d-6a7c-48eb-b535-43989469d1ca/history", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 Not Found, got %d", rr.Code)
+	}
+}
+
+func TestDeliveryEvents_StreamsFromLastEventID(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockdeliveryService(ctrl)
+
+	orderID := "f819526d-6a7c-48eb-b535-43989469d1ca"
+	createdAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	mockService.EXPECT().
+		WatchEvents(gomock.Any(), modelDelivery.EventFilter{OrderID: orderID, AfterID: 41}, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ modelDelivery.EventFilter, send func([]modelDelivery.Event) error) error {
+			if err := send(nil); err != nil {
+				return err
+			}
+			return send([]modelDelivery.Event{
+				{ID: 42, DeliveryID: 5, OrderID: orderID, CourierID: 10, ToStatus: modelDelivery.StatusAssigned, Actor: modelDelivery.ActorHTTP, CreatedAt: createdAt},
+				{ID: 43, DeliveryID: 5, OrderID: orderID, CourierID: 10, FromStatus: modelDelivery.StatusAssigned, ToStatus: modelDelivery.StatusCompleted, Actor: modelDelivery.ActorExpiry, CreatedAt: createdAt},
+			})
+		})
+
+	h := deliveryHandler.NewDeliveryHandler(mockService)
+	r := chi.NewRouter()
+	r.Get("/delivery/{order_id}/events", h.Events)
+
+	req := httptest.NewRequest("GET", "/delivery/"+orderID+"/events", nil)
+	req.Header.Set("Last-Event-ID", "41")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", rr.Code)
+	}
+	if ct := rr.Header().Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("expected text/event-stream, got %s", ct)
+	}
+
+	body := rr.Body.String()
+	if !strings.HasPrefix(body, "retry: 3000\n\n") {
+		t.Fatalf("expected retry hint first, got %q", body)
+	}
+	if !strings.Contains(body, "id: 42\nevent: assigned\ndata: ") {
+		t.Fatalf("expected assigned event 42, got %q", body)
+	}
+	if !strings.Contains(body, "id: 43\nevent: expired\ndata: ") {
+		t.Fatalf("expected expired event 43, got %q", body)
+	}
+	if strings.Contains(body, "heartbeat") {
+		t.Fatalf("expected no heartbeat right after connect, got %q", body)
+	}
+}
+
+func TestCourierEvents_InvalidLastEventID(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockdeliveryService(ctrl)
+
+	h := deliveryHandler.NewDeliveryHandler(mockService)
+	r := chi.NewRouter()
+	r.Get("/courier/{id}/events", h.CourierEvents)
+
+	req := httptest.NewRequest("GET", "/courier/10/events?last_event_id=abc", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 Bad Request, got %d", rr.Code)
+	}
+}
+
+func TestCourierEvents_TooManyStreams(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockdeliveryService(ctrl)
+
+	mockService.EXPECT().
+		WatchEvents(gomock.Any(), modelDelivery.EventFilter{CourierID: 10}, gomock.Any()).
+		Return(modelDelivery.ErrTooManyStreams)
+
+	h := deliveryHandler.NewDeliveryHandler(mockService)
+	r := chi.NewRouter()
+	r.Get("/courier/{id}/events", h.CourierEvents)
+
+	req := httptest.NewRequest("GET", "/courier/10/events", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 Service Unavailable, got %d", rr.Code)
+	}
+}
+
+func TestGetDelivery_IncludeDeleted(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockdeliveryService(ctrl)
+
+	orderID := "f819526d-6a7c-48eb-b535-43989469d1ca"
+	deletedAt := time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC)
+
+	mockService.EXPECT().
+		GetLatestDelivery(gomock.Any(), orderID).
+		Return(&modelDelivery.Delivery{
+			ID:         5,
+			OrderID:    orderID,
+			CourierID:  10,
+			Status:     modelDelivery.StatusDeleted,
+			Priority:   modelDelivery.PriorityStandard,
+			AssignedAt: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+			Deadline:   time.Date(2024, 1, 1, 12, 45, 0, 0, time.UTC),
+			DeletedAt:  &deletedAt,
+		}, nil)
+
+	h := deliveryHandler.NewDeliveryHandler(mockService)
+	r := chi.NewRouter()
+	r.Get("/delivery/{order_id}", h.Get)
+
+	req := httptest.NewRequest("GET", "/delivery/"+orderID+"?include_deleted=true", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", rr.Code)
+	}
+
+	var resp deliveryHandler.DeliveryResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != modelDelivery.StatusDeleted {
+		t.Fatalf("expected status=deleted, got %s", resp.Status)
+	}
+	if resp.DeletedAt == nil || *resp.DeletedAt != "2024-01-01T12:30:00Z" {
+		t.Fatalf("expected deleted_at, got %v", resp.DeletedAt)
+	}
+}
+
+func TestGetDelivery_NotFound(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockdeliveryService(ctrl)
+
+	mockService.EXPECT().
+		GetDelivery(gomock.Any(), "f819526d-6a7c-48eb-b535-43989469d1ca").
+		Return(nil, modelDelivery.ErrDeliveryNotFound)
+
+	h := deliveryHandler.NewDeliveryHandler(mockService)
+	r := chi.NewRouter()
+	r.Get("/delivery/{order_id}", h.Get)
+
+	req := httptest.NewRequest("GET", "/delivery/f819526d-6a7c-48eb-b535-43989469d1ca", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 Not Found, got %d", rr.Code)
+	}
+}
+
+func TestListDeliveries_CursorRoundTrip(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockdeliveryService(ctrl)
+
+	courierID := int64(10)
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	lastAssignedAt := time.Date(2024, 1, 1, 12, 0, 0, 123000000, time.UTC)
+
+	mockService.EXPECT().
+		ListDeliveries(gomock.Any(), modelDelivery.ListFilter{
+			CourierID: &courierID,
+			Statuses:  []modelDelivery.DeliveryStatus{modelDelivery.StatusAssigned, modelDelivery.StatusAccepted},
+			From:      &from,
+			Sort:      modelDelivery.SortByAssignedAt,
+			Desc:      true,
+			Limit:     1,
+		}).
+		Return(&modelDelivery.Page{
+			Deliveries: []modelDelivery.Delivery{{ID: 7, CourierID: courierID, AssignedAt: lastAssignedAt}},
+			Next:       &modelDelivery.Cursor{At: lastAssignedAt, ID: 7},
+		}, nil)
+
+	h := deliveryHandler.NewDeliveryHandler(mockService)
+	r := chi.NewRouter()
+	r.Get("/deliveries", h.List)
+
+	req := httptest.NewRequest("GET", "/deliveries?courier_id=10&status=assigned,accepted&from=2024-01-01T00:00:00Z&limit=1", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var resp deliveryHandler.ListResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Deliveries) != 1 || resp.NextCursor == "" {
+		t.Fatalf("expected 1 delivery and next cursor, got %+v", resp)
+	}
+
+	mockService.EXPECT().
+		ListDeliveries(gomock.Any(), modelDelivery.ListFilter{
+			Sort:  modelDelivery.SortByAssignedAt,
+			Desc:  true,
+			After: &modelDelivery.Cursor{At: lastAssignedAt, ID: 7},
+		}).
+		Return(&modelDelivery.Page{}, nil)
+
+	req = httptest.NewRequest("GET", "/deliveries?cursor="+resp.NextCursor, nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK for next page, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	// курсор от сортировки по убыванию не подходит к сортировке по возрастанию
+	req = httptest.NewRequest("GET", "/deliveries?order=asc&cursor="+resp.NextCursor, nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 Bad Request for mismatched cursor, got %d", rr.Code)
+	}
+}
+
+func TestListDeliveries_InvalidQuery(t *testing.T) {
+	t.Parallel()
+
+	for _, query := range []string{
+		"status=lost",
+		"sort=courier_id",
+		"order=up",
+		"limit=0",
+		"limit=501",
+		"include_deleted=maybe",
+		"cursor=bm90LWpzb24",
+	} {
+		ctrl := gomock.NewController(t)
+		h := deliveryHandler.NewDeliveryHandler(mocks.NewMockdeliveryService(ctrl))
+		r := chi.NewRouter()
+		r.Get("/deliveries", h.List)
+
+		req := httptest.NewRequest("GET", "/deliveries?"+query, nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400 Bad Request, got %d", query, rr.Code)
+		}
+		ctrl.Finish()
+	}
+}
+
+func TestCourierStats_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockdeliveryService(ctrl)
+
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC)
+	mockService.EXPECT().
+		GetCourierStats(gomock.Any(), int64(10), modelDelivery.StatsFilter{From: from, To: to}).
+		Return(&modelDelivery.CourierStats{
+			CourierID:     10,
+			TransportType: "car",
+			Assigned:      5,
+			Delivered:     3,
+			OnTime:        3,
+			Expired:       1,
+			Unassigned:    1,
+			DeliveryTime:  90 * time.Minute,
+			Timed:         3,
+			BusyTime:      2 * time.Hour,
+			ShiftTime:     8 * time.Hour,
+		}, nil)
+
+	h := deliveryHandler.NewDeliveryHandler(mockService)
+	r := chi.NewRouter()
+	r.Get("/courier/{id}/stats", h.CourierStats)
+
+	req := httptest.NewRequest("GET", "/courier/10/stats?from=2024-03-01T03:00:00%2B03:00&to=2024-03-08T00:00:00Z", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var resp deliveryHandler.CourierStatsPeriodResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.From != "2024-03-01T00:00:00Z" || resp.CourierID != 10 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	if resp.OnTimeRate != 0.75 || resp.Utilisation != 0.25 {
+		t.Fatalf("expected on_time_rate=0.75 and utilisation=0.25, got %v and %v", resp.OnTimeRate, resp.Utilisation)
+	}
+	if resp.AvgDeliverySeconds != 1800 || resp.BusySeconds != 7200 {
+		t.Fatalf("expected avg_delivery_seconds=1800 and busy_seconds=7200, got %d and %d", resp.AvgDeliverySeconds, resp.BusySeconds)
+	}
+}
+
+func TestCourierStats_InvalidPeriod(t *testing.T) {
+	t.Parallel()
+
+	h := deliveryHandler.NewDeliveryHandler(nil)
+	r := chi.NewRouter()
+	r.Get("/courier/{id}/stats", h.CourierStats)
+
+	for _, query := range []string{
+		"",
+		"?from=2024-03-01T00:00:00Z",
+		"?from=yesterday&to=2024-03-08T00:00:00Z",
+		"?from=2024-03-08T00:00:00Z&to=2024-03-01T00:00:00Z",
+		"?from=2024-01-01T00:00:00Z&to=2024-06-01T00:00:00Z",
+	} {
+		req := httptest.NewRequest("GET", "/courier/10/stats"+query, nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("%q: expected 400 Bad Request, got %d", query, rr.Code)
+		}
+	}
+}
+
+func TestCourierStats_CourierNotFound(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockdeliveryService(ctrl)
+
+	mockService.EXPECT().
+		GetCourierStats(gomock.Any(), int64(99), gomock.Any()).
+		Return(nil, modelCourier.ErrCourierNotFound)
+
+	h := deliveryHandler.NewDeliveryHandler(mockService)
+	r := chi.NewRouter()
+	r.Get("/courier/{id}/stats", h.CourierStats)
+
+	req := httptest.NewRequest("GET", "/courier/99/stats?from=2024-03-01T00:00:00Z&to=2024-03-08T00:00:00Z", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 Not Found, got %d", rr.Code)
+	}
+}
+
+func TestStats_GroupByTransport(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockdeliveryService(ctrl)
+
+	mockService.EXPECT().
+		ListCourierStats(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, filter modelDelivery.StatsFilter) ([]modelDelivery.CourierStats, error) {
+			if !filter.ByTransport || filter.CourierID != nil {
+				t.Fatalf("unexpected filter: %+v", filter)
+			}
+			return []modelDelivery.CourierStats{
+				{TransportType: "car", Delivered: 2, OnTime: 1},
+				{TransportType: "scooter", Delivered: 4, OnTime: 4},
+			}, nil
+		})
+
+	h := deliveryHandler.NewDeliveryHandler(mockService)
+	r := chi.NewRouter()
+	r.Get("/couriers/stats", h.Stats)
+
+	req := httptest.NewRequest("GET", "/couriers/stats?from=2024-03-01T00:00:00Z&to=2024-03-08T00:00:00Z&group_by=transport_type", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", rr.Code)
+	}
+
+	var resp deliveryHandler.StatsListResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.GroupBy != "transport_type" || len(resp.Stats) != 2 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	if resp.Stats[0].OnTimeRate != 0.5 || resp.Stats[1].OnTimeRate != 1 {
+		t.Fatalf("unexpected on_time_rate: %v, %v", resp.Stats[0].OnTimeRate, resp.Stats[1].OnTimeRate)
+	}
+}
+
+func TestStats_InvalidGroupBy(t *testing.T) {
+	t.Parallel()
+
+	h := deliveryHandler.NewDeliveryHandler(nil)
+	r := chi.NewRouter()
+	r.Get("/couriers/stats", h.Stats)
+
+	req := httptest.NewRequest("GET", "/couriers/stats?from=2024-03-01T00:00:00Z&to=2024-03-08T00:00:00Z&group_by=zone", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 Bad Request, got %d", rr.Code)
+	}
+}
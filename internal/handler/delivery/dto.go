@@ -3,6 +3,8 @@ package delivery
 // AssignRequest запрос на назначение курьера на заказ
 type AssignRequest struct {
 	OrderID string `json:"order_id"`
+	// Priority - standard, express или vip, по умолчанию standard
+	Priority string `json:"priority,omitempty"`
 }
 
 // AssignResponse ответ на назначение курьера
@@ -11,11 +13,15 @@ type AssignResponse struct {
 	OrderID       string `json:"order_id"`
 	TransportType string `json:"transport_type"`
 	Deadline      string `json:"delivery_deadline"`
+	// Status - assigned или offered, если курьер еще должен принять заказ
+	Status         string  `json:"status,omitempty"`
+	OfferExpiresAt *string `json:"offer_expires_at,omitempty"`
 }
 
 // UnassignRequest запрос на снятие курьера с заказа
 type UnassignRequest struct {
 	OrderID string `json:"order_id"`
+	Reason  string `json:"reason,omitempty"`
 }
 
 // UnassignResponse ответ на снятие курьера
@@ -24,3 +30,119 @@ type UnassignResponse struct {
 	Status    string `json:"status"`
 	CourierID int64  `json:"courier_id"`
 }
+
+// TransitionResponse ответ на смену статуса доставки
+type TransitionResponse struct {
+	OrderID        string `json:"order_id"`
+	CourierID      int64  `json:"courier_id"`
+	PreviousStatus string `json:"previous_status"`
+	Status         string `json:"status"`
+}
+
+// TransitionRequest необязательное тело запроса на смену статуса доставки
+type TransitionRequest struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+// DeclineResponse ответ на отказ курьера от заказа
+type DeclineResponse struct {
+	OrderID           string `json:"order_id"`
+	DeclinedCourierID int64  `json:"declined_courier_id"`
+	// Status - offered, если заказ предложен следующему курьеру, pending - если ждет в очереди, иначе unassigned
+	Status string          `json:"status"`
+	Next   *AssignResponse `json:"next,omitempty"`
+}
+
+// OfferStatsResponse ответы курьера на предложения заказов
+type OfferStatsResponse struct {
+	CourierID      int64   `json:"courier_id"`
+	Offered        int64   `json:"offered"`
+	Accepted       int64   `json:"accepted"`
+	Declined       int64   `json:"declined"`
+	Expired        int64   `json:"expired"`
+	AcceptanceRate float64 `json:"acceptance_rate"`
+}
+
+// CourierStatsResponse показатели доставок курьера или типа транспорта за период.
+// Длительности в секундах, доли от 0 до 1.
+type CourierStatsResponse struct {
+	CourierID          int64   `json:"courier_id,omitempty"`
+	TransportType      string  `json:"transport_type"`
+	Assigned           int64   `json:"assigned"`
+	Delivered          int64   `json:"delivered"`
+	OnTime             int64   `json:"on_time"`
+	Unassigned         int64   `json:"unassigned"`
+	Expired            int64   `json:"expired"`
+	Returned           int64   `json:"returned"`
+	OnTimeRate         float64 `json:"on_time_rate"`
+	AvgDeliverySeconds int64   `json:"avg_delivery_seconds"`
+	BusySeconds        int64   `json:"busy_seconds"`
+	ShiftSeconds       int64   `json:"shift_seconds"`
+	Utilisation        float64 `json:"utilisation"`
+}
+
+// CourierStatsPeriodResponse показатели одного курьера за период
+type CourierStatsPeriodResponse struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	CourierStatsResponse
+}
+
+// StatsListResponse показатели всех курьеров за период
+type StatsListResponse struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// GroupBy - transport_type, если показатели сведены по типу транспорта
+	GroupBy string                 `json:"group_by,omitempty"`
+	Stats   []CourierStatsResponse `json:"stats"`
+}
+
+// HistoryEventResponse запись в истории доставки
+type HistoryEventResponse struct {
+	DeliveryID int64   `json:"delivery_id"`
+	CourierID  int64   `json:"courier_id"`
+	FromStatus *string `json:"from_status"`
+	ToStatus   string  `json:"to_status"`
+	Actor      string  `json:"actor"`
+	Reason     string  `json:"reason"`
+	CreatedAt  string  `json:"created_at"`
+}
+
+// HistoryResponse история изменений доставки заказа
+type HistoryResponse struct {
+	OrderID string                 `json:"order_id"`
+	Events  []HistoryEventResponse `json:"events"`
+}
+
+// EventResponse событие доставки в потоке SSE
+type EventResponse struct {
+	ID         int64   `json:"id"`
+	Type       string  `json:"type"`
+	OrderID    string  `json:"order_id"`
+	DeliveryID int64   `json:"delivery_id"`
+	CourierID  int64   `json:"courier_id"`
+	FromStatus *string `json:"from_status"`
+	ToStatus   string  `json:"to_status"`
+	Actor      string  `json:"actor"`
+	Reason     string  `json:"reason"`
+	CreatedAt  string  `json:"created_at"`
+}
+
+// DeliveryResponse доставка заказа
+type DeliveryResponse struct {
+	ID         int64   `json:"id"`
+	OrderID    string  `json:"order_id"`
+	CourierID  int64   `json:"courier_id"`
+	Status     string  `json:"status"`
+	Priority   string  `json:"priority"`
+	AssignedAt string  `json:"assigned_at"`
+	Deadline   string  `json:"deadline"`
+	DeletedAt  *string `json:"deleted_at,omitempty"`
+}
+
+// ListResponse страница списка доставок
+type ListResponse struct {
+	Deliveries []DeliveryResponse `json:"deliveries"`
+	// NextCursor передается в cursor за следующей страницей, пустой на последней
+	NextCursor string `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,162 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"service-courier/internal/model/delivery"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	// streamWriteTimeout - сколько ждать записи подписчику; кто не успевает читать, отключается
+	// и переподключается с Last-Event-ID
+	streamWriteTimeout = 10 * time.Second
+	// streamHeartbeat - как часто слать комментарий в тихий поток, чтобы прокси не закрыли соединение
+	streamHeartbeat = 15 * time.Second
+	// streamRetry - через сколько EventSource переподключится после обрыва
+	streamRetry = 3 * time.Second
+)
+
+// Events - SSE-поток событий доставки заказа: назначение, снятие курьера, завершение, истечение срока
+func (h *Handler) Events(w http.ResponseWriter, r *http.Request) {
+	orderID := chi.URLParam(r, "order_id")
+	if _, err := uuid.Parse(orderID); err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "Invalid order_id",
+		})
+		return
+	}
+
+	h.streamEvents(w, r, delivery.EventFilter{OrderID: orderID})
+}
+
+// CourierEvents - SSE-поток событий по всем доставкам курьера
+func (h *Handler) CourierEvents(w http.ResponseWriter, r *http.Request) {
+	courierID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || courierID <= 0 {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "Invalid courier ID",
+		})
+		return
+	}
+
+	h.streamEvents(w, r, delivery.EventFilter{CourierID: courierID})
+}
+
+func (h *Handler) streamEvents(w http.ResponseWriter, r *http.Request, filter delivery.EventFilter) {
+	afterID, err := lastEventID(r)
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "Invalid Last-Event-ID",
+		})
+		return
+	}
+	filter.AfterID = afterID
+
+	stream := &sseWriter{w: w, rc: http.NewResponseController(w)}
+	err = h.service.WatchEvents(r.Context(), filter, stream.send)
+
+	switch {
+	case err == nil, errors.Is(err, context.Canceled):
+	case !stream.opened:
+		log.Printf("watch delivery events: %v", err)
+		h.writeError(w, err)
+	default:
+		log.Printf("delivery event stream closed: %v", err)
+	}
+}
+
+// lastEventID - id последнего полученного события: заголовок Last-Event-ID при переподключении
+// EventSource или параметр last_event_id для первого подключения
+func lastEventID(r *http.Request) (int64, error) {
+	value := r.Header.Get("Last-Event-ID")
+	if value == "" {
+		value = r.URL.Query().Get("last_event_id")
+	}
+	if value == "" {
+		return 0, nil
+	}
+
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id < 0 {
+		return 0, fmt.Errorf("invalid last event id %q", value)
+	}
+	return id, nil
+}
+
+type sseWriter struct {
+	w         http.ResponseWriter
+	rc        *http.ResponseController
+	opened    bool
+	lastWrite time.Time
+}
+
+func (s *sseWriter) send(events []delivery.Event) error {
+	if !s.opened {
+		if err := s.open(); err != nil {
+			return err
+		}
+	}
+
+	if len(events) == 0 {
+		if time.Since(s.lastWrite) < streamHeartbeat {
+			return nil
+		}
+		return s.write(func() error {
+			_, err := fmt.Fprint(s.w, ": heartbeat\n\n")
+			return err
+		})
+	}
+
+	return s.write(func() error {
+		for _, e := range events {
+			data, err := json.Marshal(EventToResponse(e))
+			if err != nil {
+				return fmt.Errorf("marshal delivery event: %w", err)
+			}
+			if _, err := fmt.Fprintf(s.w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Kind(), data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// open отвечает заголовками потока после того, как сервис принял подписку,
+// чтобы отказ по лимиту подписок ушел обычным JSON-ответом
+func (s *sseWriter) open() error {
+	header := s.w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	s.w.WriteHeader(http.StatusOK)
+	s.opened = true
+
+	return s.write(func() error {
+		_, err := fmt.Fprintf(s.w, "retry: %d\n\n", streamRetry.Milliseconds())
+		return err
+	})
+}
+
+func (s *sseWriter) write(fn func() error) error {
+	if err := s.rc.SetWriteDeadline(time.Now().Add(streamWriteTimeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	if err := fn(); err != nil {
+		return err
+	}
+	if err := s.rc.Flush(); err != nil {
+		return err
+	}
+	s.lastWrite = time.Now()
+	return nil
+}
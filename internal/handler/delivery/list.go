@@ -0,0 +1,203 @@
+package delivery
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"service-courier/internal/model/delivery"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+const maxListLimit = 500
+
+// Get - текущая доставка заказа; с include_deleted=true - последняя, даже если курьера сняли
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	orderID := chi.URLParam(r, "order_id")
+	if _, err := uuid.Parse(orderID); err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "Invalid order_id",
+		})
+		return
+	}
+
+	includeDeleted, err := parseBool(r.URL.Query().Get("include_deleted"))
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "invalid include_deleted",
+		})
+		return
+	}
+
+	var deliveryData *delivery.Delivery
+	if includeDeleted {
+		deliveryData, err = h.service.GetLatestDelivery(r.Context(), orderID)
+	} else {
+		deliveryData, err = h.service.GetDelivery(r.Context(), orderID)
+	}
+	if err != nil {
+		log.Printf("get delivery: %v", err)
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, ModelToDeliveryResponse(*deliveryData))
+}
+
+// List - доставки с фильтрами по курьеру, статусу и времени назначения, постранично по курсору
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseListFilter(r)
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	page, err := h.service.ListDeliveries(r.Context(), filter)
+	if err != nil {
+		log.Printf("list deliveries: %v", err)
+		h.writeError(w, err)
+		return
+	}
+
+	var next string
+	if page.Next != nil {
+		next = encodeCursor(filter.Sort, filter.Desc, *page.Next)
+	}
+
+	h.writeJSON(w, http.StatusOK, PageToListResponse(*page, next))
+}
+
+func parseListFilter(r *http.Request) (delivery.ListFilter, error) {
+	filter := delivery.ListFilter{
+		Sort: delivery.SortByAssignedAt,
+		Desc: true,
+	}
+	query := r.URL.Query()
+
+	if v := query.Get("courier_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			return filter, errors.New("invalid courier_id")
+		}
+		filter.CourierID = &id
+	}
+	if v := query.Get("status"); v != "" {
+		for _, item := range strings.Split(v, ",") {
+			status := delivery.DeliveryStatus(strings.TrimSpace(item))
+			if !status.IsKnown() {
+				return filter, fmt.Errorf("invalid status %q", item)
+			}
+			filter.Statuses = append(filter.Statuses, status)
+		}
+	}
+	if v := query.Get("from"); v != "" {
+		from, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return filter, errors.New("invalid from")
+		}
+		from = from.UTC()
+		filter.From = &from
+	}
+	if v := query.Get("to"); v != "" {
+		to, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return filter, errors.New("invalid to")
+		}
+		to = to.UTC()
+		filter.To = &to
+	}
+
+	includeDeleted, err := parseBool(query.Get("include_deleted"))
+	if err != nil {
+		return filter, errors.New("invalid include_deleted")
+	}
+	filter.IncludeDeleted = includeDeleted
+
+	if v := query.Get("sort"); v != "" {
+		filter.Sort = delivery.SortField(v)
+		if !filter.Sort.IsValid() {
+			return filter, errors.New("invalid sort, expected id, assigned_at or deadline")
+		}
+	}
+	switch query.Get("order") {
+	case "":
+	case "asc":
+		filter.Desc = false
+	case "desc":
+		filter.Desc = true
+	default:
+		return filter, errors.New("invalid order, expected asc or desc")
+	}
+
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.ParseUint(v, 10, 64)
+		if err != nil || limit == 0 || limit > maxListLimit {
+			return filter, fmt.Errorf("invalid limit, expected 1..%d", maxListLimit)
+		}
+		filter.Limit = limit
+	}
+
+	if v := query.Get("cursor"); v != "" {
+		after, err := decodeCursor(v, filter.Sort, filter.Desc)
+		if err != nil {
+			return filter, err
+		}
+		filter.After = &after
+	}
+
+	return filter, nil
+}
+
+func parseBool(value string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+// pageCursor - курсор страницы для клиента. Сортировка зашита в курсор,
+// чтобы его нельзя было применить к списку с другим порядком.
+type pageCursor struct {
+	Sort delivery.SortField `json:"s"`
+	Desc bool               `json:"d,omitempty"`
+	At   *time.Time         `json:"t,omitempty"`
+	ID   int64              `json:"id"`
+}
+
+func encodeCursor(sort delivery.SortField, desc bool, c delivery.Cursor) string {
+	pc := pageCursor{Sort: sort, Desc: desc, ID: c.ID}
+	if sort != delivery.SortByID {
+		pc.At = &c.At
+	}
+	data, _ := json.Marshal(pc)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(value string, sort delivery.SortField, desc bool) (delivery.Cursor, error) {
+	var pc pageCursor
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || json.Unmarshal(data, &pc) != nil {
+		return delivery.Cursor{}, errors.New("invalid cursor")
+	}
+	if pc.Sort != sort || pc.Desc != desc {
+		return delivery.Cursor{}, errors.New("cursor does not match sort and order")
+	}
+	if sort != delivery.SortByID && pc.At == nil {
+		return delivery.Cursor{}, errors.New("invalid cursor")
+	}
+
+	c := delivery.Cursor{ID: pc.ID}
+	if pc.At != nil {
+		c.At = *pc.At
+	}
+	return c, nil
+}
@@ -12,7 +12,8 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
-	delivery "service-courier/internal/service/delivery"
+	delivery "service-courier/internal/model/delivery"
+	delivery0 "service-courier/internal/service/delivery"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -42,25 +43,160 @@ func (m *MockdeliveryService) EXPECT() *MockdeliveryServiceMockRecorder {
 }
 
 // AssignCourier mocks base method.
-func (m *MockdeliveryService) AssignCourier(ctx context.Context, orderID string) (*delivery.AssignResult, error) {
+func (m *MockdeliveryService) AssignCourier(ctx context.Context, orderID string, priority delivery.Priority) (*delivery0.AssignResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AssignCourier", ctx, orderID)
-	ret0, _ := ret[0].(*delivery.AssignResult)
+	ret := m.ctrl.Call(m, "AssignCourier", ctx, orderID, priority)
+	ret0, _ := ret[0].(*delivery0.AssignResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AssignCourier indicates an expected call of AssignCourier.
-func (mr *MockdeliveryServiceMockRecorder) AssignCourier(ctx, orderID any) *gomock.Call {
+func (mr *MockdeliveryServiceMockRecorder) AssignCourier(ctx, orderID, priority any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignCourier", reflect.TypeOf((*MockdeliveryService)(nil).AssignCourier), ctx, orderID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignCourier", reflect.TypeOf((*MockdeliveryService)(nil).AssignCourier), ctx, orderID, priority)
+}
+
+// DeclineOffer mocks base method.
+func (m *MockdeliveryService) DeclineOffer(ctx context.Context, orderID string) (*delivery0.DeclineResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclineOffer", ctx, orderID)
+	ret0, _ := ret[0].(*delivery0.DeclineResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeclineOffer indicates an expected call of DeclineOffer.
+func (mr *MockdeliveryServiceMockRecorder) DeclineOffer(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineOffer", reflect.TypeOf((*MockdeliveryService)(nil).DeclineOffer), ctx, orderID)
+}
+
+// GetCourierOfferStats mocks base method.
+func (m *MockdeliveryService) GetCourierOfferStats(ctx context.Context, courierID int64) (*delivery.OfferStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCourierOfferStats", ctx, courierID)
+	ret0, _ := ret[0].(*delivery.OfferStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCourierOfferStats indicates an expected call of GetCourierOfferStats.
+func (mr *MockdeliveryServiceMockRecorder) GetCourierOfferStats(ctx, courierID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourierOfferStats", reflect.TypeOf((*MockdeliveryService)(nil).GetCourierOfferStats), ctx, courierID)
+}
+
+// GetCourierStats mocks base method.
+func (m *MockdeliveryService) GetCourierStats(ctx context.Context, courierID int64, filter delivery.StatsFilter) (*delivery.CourierStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCourierStats", ctx, courierID, filter)
+	ret0, _ := ret[0].(*delivery.CourierStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCourierStats indicates an expected call of GetCourierStats.
+func (mr *MockdeliveryServiceMockRecorder) GetCourierStats(ctx, courierID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourierStats", reflect.TypeOf((*MockdeliveryService)(nil).GetCourierStats), ctx, courierID, filter)
+}
+
+// GetDelivery mocks base method.
+func (m *MockdeliveryService) GetDelivery(ctx context.Context, orderID string) (*delivery.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelivery", ctx, orderID)
+	ret0, _ := ret[0].(*delivery.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelivery indicates an expected call of GetDelivery.
+func (mr *MockdeliveryServiceMockRecorder) GetDelivery(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelivery", reflect.TypeOf((*MockdeliveryService)(nil).GetDelivery), ctx, orderID)
+}
+
+// GetDeliveryHistory mocks base method.
+func (m *MockdeliveryService) GetDeliveryHistory(ctx context.Context, orderID string) ([]delivery.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveryHistory", ctx, orderID)
+	ret0, _ := ret[0].([]delivery.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveryHistory indicates an expected call of GetDeliveryHistory.
+func (mr *MockdeliveryServiceMockRecorder) GetDeliveryHistory(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveryHistory", reflect.TypeOf((*MockdeliveryService)(nil).GetDeliveryHistory), ctx, orderID)
+}
+
+// GetLatestDelivery mocks base method.
+func (m *MockdeliveryService) GetLatestDelivery(ctx context.Context, orderID string) (*delivery.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestDelivery", ctx, orderID)
+	ret0, _ := ret[0].(*delivery.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestDelivery indicates an expected call of GetLatestDelivery.
+func (mr *MockdeliveryServiceMockRecorder) GetLatestDelivery(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestDelivery", reflect.TypeOf((*MockdeliveryService)(nil).GetLatestDelivery), ctx, orderID)
+}
+
+// ListCourierStats mocks base method.
+func (m *MockdeliveryService) ListCourierStats(ctx context.Context, filter delivery.StatsFilter) ([]delivery.CourierStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCourierStats", ctx, filter)
+	ret0, _ := ret[0].([]delivery.CourierStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCourierStats indicates an expected call of ListCourierStats.
+func (mr *MockdeliveryServiceMockRecorder) ListCourierStats(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCourierStats", reflect.TypeOf((*MockdeliveryService)(nil).ListCourierStats), ctx, filter)
+}
+
+// ListDeliveries mocks base method.
+func (m *MockdeliveryService) ListDeliveries(ctx context.Context, filter delivery.ListFilter) (*delivery.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeliveries", ctx, filter)
+	ret0, _ := ret[0].(*delivery.Page)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeliveries indicates an expected call of ListDeliveries.
+func (mr *MockdeliveryServiceMockRecorder) ListDeliveries(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeliveries", reflect.TypeOf((*MockdeliveryService)(nil).ListDeliveries), ctx, filter)
+}
+
+// TransitionDelivery mocks base method.
+func (m *MockdeliveryService) TransitionDelivery(ctx context.Context, orderID string, to delivery.DeliveryStatus) (*delivery0.TransitionResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransitionDelivery", ctx, orderID, to)
+	ret0, _ := ret[0].(*delivery0.TransitionResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransitionDelivery indicates an expected call of TransitionDelivery.
+func (mr *MockdeliveryServiceMockRecorder) TransitionDelivery(ctx, orderID, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransitionDelivery", reflect.TypeOf((*MockdeliveryService)(nil).TransitionDelivery), ctx, orderID, to)
 }
 
 // UnassignCourier mocks base method.
-func (m *MockdeliveryService) UnassignCourier(ctx context.Context, orderID string) (*delivery.UnassignResult, error) {
+func (m *MockdeliveryService) UnassignCourier(ctx context.Context, orderID string) (*delivery0.UnassignResult, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UnassignCourier", ctx, orderID)
-	ret0, _ := ret[0].(*delivery.UnassignResult)
+	ret0, _ := ret[0].(*delivery0.UnassignResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -70,3 +206,17 @@ func (mr *MockdeliveryServiceMockRecorder) UnassignCourier(ctx, orderID any) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnassignCourier", reflect.TypeOf((*MockdeliveryService)(nil).UnassignCourier), ctx, orderID)
 }
+
+// WatchEvents mocks base method.
+func (m *MockdeliveryService) WatchEvents(ctx context.Context, filter delivery.EventFilter, send func([]delivery.Event) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchEvents", ctx, filter, send)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchEvents indicates an expected call of WatchEvents.
+func (mr *MockdeliveryServiceMockRecorder) WatchEvents(ctx, filter, send any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchEvents", reflect.TypeOf((*MockdeliveryService)(nil).WatchEvents), ctx, filter, send)
+}
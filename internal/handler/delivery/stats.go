@@ -0,0 +1,106 @@
+package delivery
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"service-courier/internal/model/delivery"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	groupByTransport = "transport_type"
+	// maxStatsPeriod - самый длинный период статистики за один запрос
+	maxStatsPeriod = 93 * 24 * time.Hour
+)
+
+// CourierStats - показатели доставок курьера за период from..to
+func (h *Handler) CourierStats(w http.ResponseWriter, r *http.Request) {
+	courierID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "Invalid courier ID",
+		})
+		return
+	}
+
+	filter, err := parseStatsPeriod(r)
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	stats, err := h.service.GetCourierStats(r.Context(), courierID, filter)
+	if err != nil {
+		log.Printf("get courier stats: %v", err)
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, CourierStatsPeriodResponse{
+		From:                 filter.From.Format(time.RFC3339),
+		To:                   filter.To.Format(time.RFC3339),
+		CourierStatsResponse: StatsToCourierStatsResponse(*stats),
+	})
+}
+
+// Stats - показатели доставок всех курьеров за период; с group_by=transport_type - по типам транспорта
+func (h *Handler) Stats(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseStatsPeriod(r)
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	switch r.URL.Query().Get("group_by") {
+	case "":
+	case groupByTransport:
+		filter.ByTransport = true
+	default:
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "invalid group_by, expected transport_type",
+		})
+		return
+	}
+
+	stats, err := h.service.ListCourierStats(r.Context(), filter)
+	if err != nil {
+		log.Printf("list courier stats: %v", err)
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, StatsToStatsListResponse(filter, stats))
+}
+
+func parseStatsPeriod(r *http.Request) (delivery.StatsFilter, error) {
+	var filter delivery.StatsFilter
+	query := r.URL.Query()
+
+	from, err := time.Parse(time.RFC3339, query.Get("from"))
+	if err != nil {
+		return filter, errors.New("invalid from, expected RFC3339 time")
+	}
+	to, err := time.Parse(time.RFC3339, query.Get("to"))
+	if err != nil {
+		return filter, errors.New("invalid to, expected RFC3339 time")
+	}
+	if !from.Before(to) {
+		return filter, errors.New("from must be before to")
+	}
+	if to.Sub(from) > maxStatsPeriod {
+		return filter, fmt.Errorf("period must not exceed %d days", int(maxStatsPeriod.Hours()/24))
+	}
+
+	filter.From = from.UTC()
+	filter.To = to.UTC()
+	return filter, nil
+}
@@ -1,10 +1,19 @@
+//go:generate mockgen -destination=./mocks/usecase_mock.go -package=mocks service-courier/internal/handler/queues/order/changed usecase
+//go:generate mockgen -destination=./mocks/producer_mock.go -package=mocks service-courier/internal/handler/queues/order/changed producer
 package changed
 
 import (
 	"context"
+	"service-courier/internal/model/inbox"
 	"service-courier/internal/model/order"
+
+	"github.com/IBM/sarama"
 )
 
 type usecase interface {
-	Process(ctx context.Context, order order.Order) error
+	ProcessOnce(ctx context.Context, id inbox.MessageID, order order.Order) error
+}
+
+type producer interface {
+	SendMessage(msg *sarama.ProducerMessage) (partition int32, offset int64, err error)
 }
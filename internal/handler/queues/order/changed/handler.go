@@ -1,10 +1,17 @@
 package changed
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"log"
 	"service-courier/internal/dto/queues/order/changed"
+	"service-courier/internal/metrics"
+	"service-courier/internal/model/inbox"
 	"service-courier/internal/model/order"
+	"service-courier/internal/pkg/retry"
+	"strconv"
+	"time"
 
 	"github.com/IBM/sarama"
 )
@@ -16,15 +23,30 @@ var allowedStatuses = map[string]struct{}{
 }
 
 type Handler struct {
-	usecase usecase
+	usecase  usecase
+	producer producer
+	dlqTopic string
+	retry    retry.RetryConfig
 }
 
-func NewHandler(u usecase) *Handler {
+func NewHandler(u usecase, p producer, dlqTopic string) *Handler {
 	return &Handler{
-		usecase: u,
+		usecase:  u,
+		producer: p,
+		dlqTopic: dlqTopic,
+		retry: retry.RetryConfig{
+			MaxAttempts: 5,
+			Strategy:    retry.NewExponentialBackoff(200*time.Millisecond, 5*time.Second, 2.0),
+		},
 	}
 }
 
+// WithRetry переопределяет политику повторов обработки сообщения
+func (h *Handler) WithRetry(cfg retry.RetryConfig) *Handler {
+	h.retry = cfg
+	return h
+}
+
 func (h *Handler) Setup(sarama.ConsumerGroupSession) error {
 	return nil
 }
@@ -38,29 +60,76 @@ func (h *Handler) ConsumeClaim(sess sarama.ConsumerGroupSession, claim sarama.Co
 		ctx := sess.Context()
 		log.Printf("order.changed handler: received message: key=%s, value=%s, partition=%d, offset=%d\n", string(dtoMsg.Key), string(dtoMsg.Value), dtoMsg.Partition, dtoMsg.Offset)
 
-		var msg changed.Message
-		err := json.Unmarshal(dtoMsg.Value, &msg)
-		if err != nil {
-			log.Printf("order.changed handler: received bad message: %v", err)
-			sess.MarkMessage(dtoMsg, "")
-			continue
-		}
-
-		if _, ok := allowedStatuses[msg.Status]; !ok {
-			log.Printf("order.changed handler: skip message with status=%s", msg.Status)
-			sess.MarkMessage(dtoMsg, "")
-			continue
+		if err := h.handle(ctx, dtoMsg); err != nil {
+			// offset не фиксируется: сообщение будет прочитано повторно после ребалансировки
+			log.Printf("order.changed handler: message left uncommitted: %v", err)
+			return err
 		}
 
-		err = h.usecase.Process(ctx, order.Order{
-			ID:     msg.OrderID,
-			Status: msg.Status,
-		})
-		if err != nil {
-			log.Printf("order.changed handler: failed procces order: %v", err)
-		}
 		sess.MarkMessage(dtoMsg, "")
+		sess.Commit()
+	}
+
+	return nil
+}
+
+// handle возвращает ошибку, только если сообщение не обработано и не отправлено в DLQ
+func (h *Handler) handle(ctx context.Context, dtoMsg *sarama.ConsumerMessage) error {
+	var msg changed.Message
+	if err := json.Unmarshal(dtoMsg.Value, &msg); err != nil {
+		log.Printf("order.changed handler: received bad message: %v", err)
+		return h.sendToDLQ(dtoMsg, fmt.Errorf("unmarshal message: %w", err))
+	}
+
+	if _, ok := allowedStatuses[msg.Status]; !ok {
+		log.Printf("order.changed handler: skip message with status=%s", msg.Status)
+		return nil
+	}
+
+	id := inbox.MessageID{
+		Topic:     dtoMsg.Topic,
+		Partition: dtoMsg.Partition,
+		Offset:    dtoMsg.Offset,
+	}
+	o := order.Order{
+		ID:       msg.OrderID,
+		Status:   msg.Status,
+		Priority: msg.Priority,
+	}
+
+	err := retry.NewRetryExecutor(h.retry).ExecuteWithContext(ctx, func(ctx context.Context) error {
+		return h.usecase.ProcessOnce(ctx, id, o)
+	})
+	if err == nil {
+		return nil
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	log.Printf("order.changed handler: failed process order %s: %v", msg.OrderID, err)
+	return h.sendToDLQ(dtoMsg, err)
+}
+
+func (h *Handler) sendToDLQ(dtoMsg *sarama.ConsumerMessage, cause error) error {
+	_, _, err := h.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: h.dlqTopic,
+		Key:   sarama.ByteEncoder(dtoMsg.Key),
+		Value: sarama.ByteEncoder(dtoMsg.Value),
+		Headers: []sarama.RecordHeader{
+			{Key: []byte(changed.HeaderError), Value: []byte(cause.Error())},
+			{Key: []byte(changed.HeaderOriginalTopic), Value: []byte(dtoMsg.Topic)},
+			{Key: []byte(changed.HeaderOriginalPartition), Value: []byte(strconv.FormatInt(int64(dtoMsg.Partition), 10))},
+			{Key: []byte(changed.HeaderOriginalOffset), Value: []byte(strconv.FormatInt(dtoMsg.Offset, 10))},
+			{Key: []byte(changed.HeaderFailedAt), Value: []byte(time.Now().UTC().Format(time.RFC3339))},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("send to dlq: %w", err)
 	}
 
+	metrics.KafkaDeadLetteredTotal.Inc()
+	log.Printf("order.changed handler: message partition=%d offset=%d sent to %s", dtoMsg.Partition, dtoMsg.Offset, h.dlqTopic)
 	return nil
 }
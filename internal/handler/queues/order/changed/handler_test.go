@@ -0,0 +1,224 @@
+package changed_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"go.uber.org/mock/gomock"
+
+	dtoChanged "service-courier/internal/dto/queues/order/changed"
+	"service-courier/internal/handler/queues/order/changed"
+	"service-courier/internal/handler/queues/order/changed/mocks"
+	modelInbox "service-courier/internal/model/inbox"
+	modelOrder "service-courier/internal/model/order"
+	"service-courier/internal/pkg/retry"
+)
+
+type fakeSession struct {
+	ctx     context.Context
+	marked  []int64
+	commits int
+}
+
+func (s *fakeSession) Claims() map[string][]int32               { return nil }
+func (s *fakeSession) MemberID() string                         { return "" }
+func (s *fakeSession) GenerationID() int32                      { return 0 }
+func (s *fakeSession) MarkOffset(string, int32, int64, string)  {}
+func (s *fakeSession) ResetOffset(string, int32, int64, string) {}
+func (s *fakeSession) Context() context.Context                 { return s.ctx }
+func (s *fakeSession) Commit()                                  { s.commits++ }
+func (s *fakeSession) MarkMessage(msg *sarama.ConsumerMessage, _ string) {
+	s.marked = append(s.marked, msg.Offset)
+}
+
+type fakeClaim struct {
+	messages chan *sarama.ConsumerMessage
+}
+
+func newFakeClaim(msgs ...*sarama.ConsumerMessage) *fakeClaim {
+	ch := make(chan *sarama.ConsumerMessage, len(msgs))
+	for _, m := range msgs {
+		ch <- m
+	}
+	close(ch)
+	return &fakeClaim{messages: ch}
+}
+
+func (c *fakeClaim) Topic() string                            { return "order.status.changed" }
+func (c *fakeClaim) Partition() int32                         { return 0 }
+func (c *fakeClaim) InitialOffset() int64                     { return 0 }
+func (c *fakeClaim) HighWaterMarkOffset() int64               { return 0 }
+func (c *fakeClaim) Messages() <-chan *sarama.ConsumerMessage { return c.messages }
+
+func newHandler(ctrl *gomock.Controller) (*changed.Handler, *mocks.Mockusecase, *mocks.Mockproducer) {
+	mockUsecase := mocks.NewMockusecase(ctrl)
+	mockProducer := mocks.NewMockproducer(ctrl)
+
+	h := changed.NewHandler(mockUsecase, mockProducer, "order.status.changed.dlq").
+		WithRetry(retry.RetryConfig{
+			MaxAttempts: 3,
+			Strategy:    retry.NewExponentialBackoff(time.Millisecond, time.Millisecond, 1),
+		})
+
+	return h, mockUsecase, mockProducer
+}
+
+func message(offset int64, value string) *sarama.ConsumerMessage {
+	return &sarama.ConsumerMessage{
+		Topic:     "order.status.changed",
+		Partition: 0,
+		Offset:    offset,
+		Key:       []byte("order-1"),
+		Value:     []byte(value),
+	}
+}
+
+func TestConsumeClaim_SuccessMarksAfterProcessing(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h, mockUsecase, _ := newHandler(ctrl)
+
+	mockUsecase.EXPECT().
+		ProcessOnce(gomock.Any(), modelInbox.MessageID{Topic: "order.status.changed", Partition: 0, Offset: 5}, modelOrder.Order{ID: "order-1", Status: modelOrder.StatusCreated}).
+		Return(nil)
+
+	sess := &fakeSession{ctx: context.Background()}
+	err := h.ConsumeClaim(sess, newFakeClaim(message(5, `{"order_id":"order-1","status":"created"}`)))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(sess.marked) != 1 || sess.marked[0] != 5 || sess.commits != 1 {
+		t.Fatalf("expected offset 5 marked and committed, got %v (%d commits)", sess.marked, sess.commits)
+	}
+}
+
+func TestConsumeClaim_RetriesTransientError(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h, mockUsecase, _ := newHandler(ctrl)
+
+	gomock.InOrder(
+		mockUsecase.EXPECT().ProcessOnce(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("db timeout")),
+		mockUsecase.EXPECT().ProcessOnce(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+	)
+
+	sess := &fakeSession{ctx: context.Background()}
+	if err := h.ConsumeClaim(sess, newFakeClaim(message(1, `{"order_id":"order-1","status":"created"}`))); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(sess.marked) != 1 {
+		t.Fatalf("expected message marked, got %v", sess.marked)
+	}
+}
+
+func TestConsumeClaim_ExhaustedRetriesGoToDLQ(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h, mockUsecase, mockProducer := newHandler(ctrl)
+
+	mockUsecase.EXPECT().
+		ProcessOnce(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(errors.New("db timeout")).
+		Times(3)
+
+	var dlq *sarama.ProducerMessage
+	mockProducer.EXPECT().
+		SendMessage(gomock.Any()).
+		DoAndReturn(func(msg *sarama.ProducerMessage) (int32, int64, error) {
+			dlq = msg
+			return 0, 0, nil
+		})
+
+	sess := &fakeSession{ctx: context.Background()}
+	if err := h.ConsumeClaim(sess, newFakeClaim(message(9, `{"order_id":"order-1","status":"created"}`))); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if dlq.Topic != "order.status.changed.dlq" {
+		t.Fatalf("expected dlq topic, got %s", dlq.Topic)
+	}
+	headers := make(map[string]string)
+	for _, h := range dlq.Headers {
+		headers[string(h.Key)] = string(h.Value)
+	}
+	if headers[dtoChanged.HeaderOriginalOffset] != "9" || headers[dtoChanged.HeaderError] == "" {
+		t.Fatalf("unexpected dlq headers %v", headers)
+	}
+	if len(sess.marked) != 1 {
+		t.Fatalf("expected message marked after dlq, got %v", sess.marked)
+	}
+}
+
+func TestConsumeClaim_BadMessageGoesToDLQ(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h, _, mockProducer := newHandler(ctrl)
+
+	mockProducer.EXPECT().
+		SendMessage(gomock.Any()).
+		Return(int32(0), int64(0), nil)
+
+	sess := &fakeSession{ctx: context.Background()}
+	if err := h.ConsumeClaim(sess, newFakeClaim(message(3, `not json`))); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(sess.marked) != 1 {
+		t.Fatalf("expected message marked, got %v", sess.marked)
+	}
+}
+
+func TestConsumeClaim_DLQFailureLeavesOffsetUncommitted(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h, mockUsecase, mockProducer := newHandler(ctrl)
+
+	mockUsecase.EXPECT().
+		ProcessOnce(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(errors.New("db timeout")).
+		Times(3)
+
+	mockProducer.EXPECT().
+		SendMessage(gomock.Any()).
+		Return(int32(0), int64(0), errors.New("broker unavailable"))
+
+	sess := &fakeSession{ctx: context.Background()}
+	err := h.ConsumeClaim(sess, newFakeClaim(
+		message(1, `{"order_id":"order-1","status":"created"}`),
+		message(2, `{"order_id":"order-2","status":"created"}`),
+	))
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if len(sess.marked) != 0 || sess.commits != 0 {
+		t.Fatalf("expected nothing marked, got %v", sess.marked)
+	}
+}
+
+func TestConsumeClaim_SkipsUnknownStatus(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	h, _, _ := newHandler(ctrl)
+
+	sess := &fakeSession{ctx: context.Background()}
+	if err := h.ConsumeClaim(sess, newFakeClaim(message(4, `{"order_id":"order-1","status":"paid"}`))); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(sess.marked) != 1 {
+		t.Fatalf("expected message marked, got %v", sess.marked)
+	}
+}
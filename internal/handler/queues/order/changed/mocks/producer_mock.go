@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service-courier/internal/handler/queues/order/changed (interfaces: producer)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/producer_mock.go -package=mocks service-courier/internal/handler/queues/order/changed producer
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	sarama "github.com/IBM/sarama"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockproducer is a mock of producer interface.
+type Mockproducer struct {
+	ctrl     *gomock.Controller
+	recorder *MockproducerMockRecorder
+	isgomock struct{}
+}
+
+// MockproducerMockRecorder is the mock recorder for Mockproducer.
+type MockproducerMockRecorder struct {
+	mock *Mockproducer
+}
+
+// NewMockproducer creates a new mock instance.
+func NewMockproducer(ctrl *gomock.Controller) *Mockproducer {
+	mock := &Mockproducer{ctrl: ctrl}
+	mock.recorder = &MockproducerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockproducer) EXPECT() *MockproducerMockRecorder {
+	return m.recorder
+}
+
+// SendMessage mocks base method.
+func (m *Mockproducer) SendMessage(msg *sarama.ProducerMessage) (int32, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendMessage", msg)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SendMessage indicates an expected call of SendMessage.
+func (mr *MockproducerMockRecorder) SendMessage(msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessage", reflect.TypeOf((*Mockproducer)(nil).SendMessage), msg)
+}
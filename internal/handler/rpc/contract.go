@@ -0,0 +1,25 @@
+//go:generate mockgen -source=contract.go -destination=./mocks/services_mock.go -package=mocks
+
+package rpc
+
+import (
+	"context"
+	modelCourier "service-courier/internal/model/courier"
+	modelDelivery "service-courier/internal/model/delivery"
+	"service-courier/internal/service/delivery"
+)
+
+type courierService interface {
+	GetCourier(ctx context.Context, id int64) (*modelCourier.Courier, error)
+	ListCouriers(ctx context.Context, filter modelCourier.ListFilter) (*modelCourier.Page, error)
+	CreateCourier(ctx context.Context, courierData modelCourier.Courier) (int64, error)
+	UpdateCourier(ctx context.Context, courierData modelCourier.Courier) error
+}
+
+type deliveryService interface {
+	AssignCourier(ctx context.Context, orderID string, priority modelDelivery.Priority) (*delivery.AssignResult, error)
+	UnassignCourier(ctx context.Context, orderID string) (*delivery.UnassignResult, error)
+	CompleteDelivery(ctx context.Context, orderID string) error
+	GetDelivery(ctx context.Context, orderID string) (*modelDelivery.Delivery, error)
+	WatchEvents(ctx context.Context, filter modelDelivery.EventFilter, send func([]modelDelivery.Event) error) error
+}
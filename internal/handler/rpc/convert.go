@@ -0,0 +1,73 @@
+package rpc
+
+import (
+	modelCourier "service-courier/internal/model/courier"
+	modelDelivery "service-courier/internal/model/delivery"
+	pb "service-courier/internal/proto/courier"
+	"service-courier/internal/service/delivery"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func courierToProto(c modelCourier.Courier) *pb.Courier {
+	resp := &pb.Courier{
+		Id:            c.ID,
+		Name:          c.Name,
+		Phone:         c.Phone,
+		Status:        string(c.Status),
+		TransportType: string(c.TransportType),
+		CreatedAt:     timestamppb.New(c.CreatedAt),
+		UpdatedAt:     timestamppb.New(c.UpdatedAt),
+	}
+
+	if c.Location != nil {
+		resp.Location = &pb.Location{
+			Lat:       c.Location.Point.Lat,
+			Lon:       c.Location.Point.Lon,
+			UpdatedAt: timestamppb.New(c.Location.UpdatedAt),
+		}
+	}
+
+	return resp
+}
+
+func deliveryToProto(d modelDelivery.Delivery) *pb.Delivery {
+	return &pb.Delivery{
+		Id:         d.ID,
+		CourierId:  d.CourierID,
+		OrderId:    d.OrderID,
+		Status:     string(d.Status),
+		Priority:   string(d.Priority),
+		AssignedAt: timestamppb.New(d.AssignedAt),
+		Deadline:   timestamppb.New(d.Deadline),
+	}
+}
+
+func assignResultToProto(res delivery.AssignResult) *pb.AssignDeliveryResponse {
+	resp := &pb.AssignDeliveryResponse{
+		OrderId:       res.OrderID,
+		CourierId:     res.CourierID,
+		TransportType: string(res.TransportType),
+		Deadline:      timestamppb.New(res.Deadline),
+		Status:        string(res.Status),
+	}
+	if res.OfferExpiresAt != nil {
+		resp.OfferExpiresAt = timestamppb.New(*res.OfferExpiresAt)
+	}
+	return resp
+}
+
+func eventToProto(e modelDelivery.Event) *pb.DeliveryEvent {
+	return &pb.DeliveryEvent{
+		Id:         e.ID,
+		Type:       e.Kind(),
+		OrderId:    e.OrderID,
+		DeliveryId: e.DeliveryID,
+		CourierId:  e.CourierID,
+		FromStatus: string(e.FromStatus),
+		ToStatus:   string(e.ToStatus),
+		Actor:      string(e.Actor),
+		Reason:     e.Reason,
+		CreatedAt:  timestamppb.New(e.CreatedAt),
+	}
+}
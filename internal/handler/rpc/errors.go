@@ -0,0 +1,50 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"log"
+	"service-courier/internal/model/courier"
+	"service-courier/internal/model/delivery"
+	"service-courier/internal/model/zone"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatus переводит доменную ошибку в gRPC-статус. Неизвестные ошибки логируются и отдаются как Internal.
+func toStatus(method string, err error) error {
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	case errors.Is(err, courier.ErrCourierNotFound):
+		return status.Error(codes.NotFound, "courier not found")
+	case errors.Is(err, courier.ErrPhoneExists):
+		return status.Error(codes.AlreadyExists, "courier with this phone already exists")
+	case errors.Is(err, courier.ErrBusyStatusManaged):
+		return status.Error(codes.FailedPrecondition, "busy status is set by the system")
+	case errors.Is(err, courier.ErrHasActiveDeliveries):
+		return status.Error(codes.FailedPrecondition, "courier has active deliveries")
+	case errors.Is(err, courier.ErrVersionMismatch):
+		return status.Error(codes.Aborted, "courier was modified concurrently")
+	case errors.Is(err, delivery.ErrDeliveryNotFound):
+		return status.Error(codes.NotFound, "delivery not found")
+	case errors.Is(err, delivery.ErrOrderAlreadyAssigned):
+		return status.Error(codes.AlreadyExists, "order already assigned")
+	case errors.Is(err, delivery.ErrInvalidTransition):
+		return status.Error(codes.FailedPrecondition, "invalid delivery status transition")
+	case errors.Is(err, delivery.ErrOfferExpired):
+		return status.Error(codes.FailedPrecondition, "delivery offer expired")
+	case errors.Is(err, delivery.ErrCourierUnavailable):
+		return status.Error(codes.FailedPrecondition, "courier can no longer take the delivery")
+	case errors.Is(err, delivery.ErrTooManyStreams):
+		return status.Error(codes.Unavailable, "too many event streams")
+	case errors.Is(err, courier.ErrNoAvailableCouriers):
+		return status.Error(codes.ResourceExhausted, "no available couriers")
+	case errors.Is(err, zone.ErrOutsideZones):
+		return status.Error(codes.FailedPrecondition, "order location is outside of delivery zones")
+	default:
+		log.Printf("%s: %v", method, err)
+		return status.Error(codes.Internal, "internal error")
+	}
+}
@@ -0,0 +1,200 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+//
+// Generated by this command:
+//
+//	mockgen -source=contract.go -destination=./mocks/services_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	courier "service-courier/internal/model/courier"
+	delivery "service-courier/internal/model/delivery"
+	delivery0 "service-courier/internal/service/delivery"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockcourierService is a mock of courierService interface.
+type MockcourierService struct {
+	ctrl     *gomock.Controller
+	recorder *MockcourierServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockcourierServiceMockRecorder is the mock recorder for MockcourierService.
+type MockcourierServiceMockRecorder struct {
+	mock *MockcourierService
+}
+
+// NewMockcourierService creates a new mock instance.
+func NewMockcourierService(ctrl *gomock.Controller) *MockcourierService {
+	mock := &MockcourierService{ctrl: ctrl}
+	mock.recorder = &MockcourierServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcourierService) EXPECT() *MockcourierServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateCourier mocks base method.
+func (m *MockcourierService) CreateCourier(ctx context.Context, courierData courier.Courier) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCourier", ctx, courierData)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCourier indicates an expected call of CreateCourier.
+func (mr *MockcourierServiceMockRecorder) CreateCourier(ctx, courierData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCourier", reflect.TypeOf((*MockcourierService)(nil).CreateCourier), ctx, courierData)
+}
+
+// GetCourier mocks base method.
+func (m *MockcourierService) GetCourier(ctx context.Context, id int64) (*courier.Courier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCourier", ctx, id)
+	ret0, _ := ret[0].(*courier.Courier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCourier indicates an expected call of GetCourier.
+func (mr *MockcourierServiceMockRecorder) GetCourier(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourier", reflect.TypeOf((*MockcourierService)(nil).GetCourier), ctx, id)
+}
+
+// ListCouriers mocks base method.
+func (m *MockcourierService) ListCouriers(ctx context.Context, filter courier.ListFilter) (*courier.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCouriers", ctx, filter)
+	ret0, _ := ret[0].(*courier.Page)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCouriers indicates an expected call of ListCouriers.
+func (mr *MockcourierServiceMockRecorder) ListCouriers(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCouriers", reflect.TypeOf((*MockcourierService)(nil).ListCouriers), ctx, filter)
+}
+
+// UpdateCourier mocks base method.
+func (m *MockcourierService) UpdateCourier(ctx context.Context, courierData courier.Courier) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCourier", ctx, courierData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCourier indicates an expected call of UpdateCourier.
+func (mr *MockcourierServiceMockRecorder) UpdateCourier(ctx, courierData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCourier", reflect.TypeOf((*MockcourierService)(nil).UpdateCourier), ctx, courierData)
+}
+
+// MockdeliveryService is a mock of deliveryService interface.
+type MockdeliveryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockdeliveryServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockdeliveryServiceMockRecorder is the mock recorder for MockdeliveryService.
+type MockdeliveryServiceMockRecorder struct {
+	mock *MockdeliveryService
+}
+
+// NewMockdeliveryService creates a new mock instance.
+func NewMockdeliveryService(ctrl *gomock.Controller) *MockdeliveryService {
+	mock := &MockdeliveryService{ctrl: ctrl}
+	mock.recorder = &MockdeliveryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdeliveryService) EXPECT() *MockdeliveryServiceMockRecorder {
+	return m.recorder
+}
+
+// AssignCourier mocks base method.
+func (m *MockdeliveryService) AssignCourier(ctx context.Context, orderID string, priority delivery.Priority) (*delivery0.AssignResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignCourier", ctx, orderID, priority)
+	ret0, _ := ret[0].(*delivery0.AssignResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignCourier indicates an expected call of AssignCourier.
+func (mr *MockdeliveryServiceMockRecorder) AssignCourier(ctx, orderID, priority any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignCourier", reflect.TypeOf((*MockdeliveryService)(nil).AssignCourier), ctx, orderID, priority)
+}
+
+// CompleteDelivery mocks base method.
+func (m *MockdeliveryService) CompleteDelivery(ctx context.Context, orderID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteDelivery", ctx, orderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteDelivery indicates an expected call of CompleteDelivery.
+func (mr *MockdeliveryServiceMockRecorder) CompleteDelivery(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteDelivery", reflect.TypeOf((*MockdeliveryService)(nil).CompleteDelivery), ctx, orderID)
+}
+
+// GetDelivery mocks base method.
+func (m *MockdeliveryService) GetDelivery(ctx context.Context, orderID string) (*delivery.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelivery", ctx, orderID)
+	ret0, _ := ret[0].(*delivery.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDelivery indicates an expected call of GetDelivery.
+func (mr *MockdeliveryServiceMockRecorder) GetDelivery(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelivery", reflect.TypeOf((*MockdeliveryService)(nil).GetDelivery), ctx, orderID)
+}
+
+// UnassignCourier mocks base method.
+func (m *MockdeliveryService) UnassignCourier(ctx context.Context, orderID string) (*delivery0.UnassignResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnassignCourier", ctx, orderID)
+	ret0, _ := ret[0].(*delivery0.UnassignResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnassignCourier indicates an expected call of UnassignCourier.
+func (mr *MockdeliveryServiceMockRecorder) UnassignCourier(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnassignCourier", reflect.TypeOf((*MockdeliveryService)(nil).UnassignCourier), ctx, orderID)
+}
+
+// WatchEvents mocks base method.
+func (m *MockdeliveryService) WatchEvents(ctx context.Context, filter delivery.EventFilter, send func([]delivery.Event) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchEvents", ctx, filter, send)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchEvents indicates an expected call of WatchEvents.
+func (mr *MockdeliveryServiceMockRecorder) WatchEvents(ctx, filter, send any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchEvents", reflect.TypeOf((*MockdeliveryService)(nil).WatchEvents), ctx, filter, send)
+}
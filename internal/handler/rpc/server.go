@@ -0,0 +1,225 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	courierDto "service-courier/internal/dto/courier"
+	modelDelivery "service-courier/internal/model/delivery"
+	pb "service-courier/internal/proto/courier"
+	serviceDelivery "service-courier/internal/service/delivery"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server - gRPC API курьеров и доставок поверх тех же сервисов, что и HTTP
+type Server struct {
+	pb.UnimplementedCourierServiceServer
+
+	couriers   courierService
+	deliveries deliveryService
+}
+
+func NewServer(couriers courierService, deliveries deliveryService) *Server {
+	return &Server{
+		couriers:   couriers,
+		deliveries: deliveries,
+	}
+}
+
+func (s *Server) GetCourier(ctx context.Context, req *pb.GetCourierRequest) (*pb.GetCourierResponse, error) {
+	if req.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid courier id")
+	}
+
+	courierData, err := s.couriers.GetCourier(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus("get courier", err)
+	}
+
+	return &pb.GetCourierResponse{Courier: courierToProto(*courierData)}, nil
+}
+
+func (s *Server) ListCouriers(ctx context.Context, req *pb.ListCouriersRequest) (*pb.ListCouriersResponse, error) {
+	// правила фильтров и курсора общие с HTTP API
+	listReq := courierDto.ListRequest{
+		Statuses:   req.GetStatuses(),
+		Transports: req.GetTransportTypes(),
+		Search:     req.GetQuery(),
+		ZoneID:     req.GetZoneId(),
+		Sort:       req.GetSort(),
+		Order:      req.GetOrder(),
+		Limit:      req.GetLimit(),
+		Cursor:     req.GetCursor(),
+	}
+	filter, err := listReq.ToFilter()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	page, err := s.couriers.ListCouriers(ctx, filter)
+	if err != nil {
+		return nil, toStatus("list couriers", err)
+	}
+
+	resp := &pb.ListCouriersResponse{
+		Couriers:   make([]*pb.Courier, len(page.Couriers)),
+		Total:      page.Total,
+		NextCursor: courierDto.NextCursor(filter, *page),
+	}
+	for i, c := range page.Couriers {
+		resp.Couriers[i] = courierToProto(c)
+	}
+	return resp, nil
+}
+
+func (s *Server) CreateCourier(ctx context.Context, req *pb.CreateCourierRequest) (*pb.CreateCourierResponse, error) {
+	// правила валидации общие с HTTP API
+	createReq := courierDto.CreateRequest{
+		Name:          req.GetName(),
+		Phone:         req.GetPhone(),
+		Status:        req.GetStatus(),
+		TransportType: req.GetTransportType(),
+	}
+	if err := createReq.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	id, err := s.couriers.CreateCourier(ctx, createReq.ToModel())
+	if err != nil {
+		return nil, toStatus("create courier", err)
+	}
+
+	return &pb.CreateCourierResponse{Id: id}, nil
+}
+
+func (s *Server) UpdateCourier(ctx context.Context, req *pb.UpdateCourierRequest) (*pb.UpdateCourierResponse, error) {
+	updateReq := courierDto.UpdateRequest{
+		ID:            req.GetId(),
+		Name:          req.GetName(),
+		Phone:         req.GetPhone(),
+		Status:        req.GetStatus(),
+		TransportType: req.GetTransportType(),
+	}
+	if err := updateReq.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := s.couriers.UpdateCourier(ctx, updateReq.ToModel()); err != nil {
+		return nil, toStatus("update courier", err)
+	}
+
+	return &pb.UpdateCourierResponse{}, nil
+}
+
+func (s *Server) AssignDelivery(ctx context.Context, req *pb.AssignDeliveryRequest) (*pb.AssignDeliveryResponse, error) {
+	if err := validateOrderID(req.GetOrderId()); err != nil {
+		return nil, err
+	}
+
+	priority, err := modelDelivery.ParsePriority(req.GetPriority())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid priority")
+	}
+
+	ctx = serviceDelivery.WithChangeSource(ctx, modelDelivery.ActorGRPC, "")
+	result, err := s.deliveries.AssignCourier(ctx, req.GetOrderId(), priority)
+	if errors.Is(err, modelDelivery.ErrAssignmentPending) {
+		return &pb.AssignDeliveryResponse{OrderId: req.GetOrderId(), Pending: true}, nil
+	}
+	if err != nil {
+		return nil, toStatus("assign delivery", err)
+	}
+
+	return assignResultToProto(*result), nil
+}
+
+func (s *Server) UnassignDelivery(ctx context.Context, req *pb.UnassignDeliveryRequest) (*pb.UnassignDeliveryResponse, error) {
+	if err := validateOrderID(req.GetOrderId()); err != nil {
+		return nil, err
+	}
+
+	ctx = serviceDelivery.WithChangeSource(ctx, modelDelivery.ActorGRPC, req.GetReason())
+	result, err := s.deliveries.UnassignCourier(ctx, req.GetOrderId())
+	if err != nil {
+		return nil, toStatus("unassign delivery", err)
+	}
+
+	return &pb.UnassignDeliveryResponse{
+		OrderId:   result.OrderID,
+		Status:    result.Status,
+		CourierId: result.CourierID,
+	}, nil
+}
+
+func (s *Server) CompleteDelivery(ctx context.Context, req *pb.CompleteDeliveryRequest) (*pb.CompleteDeliveryResponse, error) {
+	if err := validateOrderID(req.GetOrderId()); err != nil {
+		return nil, err
+	}
+
+	ctx = serviceDelivery.WithChangeSource(ctx, modelDelivery.ActorGRPC, "order completed")
+	if err := s.deliveries.CompleteDelivery(ctx, req.GetOrderId()); err != nil {
+		return nil, toStatus("complete delivery", err)
+	}
+
+	return &pb.CompleteDeliveryResponse{}, nil
+}
+
+func (s *Server) GetDelivery(ctx context.Context, req *pb.GetDeliveryRequest) (*pb.GetDeliveryResponse, error) {
+	if err := validateOrderID(req.GetOrderId()); err != nil {
+		return nil, err
+	}
+
+	deliveryData, err := s.deliveries.GetDelivery(ctx, req.GetOrderId())
+	if err != nil {
+		return nil, toStatus("get delivery", err)
+	}
+
+	return &pb.GetDeliveryResponse{Delivery: deliveryToProto(*deliveryData)}, nil
+}
+
+func validateOrderID(orderID string) error {
+	if _, err := uuid.Parse(orderID); err != nil {
+		return status.Error(codes.InvalidArgument, "invalid order_id")
+	}
+	return nil
+}
+
+// WatchDeliveryEvents отдает события доставки, пока клиент не отменит вызов. Отправка блокируется
+// flow control gRPC, так что медленный клиент тормозит только свой поток.
+func (s *Server) WatchDeliveryEvents(req *pb.WatchDeliveryEventsRequest, stream grpc.ServerStreamingServer[pb.DeliveryEvent]) error {
+	filter := modelDelivery.EventFilter{
+		OrderID:   req.GetOrderId(),
+		CourierID: req.GetCourierId(),
+		AfterID:   req.GetAfterId(),
+	}
+
+	switch {
+	case (filter.OrderID == "") == (filter.CourierID == 0):
+		return status.Error(codes.InvalidArgument, "exactly one of order_id and courier_id is required")
+	case filter.CourierID < 0:
+		return status.Error(codes.InvalidArgument, "invalid courier id")
+	case filter.AfterID < 0:
+		return status.Error(codes.InvalidArgument, "invalid after_id")
+	}
+	if filter.OrderID != "" {
+		if err := validateOrderID(filter.OrderID); err != nil {
+			return err
+		}
+	}
+
+	err := s.deliveries.WatchEvents(stream.Context(), filter, func(events []modelDelivery.Event) error {
+		for _, e := range events {
+			if err := stream.Send(eventToProto(e)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return toStatus("watch delivery events", err)
+	}
+	return nil
+}
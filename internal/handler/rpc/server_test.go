@@ -0,0 +1,342 @@
+package rpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	rpcHandler "service-courier/internal/handler/rpc"
+	"service-courier/internal/handler/rpc/mocks"
+	modelCourier "service-courier/internal/model/courier"
+	modelDelivery "service-courier/internal/model/delivery"
+	pb "service-courier/internal/proto/courier"
+	serviceDelivery "service-courier/internal/service/delivery"
+)
+
+const orderID = "f819526d-6a7c-48eb-b535-43989469d1ca"
+
+// newClient поднимает сервер в памяти, чтобы проверять коды статусов так, как их видит клиент
+func newClient(t *testing.T) (pb.CourierServiceClient, *mocks.MockcourierService, *mocks.MockdeliveryService) {
+	ctrl := gomock.NewController(t)
+	couriers := mocks.NewMockcourierService(ctrl)
+	deliveries := mocks.NewMockdeliveryService(ctrl)
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	pb.RegisterCourierServiceServer(srv, rpcHandler.NewServer(couriers, deliveries))
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return pb.NewCourierServiceClient(conn), couriers, deliveries
+}
+
+func TestGetCourier_Success(t *testing.T) {
+	t.Parallel()
+	client, couriers, _ := newClient(t)
+
+	updatedAt := time.Date(2025, 11, 30, 12, 0, 0, 0, time.UTC)
+	couriers.EXPECT().GetCourier(gomock.Any(), int64(1)).Return(&modelCourier.Courier{
+		ID:            1,
+		Name:          "Ivan",
+		Phone:         "+78005553535",
+		Status:        modelCourier.StatusAvailable,
+		TransportType: modelCourier.TransportCar,
+		Location:      &modelCourier.Location{UpdatedAt: updatedAt},
+	}, nil)
+
+	resp, err := client.GetCourier(context.Background(), &pb.GetCourierRequest{Id: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "Ivan", resp.GetCourier().GetName())
+	assert.Equal(t, "car", resp.GetCourier().GetTransportType())
+	assert.Equal(t, updatedAt, resp.GetCourier().GetLocation().GetUpdatedAt().AsTime())
+}
+
+func TestGetCourier_NotFound(t *testing.T) {
+	t.Parallel()
+	client, couriers, _ := newClient(t)
+
+	couriers.EXPECT().GetCourier(gomock.Any(), int64(42)).Return(nil, modelCourier.ErrCourierNotFound)
+
+	_, err := client.GetCourier(context.Background(), &pb.GetCourierRequest{Id: 42})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestListCouriers_FiltersAndCursor(t *testing.T) {
+	t.Parallel()
+	client, couriers, _ := newClient(t)
+
+	zoneID := int64(3)
+	couriers.EXPECT().
+		ListCouriers(gomock.Any(), modelCourier.ListFilter{
+			Statuses:   []modelCourier.CourierStatus{modelCourier.StatusAvailable},
+			Transports: []modelCourier.TransportType{modelCourier.TransportCar},
+			ZoneID:     &zoneID,
+			Sort:       modelCourier.SortByName,
+			Limit:      1,
+		}).
+		Return(&modelCourier.Page{
+			Couriers: []modelCourier.Courier{{ID: 5, Name: "Ivan"}},
+			Total:    2,
+			Next:     &modelCourier.Cursor{Name: "Ivan", ID: 5},
+		}, nil)
+
+	resp, err := client.ListCouriers(context.Background(), &pb.ListCouriersRequest{
+		Statuses:       []string{"available"},
+		TransportTypes: []string{"car"},
+		ZoneId:         zoneID,
+		Sort:           "name",
+		Limit:          1,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.GetCouriers(), 1)
+	assert.Equal(t, int64(2), resp.GetTotal())
+	require.NotEmpty(t, resp.GetNextCursor())
+
+	// курсор из ответа продолжает список с того же места
+	couriers.EXPECT().
+		ListCouriers(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, filter modelCourier.ListFilter) (*modelCourier.Page, error) {
+			assert.Equal(t, &modelCourier.Cursor{Name: "Ivan", ID: 5}, filter.After)
+			return &modelCourier.Page{Total: 2}, nil
+		})
+
+	resp, err = client.ListCouriers(context.Background(), &pb.ListCouriersRequest{Sort: "name", Cursor: resp.GetNextCursor()})
+	require.NoError(t, err)
+	assert.Empty(t, resp.GetNextCursor())
+}
+
+func TestListCouriers_InvalidArgument(t *testing.T) {
+	t.Parallel()
+	client, _, _ := newClient(t)
+
+	for _, req := range []*pb.ListCouriersRequest{
+		{Statuses: []string{"sleeping"}},
+		{Limit: 1000},
+		{Sort: "phone"},
+		{Sort: "name", Cursor: "not-a-cursor"},
+	} {
+		_, err := client.ListCouriers(context.Background(), req)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "request %v", req)
+	}
+}
+
+func TestCreateCourier_InvalidArgument(t *testing.T) {
+	t.Parallel()
+	client, _, _ := newClient(t)
+
+	_, err := client.CreateCourier(context.Background(), &pb.CreateCourierRequest{
+		Name:          "Ivan",
+		Phone:         "88005553535",
+		Status:        "available",
+		TransportType: "car",
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestCreateCourier_PhoneExists(t *testing.T) {
+	t.Parallel()
+	client, couriers, _ := newClient(t)
+
+	couriers.EXPECT().CreateCourier(gomock.Any(), gomock.Any()).Return(int64(0), modelCourier.ErrPhoneExists)
+
+	_, err := client.CreateCourier(context.Background(), &pb.CreateCourierRequest{
+		Name:          "Ivan",
+		Phone:         "+78005553535",
+		Status:        "available",
+		TransportType: "car",
+	})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+func TestUpdateCourier_VersionMismatch(t *testing.T) {
+	t.Parallel()
+	client, couriers, _ := newClient(t)
+
+	couriers.EXPECT().UpdateCourier(gomock.Any(), gomock.Any()).Return(modelCourier.ErrVersionMismatch)
+
+	_, err := client.UpdateCourier(context.Background(), &pb.UpdateCourierRequest{Id: 1, Name: "Ivan"})
+	assert.Equal(t, codes.Aborted, status.Code(err))
+}
+
+func TestAssignDelivery_Success(t *testing.T) {
+	t.Parallel()
+	client, _, deliveries := newClient(t)
+
+	deadline := time.Date(2025, 11, 30, 12, 0, 0, 0, time.UTC)
+	deliveries.EXPECT().
+		AssignCourier(gomock.Any(), orderID, modelDelivery.PriorityExpress).
+		Return(&serviceDelivery.AssignResult{
+			OrderID:       orderID,
+			CourierID:     10,
+			TransportType: modelCourier.TransportScooter,
+			Deadline:      deadline,
+			Status:        modelDelivery.StatusAssigned,
+		}, nil)
+
+	resp, err := client.AssignDelivery(context.Background(), &pb.AssignDeliveryRequest{OrderId: orderID, Priority: "express"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), resp.GetCourierId())
+	assert.Equal(t, deadline, resp.GetDeadline().AsTime())
+	assert.False(t, resp.GetPending())
+	assert.Nil(t, resp.GetOfferExpiresAt())
+}
+
+func TestAssignDelivery_Pending(t *testing.T) {
+	t.Parallel()
+	client, _, deliveries := newClient(t)
+
+	deliveries.EXPECT().
+		AssignCourier(gomock.Any(), orderID, modelDelivery.PriorityStandard).
+		Return(nil, modelDelivery.ErrAssignmentPending)
+
+	resp, err := client.AssignDelivery(context.Background(), &pb.AssignDeliveryRequest{OrderId: orderID})
+	require.NoError(t, err)
+	assert.True(t, resp.GetPending())
+}
+
+func TestAssignDelivery_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		err      error
+		expected codes.Code
+	}{
+		{name: "already assigned", err: modelDelivery.ErrOrderAlreadyAssigned, expected: codes.AlreadyExists},
+		{name: "no couriers", err: modelCourier.ErrNoAvailableCouriers, expected: codes.ResourceExhausted},
+		{name: "internal", err: assert.AnError, expected: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client, _, deliveries := newClient(t)
+
+			deliveries.EXPECT().AssignCourier(gomock.Any(), orderID, gomock.Any()).Return(nil, tt.err)
+
+			_, err := client.AssignDelivery(context.Background(), &pb.AssignDeliveryRequest{OrderId: orderID})
+			assert.Equal(t, tt.expected, status.Code(err))
+		})
+	}
+}
+
+func TestAssignDelivery_InvalidRequest(t *testing.T) {
+	t.Parallel()
+	client, _, _ := newClient(t)
+
+	_, err := client.AssignDelivery(context.Background(), &pb.AssignDeliveryRequest{OrderId: "not-a-uuid"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.AssignDelivery(context.Background(), &pb.AssignDeliveryRequest{OrderId: orderID, Priority: "urgent"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGetDelivery(t *testing.T) {
+	t.Parallel()
+	client, _, deliveries := newClient(t)
+
+	deliveries.EXPECT().GetDelivery(gomock.Any(), orderID).Return(&modelDelivery.Delivery{
+		ID:        5,
+		CourierID: 10,
+		OrderID:   orderID,
+		Status:    modelDelivery.StatusPickedUp,
+		Priority:  modelDelivery.PriorityVIP,
+	}, nil)
+
+	resp, err := client.GetDelivery(context.Background(), &pb.GetDeliveryRequest{OrderId: orderID})
+	require.NoError(t, err)
+	assert.Equal(t, "picked_up", resp.GetDelivery().GetStatus())
+	assert.Equal(t, "vip", resp.GetDelivery().GetPriority())
+
+	deliveries.EXPECT().GetDelivery(gomock.Any(), orderID).Return(nil, modelDelivery.ErrDeliveryNotFound)
+
+	_, err = client.GetDelivery(context.Background(), &pb.GetDeliveryRequest{OrderId: orderID})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestCompleteDelivery_InvalidTransition(t *testing.T) {
+	t.Parallel()
+	client, _, deliveries := newClient(t)
+
+	deliveries.EXPECT().
+		CompleteDelivery(gomock.Any(), orderID).
+		Return(&modelDelivery.TransitionError{From: modelDelivery.StatusDelivered, To: modelDelivery.StatusCompleted})
+
+	_, err := client.CompleteDelivery(context.Background(), &pb.CompleteDeliveryRequest{OrderId: orderID})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestWatchDeliveryEvents_StreamsEvents(t *testing.T) {
+	t.Parallel()
+	client, _, deliveries := newClient(t)
+
+	deliveries.EXPECT().
+		WatchEvents(gomock.Any(), modelDelivery.EventFilter{OrderID: orderID, AfterID: 7}, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ modelDelivery.EventFilter, send func([]modelDelivery.Event) error) error {
+			return send([]modelDelivery.Event{
+				{ID: 8, OrderID: orderID, CourierID: 10, ToStatus: modelDelivery.StatusAssigned},
+				{ID: 9, OrderID: orderID, CourierID: 10, FromStatus: modelDelivery.StatusAssigned, ToStatus: modelDelivery.StatusDeleted},
+			})
+		})
+
+	stream, err := client.WatchDeliveryEvents(context.Background(), &pb.WatchDeliveryEventsRequest{OrderId: orderID, AfterId: 7})
+	require.NoError(t, err)
+
+	first, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), first.GetId())
+	assert.Equal(t, "assigned", first.GetType())
+	assert.Empty(t, first.GetFromStatus())
+
+	second, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, "unassigned", second.GetType())
+	assert.Equal(t, "assigned", second.GetFromStatus())
+}
+
+func TestWatchDeliveryEvents_RequiresOneTarget(t *testing.T) {
+	t.Parallel()
+	client, _, _ := newClient(t)
+
+	stream, err := client.WatchDeliveryEvents(context.Background(), &pb.WatchDeliveryEventsRequest{OrderId: orderID, CourierId: 10})
+	require.NoError(t, err)
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestWatchDeliveryEvents_TooManyStreams(t *testing.T) {
+	t.Parallel()
+	client, _, deliveries := newClient(t)
+
+	deliveries.EXPECT().
+		WatchEvents(gomock.Any(), modelDelivery.EventFilter{CourierID: 10}, gomock.Any()).
+		Return(modelDelivery.ErrTooManyStreams)
+
+	stream, err := client.WatchDeliveryEvents(context.Background(), &pb.WatchDeliveryEventsRequest{CourierId: 10})
+	require.NoError(t, err)
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
@@ -0,0 +1,15 @@
+//go:generate mockgen -source=contract.go -destination=./mocks/shift_service_mock.go -package=mocks
+package shift
+
+import (
+	"context"
+	"service-courier/internal/model/courier"
+)
+
+type shiftService interface {
+	GetShift(ctx context.Context, id int64) (*courier.Shift, error)
+	ListShifts(ctx context.Context, filter courier.ShiftFilter) ([]courier.Shift, error)
+	CreateShift(ctx context.Context, shiftData courier.Shift) (int64, error)
+	UpdateShift(ctx context.Context, shiftData courier.Shift) error
+	DeleteShift(ctx context.Context, id int64) error
+}
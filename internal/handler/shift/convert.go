@@ -0,0 +1,44 @@
+package shift
+
+import (
+	"service-courier/internal/model/courier"
+	"time"
+)
+
+func ModelToResponse(shift courier.Shift) Shift {
+	return Shift{
+		ID:         shift.ID,
+		CourierID:  shift.CourierID,
+		StartsAt:   shift.StartsAt.Format(time.RFC3339),
+		EndsAt:     shift.EndsAt.Format(time.RFC3339),
+		ZoneID:     shift.ZoneID,
+		StartedAt:  formatTime(shift.StartedAt),
+		FinishedAt: formatTime(shift.FinishedAt),
+	}
+}
+
+func (r CreateRequest) ToModel() courier.Shift {
+	return courier.Shift{
+		CourierID: r.CourierID,
+		StartsAt:  r.StartsAt.UTC(),
+		EndsAt:    r.EndsAt.UTC(),
+		ZoneID:    r.ZoneID,
+	}
+}
+
+func (r UpdateRequest) ToModel(id int64) courier.Shift {
+	return courier.Shift{
+		ID:       id,
+		StartsAt: r.StartsAt.UTC(),
+		EndsAt:   r.EndsAt.UTC(),
+		ZoneID:   r.ZoneID,
+	}
+}
+
+func formatTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format(time.RFC3339)
+	return &formatted
+}
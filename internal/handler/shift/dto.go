@@ -0,0 +1,29 @@
+package shift
+
+import "time"
+
+// Shift - модель смены для ответа
+type Shift struct {
+	ID         int64   `json:"id"`
+	CourierID  int64   `json:"courier_id"`
+	StartsAt   string  `json:"starts_at"`
+	EndsAt     string  `json:"ends_at"`
+	ZoneID     *int64  `json:"zone_id"`
+	StartedAt  *string `json:"started_at,omitempty"`
+	FinishedAt *string `json:"finished_at,omitempty"`
+}
+
+// CreateRequest запрос на создание смены
+type CreateRequest struct {
+	CourierID int64     `json:"courier_id"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	ZoneID    *int64    `json:"zone_id,omitempty"`
+}
+
+// UpdateRequest запрос на изменение периода и зоны смены
+type UpdateRequest struct {
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	ZoneID   *int64    `json:"zone_id,omitempty"`
+}
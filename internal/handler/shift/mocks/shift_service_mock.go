@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+//
+// Generated by this command:
+//
+//	mockgen -source=contract.go -destination=./mocks/shift_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	courier "service-courier/internal/model/courier"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockshiftService is a mock of shiftService interface.
+type MockshiftService struct {
+	ctrl     *gomock.Controller
+	recorder *MockshiftServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockshiftServiceMockRecorder is the mock recorder for MockshiftService.
+type MockshiftServiceMockRecorder struct {
+	mock *MockshiftService
+}
+
+// NewMockshiftService creates a new mock instance.
+func NewMockshiftService(ctrl *gomock.Controller) *MockshiftService {
+	mock := &MockshiftService{ctrl: ctrl}
+	mock.recorder = &MockshiftServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockshiftService) EXPECT() *MockshiftServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateShift mocks base method.
+func (m *MockshiftService) CreateShift(ctx context.Context, shiftData courier.Shift) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShift", ctx, shiftData)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateShift indicates an expected call of CreateShift.
+func (mr *MockshiftServiceMockRecorder) CreateShift(ctx, shiftData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShift", reflect.TypeOf((*MockshiftService)(nil).CreateShift), ctx, shiftData)
+}
+
+// DeleteShift mocks base method.
+func (m *MockshiftService) DeleteShift(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteShift", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteShift indicates an expected call of DeleteShift.
+func (mr *MockshiftServiceMockRecorder) DeleteShift(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteShift", reflect.TypeOf((*MockshiftService)(nil).DeleteShift), ctx, id)
+}
+
+// GetShift mocks base method.
+func (m *MockshiftService) GetShift(ctx context.Context, id int64) (*courier.Shift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShift", ctx, id)
+	ret0, _ := ret[0].(*courier.Shift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShift indicates an expected call of GetShift.
+func (mr *MockshiftServiceMockRecorder) GetShift(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShift", reflect.TypeOf((*MockshiftService)(nil).GetShift), ctx, id)
+}
+
+// ListShifts mocks base method.
+func (m *MockshiftService) ListShifts(ctx context.Context, filter courier.ShiftFilter) ([]courier.Shift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListShifts", ctx, filter)
+	ret0, _ := ret[0].([]courier.Shift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListShifts indicates an expected call of ListShifts.
+func (mr *MockshiftServiceMockRecorder) ListShifts(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListShifts", reflect.TypeOf((*MockshiftService)(nil).ListShifts), ctx, filter)
+}
+
+// UpdateShift mocks base method.
+func (m *MockshiftService) UpdateShift(ctx context.Context, shiftData courier.Shift) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateShift", ctx, shiftData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateShift indicates an expected call of UpdateShift.
+func (mr *MockshiftServiceMockRecorder) UpdateShift(ctx, shiftData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateShift", reflect.TypeOf((*MockshiftService)(nil).UpdateShift), ctx, shiftData)
+}
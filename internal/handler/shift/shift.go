@@ -0,0 +1,211 @@
+package shift
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"service-courier/internal/model/courier"
+	"service-courier/internal/model/zone"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler struct {
+	service shiftService
+}
+
+func NewShiftHandler(service shiftService) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "Invalid shift ID",
+		})
+		return
+	}
+
+	shiftData, err := h.service.GetShift(r.Context(), id)
+	if err != nil {
+		log.Printf("get shift: %v", err)
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, ModelToResponse(*shiftData))
+}
+
+// List возвращает смены с необязательными фильтрами courier_id, from и to (RFC3339)
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseFilter(r)
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	shifts, err := h.service.ListShifts(r.Context(), filter)
+	if err != nil {
+		log.Printf("list shifts: %v", err)
+		h.writeError(w, err)
+		return
+	}
+
+	response := make([]Shift, len(shifts))
+	for i, s := range shifts {
+		response[i] = ModelToResponse(s)
+	}
+
+	h.writeJSON(w, http.StatusOK, response)
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	var req CreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "Invalid JSON",
+		})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	id, err := h.service.CreateShift(r.Context(), req.ToModel())
+	if err != nil {
+		log.Printf("create shift: %v", err)
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"id":      id,
+		"message": "Shift created successfully",
+	})
+}
+
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "Invalid shift ID",
+		})
+		return
+	}
+
+	var req UpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "Invalid JSON",
+		})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if err := h.service.UpdateShift(r.Context(), req.ToModel(id)); err != nil {
+		log.Printf("update shift: %v", err)
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]string{
+		"message": "Shift updated successfully",
+	})
+}
+
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "Invalid shift ID",
+		})
+		return
+	}
+
+	if err := h.service.DeleteShift(r.Context(), id); err != nil {
+		log.Printf("delete shift: %v", err)
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]string{
+		"message": "Shift deleted successfully",
+	})
+}
+
+func parseFilter(r *http.Request) (courier.ShiftFilter, error) {
+	var filter courier.ShiftFilter
+	query := r.URL.Query()
+
+	if v := query.Get("courier_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			return filter, errors.New("invalid courier_id")
+		}
+		filter.CourierID = &id
+	}
+	if v := query.Get("from"); v != "" {
+		from, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return filter, errors.New("invalid from")
+		}
+		from = from.UTC()
+		filter.From = &from
+	}
+	if v := query.Get("to"); v != "" {
+		to, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return filter, errors.New("invalid to")
+		}
+		to = to.UTC()
+		filter.To = &to
+	}
+
+	return filter, nil
+}
+
+func (h *Handler) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		http.Error(w, `{"error": "Failed to encode response"}`, http.StatusInternalServerError)
+	}
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, err error) {
+	message, status := h.mapError(err)
+	h.writeJSON(w, status, map[string]string{"error": message})
+}
+
+func (h *Handler) mapError(err error) (string, int) {
+	switch {
+	case errors.Is(err, courier.ErrShiftNotFound):
+		return "Shift not found", http.StatusNotFound
+	case errors.Is(err, courier.ErrCourierNotFound):
+		return "Courier not found", http.StatusNotFound
+	case errors.Is(err, zone.ErrZoneNotFound):
+		return "Zone not found", http.StatusNotFound
+	case errors.Is(err, courier.ErrShiftOverlap):
+		return "Shift overlaps another shift of the courier", http.StatusConflict
+	case errors.Is(err, courier.ErrInvalidShiftPeriod):
+		return "Shift must end after it starts", http.StatusBadRequest
+	default:
+		return "Internal server error", http.StatusInternalServerError
+	}
+}
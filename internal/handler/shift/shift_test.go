@@ -0,0 +1,246 @@
+package shift_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/mock/gomock"
+
+	shiftHandler "service-courier/internal/handler/shift"
+	"service-courier/internal/handler/shift/mocks"
+	model "service-courier/internal/model/courier"
+	modelZone "service-courier/internal/model/zone"
+)
+
+func newRouter(h *shiftHandler.Handler) *chi.Mux {
+	r := chi.NewRouter()
+	r.Get("/shifts", h.List)
+	r.Get("/shift/{id}", h.Get)
+	r.Post("/shift", h.Create)
+	r.Put("/shift/{id}", h.Update)
+	r.Delete("/shift/{id}", h.Delete)
+	return r
+}
+
+func TestGetShift_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockshiftService(ctrl)
+
+	mockService.EXPECT().
+		GetShift(gomock.Any(), int64(1)).
+		Return(&model.Shift{ID: 1, CourierID: 2, StartsAt: time.Now(), EndsAt: time.Now().Add(time.Hour)}, nil)
+
+	req := httptest.NewRequest("GET", "/shift/1", nil)
+	rr := httptest.NewRecorder()
+	newRouter(shiftHandler.NewShiftHandler(mockService)).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", rr.Code)
+	}
+}
+
+func TestGetShift_NotFound(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockshiftService(ctrl)
+
+	mockService.EXPECT().
+		GetShift(gomock.Any(), int64(5)).
+		Return(nil, model.ErrShiftNotFound)
+
+	req := httptest.NewRequest("GET", "/shift/5", nil)
+	rr := httptest.NewRecorder()
+	newRouter(shiftHandler.NewShiftHandler(mockService)).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 Not Found, got %d", rr.Code)
+	}
+}
+
+func TestListShifts_FilterByCourier(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockshiftService(ctrl)
+
+	mockService.EXPECT().
+		ListShifts(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ interface{}, filter model.ShiftFilter) ([]model.Shift, error) {
+			if filter.CourierID == nil || *filter.CourierID != 3 {
+				t.Fatalf("expected courier filter 3, got %v", filter.CourierID)
+			}
+			return []model.Shift{{ID: 1, CourierID: 3}}, nil
+		})
+
+	req := httptest.NewRequest("GET", "/shifts?courier_id=3", nil)
+	rr := httptest.NewRecorder()
+	newRouter(shiftHandler.NewShiftHandler(mockService)).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", rr.Code)
+	}
+}
+
+func TestListShifts_InvalidFrom(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockshiftService(ctrl)
+
+	req := httptest.NewRequest("GET", "/shifts?from=yesterday", nil)
+	rr := httptest.NewRecorder()
+	newRouter(shiftHandler.NewShiftHandler(mockService)).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 Bad Request, got %d", rr.Code)
+	}
+}
+
+func TestCreateShift_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockshiftService(ctrl)
+
+	mockService.EXPECT().
+		CreateShift(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, s model.Shift) (int64, error) {
+			if s.ZoneID == nil || *s.ZoneID != 3 {
+				t.Errorf("expected ZoneID=3, got %v", s.ZoneID)
+			}
+			return 1, nil
+		})
+
+	body := `{"courier_id":1,"starts_at":"2024-01-01T09:00:00Z","ends_at":"2024-01-01T17:00:00Z","zone_id":3}`
+	req := httptest.NewRequest("POST", "/shift", bytes.NewBufferString(body))
+	rr := httptest.NewRecorder()
+	newRouter(shiftHandler.NewShiftHandler(mockService)).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected 201 Created, got %d", rr.Code)
+	}
+}
+
+func TestCreateShift_InvalidPeriod(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockshiftService(ctrl)
+
+	body := `{"courier_id":1,"starts_at":"2024-01-01T17:00:00Z","ends_at":"2024-01-01T09:00:00Z"}`
+	req := httptest.NewRequest("POST", "/shift", bytes.NewBufferString(body))
+	rr := httptest.NewRecorder()
+	newRouter(shiftHandler.NewShiftHandler(mockService)).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 Bad Request, got %d", rr.Code)
+	}
+}
+
+func TestCreateShift_InvalidZone(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockshiftService(ctrl)
+
+	body := `{"courier_id":1,"starts_at":"2024-01-01T09:00:00Z","ends_at":"2024-01-01T17:00:00Z","zone_id":0}`
+	req := httptest.NewRequest("POST", "/shift", bytes.NewBufferString(body))
+	rr := httptest.NewRecorder()
+	newRouter(shiftHandler.NewShiftHandler(mockService)).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 Bad Request, got %d", rr.Code)
+	}
+}
+
+func TestCreateShift_ZoneNotFound(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockshiftService(ctrl)
+
+	mockService.EXPECT().
+		CreateShift(gomock.Any(), gomock.Any()).
+		Return(int64(0), modelZone.ErrZoneNotFound)
+
+	body := `{"courier_id":1,"starts_at":"2024-01-01T09:00:00Z","ends_at":"2024-01-01T17:00:00Z","zone_id":3}`
+	req := httptest.NewRequest("POST", "/shift", bytes.NewBufferString(body))
+	rr := httptest.NewRecorder()
+	newRouter(shiftHandler.NewShiftHandler(mockService)).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 Not Found, got %d", rr.Code)
+	}
+}
+
+func TestCreateShift_Overlap(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockshiftService(ctrl)
+
+	mockService.EXPECT().
+		CreateShift(gomock.Any(), gomock.Any()).
+		Return(int64(0), model.ErrShiftOverlap)
+
+	body := `{"courier_id":1,"starts_at":"2024-01-01T09:00:00Z","ends_at":"2024-01-01T17:00:00Z"}`
+	req := httptest.NewRequest("POST", "/shift", bytes.NewBufferString(body))
+	rr := httptest.NewRecorder()
+	newRouter(shiftHandler.NewShiftHandler(mockService)).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusConflict {
+		t.Fatalf("expected 409 Conflict, got %d", rr.Code)
+	}
+}
+
+func TestUpdateShift_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockshiftService(ctrl)
+
+	mockService.EXPECT().
+		UpdateShift(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ interface{}, s model.Shift) error {
+			if s.ID != 4 {
+				t.Fatalf("expected shift 4, got %d", s.ID)
+			}
+			return nil
+		})
+
+	body := `{"starts_at":"2024-01-01T09:00:00Z","ends_at":"2024-01-01T13:00:00Z"}`
+	req := httptest.NewRequest("PUT", "/shift/4", bytes.NewBufferString(body))
+	rr := httptest.NewRecorder()
+	newRouter(shiftHandler.NewShiftHandler(mockService)).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", rr.Code)
+	}
+}
+
+func TestDeleteShift_NotFound(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockshiftService(ctrl)
+
+	mockService.EXPECT().
+		DeleteShift(gomock.Any(), int64(9)).
+		Return(model.ErrShiftNotFound)
+
+	req := httptest.NewRequest("DELETE", "/shift/9", nil)
+	rr := httptest.NewRecorder()
+	newRouter(shiftHandler.NewShiftHandler(mockService)).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 Not Found, got %d", rr.Code)
+	}
+}
@@ -0,0 +1,30 @@
+package shift
+
+import (
+	"fmt"
+	"time"
+)
+
+func (r CreateRequest) Validate() error {
+	if r.CourierID <= 0 {
+		return fmt.Errorf("invalid courier_id")
+	}
+	return validatePeriod(r.StartsAt, r.EndsAt, r.ZoneID)
+}
+
+func (r UpdateRequest) Validate() error {
+	return validatePeriod(r.StartsAt, r.EndsAt, r.ZoneID)
+}
+
+func validatePeriod(startsAt, endsAt time.Time, zoneID *int64) error {
+	if startsAt.IsZero() || endsAt.IsZero() {
+		return fmt.Errorf("starts_at and ends_at are required")
+	}
+	if !endsAt.After(startsAt) {
+		return fmt.Errorf("ends_at must be after starts_at")
+	}
+	if zoneID != nil && *zoneID <= 0 {
+		return fmt.Errorf("invalid zone_id")
+	}
+	return nil
+}
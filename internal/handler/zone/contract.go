@@ -0,0 +1,18 @@
+//go:generate mockgen -source=contract.go -destination=./mocks/zone_service_mock.go -package=mocks
+package zone
+
+import (
+	"context"
+	"service-courier/internal/model/zone"
+)
+
+type zoneService interface {
+	GetZone(ctx context.Context, id int64) (*zone.Zone, error)
+	ListZones(ctx context.Context) ([]zone.Zone, error)
+	CreateZone(ctx context.Context, zoneData zone.Zone) (int64, error)
+	UpdateZone(ctx context.Context, zoneData zone.Zone) error
+	DeleteZone(ctx context.Context, id int64) error
+	ImportZones(ctx context.Context, zones []zone.Zone, neighbors map[string][]string) ([]int64, error)
+	SetCourierZones(ctx context.Context, courierID int64, zoneIDs []int64) error
+	GetCourierZones(ctx context.Context, courierID int64) ([]int64, error)
+}
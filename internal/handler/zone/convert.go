@@ -0,0 +1,85 @@
+package zone
+
+import (
+	"fmt"
+	"service-courier/internal/model/zone"
+	"service-courier/internal/pkg/geojson"
+)
+
+func ModelToResponse(zoneData zone.Zone) Zone {
+	neighbors := zoneData.Neighbors
+	if neighbors == nil {
+		neighbors = []int64{}
+	}
+	return Zone{
+		ID:        zoneData.ID,
+		Name:      zoneData.Name,
+		City:      zoneData.City,
+		Geometry:  geojson.NewPolygon(zoneData.Polygon),
+		Neighbors: neighbors,
+	}
+}
+
+func (r Request) ToModel(id int64) (zone.Zone, error) {
+	polygon, err := r.Geometry.Polygon()
+	if err != nil {
+		return zone.Zone{}, fmt.Errorf("invalid geometry: %w", err)
+	}
+	return zone.Zone{
+		ID:        id,
+		Name:      r.Name,
+		City:      r.City,
+		Polygon:   polygon,
+		Neighbors: r.Neighbors,
+	}, nil
+}
+
+// ModelsToFeatureCollection заменяет идентификаторы соседей их именами
+func ModelsToFeatureCollection(zones []zone.Zone) FeatureCollection {
+	names := make(map[int64]string, len(zones))
+	for _, z := range zones {
+		names[z.ID] = z.Name
+	}
+
+	features := make([]Feature, len(zones))
+	for i, z := range zones {
+		neighbors := make([]string, 0, len(z.Neighbors))
+		for _, id := range z.Neighbors {
+			if name, ok := names[id]; ok {
+				neighbors = append(neighbors, name)
+			}
+		}
+		features[i] = Feature{
+			Type:     geojson.TypeFeature,
+			Geometry: geojson.NewPolygon(z.Polygon),
+			Properties: FeatureProperties{
+				Name:      z.Name,
+				City:      z.City,
+				Neighbors: neighbors,
+			},
+		}
+	}
+
+	return FeatureCollection{Type: geojson.TypeFeatureCollection, Features: features}
+}
+
+// ToModels возвращает зоны и их соседей по именам
+func (c FeatureCollection) ToModels() ([]zone.Zone, map[string][]string, error) {
+	zones := make([]zone.Zone, len(c.Features))
+	neighbors := make(map[string][]string, len(c.Features))
+
+	for i, feature := range c.Features {
+		polygon, err := feature.Geometry.Polygon()
+		if err != nil {
+			return nil, nil, fmt.Errorf("feature %d: invalid geometry: %w", i, err)
+		}
+		zones[i] = zone.Zone{
+			Name:    feature.Properties.Name,
+			City:    feature.Properties.City,
+			Polygon: polygon,
+		}
+		neighbors[feature.Properties.Name] = feature.Properties.Neighbors
+	}
+
+	return zones, neighbors, nil
+}
@@ -0,0 +1,45 @@
+package zone
+
+import "service-courier/internal/pkg/geojson"
+
+// Zone - модель зоны для ответа
+type Zone struct {
+	ID        int64            `json:"id"`
+	Name      string           `json:"name"`
+	City      string           `json:"city"`
+	Geometry  geojson.Geometry `json:"geometry"`
+	Neighbors []int64          `json:"neighbors"`
+}
+
+// Request запрос на создание или изменение зоны
+type Request struct {
+	Name      string           `json:"name"`
+	City      string           `json:"city"`
+	Geometry  geojson.Geometry `json:"geometry"`
+	Neighbors []int64          `json:"neighbors"`
+}
+
+// FeatureCollection - зоны в формате GeoJSON для импорта и экспорта
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+type Feature struct {
+	Type       string            `json:"type"`
+	Geometry   geojson.Geometry  `json:"geometry"`
+	Properties FeatureProperties `json:"properties"`
+}
+
+// FeatureProperties - свойства зоны. Соседи задаются именами, чтобы файл можно было
+// перенести между окружениями с разными идентификаторами.
+type FeatureProperties struct {
+	Name      string   `json:"name"`
+	City      string   `json:"city"`
+	Neighbors []string `json:"neighbors"`
+}
+
+// CourierZonesRequest запрос на закрепление курьера за зонами
+type CourierZonesRequest struct {
+	ZoneIDs []int64 `json:"zone_ids"`
+}
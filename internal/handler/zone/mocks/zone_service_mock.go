@@ -0,0 +1,159 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+//
+// Generated by this command:
+//
+//	mockgen -source=contract.go -destination=./mocks/zone_service_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	zone "service-courier/internal/model/zone"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockzoneService is a mock of zoneService interface.
+type MockzoneService struct {
+	ctrl     *gomock.Controller
+	recorder *MockzoneServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockzoneServiceMockRecorder is the mock recorder for MockzoneService.
+type MockzoneServiceMockRecorder struct {
+	mock *MockzoneService
+}
+
+// NewMockzoneService creates a new mock instance.
+func NewMockzoneService(ctrl *gomock.Controller) *MockzoneService {
+	mock := &MockzoneService{ctrl: ctrl}
+	mock.recorder = &MockzoneServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockzoneService) EXPECT() *MockzoneServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateZone mocks base method.
+func (m *MockzoneService) CreateZone(ctx context.Context, zoneData zone.Zone) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateZone", ctx, zoneData)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateZone indicates an expected call of CreateZone.
+func (mr *MockzoneServiceMockRecorder) CreateZone(ctx, zoneData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateZone", reflect.TypeOf((*MockzoneService)(nil).CreateZone), ctx, zoneData)
+}
+
+// DeleteZone mocks base method.
+func (m *MockzoneService) DeleteZone(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteZone", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteZone indicates an expected call of DeleteZone.
+func (mr *MockzoneServiceMockRecorder) DeleteZone(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteZone", reflect.TypeOf((*MockzoneService)(nil).DeleteZone), ctx, id)
+}
+
+// GetCourierZones mocks base method.
+func (m *MockzoneService) GetCourierZones(ctx context.Context, courierID int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCourierZones", ctx, courierID)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCourierZones indicates an expected call of GetCourierZones.
+func (mr *MockzoneServiceMockRecorder) GetCourierZones(ctx, courierID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourierZones", reflect.TypeOf((*MockzoneService)(nil).GetCourierZones), ctx, courierID)
+}
+
+// GetZone mocks base method.
+func (m *MockzoneService) GetZone(ctx context.Context, id int64) (*zone.Zone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetZone", ctx, id)
+	ret0, _ := ret[0].(*zone.Zone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetZone indicates an expected call of GetZone.
+func (mr *MockzoneServiceMockRecorder) GetZone(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetZone", reflect.TypeOf((*MockzoneService)(nil).GetZone), ctx, id)
+}
+
+// ImportZones mocks base method.
+func (m *MockzoneService) ImportZones(ctx context.Context, zones []zone.Zone, neighbors map[string][]string) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportZones", ctx, zones, neighbors)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportZones indicates an expected call of ImportZones.
+func (mr *MockzoneServiceMockRecorder) ImportZones(ctx, zones, neighbors any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportZones", reflect.TypeOf((*MockzoneService)(nil).ImportZones), ctx, zones, neighbors)
+}
+
+// ListZones mocks base method.
+func (m *MockzoneService) ListZones(ctx context.Context) ([]zone.Zone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListZones", ctx)
+	ret0, _ := ret[0].([]zone.Zone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListZones indicates an expected call of ListZones.
+func (mr *MockzoneServiceMockRecorder) ListZones(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListZones", reflect.TypeOf((*MockzoneService)(nil).ListZones), ctx)
+}
+
+// SetCourierZones mocks base method.
+func (m *MockzoneService) SetCourierZones(ctx context.Context, courierID int64, zoneIDs []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCourierZones", ctx, courierID, zoneIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCourierZones indicates an expected call of SetCourierZones.
+func (mr *MockzoneServiceMockRecorder) SetCourierZones(ctx, courierID, zoneIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCourierZones", reflect.TypeOf((*MockzoneService)(nil).SetCourierZones), ctx, courierID, zoneIDs)
+}
+
+// UpdateZone mocks base method.
+func (m *MockzoneService) UpdateZone(ctx context.Context, zoneData zone.Zone) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateZone", ctx, zoneData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateZone indicates an expected call of UpdateZone.
+func (mr *MockzoneServiceMockRecorder) UpdateZone(ctx, zoneData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateZone", reflect.TypeOf((*MockzoneService)(nil).UpdateZone), ctx, zoneData)
+}
@@ -0,0 +1,56 @@
+package zone
+
+import (
+	"fmt"
+	"service-courier/internal/pkg/geojson"
+)
+
+func (r Request) Validate() error {
+	return validateNames(r.Name, r.City)
+}
+
+func (c FeatureCollection) Validate() error {
+	if c.Type != geojson.TypeFeatureCollection {
+		return fmt.Errorf("type must be %s", geojson.TypeFeatureCollection)
+	}
+	if len(c.Features) == 0 {
+		return fmt.Errorf("features are required")
+	}
+
+	seen := make(map[string]bool, len(c.Features))
+	for i, feature := range c.Features {
+		if feature.Type != geojson.TypeFeature {
+			return fmt.Errorf("feature %d: type must be %s", i, geojson.TypeFeature)
+		}
+		if err := validateNames(feature.Properties.Name, feature.Properties.City); err != nil {
+			return fmt.Errorf("feature %d: %w", i, err)
+		}
+		if seen[feature.Properties.Name] {
+			return fmt.Errorf("feature %d: duplicate name %q", i, feature.Properties.Name)
+		}
+		seen[feature.Properties.Name] = true
+	}
+	return nil
+}
+
+func (r CourierZonesRequest) Validate() error {
+	for _, id := range r.ZoneIDs {
+		if id <= 0 {
+			return fmt.Errorf("invalid zone id %d", id)
+		}
+	}
+	return nil
+}
+
+func validateNames(name, city string) error {
+	if name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(name) > 100 {
+		return fmt.Errorf("name is too long")
+	}
+	if len(city) > 100 {
+		return fmt.Errorf("city is too long")
+	}
+	return nil
+}
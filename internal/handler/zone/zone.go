@@ -0,0 +1,279 @@
+package zone
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"service-courier/internal/model/courier"
+	"service-courier/internal/model/zone"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type Handler struct {
+	service zoneService
+}
+
+func NewZoneHandler(service zoneService) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "Invalid zone ID",
+		})
+		return
+	}
+
+	zoneData, err := h.service.GetZone(r.Context(), id)
+	if err != nil {
+		log.Printf("get zone: %v", err)
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, ModelToResponse(*zoneData))
+}
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	zones, err := h.service.ListZones(r.Context())
+	if err != nil {
+		log.Printf("list zones: %v", err)
+		h.writeError(w, err)
+		return
+	}
+
+	response := make([]Zone, len(zones))
+	for i, z := range zones {
+		response[i] = ModelToResponse(z)
+	}
+
+	h.writeJSON(w, http.StatusOK, response)
+}
+
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	zoneData, ok := h.decodeRequest(w, r, 0)
+	if !ok {
+		return
+	}
+
+	id, err := h.service.CreateZone(r.Context(), zoneData)
+	if err != nil {
+		log.Printf("create zone: %v", err)
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"id":      id,
+		"message": "Zone created successfully",
+	})
+}
+
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "Invalid zone ID",
+		})
+		return
+	}
+
+	zoneData, ok := h.decodeRequest(w, r, id)
+	if !ok {
+		return
+	}
+
+	if err := h.service.UpdateZone(r.Context(), zoneData); err != nil {
+		log.Printf("update zone: %v", err)
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]string{
+		"message": "Zone updated successfully",
+	})
+}
+
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "Invalid zone ID",
+		})
+		return
+	}
+
+	if err := h.service.DeleteZone(r.Context(), id); err != nil {
+		log.Printf("delete zone: %v", err)
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]string{
+		"message": "Zone deleted successfully",
+	})
+}
+
+// Export выгружает все зоны как GeoJSON FeatureCollection
+func (h *Handler) Export(w http.ResponseWriter, r *http.Request) {
+	zones, err := h.service.ListZones(r.Context())
+	if err != nil {
+		log.Printf("export zones: %v", err)
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, ModelsToFeatureCollection(zones))
+}
+
+// Import создает или обновляет зоны из GeoJSON FeatureCollection, сопоставляя их по имени
+func (h *Handler) Import(w http.ResponseWriter, r *http.Request) {
+	var req FeatureCollection
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "Invalid JSON",
+		})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	zones, neighbors, err := req.ToModels()
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	ids, err := h.service.ImportZones(r.Context(), zones, neighbors)
+	if err != nil {
+		log.Printf("import zones: %v", err)
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"ids":     ids,
+		"message": "Zones imported successfully",
+	})
+}
+
+func (h *Handler) GetCourierZones(w http.ResponseWriter, r *http.Request) {
+	courierID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "Invalid courier ID",
+		})
+		return
+	}
+
+	zoneIDs, err := h.service.GetCourierZones(r.Context(), courierID)
+	if err != nil {
+		log.Printf("get courier zones: %v", err)
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, CourierZonesRequest{ZoneIDs: zoneIDs})
+}
+
+func (h *Handler) SetCourierZones(w http.ResponseWriter, r *http.Request) {
+	courierID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "Invalid courier ID",
+		})
+		return
+	}
+
+	var req CourierZonesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "Invalid JSON",
+		})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if err := h.service.SetCourierZones(r.Context(), courierID, req.ZoneIDs); err != nil {
+		log.Printf("set courier zones: %v", err)
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, map[string]string{
+		"message": "Courier zones updated successfully",
+	})
+}
+
+func (h *Handler) decodeRequest(w http.ResponseWriter, r *http.Request, id int64) (zone.Zone, bool) {
+	var req Request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "Invalid JSON",
+		})
+		return zone.Zone{}, false
+	}
+
+	if err := req.Validate(); err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return zone.Zone{}, false
+	}
+
+	zoneData, err := req.ToModel(id)
+	if err != nil {
+		h.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return zone.Zone{}, false
+	}
+
+	return zoneData, true
+}
+
+func (h *Handler) writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		http.Error(w, `{"error": "Failed to encode response"}`, http.StatusInternalServerError)
+	}
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, err error) {
+	message, status := h.mapError(err)
+	h.writeJSON(w, status, map[string]string{"error": message})
+}
+
+func (h *Handler) mapError(err error) (string, int) {
+	switch {
+	case errors.Is(err, zone.ErrZoneNotFound):
+		return "Zone not found", http.StatusNotFound
+	case errors.Is(err, courier.ErrCourierNotFound):
+		return "Courier not found", http.StatusNotFound
+	case errors.Is(err, zone.ErrZoneNameExists):
+		return "Zone with this name already exists", http.StatusConflict
+	case errors.Is(err, zone.ErrUnknownNeighbor):
+		return "Neighbor zone not found", http.StatusBadRequest
+	default:
+		return "Internal server error", http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,241 @@
+package zone_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/mock/gomock"
+
+	zoneHandler "service-courier/internal/handler/zone"
+	"service-courier/internal/handler/zone/mocks"
+	modelCourier "service-courier/internal/model/courier"
+	model "service-courier/internal/model/zone"
+	"service-courier/internal/pkg/geo"
+)
+
+const squareGeometry = `{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,10],[0,0]]]}`
+
+var square = geo.Polygon{{
+	{Lat: 0, Lon: 0},
+	{Lat: 0, Lon: 10},
+	{Lat: 10, Lon: 10},
+	{Lat: 10, Lon: 0},
+	{Lat: 0, Lon: 0},
+}}
+
+func newRouter(h *zoneHandler.Handler) *chi.Mux {
+	r := chi.NewRouter()
+	r.Get("/zones", h.List)
+	r.Get("/zones/geojson", h.Export)
+	r.Post("/zones/geojson", h.Import)
+	r.Get("/zone/{id}", h.Get)
+	r.Post("/zone", h.Create)
+	r.Put("/zone/{id}", h.Update)
+	r.Delete("/zone/{id}", h.Delete)
+	r.Get("/courier/{id}/zones", h.GetCourierZones)
+	r.Put("/courier/{id}/zones", h.SetCourierZones)
+	return r
+}
+
+func TestGetZone_NotFound(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockzoneService(ctrl)
+
+	mockService.EXPECT().
+		GetZone(gomock.Any(), int64(5)).
+		Return(nil, model.ErrZoneNotFound)
+
+	req := httptest.NewRequest("GET", "/zone/5", nil)
+	rr := httptest.NewRecorder()
+	newRouter(zoneHandler.NewZoneHandler(mockService)).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 Not Found, got %d", rr.Code)
+	}
+}
+
+func TestCreateZone_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockzoneService(ctrl)
+
+	mockService.EXPECT().
+		CreateZone(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ interface{}, z model.Zone) (int64, error) {
+			if !z.Polygon.Contains(geo.Point{Lat: 5, Lon: 5}) {
+				t.Fatalf("expected polygon to contain the center, got %v", z.Polygon)
+			}
+			return 1, nil
+		})
+
+	body := `{"name":"center","city":"Moscow","geometry":` + squareGeometry + `,"neighbors":[2]}`
+	req := httptest.NewRequest("POST", "/zone", bytes.NewBufferString(body))
+	rr := httptest.NewRecorder()
+	newRouter(zoneHandler.NewZoneHandler(mockService)).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected 201 Created, got %d", rr.Code)
+	}
+}
+
+func TestCreateZone_InvalidGeometry(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockzoneService(ctrl)
+
+	// Кольцо не замкнуто
+	body := `{"name":"center","geometry":{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,10]]]}}`
+	req := httptest.NewRequest("POST", "/zone", bytes.NewBufferString(body))
+	rr := httptest.NewRecorder()
+	newRouter(zoneHandler.NewZoneHandler(mockService)).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 Bad Request, got %d", rr.Code)
+	}
+}
+
+func TestCreateZone_NameExists(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockzoneService(ctrl)
+
+	mockService.EXPECT().
+		CreateZone(gomock.Any(), gomock.Any()).
+		Return(int64(0), model.ErrZoneNameExists)
+
+	body := `{"name":"center","geometry":` + squareGeometry + `}`
+	req := httptest.NewRequest("POST", "/zone", bytes.NewBufferString(body))
+	rr := httptest.NewRecorder()
+	newRouter(zoneHandler.NewZoneHandler(mockService)).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusConflict {
+		t.Fatalf("expected 409 Conflict, got %d", rr.Code)
+	}
+}
+
+func TestUpdateZone_UnknownNeighbor(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockzoneService(ctrl)
+
+	mockService.EXPECT().
+		UpdateZone(gomock.Any(), gomock.Any()).
+		Return(model.ErrUnknownNeighbor)
+
+	body := `{"name":"center","geometry":` + squareGeometry + `,"neighbors":[42]}`
+	req := httptest.NewRequest("PUT", "/zone/1", bytes.NewBufferString(body))
+	rr := httptest.NewRecorder()
+	newRouter(zoneHandler.NewZoneHandler(mockService)).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 Bad Request, got %d", rr.Code)
+	}
+}
+
+func TestExportZones_NeighborsByName(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockzoneService(ctrl)
+
+	mockService.EXPECT().
+		ListZones(gomock.Any()).
+		Return([]model.Zone{
+			{ID: 1, Name: "center", Polygon: square, Neighbors: []int64{2}},
+			{ID: 2, Name: "north", Polygon: square, Neighbors: []int64{1}},
+		}, nil)
+
+	req := httptest.NewRequest("GET", "/zones/geojson", nil)
+	rr := httptest.NewRecorder()
+	newRouter(zoneHandler.NewZoneHandler(mockService)).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", rr.Code)
+	}
+
+	var collection zoneHandler.FeatureCollection
+	if err := json.NewDecoder(rr.Body).Decode(&collection); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if collection.Type != "FeatureCollection" || len(collection.Features) != 2 {
+		t.Fatalf("unexpected collection %+v", collection)
+	}
+	if neighbors := collection.Features[0].Properties.Neighbors; len(neighbors) != 1 || neighbors[0] != "north" {
+		t.Fatalf("expected neighbor north, got %v", neighbors)
+	}
+}
+
+func TestImportZones_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockzoneService(ctrl)
+
+	mockService.EXPECT().
+		ImportZones(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ interface{}, zones []model.Zone, neighbors map[string][]string) ([]int64, error) {
+			if len(zones) != 1 || zones[0].Name != "center" {
+				t.Fatalf("unexpected zones %+v", zones)
+			}
+			if len(neighbors["center"]) != 1 || neighbors["center"][0] != "north" {
+				t.Fatalf("unexpected neighbors %v", neighbors)
+			}
+			return []int64{1}, nil
+		})
+
+	body := `{"type":"FeatureCollection","features":[{"type":"Feature","geometry":` + squareGeometry +
+		`,"properties":{"name":"center","neighbors":["north"]}}]}`
+	req := httptest.NewRequest("POST", "/zones/geojson", bytes.NewBufferString(body))
+	rr := httptest.NewRecorder()
+	newRouter(zoneHandler.NewZoneHandler(mockService)).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", rr.Code)
+	}
+}
+
+func TestImportZones_DuplicateName(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockzoneService(ctrl)
+
+	feature := `{"type":"Feature","geometry":` + squareGeometry + `,"properties":{"name":"center"}}`
+	body := `{"type":"FeatureCollection","features":[` + feature + `,` + feature + `]}`
+	req := httptest.NewRequest("POST", "/zones/geojson", bytes.NewBufferString(body))
+	rr := httptest.NewRecorder()
+	newRouter(zoneHandler.NewZoneHandler(mockService)).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 Bad Request, got %d", rr.Code)
+	}
+}
+
+func TestSetCourierZones_CourierNotFound(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := mocks.NewMockzoneService(ctrl)
+
+	mockService.EXPECT().
+		SetCourierZones(gomock.Any(), int64(7), []int64{1, 2}).
+		Return(modelCourier.ErrCourierNotFound)
+
+	req := httptest.NewRequest("PUT", "/courier/7/zones", bytes.NewBufferString(`{"zone_ids":[1,2]}`))
+	rr := httptest.NewRecorder()
+	newRouter(zoneHandler.NewZoneHandler(mockService)).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 Not Found, got %d", rr.Code)
+	}
+}
@@ -79,18 +79,119 @@ CREATE TABLE IF NOT EXISTS couriers (
     phone               TEXT NOT NULL UNIQUE,
     status              TEXT NOT NULL DEFAULT 'available',
     transport_type      TEXT NOT NULL DEFAULT 'on_foot',
+    latitude            DOUBLE PRECISION,
+    longitude           DOUBLE PRECISION,
+    location_updated_at TIMESTAMP,
     created_at          TIMESTAMP DEFAULT now(),
-    updated_at          TIMESTAMP DEFAULT now()
+    updated_at          TIMESTAMP DEFAULT now(),
+    version             BIGINT NOT NULL DEFAULT 1,
+    deactivated_at      TIMESTAMP DEFAULT NULL,
+    deleted_at          TIMESTAMP DEFAULT NULL,
+    erased_at           TIMESTAMP DEFAULT NULL
 );
 
 CREATE TABLE IF NOT EXISTS delivery (
     id                  BIGSERIAL PRIMARY KEY,
     courier_id          BIGINT NOT NULL,
     order_id            VARCHAR(255) NOT NULL,
-    status              VARCHAR(50) NOT NULL DEFAULT 'active',
+    status              VARCHAR(50) NOT NULL DEFAULT 'assigned',
     assigned_at         TIMESTAMP NOT NULL DEFAULT NOW(),
     deadline            TIMESTAMP NOT NULL,
-    deleted_at          TIMESTAMP DEFAULT NULL
+    deleted_at          TIMESTAMP DEFAULT NULL,
+    priority            VARCHAR(20) NOT NULL DEFAULT 'standard',
+    finished_at         TIMESTAMP DEFAULT NULL
+);
+
+CREATE TABLE IF NOT EXISTS delivery_events (
+    id                  BIGSERIAL PRIMARY KEY,
+    delivery_id         BIGINT NOT NULL,
+    order_id            VARCHAR(255) NOT NULL,
+    courier_id          BIGINT NOT NULL,
+    from_status         VARCHAR(50),
+    to_status           VARCHAR(50) NOT NULL,
+    actor               VARCHAR(50) NOT NULL,
+    reason              TEXT NOT NULL DEFAULT '',
+    created_at          TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS outbox (
+    id                  BIGSERIAL PRIMARY KEY,
+    topic               VARCHAR(255) NOT NULL,
+    key                 VARCHAR(255) NOT NULL,
+    payload             BYTEA NOT NULL,
+    created_at          TIMESTAMP NOT NULL DEFAULT NOW(),
+    published_at        TIMESTAMP DEFAULT NULL
+);
+
+CREATE TABLE IF NOT EXISTS inbox (
+    topic               VARCHAR(255) NOT NULL,
+    kafka_partition     INTEGER NOT NULL,
+    kafka_offset        BIGINT NOT NULL,
+    processed_at        TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (topic, kafka_partition, kafka_offset)
+);
+
+CREATE TABLE IF NOT EXISTS worker_cursors (
+    name                VARCHAR(100) PRIMARY KEY,
+    position            TIMESTAMP NOT NULL,
+    updated_at          TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS courier_shifts (
+    id                  BIGSERIAL PRIMARY KEY,
+    courier_id          BIGINT NOT NULL REFERENCES couriers(id) ON DELETE CASCADE,
+    starts_at           TIMESTAMP NOT NULL,
+    ends_at             TIMESTAMP NOT NULL,
+    zone                VARCHAR(100) NOT NULL DEFAULT '',
+    started_at          TIMESTAMP DEFAULT NULL,
+    finished_at         TIMESTAMP DEFAULT NULL,
+    created_at          TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at          TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT courier_shifts_period_check CHECK (ends_at > starts_at)
+);
+
+CREATE TABLE IF NOT EXISTS zones (
+    id                  BIGSERIAL PRIMARY KEY,
+    name                VARCHAR(100) NOT NULL UNIQUE,
+    city                VARCHAR(100) NOT NULL DEFAULT '',
+    polygon             JSONB NOT NULL,
+    min_lat             DOUBLE PRECISION NOT NULL,
+    min_lon             DOUBLE PRECISION NOT NULL,
+    max_lat             DOUBLE PRECISION NOT NULL,
+    max_lon             DOUBLE PRECISION NOT NULL,
+    neighbor_ids        BIGINT[] NOT NULL DEFAULT '{}',
+    created_at          TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at          TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS courier_zones (
+    courier_id          BIGINT NOT NULL REFERENCES couriers(id) ON DELETE CASCADE,
+    zone_id             BIGINT NOT NULL REFERENCES zones(id) ON DELETE CASCADE,
+    PRIMARY KEY (courier_id, zone_id)
+);
+
+CREATE TABLE IF NOT EXISTS pending_assignments (
+    id                  BIGSERIAL PRIMARY KEY,
+    order_id            VARCHAR(255) NOT NULL UNIQUE,
+    priority            INTEGER NOT NULL DEFAULT 0,
+    dest_lat            DOUBLE PRECISION DEFAULT NULL,
+    dest_lon            DOUBLE PRECISION DEFAULT NULL,
+    attempts            INTEGER NOT NULL DEFAULT 0,
+    last_error          TEXT NOT NULL DEFAULT '',
+    created_at          TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at          TIMESTAMP NOT NULL,
+    last_attempt_at     TIMESTAMP DEFAULT NULL
+);
+
+CREATE TABLE IF NOT EXISTS delivery_offers (
+    id                  BIGSERIAL PRIMARY KEY,
+    delivery_id         BIGINT NOT NULL,
+    order_id            VARCHAR(255) NOT NULL,
+    courier_id          BIGINT NOT NULL,
+    outcome             VARCHAR(20) NOT NULL DEFAULT 'pending',
+    offered_at          TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at          TIMESTAMP NOT NULL,
+    responded_at        TIMESTAMP DEFAULT NULL
 );
 `
 	if _, err := pool.Exec(ctx, sql); err != nil {
@@ -98,3 +199,15 @@ CREATE TABLE IF NOT EXISTS delivery (
 	}
 	return nil
 }
+
+// StartShift открывает курьеру смену, покрывающую текущий момент, чтобы он участвовал в назначении
+func StartShift(t *testing.T, pool *pgxpool.Pool, courierID int64) {
+	t.Helper()
+
+	_, err := pool.Exec(context.Background(), `
+INSERT INTO courier_shifts (courier_id, starts_at, ends_at, started_at)
+VALUES ($1, NOW() - INTERVAL '1 hour', NOW() + INTERVAL '8 hours', NOW())`, courierID)
+	if err != nil {
+		t.Fatalf("failed to start shift: %v", err)
+	}
+}
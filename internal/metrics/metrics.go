@@ -28,6 +28,62 @@ var (
 		Help: "Количество ретраев в gateway",
 	})
 
+	OutboxPublishedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "outbox_published_total",
+		Help: "Количество сообщений outbox, опубликованных в Kafka",
+	})
+
+	KafkaDeadLetteredTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "kafka_dead_lettered_total",
+		Help: "Количество сообщений Kafka, отправленных в dead-letter топик",
+	})
+
+	PendingAssignmentsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "pending_assignments_total",
+			Help: "Заказы в очереди ожидания курьера по приоритету и исходу: queued, assigned, expired",
+		},
+		[]string{"priority", "outcome"},
+	)
+
+	AssignmentWaitSeconds = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "assignment_wait_seconds",
+			Help:    "Время ожидания курьера в очереди по приоритету заказа",
+			Buckets: []float64{5, 15, 30, 60, 120, 300, 600, 900, 1800},
+		},
+		[]string{"priority"},
+	)
+
+	DeliveryOffersTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "delivery_offers_total",
+			Help: "Предложения заказов курьерам по исходу: offered, accepted, declined, expired",
+		},
+		[]string{"outcome"},
+	)
+
+	DeliveryEventStreams = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "delivery_event_streams",
+		Help: "Открытые подписки на события доставки (SSE и gRPC)",
+	})
+
+	CourierAssignmentsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "courier_assignments_total",
+			Help: "Назначения курьеров по стратегии выбора",
+		},
+		[]string{"strategy"},
+	)
+
+	DeliverySLATotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "delivery_sla_total",
+			Help: "Завершенные доставки по приоритету и соблюдению дедлайна: on_time, late",
+		},
+		[]string{"priority", "outcome"},
+	)
+
 	HTTPRequestTotal = promauto.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "http_requests_total",
@@ -86,3 +142,8 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.statusCode = code
 	rw.ResponseWriter.WriteHeader(code)
 }
+
+// Unwrap открывает исходный writer для http.ResponseController: без него SSE не сможет делать Flush
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
@@ -1,6 +1,9 @@
 package courier
 
-import "time"
+import (
+	"service-courier/internal/pkg/geo"
+	"time"
+)
 
 type Courier struct {
 	ID            int64
@@ -8,8 +11,23 @@ type Courier struct {
 	Phone         string
 	Status        CourierStatus
 	TransportType TransportType
+	Location      *Location
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
+	// Version растет при каждом изменении курьера, кроме обновления позиции.
+	// Ненулевой Version при обновлении - ожидаемая версия: если курьера уже изменили, обновление отклоняется.
+	Version int64
+	// DeactivatedAt - курьер выключен из назначения заказов, история и текущие доставки сохраняются
+	DeactivatedAt *time.Time
+}
+
+// ErasedName - имя курьера после удаления персональных данных
+const ErasedName = "Erased courier"
+
+// Location - последняя известная позиция курьера
+type Location struct {
+	Point     geo.Point
+	UpdatedAt time.Time
 }
 
 type CourierStatus string
@@ -27,3 +45,33 @@ const (
 	TransportScooter = "scooter"
 	TransportCar     = "car"
 )
+
+// Candidate - доступный курьер вместе с количеством его доставок
+type Candidate struct {
+	Courier    Courier
+	Deliveries int64
+	// Active - доставки, которые курьер везет прямо сейчас
+	Active int64
+}
+
+// Capacity - сколько активных доставок одновременно может везти курьер на каждом виде транспорта
+type Capacity map[TransportType]int
+
+// AvailableFilter - условия выбора курьера для назначения
+type AvailableFilter struct {
+	Capacity Capacity
+	// ZoneIDs - если не пусто, выбираются только курьеры, закрепленные за одной из зон
+	ZoneIDs []int64
+	// Transports - если не пусто, выбираются только курьеры с одним из видов транспорта
+	Transports []TransportType
+	// ExcludeIDs - курьеры, которых нельзя выбирать, например отказавшиеся от заказа
+	ExcludeIDs []int64
+}
+
+// Of возвращает вместимость транспорта, для неизвестного транспорта - одна доставка
+func (c Capacity) Of(t TransportType) int {
+	if n, ok := c[t]; ok && n > 0 {
+		return n
+	}
+	return 1
+}
@@ -5,5 +5,14 @@ import "errors"
 var (
 	ErrCourierNotFound     = errors.New("courier not found")
 	ErrPhoneExists         = errors.New("courier with this phone already exists")
+	ErrVersionMismatch     = errors.New("courier was modified since the given version")
+	ErrBusyStatusManaged   = errors.New("busy status is set by the system")
+	ErrHasActiveDeliveries = errors.New("courier has active deliveries")
+	ErrDuplicatePhone      = errors.New("phone repeats an earlier row of the import")
 	ErrNoAvailableCouriers = errors.New("no available couriers")
+	// ErrCourierTaken - выбранного курьера заняли или вывели из назначения, пока под него создавалась доставка
+	ErrCourierTaken       = errors.New("courier is no longer available")
+	ErrShiftNotFound      = errors.New("shift not found")
+	ErrShiftOverlap       = errors.New("shift overlaps another shift of the courier")
+	ErrInvalidShiftPeriod = errors.New("shift must end after it starts")
 )
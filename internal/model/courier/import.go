@@ -0,0 +1,39 @@
+package courier
+
+// ImportMode - что делать с импортом, если часть строк не прошла проверку
+type ImportMode string
+
+const (
+	// ImportAtomic - одна ошибочная строка отменяет весь импорт
+	ImportAtomic ImportMode = "atomic"
+	// ImportPartial - корректные строки создаются, ошибочные только попадают в отчет
+	ImportPartial ImportMode = "partial"
+)
+
+func (m ImportMode) IsValid() bool {
+	return m == ImportAtomic || m == ImportPartial
+}
+
+// ImportRow - курьер из файла импорта. Err - ошибка разбора или проверки строки.
+type ImportRow struct {
+	Line    int
+	Courier Courier
+	Err     error
+}
+
+// ImportResult - итог по строке импорта: ID созданного курьера или ошибка
+type ImportResult struct {
+	Line  int
+	Phone string
+	ID    int64
+	Err   error
+}
+
+// ImportReport - отчет об импорте по строкам. Committed - курьеры записаны в базу.
+type ImportReport struct {
+	Mode      ImportMode
+	Committed bool
+	Created   int
+	Failed    int
+	Rows      []ImportResult
+}
@@ -0,0 +1,42 @@
+package courier
+
+// SortField - поле сортировки списка курьеров
+type SortField string
+
+const (
+	SortByID   SortField = "id"
+	SortByName SortField = "name"
+)
+
+func (f SortField) IsValid() bool {
+	return f == SortByID || f == SortByName
+}
+
+// Cursor - позиция keyset-пагинации: имя и id последнего курьера страницы.
+// При сортировке по id имя не используется.
+type Cursor struct {
+	Name string
+	ID   int64
+}
+
+// ListFilter - условия выборки курьеров для списка
+type ListFilter struct {
+	Statuses   []CourierStatus
+	Transports []TransportType
+	// Search - подстрока имени без учета регистра или телефона
+	Search string
+	// ZoneID - только курьеры, закрепленные за зоной
+	ZoneID *int64
+	Sort   SortField
+	Desc   bool
+	After  *Cursor
+	Limit  uint64
+}
+
+// Page - страница списка курьеров. Total - сколько курьеров подходит под фильтр на всех страницах,
+// Next задан, если за страницей есть еще курьеры.
+type Page struct {
+	Couriers []Courier
+	Total    int64
+	Next     *Cursor
+}
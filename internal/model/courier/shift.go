@@ -0,0 +1,25 @@
+package courier
+
+import "time"
+
+// Shift - рабочая смена курьера
+type Shift struct {
+	ID        int64
+	CourierID int64
+	StartsAt  time.Time
+	EndsAt    time.Time
+	// ZoneID - зона из справочника zones, nil - смена без зоны
+	ZoneID *int64
+	// StartedAt и FinishedAt проставляет планировщик, когда применил начало и конец смены
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// ShiftFilter - условия выборки смен
+type ShiftFilter struct {
+	CourierID *int64
+	From      *time.Time
+	To        *time.Time
+}
@@ -7,14 +7,25 @@ type Delivery struct {
 	CourierID  int64
 	OrderID    string
 	Status     DeliveryStatus
+	Priority   Priority
 	AssignedAt time.Time
 	Deadline   time.Time
+	// DeletedAt - когда курьера сняли или предложение закрыли; nil у текущей доставки
+	DeletedAt *time.Time
 }
 
 type DeliveryStatus string
 
 const (
-	StatusActive    = "active"
+	StatusOffered   = "offered"
+	StatusDeclined  = "declined"
+	StatusAssigned  = "assigned"
+	StatusAccepted  = "accepted"
+	StatusPickedUp  = "picked_up"
+	StatusInTransit = "in_transit"
+	StatusDelivered = "delivered"
+	StatusFailed    = "failed"
+	StatusReturned  = "returned"
 	StatusCompleted = "completed"
 	StatusDeleted   = "deleted"
 )
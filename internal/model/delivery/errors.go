@@ -1,8 +1,31 @@
 package delivery
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrDeliveryNotFound     = errors.New("delivery not found")
 	ErrOrderAlreadyAssigned = errors.New("order already assigned")
+	ErrInvalidTransition    = errors.New("invalid delivery status transition")
+	ErrAssignmentPending    = errors.New("no available couriers, order is queued for assignment")
+	ErrUnknownPriority      = errors.New("unknown order priority")
+	ErrOfferExpired         = errors.New("delivery offer expired")
+	ErrCourierUnavailable   = errors.New("courier can no longer take the offered delivery")
+	ErrTooManyStreams       = errors.New("too many delivery event streams")
 )
+
+// TransitionError - недопустимый переход статуса доставки
+type TransitionError struct {
+	From DeliveryStatus
+	To   DeliveryStatus
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("%s: %s -> %s", ErrInvalidTransition, e.From, e.To)
+}
+
+func (e *TransitionError) Unwrap() error {
+	return ErrInvalidTransition
+}
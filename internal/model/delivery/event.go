@@ -0,0 +1,49 @@
+package delivery
+
+import "time"
+
+// Event - запись в истории изменений доставки
+type Event struct {
+	ID         int64
+	DeliveryID int64
+	OrderID    string
+	CourierID  int64
+	FromStatus DeliveryStatus
+	ToStatus   DeliveryStatus
+	Actor      Actor
+	Reason     string
+	CreatedAt  time.Time
+}
+
+// Actor - кто инициировал изменение доставки
+type Actor string
+
+const (
+	ActorHTTP        Actor = "http"
+	ActorGRPC        Actor = "grpc"
+	ActorKafka       Actor = "kafka_worker"
+	ActorExpiry      Actor = "expiry_worker"
+	ActorOrderPoller Actor = "order_poller"
+	ActorPending     Actor = "pending_worker"
+	ActorSystem      Actor = "system"
+)
+
+// Kind - тип события для подписчиков: назначение, снятие курьера, завершение, истечение срока
+func (e Event) Kind() string {
+	switch {
+	case e.ToStatus == StatusDeleted:
+		return StatusUnassigned
+	case e.ToStatus == StatusCompleted && e.Actor == ActorExpiry:
+		return "expired"
+	default:
+		return string(e.ToStatus)
+	}
+}
+
+// EventFilter - выборка событий для подписки: по заказу или по курьеру, начиная после AfterID
+type EventFilter struct {
+	OrderID   string
+	CourierID int64
+	AfterID   int64
+	Limit     uint64
+}
@@ -0,0 +1,50 @@
+package delivery
+
+import "time"
+
+// SortField - поле сортировки списка доставок
+type SortField string
+
+const (
+	SortByID         SortField = "id"
+	SortByAssignedAt SortField = "assigned_at"
+	SortByDeadline   SortField = "deadline"
+)
+
+func (f SortField) IsValid() bool {
+	switch f {
+	case SortByID, SortByAssignedAt, SortByDeadline:
+		return true
+	default:
+		return false
+	}
+}
+
+// Cursor - позиция keyset-пагинации: значение поля сортировки и id последней строки страницы.
+// При сортировке по id значение At не используется.
+type Cursor struct {
+	At time.Time
+	ID int64
+}
+
+// ListFilter - условия выборки доставок
+type ListFilter struct {
+	OrderID   string
+	CourierID *int64
+	Statuses  []DeliveryStatus
+	// From, To - полуинтервал [From, To) по assigned_at
+	From *time.Time
+	To   *time.Time
+	// IncludeDeleted - вместе со снятыми курьерами и закрытыми предложениями
+	IncludeDeleted bool
+	Sort           SortField
+	Desc           bool
+	After          *Cursor
+	Limit          uint64
+}
+
+// Page - страница списка доставок; Next задан, если за ней есть еще строки
+type Page struct {
+	Deliveries []Delivery
+	Next       *Cursor
+}
@@ -0,0 +1,46 @@
+package delivery
+
+import "time"
+
+// Offer - предложение заказа курьеру. Курьер принимает или отклоняет его до ExpiresAt,
+// иначе заказ предлагается следующему курьеру.
+type Offer struct {
+	ID          int64
+	DeliveryID  int64
+	OrderID     string
+	CourierID   int64
+	Outcome     OfferOutcome
+	OfferedAt   time.Time
+	ExpiresAt   time.Time
+	RespondedAt *time.Time
+}
+
+// OfferOutcome - чем закончилось предложение
+type OfferOutcome string
+
+const (
+	OfferPending  OfferOutcome = "pending"
+	OfferAccepted OfferOutcome = "accepted"
+	OfferDeclined OfferOutcome = "declined"
+	OfferExpired  OfferOutcome = "expired"
+	// OfferWithdrawn - доставку сняли или закрыли раньше ответа курьера, в статистике не учитывается
+	OfferWithdrawn OfferOutcome = "withdrawn"
+)
+
+// OfferStats - ответы курьера на предложения
+type OfferStats struct {
+	CourierID int64
+	Offered   int64
+	Accepted  int64
+	Declined  int64
+	Expired   int64
+}
+
+// AcceptanceRate - доля принятых среди предложений, на которые курьер ответил или не успел ответить
+func (s OfferStats) AcceptanceRate() float64 {
+	answered := s.Accepted + s.Declined + s.Expired
+	if answered == 0 {
+		return 0
+	}
+	return float64(s.Accepted) / float64(answered)
+}
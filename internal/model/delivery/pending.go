@@ -0,0 +1,20 @@
+package delivery
+
+import (
+	"service-courier/internal/pkg/geo"
+	"time"
+)
+
+// PendingAssignment - заказ, которому не хватило свободного курьера.
+// Ждет в очереди, пока курьер не освободится или не истечет SLA.
+type PendingAssignment struct {
+	ID            int64
+	OrderID       string
+	Priority      Priority
+	Destination   *geo.Point
+	Attempts      int
+	LastError     string
+	CreatedAt     time.Time
+	ExpiresAt     time.Time
+	LastAttemptAt *time.Time
+}
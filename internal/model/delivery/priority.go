@@ -0,0 +1,47 @@
+package delivery
+
+import "strings"
+
+// Priority - уровень обслуживания заказа
+type Priority string
+
+const (
+	PriorityStandard Priority = "standard"
+	PriorityExpress  Priority = "express"
+	PriorityVIP      Priority = "vip"
+)
+
+// Priorities перечисляет уровни от младшего к старшему
+var Priorities = []Priority{PriorityStandard, PriorityExpress, PriorityVIP}
+
+// ParsePriority разбирает приоритет заказа. Пустая строка - стандартный заказ.
+func ParsePriority(value string) (Priority, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return PriorityStandard, nil
+	}
+	for _, p := range Priorities {
+		if string(p) == value {
+			return p, nil
+		}
+	}
+	return "", ErrUnknownPriority
+}
+
+// Rank - место в очереди: чем больше, тем раньше заказ получает курьера
+func (p Priority) Rank() int {
+	for i, known := range Priorities {
+		if known == p {
+			return i
+		}
+	}
+	return 0
+}
+
+// PriorityFromRank восстанавливает приоритет по месту в очереди
+func PriorityFromRank(rank int) Priority {
+	if rank < 0 || rank >= len(Priorities) {
+		return PriorityStandard
+	}
+	return Priorities[rank]
+}
@@ -0,0 +1,63 @@
+package delivery
+
+import "time"
+
+// StatsFilter - период и разрез статистики доставок
+type StatsFilter struct {
+	CourierID *int64
+	// From, To - полуинтервал [From, To): доставки считаются по assigned_at,
+	// занятость и смены обрезаются по границам периода
+	From time.Time
+	To   time.Time
+	// ByTransport - сводить курьеров по типу транспорта
+	ByTransport bool
+}
+
+// CourierStats - показатели курьера или, при разрезе по транспорту, всех курьеров с этим транспортом (CourierID = 0)
+type CourierStats struct {
+	CourierID     int64
+	TransportType string
+	// Assigned - назначенные за период доставки, без предложений, которые курьер не принял
+	Assigned int64
+	// Delivered - доставленные курьером и закрытые заказом до дедлайна
+	Delivered int64
+	// OnTime - завершенные не позже дедлайна
+	OnTime int64
+	// Unassigned - курьера сняли с доставки
+	Unassigned int64
+	// Expired - закрытые системой после дедлайна
+	Expired  int64
+	Returned int64
+	// DeliveryTime - суммарное время от назначения до завершения по Timed доставленным заказам
+	DeliveryTime time.Duration
+	Timed        int64
+	// BusyTime - время, когда у курьера была хотя бы одна незавершенная доставка
+	BusyTime time.Duration
+	// ShiftTime - время смен курьера в периоде
+	ShiftTime time.Duration
+}
+
+// OnTimeRate - доля уложившихся в дедлайн среди доставленных и просроченных
+func (s CourierStats) OnTimeRate() float64 {
+	finished := s.Delivered + s.Expired
+	if finished == 0 {
+		return 0
+	}
+	return float64(s.OnTime) / float64(finished)
+}
+
+// AvgDeliveryTime - среднее время от назначения до доставки
+func (s CourierStats) AvgDeliveryTime() time.Duration {
+	if s.Timed == 0 {
+		return 0
+	}
+	return s.DeliveryTime / time.Duration(s.Timed)
+}
+
+// Utilisation - доля времени смен, занятая доставками; без смен в периоде - 0
+func (s CourierStats) Utilisation() float64 {
+	if s.ShiftTime <= 0 {
+		return 0
+	}
+	return float64(s.BusyTime) / float64(s.ShiftTime)
+}
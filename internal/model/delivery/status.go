@@ -0,0 +1,63 @@
+package delivery
+
+// transitions - разрешенные переходы жизненного цикла доставки.
+// completed (закрыто заказом или по дедлайну) и deleted (курьер снят)
+// выставляет система из любого незавершенного статуса.
+// offered - заказ предложен курьеру: он принимает его (accepted) или отклоняет (declined).
+var transitions = map[DeliveryStatus][]DeliveryStatus{
+	StatusOffered:   {StatusAccepted, StatusDeclined, StatusCompleted, StatusDeleted},
+	StatusAssigned:  {StatusAccepted, StatusFailed, StatusCompleted, StatusDeleted},
+	StatusAccepted:  {StatusPickedUp, StatusFailed, StatusCompleted, StatusDeleted},
+	StatusPickedUp:  {StatusInTransit, StatusFailed, StatusCompleted, StatusDeleted},
+	StatusInTransit: {StatusDelivered, StatusFailed, StatusCompleted, StatusDeleted},
+	StatusFailed:    {StatusReturned, StatusCompleted, StatusDeleted},
+}
+
+func CanTransition(from, to DeliveryStatus) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+func ValidateTransition(from, to DeliveryStatus) error {
+	if !CanTransition(from, to) {
+		return &TransitionError{From: from, To: to}
+	}
+	return nil
+}
+
+// IsTerminal - из статуса больше нет переходов, курьер свободен
+func (s DeliveryStatus) IsTerminal() bool {
+	_, ok := transitions[s]
+	return !ok
+}
+
+// ActiveStatuses - статусы, в которых доставка еще не завершена
+func ActiveStatuses() []DeliveryStatus {
+	return []DeliveryStatus{StatusOffered, StatusAssigned, StatusAccepted, StatusPickedUp, StatusInTransit, StatusFailed}
+}
+
+// IsKnown - статус из жизненного цикла доставки
+func (s DeliveryStatus) IsKnown() bool {
+	switch s {
+	case StatusOffered, StatusDeclined, StatusAssigned, StatusAccepted, StatusPickedUp, StatusInTransit,
+		StatusDelivered, StatusFailed, StatusReturned, StatusCompleted, StatusDeleted:
+		return true
+	default:
+		return false
+	}
+}
+
+// SourcesOf - статусы, из которых разрешен переход в to
+func SourcesOf(to DeliveryStatus) []DeliveryStatus {
+	sources := make([]DeliveryStatus, 0)
+	for _, from := range ActiveStatuses() {
+		if CanTransition(from, to) {
+			sources = append(sources, from)
+		}
+	}
+	return sources
+}
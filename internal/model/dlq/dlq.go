@@ -0,0 +1,27 @@
+package dlq
+
+import (
+	"service-courier/internal/model/inbox"
+	"time"
+)
+
+// Message - сообщение из dead-letter топика вместе с причиной ошибки
+type Message struct {
+	Partition int32
+	Offset    int64
+	Key       string
+	Value     []byte
+	Error     string
+	Original  inbox.MessageID
+	FailedAt  time.Time
+}
+
+// Ref - позиция сообщения в dead-letter топике
+type Ref struct {
+	Partition int32
+	Offset    int64
+}
+
+func (m Message) Ref() Ref {
+	return Ref{Partition: m.Partition, Offset: m.Offset}
+}
@@ -0,0 +1,5 @@
+package dlq
+
+import "errors"
+
+var ErrMessageNotFound = errors.New("dead-letter message not found")
@@ -0,0 +1,8 @@
+package inbox
+
+// MessageID - позиция сообщения Kafka, по которой отсекаются повторные доставки
+type MessageID struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+}
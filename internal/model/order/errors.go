@@ -0,0 +1,5 @@
+package order
+
+import "errors"
+
+var ErrOrderNotFound = errors.New("order not found")
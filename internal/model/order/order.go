@@ -3,11 +3,22 @@ package order
 import "time"
 
 type Order struct {
-	ID        string
-	Status    string
+	ID      string
+	Status  string
+	Address Address
+	// Priority - уровень обслуживания (standard, express, vip), пусто - standard
+	Priority  string
 	CreatedAt time.Time
 }
 
+type Address struct {
+	Street    string
+	House     string
+	Apartment string
+	Floor     string
+	Comment   string
+}
+
 const (
 	StatusCreated   = "created"
 	StatusCancelled = "cancelled"
@@ -0,0 +1,12 @@
+package outbox
+
+import "time"
+
+// Message - сообщение, ожидающее публикации в Kafka
+type Message struct {
+	ID        int64
+	Topic     string
+	Key       string
+	Payload   []byte
+	CreatedAt time.Time
+}
@@ -0,0 +1,10 @@
+package zone
+
+import "errors"
+
+var (
+	ErrZoneNotFound    = errors.New("zone not found")
+	ErrZoneNameExists  = errors.New("zone with this name already exists")
+	ErrUnknownNeighbor = errors.New("neighbor zone not found")
+	ErrOutsideZones    = errors.New("order location does not belong to any delivery zone")
+)
@@ -0,0 +1,18 @@
+package zone
+
+import (
+	"service-courier/internal/pkg/geo"
+	"time"
+)
+
+// Zone - зона доставки, в которой работают закрепленные за ней курьеры
+type Zone struct {
+	ID      int64
+	Name    string
+	City    string
+	Polygon geo.Polygon
+	// Neighbors - зоны, из которых можно взять курьера, если в этой зоне свободных нет
+	Neighbors []int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,57 @@
+package geo
+
+import "math"
+
+const earthRadiusMeters = 6371000.0
+
+type Point struct {
+	Lat float64
+	Lon float64
+}
+
+func (p Point) Valid() bool {
+	return p.Lat >= -90 && p.Lat <= 90 && p.Lon >= -180 && p.Lon <= 180
+}
+
+// DistanceStrategy считает расстояние между двумя точками в метрах
+type DistanceStrategy interface {
+	Distance(a, b Point) float64
+}
+
+// Haversine - расстояние по большому кругу
+type Haversine struct{}
+
+func (Haversine) Distance(a, b Point) float64 {
+	lat1 := toRadians(a.Lat)
+	lat2 := toRadians(b.Lat)
+	dLat := lat2 - lat1
+	dLon := toRadians(b.Lon - a.Lon)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return 2 * earthRadiusMeters * math.Asin(math.Sqrt(h))
+}
+
+// Equirectangular - быстрая аппроксимация для коротких расстояний внутри города
+type Equirectangular struct{}
+
+func (Equirectangular) Distance(a, b Point) float64 {
+	x := toRadians(b.Lon-a.Lon) * math.Cos(toRadians(a.Lat+b.Lat)/2)
+	y := toRadians(b.Lat - a.Lat)
+	return math.Sqrt(x*x+y*y) * earthRadiusMeters
+}
+
+func toRadians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+// NewDistanceStrategy возвращает стратегию по имени, по умолчанию - Haversine
+func NewDistanceStrategy(name string) DistanceStrategy {
+	switch name {
+	case "equirectangular":
+		return Equirectangular{}
+	default:
+		return Haversine{}
+	}
+}
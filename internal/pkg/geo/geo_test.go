@@ -0,0 +1,97 @@
+package geo_test
+
+import (
+	"math"
+	"testing"
+
+	"service-courier/internal/pkg/geo"
+)
+
+func TestHaversineDistance(t *testing.T) {
+	moscow := geo.Point{Lat: 55.7558, Lon: 37.6173}
+	spb := geo.Point{Lat: 59.9343, Lon: 30.3351}
+
+	d := geo.Haversine{}.Distance(moscow, spb)
+
+	// ~634 км между центрами городов
+	if math.Abs(d-634000) > 5000 {
+		t.Fatalf("expected ~634km, got %.0fm", d)
+	}
+}
+
+func TestHaversineDistance_SamePoint(t *testing.T) {
+	p := geo.Point{Lat: 55.7558, Lon: 37.6173}
+
+	if d := (geo.Haversine{}).Distance(p, p); d != 0 {
+		t.Fatalf("expected 0, got %f", d)
+	}
+}
+
+func TestEquirectangularCloseToHaversine(t *testing.T) {
+	a := geo.Point{Lat: 55.7558, Lon: 37.6173}
+	b := geo.Point{Lat: 55.7601, Lon: 37.6186}
+
+	h := geo.Haversine{}.Distance(a, b)
+	e := geo.Equirectangular{}.Distance(a, b)
+
+	if math.Abs(h-e) > 1 {
+		t.Fatalf("expected approximations to match within 1m, haversine=%f equirectangular=%f", h, e)
+	}
+}
+
+func TestPointValid(t *testing.T) {
+	if !(geo.Point{Lat: 55.7, Lon: 37.6}).Valid() {
+		t.Fatalf("expected point to be valid")
+	}
+	if (geo.Point{Lat: 91, Lon: 0}).Valid() {
+		t.Fatalf("expected latitude 91 to be invalid")
+	}
+	if (geo.Point{Lat: 0, Lon: -181}).Valid() {
+		t.Fatalf("expected longitude -181 to be invalid")
+	}
+}
+
+func square(minLat, minLon, maxLat, maxLon float64) []geo.Point {
+	return []geo.Point{
+		{Lat: minLat, Lon: minLon},
+		{Lat: minLat, Lon: maxLon},
+		{Lat: maxLat, Lon: maxLon},
+		{Lat: maxLat, Lon: minLon},
+		{Lat: minLat, Lon: minLon},
+	}
+}
+
+func TestPolygonContains(t *testing.T) {
+	polygon := geo.Polygon{square(55, 37, 56, 38), square(55.4, 37.4, 55.6, 37.6)}
+
+	if !polygon.Contains(geo.Point{Lat: 55.2, Lon: 37.2}) {
+		t.Fatalf("expected point inside polygon")
+	}
+	if polygon.Contains(geo.Point{Lat: 55.5, Lon: 37.5}) {
+		t.Fatalf("expected point in hole to be outside")
+	}
+	if polygon.Contains(geo.Point{Lat: 59.9, Lon: 30.3}) {
+		t.Fatalf("expected point outside polygon")
+	}
+}
+
+func TestPolygonValidate(t *testing.T) {
+	if err := (geo.Polygon{square(55, 37, 56, 38)}).Validate(); err != nil {
+		t.Fatalf("expected valid polygon, got %v", err)
+	}
+
+	open := square(55, 37, 56, 38)[:4]
+	if err := (geo.Polygon{open}).Validate(); err == nil {
+		t.Fatalf("expected error for open ring")
+	}
+	if err := (geo.Polygon{}).Validate(); err == nil {
+		t.Fatalf("expected error for empty polygon")
+	}
+}
+
+func TestPolygonBounds(t *testing.T) {
+	min, max := geo.Polygon{square(55, 37, 56, 38)}.Bounds()
+	if min != (geo.Point{Lat: 55, Lon: 37}) || max != (geo.Point{Lat: 56, Lon: 38}) {
+		t.Fatalf("unexpected bounds: %v %v", min, max)
+	}
+}
@@ -0,0 +1,79 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Polygon - многоугольник в терминах GeoJSON: первое кольцо - внешняя граница, остальные - дыры.
+// Каждое кольцо замкнуто: последняя точка совпадает с первой.
+type Polygon [][]Point
+
+func (p Polygon) Validate() error {
+	if len(p) == 0 {
+		return errors.New("polygon has no rings")
+	}
+	for i, ring := range p {
+		if len(ring) < 4 {
+			return fmt.Errorf("ring %d must have at least 4 points", i)
+		}
+		if ring[0] != ring[len(ring)-1] {
+			return fmt.Errorf("ring %d is not closed", i)
+		}
+		for _, point := range ring {
+			if !point.Valid() {
+				return fmt.Errorf("ring %d has invalid coordinates", i)
+			}
+		}
+	}
+	return nil
+}
+
+// Contains проверяет, что точка лежит внутри внешней границы и вне дыр
+func (p Polygon) Contains(point Point) bool {
+	if len(p) == 0 || !ringContains(p[0], point) {
+		return false
+	}
+	for _, hole := range p[1:] {
+		if ringContains(hole, point) {
+			return false
+		}
+	}
+	return true
+}
+
+// Bounds возвращает углы прямоугольника, описанного вокруг внешней границы
+func (p Polygon) Bounds() (min, max Point) {
+	if len(p) == 0 || len(p[0]) == 0 {
+		return Point{}, Point{}
+	}
+	min, max = p[0][0], p[0][0]
+	for _, point := range p[0][1:] {
+		if point.Lat < min.Lat {
+			min.Lat = point.Lat
+		}
+		if point.Lon < min.Lon {
+			min.Lon = point.Lon
+		}
+		if point.Lat > max.Lat {
+			max.Lat = point.Lat
+		}
+		if point.Lon > max.Lon {
+			max.Lon = point.Lon
+		}
+	}
+	return min, max
+}
+
+// ringContains - ray casting: луч из точки пересекает границу нечетное число раз, если точка внутри
+func ringContains(ring []Point, point Point) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a, b := ring[i], ring[j]
+		if (a.Lat > point.Lat) != (b.Lat > point.Lat) &&
+			point.Lon < (b.Lon-a.Lon)*(point.Lat-a.Lat)/(b.Lat-a.Lat)+a.Lon {
+			inside = !inside
+		}
+	}
+	return inside
+}
@@ -0,0 +1,52 @@
+package geojson
+
+import (
+	"fmt"
+	"service-courier/internal/pkg/geo"
+)
+
+const (
+	TypePolygon           = "Polygon"
+	TypeFeature           = "Feature"
+	TypeFeatureCollection = "FeatureCollection"
+)
+
+// Geometry - геометрия GeoJSON. Поддерживается только Polygon, координаты в порядке [lon, lat].
+type Geometry struct {
+	Type        string        `json:"type"`
+	Coordinates [][][]float64 `json:"coordinates"`
+}
+
+func NewPolygon(polygon geo.Polygon) Geometry {
+	coordinates := make([][][]float64, len(polygon))
+	for i, ring := range polygon {
+		coordinates[i] = make([][]float64, len(ring))
+		for j, point := range ring {
+			coordinates[i][j] = []float64{point.Lon, point.Lat}
+		}
+	}
+	return Geometry{Type: TypePolygon, Coordinates: coordinates}
+}
+
+// Polygon разбирает и проверяет геометрию
+func (g Geometry) Polygon() (geo.Polygon, error) {
+	if g.Type != TypePolygon {
+		return nil, fmt.Errorf("unsupported geometry type %q", g.Type)
+	}
+
+	polygon := make(geo.Polygon, len(g.Coordinates))
+	for i, ring := range g.Coordinates {
+		polygon[i] = make([]geo.Point, len(ring))
+		for j, position := range ring {
+			if len(position) < 2 {
+				return nil, fmt.Errorf("ring %d has invalid position", i)
+			}
+			polygon[i][j] = geo.Point{Lat: position[1], Lon: position[0]}
+		}
+	}
+
+	if err := polygon.Validate(); err != nil {
+		return nil, err
+	}
+	return polygon, nil
+}
@@ -0,0 +1,37 @@
+package geojson_test
+
+import (
+	"testing"
+
+	"service-courier/internal/pkg/geo"
+	"service-courier/internal/pkg/geojson"
+)
+
+func TestPolygonRoundTrip(t *testing.T) {
+	polygon := geo.Polygon{{
+		{Lat: 55, Lon: 37},
+		{Lat: 55, Lon: 38},
+		{Lat: 56, Lon: 38},
+		{Lat: 55, Lon: 37},
+	}}
+
+	geometry := geojson.NewPolygon(polygon)
+	if got := geometry.Coordinates[0][1]; got[0] != 38 || got[1] != 55 {
+		t.Fatalf("expected [lon, lat] order, got %v", got)
+	}
+
+	parsed, err := geometry.Polygon()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(parsed) != 1 || parsed[0][2] != polygon[0][2] {
+		t.Fatalf("unexpected polygon: %v", parsed)
+	}
+}
+
+func TestPolygon_UnsupportedType(t *testing.T) {
+	geometry := geojson.Geometry{Type: "Point"}
+	if _, err := geometry.Polygon(); err == nil {
+		t.Fatal("expected error for non-polygon geometry")
+	}
+}
@@ -0,0 +1,144 @@
+package matching
+
+import "math"
+
+// Forbidden - стоимость недопустимой пары. Такие пары в результат не попадают.
+const Forbidden = 1e9
+
+// Solve находит назначение строк столбцам с минимальной суммарной стоимостью (венгерский алгоритм, O(n²m)).
+// Возвращает для каждой строки номер столбца или -1, если строке не досталось допустимой пары.
+func Solve(cost [][]float64) []int {
+	n := len(cost)
+	result := unassigned(n)
+	if n == 0 || len(cost[0]) == 0 {
+		return result
+	}
+	m := len(cost[0])
+
+	// алгоритм требует строк не больше, чем столбцов
+	if n > m {
+		for j, i := range Solve(transpose(cost)) {
+			if i >= 0 {
+				result[i] = j
+			}
+		}
+		return result
+	}
+
+	// потенциалы строк и столбцов, p[j] - строка, занявшая столбец j (нумерация с 1, 0 - фиктивный)
+	u := make([]float64, n+1)
+	v := make([]float64, m+1)
+	p := make([]int, m+1)
+	way := make([]int, m+1)
+
+	for i := 1; i <= n; i++ {
+		p[0] = i
+		j0 := 0
+		minv := make([]float64, m+1)
+		used := make([]bool, m+1)
+		for j := range minv {
+			minv[j] = math.Inf(1)
+		}
+
+		for {
+			used[j0] = true
+			i0, delta, j1 := p[j0], math.Inf(1), 0
+
+			for j := 1; j <= m; j++ {
+				if used[j] {
+					continue
+				}
+				if cur := cost[i0-1][j-1] - u[i0] - v[j]; cur < minv[j] {
+					minv[j] = cur
+					way[j] = j0
+				}
+				if minv[j] < delta {
+					delta = minv[j]
+					j1 = j
+				}
+			}
+
+			for j := 0; j <= m; j++ {
+				if used[j] {
+					u[p[j]] += delta
+					v[j] -= delta
+				} else {
+					minv[j] -= delta
+				}
+			}
+
+			j0 = j1
+			if p[j0] == 0 {
+				break
+			}
+		}
+
+		// разворачиваем увеличивающую цепочку
+		for j0 != 0 {
+			j1 := way[j0]
+			p[j0] = p[j1]
+			j0 = j1
+		}
+	}
+
+	for j := 1; j <= m; j++ {
+		if i := p[j] - 1; i >= 0 && cost[i][j-1] < Forbidden {
+			result[i] = j - 1
+		}
+	}
+	return result
+}
+
+// Greedy назначает строки по очереди самому дешевому свободному столбцу -
+// так работает назначение заказов по одному.
+func Greedy(cost [][]float64) []int {
+	result := unassigned(len(cost))
+	if len(cost) == 0 {
+		return result
+	}
+
+	taken := make([]bool, len(cost[0]))
+	for i, row := range cost {
+		best := -1
+		for j, c := range row {
+			if !taken[j] && c < Forbidden && (best < 0 || c < row[best]) {
+				best = j
+			}
+		}
+		if best >= 0 {
+			taken[best] = true
+			result[i] = best
+		}
+	}
+	return result
+}
+
+// Total - суммарная стоимость назначения
+func Total(cost [][]float64, assignment []int) float64 {
+	total := 0.0
+	for i, j := range assignment {
+		if j >= 0 {
+			total += cost[i][j]
+		}
+	}
+	return total
+}
+
+func unassigned(n int) []int {
+	result := make([]int, n)
+	for i := range result {
+		result[i] = -1
+	}
+	return result
+}
+
+func transpose(cost [][]float64) [][]float64 {
+	t := make([][]float64, len(cost[0]))
+	for j := range t {
+		t[j] = make([]float64, len(cost))
+		for i := range cost {
+			t[j][i] = cost[i][j]
+		}
+	}
+	return t
+}
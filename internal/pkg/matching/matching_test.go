@@ -0,0 +1,170 @@
+package matching_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"service-courier/internal/pkg/matching"
+)
+
+// bruteForce перебирает все назначения и возвращает минимальную стоимость при максимальном числе допустимых пар
+func bruteForce(cost [][]float64) (int, float64) {
+	n, m := len(cost), len(cost[0])
+	taken := make([]bool, m)
+	bestPairs, bestTotal := -1, math.Inf(1)
+
+	var walk func(i, pairs int, total float64)
+	walk = func(i, pairs int, total float64) {
+		if i == n {
+			if pairs > bestPairs || (pairs == bestPairs && total < bestTotal) {
+				bestPairs, bestTotal = pairs, total
+			}
+			return
+		}
+		walk(i+1, pairs, total)
+		for j := 0; j < m; j++ {
+			if taken[j] || cost[i][j] >= matching.Forbidden {
+				continue
+			}
+			taken[j] = true
+			walk(i+1, pairs+1, total+cost[i][j])
+			taken[j] = false
+		}
+	}
+	walk(0, 0, 0)
+	return bestPairs, bestTotal
+}
+
+func randomCost(rnd *rand.Rand, n, m int, forbidden float64) [][]float64 {
+	cost := make([][]float64, n)
+	for i := range cost {
+		cost[i] = make([]float64, m)
+		for j := range cost[i] {
+			cost[i][j] = float64(rnd.Intn(50)) - 10
+			if rnd.Float64() < forbidden {
+				cost[i][j] = matching.Forbidden
+			}
+		}
+	}
+	return cost
+}
+
+func pairs(assignment []int) int {
+	n := 0
+	for _, j := range assignment {
+		if j >= 0 {
+			n++
+		}
+	}
+	return n
+}
+
+func TestSolve_MatchesBruteForce(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	for iter := 0; iter < 300; iter++ {
+		n, m := 1+rnd.Intn(5), 1+rnd.Intn(5)
+		cost := randomCost(rnd, n, m, 0.2)
+
+		assignment := matching.Solve(cost)
+		if len(assignment) != n {
+			t.Fatalf("expected %d rows, got %d", n, len(assignment))
+		}
+
+		seen := make(map[int]bool)
+		for i, j := range assignment {
+			if j < 0 {
+				continue
+			}
+			if seen[j] {
+				t.Fatalf("column %d assigned twice: %v", j, assignment)
+			}
+			if cost[i][j] >= matching.Forbidden {
+				t.Fatalf("forbidden pair %d-%d in result", i, j)
+			}
+			seen[j] = true
+		}
+
+		expectedPairs, expectedTotal := bruteForce(cost)
+		if got := pairs(assignment); got != expectedPairs {
+			t.Fatalf("cost %v: expected %d pairs, got %d", cost, expectedPairs, got)
+		}
+		if got := matching.Total(cost, assignment); math.Abs(got-expectedTotal) > 1e-9 {
+			t.Fatalf("cost %v: expected total %.1f, got %.1f", cost, expectedTotal, got)
+		}
+	}
+}
+
+func TestSolve_BeatsGreedy(t *testing.T) {
+	// первый заказ забирает единственного курьера, который нужен второму
+	cost := [][]float64{
+		{1, 2},
+		{1, 10},
+	}
+
+	greedy := matching.Greedy(cost)
+	optimal := matching.Solve(cost)
+
+	if total := matching.Total(cost, greedy); total != 11 {
+		t.Fatalf("expected greedy total 11, got %.0f", total)
+	}
+	if total := matching.Total(cost, optimal); total != 3 {
+		t.Fatalf("expected optimal total 3, got %.0f", total)
+	}
+}
+
+func TestSolve_Empty(t *testing.T) {
+	if got := matching.Solve(nil); len(got) != 0 {
+		t.Fatalf("expected empty result, got %v", got)
+	}
+	if got := matching.Solve([][]float64{{}, {}}); got[0] != -1 || got[1] != -1 {
+		t.Fatalf("expected unassigned rows, got %v", got)
+	}
+}
+
+// peakCost моделирует час пик: заказы и курьеры разбросаны по городу 10x10 км,
+// стоимость - минуты пути на самокате (15 км/ч) плюс штраф за уже взятые заказы
+func peakCost(orders, couriers int) [][]float64 {
+	rnd := rand.New(rand.NewSource(42))
+
+	type point struct{ x, y float64 }
+	courierPoints := make([]point, couriers)
+	load := make([]int, couriers)
+	for j := range courierPoints {
+		courierPoints[j] = point{rnd.Float64() * 10, rnd.Float64() * 10}
+		load[j] = rnd.Intn(2)
+	}
+
+	cost := make([][]float64, orders)
+	for i := range cost {
+		order := point{rnd.Float64() * 10, rnd.Float64() * 10}
+		cost[i] = make([]float64, couriers)
+		for j, c := range courierPoints {
+			km := math.Hypot(order.x-c.x, order.y-c.y)
+			cost[i][j] = km/15*60 + 10*float64(load[j])
+		}
+	}
+	return cost
+}
+
+func benchmarkDispatch(b *testing.B, solve func([][]float64) []int, orders, couriers int) {
+	cost := peakCost(orders, couriers)
+
+	var assignment []int
+	b.ResetTimer()
+	for range b.N {
+		assignment = solve(cost)
+	}
+	b.StopTimer()
+
+	b.ReportMetric(matching.Total(cost, assignment), "total-minutes")
+	b.ReportMetric(matching.Total(cost, assignment)/float64(pairs(assignment)), "minutes/order")
+}
+
+func BenchmarkGreedy_50x40(b *testing.B)      { benchmarkDispatch(b, matching.Greedy, 50, 40) }
+func BenchmarkHungarian_50x40(b *testing.B)   { benchmarkDispatch(b, matching.Solve, 50, 40) }
+func BenchmarkGreedy_100x100(b *testing.B)    { benchmarkDispatch(b, matching.Greedy, 100, 100) }
+func BenchmarkHungarian_100x100(b *testing.B) { benchmarkDispatch(b, matching.Solve, 100, 100) }
+func BenchmarkGreedy_200x150(b *testing.B)    { benchmarkDispatch(b, matching.Greedy, 200, 150) }
+func BenchmarkHungarian_200x150(b *testing.B) { benchmarkDispatch(b, matching.Solve, 200, 150) }
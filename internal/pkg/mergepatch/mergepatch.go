@@ -0,0 +1,51 @@
+package mergepatch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrNotObject - патч не является JSON-объектом
+var ErrNotObject = errors.New("merge patch must be a JSON object")
+
+// Apply применяет JSON Merge Patch (RFC 7396) к документу target: поля патча заменяют поля
+// документа, null удаляет поле, вложенные объекты сливаются рекурсивно, массивы заменяются целиком.
+// Патч к ресурсу обязан быть объектом, иначе он заменил бы ресурс целиком.
+func Apply(target, patch []byte) ([]byte, error) {
+	var patchDoc interface{}
+	if err := json.Unmarshal(patch, &patchDoc); err != nil {
+		return nil, fmt.Errorf("invalid merge patch: %w", err)
+	}
+	if _, ok := patchDoc.(map[string]interface{}); !ok {
+		return nil, ErrNotObject
+	}
+
+	var targetDoc interface{}
+	if err := json.Unmarshal(target, &targetDoc); err != nil {
+		return nil, fmt.Errorf("invalid target document: %w", err)
+	}
+
+	return json.Marshal(merge(targetDoc, patchDoc))
+}
+
+func merge(target, patch interface{}) interface{} {
+	patchObj, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObj, ok := target.(map[string]interface{})
+	if !ok {
+		targetObj = make(map[string]interface{}, len(patchObj))
+	}
+
+	for key, value := range patchObj {
+		if value == nil {
+			delete(targetObj, key)
+			continue
+		}
+		targetObj[key] = merge(targetObj[key], value)
+	}
+	return targetObj
+}
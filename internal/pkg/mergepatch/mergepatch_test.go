@@ -0,0 +1,52 @@
+package mergepatch_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"service-courier/internal/pkg/mergepatch"
+)
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		patch  string
+		want   string
+	}{
+		{"replace field", `{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"add field", `{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{"remove field", `{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{"replace array", `{"a":["b"]}`, `{"a":["c","d"]}`, `{"a":["c","d"]}`},
+		{"merge nested", `{"a":{"b":"c","d":"e"}}`, `{"a":{"b":"x","d":null}}`, `{"a":{"b":"x"}}`},
+		{"object over scalar", `{"a":"b"}`, `{"a":{"c":null,"d":1}}`, `{"a":{"d":1}}`},
+		{"empty patch", `{"a":"b"}`, `{}`, `{"a":"b"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mergepatch.Apply([]byte(tt.target), []byte(tt.patch))
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+
+			var gotDoc, wantDoc interface{}
+			_ = json.Unmarshal(got, &gotDoc)
+			_ = json.Unmarshal([]byte(tt.want), &wantDoc)
+			if !reflect.DeepEqual(gotDoc, wantDoc) {
+				t.Errorf("Apply() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApply_InvalidPatch(t *testing.T) {
+	if _, err := mergepatch.Apply([]byte(`{}`), []byte(`["a"]`)); !errors.Is(err, mergepatch.ErrNotObject) {
+		t.Errorf("expected ErrNotObject for array patch, got %v", err)
+	}
+	if _, err := mergepatch.Apply([]byte(`{}`), []byte(`{`)); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
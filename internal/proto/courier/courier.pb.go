@@ -0,0 +1,1584 @@
+// courier.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: courier.proto
+
+// API сервиса курьеров для внутренних gRPC-клиентов
+
+package courier
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Последняя известная позиция курьера
+type Location struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lat           float64                `protobuf:"fixed64,1,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lon           float64                `protobuf:"fixed64,2,opt,name=lon,proto3" json:"lon,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Location) Reset() {
+	*x = Location{}
+	mi := &file_courier_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Location) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Location) ProtoMessage() {}
+
+func (x *Location) ProtoReflect() protoreflect.Message {
+	mi := &file_courier_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Location.ProtoReflect.Descriptor instead.
+func (*Location) Descriptor() ([]byte, []int) {
+	return file_courier_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Location) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *Location) GetLon() float64 {
+	if x != nil {
+		return x.Lon
+	}
+	return 0
+}
+
+func (x *Location) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Курьер
+type Courier struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Phone         string                 `protobuf:"bytes,3,opt,name=phone,proto3" json:"phone,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`                                    // available, busy или paused
+	TransportType string                 `protobuf:"bytes,5,opt,name=transport_type,json=transportType,proto3" json:"transport_type,omitempty"` // on_foot, scooter или car
+	Location      *Location              `protobuf:"bytes,6,opt,name=location,proto3" json:"location,omitempty"`                                // не задана, если курьер еще не присылал позицию
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Courier) Reset() {
+	*x = Courier{}
+	mi := &file_courier_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Courier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Courier) ProtoMessage() {}
+
+func (x *Courier) ProtoReflect() protoreflect.Message {
+	mi := &file_courier_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Courier.ProtoReflect.Descriptor instead.
+func (*Courier) Descriptor() ([]byte, []int) {
+	return file_courier_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Courier) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Courier) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Courier) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *Courier) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Courier) GetTransportType() string {
+	if x != nil {
+		return x.TransportType
+	}
+	return ""
+}
+
+func (x *Courier) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *Courier) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Courier) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Доставка заказа
+type Delivery struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CourierId     int64                  `protobuf:"varint,2,opt,name=courier_id,json=courierId,proto3" json:"courier_id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Priority      string                 `protobuf:"bytes,5,opt,name=priority,proto3" json:"priority,omitempty"`
+	AssignedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=assigned_at,json=assignedAt,proto3" json:"assigned_at,omitempty"`
+	Deadline      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Delivery) Reset() {
+	*x = Delivery{}
+	mi := &file_courier_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Delivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Delivery) ProtoMessage() {}
+
+func (x *Delivery) ProtoReflect() protoreflect.Message {
+	mi := &file_courier_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Delivery.ProtoReflect.Descriptor instead.
+func (*Delivery) Descriptor() ([]byte, []int) {
+	return file_courier_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Delivery) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Delivery) GetCourierId() int64 {
+	if x != nil {
+		return x.CourierId
+	}
+	return 0
+}
+
+func (x *Delivery) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Delivery) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Delivery) GetPriority() string {
+	if x != nil {
+		return x.Priority
+	}
+	return ""
+}
+
+func (x *Delivery) GetAssignedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AssignedAt
+	}
+	return nil
+}
+
+func (x *Delivery) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+type GetCourierRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCourierRequest) Reset() {
+	*x = GetCourierRequest{}
+	mi := &file_courier_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCourierRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCourierRequest) ProtoMessage() {}
+
+func (x *GetCourierRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_courier_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCourierRequest.ProtoReflect.Descriptor instead.
+func (*GetCourierRequest) Descriptor() ([]byte, []int) {
+	return file_courier_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetCourierRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetCourierResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Courier       *Courier               `protobuf:"bytes,1,opt,name=courier,proto3" json:"courier,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCourierResponse) Reset() {
+	*x = GetCourierResponse{}
+	mi := &file_courier_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCourierResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCourierResponse) ProtoMessage() {}
+
+func (x *GetCourierResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_courier_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCourierResponse.ProtoReflect.Descriptor instead.
+func (*GetCourierResponse) Descriptor() ([]byte, []int) {
+	return file_courier_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetCourierResponse) GetCourier() *Courier {
+	if x != nil {
+		return x.Courier
+	}
+	return nil
+}
+
+// Страница списка курьеров, фильтры те же, что у GET /couriers.
+// cursor - next_cursor предыдущей страницы, передается с теми же sort и order
+type ListCouriersRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Statuses       []string               `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	TransportTypes []string               `protobuf:"bytes,2,rep,name=transport_types,json=transportTypes,proto3" json:"transport_types,omitempty"`
+	Query          string                 `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"` // подстрока имени или телефона
+	ZoneId         int64                  `protobuf:"varint,4,opt,name=zone_id,json=zoneId,proto3" json:"zone_id,omitempty"`
+	Sort           string                 `protobuf:"bytes,5,opt,name=sort,proto3" json:"sort,omitempty"`    // id или name, по умолчанию id
+	Order          string                 `protobuf:"bytes,6,opt,name=order,proto3" json:"order,omitempty"`  // asc или desc, по умолчанию asc
+	Limit          uint64                 `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"` // по умолчанию 50, не больше 500
+	Cursor         string                 `protobuf:"bytes,8,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListCouriersRequest) Reset() {
+	*x = ListCouriersRequest{}
+	mi := &file_courier_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCouriersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCouriersRequest) ProtoMessage() {}
+
+func (x *ListCouriersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_courier_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCouriersRequest.ProtoReflect.Descriptor instead.
+func (*ListCouriersRequest) Descriptor() ([]byte, []int) {
+	return file_courier_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListCouriersRequest) GetStatuses() []string {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListCouriersRequest) GetTransportTypes() []string {
+	if x != nil {
+		return x.TransportTypes
+	}
+	return nil
+}
+
+func (x *ListCouriersRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ListCouriersRequest) GetZoneId() int64 {
+	if x != nil {
+		return x.ZoneId
+	}
+	return 0
+}
+
+func (x *ListCouriersRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListCouriersRequest) GetOrder() string {
+	if x != nil {
+		return x.Order
+	}
+	return ""
+}
+
+func (x *ListCouriersRequest) GetLimit() uint64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListCouriersRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ListCouriersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Couriers      []*Courier             `protobuf:"bytes,1,rep,name=couriers,proto3" json:"couriers,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`                            // сколько курьеров подходит под фильтр на всех страницах
+	NextCursor    string                 `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // пусто на последней странице
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCouriersResponse) Reset() {
+	*x = ListCouriersResponse{}
+	mi := &file_courier_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCouriersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCouriersResponse) ProtoMessage() {}
+
+func (x *ListCouriersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_courier_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCouriersResponse.ProtoReflect.Descriptor instead.
+func (*ListCouriersResponse) Descriptor() ([]byte, []int) {
+	return file_courier_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListCouriersResponse) GetCouriers() []*Courier {
+	if x != nil {
+		return x.Couriers
+	}
+	return nil
+}
+
+func (x *ListCouriersResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListCouriersResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type CreateCourierRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Phone         string                 `protobuf:"bytes,2,opt,name=phone,proto3" json:"phone,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	TransportType string                 `protobuf:"bytes,4,opt,name=transport_type,json=transportType,proto3" json:"transport_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCourierRequest) Reset() {
+	*x = CreateCourierRequest{}
+	mi := &file_courier_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCourierRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCourierRequest) ProtoMessage() {}
+
+func (x *CreateCourierRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_courier_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCourierRequest.ProtoReflect.Descriptor instead.
+func (*CreateCourierRequest) Descriptor() ([]byte, []int) {
+	return file_courier_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateCourierRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateCourierRequest) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *CreateCourierRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CreateCourierRequest) GetTransportType() string {
+	if x != nil {
+		return x.TransportType
+	}
+	return ""
+}
+
+type CreateCourierResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCourierResponse) Reset() {
+	*x = CreateCourierResponse{}
+	mi := &file_courier_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCourierResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCourierResponse) ProtoMessage() {}
+
+func (x *CreateCourierResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_courier_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCourierResponse.ProtoReflect.Descriptor instead.
+func (*CreateCourierResponse) Descriptor() ([]byte, []int) {
+	return file_courier_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateCourierResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// Пустые поля не меняются
+type UpdateCourierRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Phone         string                 `protobuf:"bytes,3,opt,name=phone,proto3" json:"phone,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	TransportType string                 `protobuf:"bytes,5,opt,name=transport_type,json=transportType,proto3" json:"transport_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCourierRequest) Reset() {
+	*x = UpdateCourierRequest{}
+	mi := &file_courier_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCourierRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCourierRequest) ProtoMessage() {}
+
+func (x *UpdateCourierRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_courier_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCourierRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCourierRequest) Descriptor() ([]byte, []int) {
+	return file_courier_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateCourierRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateCourierRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateCourierRequest) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *UpdateCourierRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UpdateCourierRequest) GetTransportType() string {
+	if x != nil {
+		return x.TransportType
+	}
+	return ""
+}
+
+type UpdateCourierResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCourierResponse) Reset() {
+	*x = UpdateCourierResponse{}
+	mi := &file_courier_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCourierResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCourierResponse) ProtoMessage() {}
+
+func (x *UpdateCourierResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_courier_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCourierResponse.ProtoReflect.Descriptor instead.
+func (*UpdateCourierResponse) Descriptor() ([]byte, []int) {
+	return file_courier_proto_rawDescGZIP(), []int{10}
+}
+
+type AssignDeliveryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Priority      string                 `protobuf:"bytes,2,opt,name=priority,proto3" json:"priority,omitempty"` // standard, express или vip, по умолчанию standard
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignDeliveryRequest) Reset() {
+	*x = AssignDeliveryRequest{}
+	mi := &file_courier_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignDeliveryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignDeliveryRequest) ProtoMessage() {}
+
+func (x *AssignDeliveryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_courier_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignDeliveryRequest.ProtoReflect.Descriptor instead.
+func (*AssignDeliveryRequest) Descriptor() ([]byte, []int) {
+	return file_courier_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AssignDeliveryRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *AssignDeliveryRequest) GetPriority() string {
+	if x != nil {
+		return x.Priority
+	}
+	return ""
+}
+
+type AssignDeliveryResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// Курьера нет, заказ ждет в очереди - остальные поля не заданы
+	Pending        bool                   `protobuf:"varint,2,opt,name=pending,proto3" json:"pending,omitempty"`
+	CourierId      int64                  `protobuf:"varint,3,opt,name=courier_id,json=courierId,proto3" json:"courier_id,omitempty"`
+	TransportType  string                 `protobuf:"bytes,4,opt,name=transport_type,json=transportType,proto3" json:"transport_type,omitempty"`
+	Deadline       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	Status         string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"` // assigned или offered, если курьер еще должен принять заказ
+	OfferExpiresAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=offer_expires_at,json=offerExpiresAt,proto3" json:"offer_expires_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AssignDeliveryResponse) Reset() {
+	*x = AssignDeliveryResponse{}
+	mi := &file_courier_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignDeliveryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignDeliveryResponse) ProtoMessage() {}
+
+func (x *AssignDeliveryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_courier_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignDeliveryResponse.ProtoReflect.Descriptor instead.
+func (*AssignDeliveryResponse) Descriptor() ([]byte, []int) {
+	return file_courier_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AssignDeliveryResponse) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *AssignDeliveryResponse) GetPending() bool {
+	if x != nil {
+		return x.Pending
+	}
+	return false
+}
+
+func (x *AssignDeliveryResponse) GetCourierId() int64 {
+	if x != nil {
+		return x.CourierId
+	}
+	return 0
+}
+
+func (x *AssignDeliveryResponse) GetTransportType() string {
+	if x != nil {
+		return x.TransportType
+	}
+	return ""
+}
+
+func (x *AssignDeliveryResponse) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+func (x *AssignDeliveryResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *AssignDeliveryResponse) GetOfferExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OfferExpiresAt
+	}
+	return nil
+}
+
+type UnassignDeliveryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnassignDeliveryRequest) Reset() {
+	*x = UnassignDeliveryRequest{}
+	mi := &file_courier_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnassignDeliveryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnassignDeliveryRequest) ProtoMessage() {}
+
+func (x *UnassignDeliveryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_courier_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnassignDeliveryRequest.ProtoReflect.Descriptor instead.
+func (*UnassignDeliveryRequest) Descriptor() ([]byte, []int) {
+	return file_courier_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UnassignDeliveryRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *UnassignDeliveryRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type UnassignDeliveryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	CourierId     int64                  `protobuf:"varint,3,opt,name=courier_id,json=courierId,proto3" json:"courier_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnassignDeliveryResponse) Reset() {
+	*x = UnassignDeliveryResponse{}
+	mi := &file_courier_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnassignDeliveryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnassignDeliveryResponse) ProtoMessage() {}
+
+func (x *UnassignDeliveryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_courier_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnassignDeliveryResponse.ProtoReflect.Descriptor instead.
+func (*UnassignDeliveryResponse) Descriptor() ([]byte, []int) {
+	return file_courier_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UnassignDeliveryResponse) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *UnassignDeliveryResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UnassignDeliveryResponse) GetCourierId() int64 {
+	if x != nil {
+		return x.CourierId
+	}
+	return 0
+}
+
+type CompleteDeliveryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteDeliveryRequest) Reset() {
+	*x = CompleteDeliveryRequest{}
+	mi := &file_courier_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteDeliveryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteDeliveryRequest) ProtoMessage() {}
+
+func (x *CompleteDeliveryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_courier_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteDeliveryRequest.ProtoReflect.Descriptor instead.
+func (*CompleteDeliveryRequest) Descriptor() ([]byte, []int) {
+	return file_courier_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CompleteDeliveryRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type CompleteDeliveryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteDeliveryResponse) Reset() {
+	*x = CompleteDeliveryResponse{}
+	mi := &file_courier_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteDeliveryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteDeliveryResponse) ProtoMessage() {}
+
+func (x *CompleteDeliveryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_courier_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteDeliveryResponse.ProtoReflect.Descriptor instead.
+func (*CompleteDeliveryResponse) Descriptor() ([]byte, []int) {
+	return file_courier_proto_rawDescGZIP(), []int{16}
+}
+
+type GetDeliveryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeliveryRequest) Reset() {
+	*x = GetDeliveryRequest{}
+	mi := &file_courier_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeliveryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeliveryRequest) ProtoMessage() {}
+
+func (x *GetDeliveryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_courier_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeliveryRequest.ProtoReflect.Descriptor instead.
+func (*GetDeliveryRequest) Descriptor() ([]byte, []int) {
+	return file_courier_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetDeliveryRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type GetDeliveryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Delivery      *Delivery              `protobuf:"bytes,1,opt,name=delivery,proto3" json:"delivery,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeliveryResponse) Reset() {
+	*x = GetDeliveryResponse{}
+	mi := &file_courier_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeliveryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeliveryResponse) ProtoMessage() {}
+
+func (x *GetDeliveryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_courier_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeliveryResponse.ProtoReflect.Descriptor instead.
+func (*GetDeliveryResponse) Descriptor() ([]byte, []int) {
+	return file_courier_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetDeliveryResponse) GetDelivery() *Delivery {
+	if x != nil {
+		return x.Delivery
+	}
+	return nil
+}
+
+// Ровно одно из order_id и courier_id.
+// after_id - id последнего полученного события, чтобы продолжить поток после переподключения
+type WatchDeliveryEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	CourierId     int64                  `protobuf:"varint,2,opt,name=courier_id,json=courierId,proto3" json:"courier_id,omitempty"`
+	AfterId       int64                  `protobuf:"varint,3,opt,name=after_id,json=afterId,proto3" json:"after_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchDeliveryEventsRequest) Reset() {
+	*x = WatchDeliveryEventsRequest{}
+	mi := &file_courier_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchDeliveryEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchDeliveryEventsRequest) ProtoMessage() {}
+
+func (x *WatchDeliveryEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_courier_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchDeliveryEventsRequest.ProtoReflect.Descriptor instead.
+func (*WatchDeliveryEventsRequest) Descriptor() ([]byte, []int) {
+	return file_courier_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *WatchDeliveryEventsRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *WatchDeliveryEventsRequest) GetCourierId() int64 {
+	if x != nil {
+		return x.CourierId
+	}
+	return 0
+}
+
+func (x *WatchDeliveryEventsRequest) GetAfterId() int64 {
+	if x != nil {
+		return x.AfterId
+	}
+	return 0
+}
+
+// Событие доставки из истории изменений
+type DeliveryEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"` // offered, assigned, unassigned, completed, expired и т.д.
+	OrderId       string                 `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	DeliveryId    int64                  `protobuf:"varint,4,opt,name=delivery_id,json=deliveryId,proto3" json:"delivery_id,omitempty"`
+	CourierId     int64                  `protobuf:"varint,5,opt,name=courier_id,json=courierId,proto3" json:"courier_id,omitempty"`
+	FromStatus    string                 `protobuf:"bytes,6,opt,name=from_status,json=fromStatus,proto3" json:"from_status,omitempty"` // пусто для первого события доставки
+	ToStatus      string                 `protobuf:"bytes,7,opt,name=to_status,json=toStatus,proto3" json:"to_status,omitempty"`
+	Actor         string                 `protobuf:"bytes,8,opt,name=actor,proto3" json:"actor,omitempty"`
+	Reason        string                 `protobuf:"bytes,9,opt,name=reason,proto3" json:"reason,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeliveryEvent) Reset() {
+	*x = DeliveryEvent{}
+	mi := &file_courier_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeliveryEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryEvent) ProtoMessage() {}
+
+func (x *DeliveryEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_courier_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryEvent.ProtoReflect.Descriptor instead.
+func (*DeliveryEvent) Descriptor() ([]byte, []int) {
+	return file_courier_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DeliveryEvent) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeliveryEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *DeliveryEvent) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *DeliveryEvent) GetDeliveryId() int64 {
+	if x != nil {
+		return x.DeliveryId
+	}
+	return 0
+}
+
+func (x *DeliveryEvent) GetCourierId() int64 {
+	if x != nil {
+		return x.CourierId
+	}
+	return 0
+}
+
+func (x *DeliveryEvent) GetFromStatus() string {
+	if x != nil {
+		return x.FromStatus
+	}
+	return ""
+}
+
+func (x *DeliveryEvent) GetToStatus() string {
+	if x != nil {
+		return x.ToStatus
+	}
+	return ""
+}
+
+func (x *DeliveryEvent) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *DeliveryEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *DeliveryEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+var File_courier_proto protoreflect.FileDescriptor
+
+const file_courier_proto_rawDesc = "" +
+	"\n" +
+	"\rcourier.proto\x12\n" +
+	"courier.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"i\n" +
+	"\bLocation\x12\x10\n" +
+	"\x03lat\x18\x01 \x01(\x01R\x03lat\x12\x10\n" +
+	"\x03lon\x18\x02 \x01(\x01R\x03lon\x129\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xaa\x02\n" +
+	"\aCourier\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05phone\x18\x03 \x01(\tR\x05phone\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12%\n" +
+	"\x0etransport_type\x18\x05 \x01(\tR\rtransportType\x120\n" +
+	"\blocation\x18\x06 \x01(\v2\x14.courier.v1.LocationR\blocation\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xfd\x01\n" +
+	"\bDelivery\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"courier_id\x18\x02 \x01(\x03R\tcourierId\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\tR\aorderId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x1a\n" +
+	"\bpriority\x18\x05 \x01(\tR\bpriority\x12;\n" +
+	"\vassigned_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"assignedAt\x126\n" +
+	"\bdeadline\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bdeadline\"#\n" +
+	"\x11GetCourierRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"C\n" +
+	"\x12GetCourierResponse\x12-\n" +
+	"\acourier\x18\x01 \x01(\v2\x13.courier.v1.CourierR\acourier\"\xe1\x01\n" +
+	"\x13ListCouriersRequest\x12\x1a\n" +
+	"\bstatuses\x18\x01 \x03(\tR\bstatuses\x12'\n" +
+	"\x0ftransport_types\x18\x02 \x03(\tR\x0etransportTypes\x12\x14\n" +
+	"\x05query\x18\x03 \x01(\tR\x05query\x12\x17\n" +
+	"\azone_id\x18\x04 \x01(\x03R\x06zoneId\x12\x12\n" +
+	"\x04sort\x18\x05 \x01(\tR\x04sort\x12\x14\n" +
+	"\x05order\x18\x06 \x01(\tR\x05order\x12\x14\n" +
+	"\x05limit\x18\a \x01(\x04R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\b \x01(\tR\x06cursor\"~\n" +
+	"\x14ListCouriersResponse\x12/\n" +
+	"\bcouriers\x18\x01 \x03(\v2\x13.courier.v1.CourierR\bcouriers\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\"\x7f\n" +
+	"\x14CreateCourierRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05phone\x18\x02 \x01(\tR\x05phone\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12%\n" +
+	"\x0etransport_type\x18\x04 \x01(\tR\rtransportType\"'\n" +
+	"\x15CreateCourierResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x8f\x01\n" +
+	"\x14UpdateCourierRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05phone\x18\x03 \x01(\tR\x05phone\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12%\n" +
+	"\x0etransport_type\x18\x05 \x01(\tR\rtransportType\"\x17\n" +
+	"\x15UpdateCourierResponse\"N\n" +
+	"\x15AssignDeliveryRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1a\n" +
+	"\bpriority\x18\x02 \x01(\tR\bpriority\"\xa9\x02\n" +
+	"\x16AssignDeliveryResponse\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x18\n" +
+	"\apending\x18\x02 \x01(\bR\apending\x12\x1d\n" +
+	"\n" +
+	"courier_id\x18\x03 \x01(\x03R\tcourierId\x12%\n" +
+	"\x0etransport_type\x18\x04 \x01(\tR\rtransportType\x126\n" +
+	"\bdeadline\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bdeadline\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12D\n" +
+	"\x10offer_expires_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x0eofferExpiresAt\"L\n" +
+	"\x17UnassignDeliveryRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"l\n" +
+	"\x18UnassignDeliveryResponse\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"courier_id\x18\x03 \x01(\x03R\tcourierId\"4\n" +
+	"\x17CompleteDeliveryRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"\x1a\n" +
+	"\x18CompleteDeliveryResponse\"/\n" +
+	"\x12GetDeliveryRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"G\n" +
+	"\x13GetDeliveryResponse\x120\n" +
+	"\bdelivery\x18\x01 \x01(\v2\x14.courier.v1.DeliveryR\bdelivery\"q\n" +
+	"\x1aWatchDeliveryEventsRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1d\n" +
+	"\n" +
+	"courier_id\x18\x02 \x01(\x03R\tcourierId\x12\x19\n" +
+	"\bafter_id\x18\x03 \x01(\x03R\aafterId\"\xb5\x02\n" +
+	"\rDeliveryEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\tR\aorderId\x12\x1f\n" +
+	"\vdelivery_id\x18\x04 \x01(\x03R\n" +
+	"deliveryId\x12\x1d\n" +
+	"\n" +
+	"courier_id\x18\x05 \x01(\x03R\tcourierId\x12\x1f\n" +
+	"\vfrom_status\x18\x06 \x01(\tR\n" +
+	"fromStatus\x12\x1b\n" +
+	"\tto_status\x18\a \x01(\tR\btoStatus\x12\x14\n" +
+	"\x05actor\x18\b \x01(\tR\x05actor\x12\x16\n" +
+	"\x06reason\x18\t \x01(\tR\x06reason\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt2\x9f\x06\n" +
+	"\x0eCourierService\x12K\n" +
+	"\n" +
+	"GetCourier\x12\x1d.courier.v1.GetCourierRequest\x1a\x1e.courier.v1.GetCourierResponse\x12Q\n" +
+	"\fListCouriers\x12\x1f.courier.v1.ListCouriersRequest\x1a .courier.v1.ListCouriersResponse\x12T\n" +
+	"\rCreateCourier\x12 .courier.v1.CreateCourierRequest\x1a!.courier.v1.CreateCourierResponse\x12T\n" +
+	"\rUpdateCourier\x12 .courier.v1.UpdateCourierRequest\x1a!.courier.v1.UpdateCourierResponse\x12W\n" +
+	"\x0eAssignDelivery\x12!.courier.v1.AssignDeliveryRequest\x1a\".courier.v1.AssignDeliveryResponse\x12]\n" +
+	"\x10UnassignDelivery\x12#.courier.v1.UnassignDeliveryRequest\x1a$.courier.v1.UnassignDeliveryResponse\x12]\n" +
+	"\x10CompleteDelivery\x12#.courier.v1.CompleteDeliveryRequest\x1a$.courier.v1.CompleteDeliveryResponse\x12N\n" +
+	"\vGetDelivery\x12\x1e.courier.v1.GetDeliveryRequest\x1a\x1f.courier.v1.GetDeliveryResponse\x12Z\n" +
+	"\x13WatchDeliveryEvents\x12&.courier.v1.WatchDeliveryEventsRequest\x1a\x19.courier.v1.DeliveryEvent0\x01B(Z&service-courier/internal/proto/courierb\x06proto3"
+
+var (
+	file_courier_proto_rawDescOnce sync.Once
+	file_courier_proto_rawDescData []byte
+)
+
+func file_courier_proto_rawDescGZIP() []byte {
+	file_courier_proto_rawDescOnce.Do(func() {
+		file_courier_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_courier_proto_rawDesc), len(file_courier_proto_rawDesc)))
+	})
+	return file_courier_proto_rawDescData
+}
+
+var file_courier_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_courier_proto_goTypes = []any{
+	(*Location)(nil),                   // 0: courier.v1.Location
+	(*Courier)(nil),                    // 1: courier.v1.Courier
+	(*Delivery)(nil),                   // 2: courier.v1.Delivery
+	(*GetCourierRequest)(nil),          // 3: courier.v1.GetCourierRequest
+	(*GetCourierResponse)(nil),         // 4: courier.v1.GetCourierResponse
+	(*ListCouriersRequest)(nil),        // 5: courier.v1.ListCouriersRequest
+	(*ListCouriersResponse)(nil),       // 6: courier.v1.ListCouriersResponse
+	(*CreateCourierRequest)(nil),       // 7: courier.v1.CreateCourierRequest
+	(*CreateCourierResponse)(nil),      // 8: courier.v1.CreateCourierResponse
+	(*UpdateCourierRequest)(nil),       // 9: courier.v1.UpdateCourierRequest
+	(*UpdateCourierResponse)(nil),      // 10: courier.v1.UpdateCourierResponse
+	(*AssignDeliveryRequest)(nil),      // 11: courier.v1.AssignDeliveryRequest
+	(*AssignDeliveryResponse)(nil),     // 12: courier.v1.AssignDeliveryResponse
+	(*UnassignDeliveryRequest)(nil),    // 13: courier.v1.UnassignDeliveryRequest
+	(*UnassignDeliveryResponse)(nil),   // 14: courier.v1.UnassignDeliveryResponse
+	(*CompleteDeliveryRequest)(nil),    // 15: courier.v1.CompleteDeliveryRequest
+	(*CompleteDeliveryResponse)(nil),   // 16: courier.v1.CompleteDeliveryResponse
+	(*GetDeliveryRequest)(nil),         // 17: courier.v1.GetDeliveryRequest
+	(*GetDeliveryResponse)(nil),        // 18: courier.v1.GetDeliveryResponse
+	(*WatchDeliveryEventsRequest)(nil), // 19: courier.v1.WatchDeliveryEventsRequest
+	(*DeliveryEvent)(nil),              // 20: courier.v1.DeliveryEvent
+	(*timestamppb.Timestamp)(nil),      // 21: google.protobuf.Timestamp
+}
+var file_courier_proto_depIdxs = []int32{
+	21, // 0: courier.v1.Location.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 1: courier.v1.Courier.location:type_name -> courier.v1.Location
+	21, // 2: courier.v1.Courier.created_at:type_name -> google.protobuf.Timestamp
+	21, // 3: courier.v1.Courier.updated_at:type_name -> google.protobuf.Timestamp
+	21, // 4: courier.v1.Delivery.assigned_at:type_name -> google.protobuf.Timestamp
+	21, // 5: courier.v1.Delivery.deadline:type_name -> google.protobuf.Timestamp
+	1,  // 6: courier.v1.GetCourierResponse.courier:type_name -> courier.v1.Courier
+	1,  // 7: courier.v1.ListCouriersResponse.couriers:type_name -> courier.v1.Courier
+	21, // 8: courier.v1.AssignDeliveryResponse.deadline:type_name -> google.protobuf.Timestamp
+	21, // 9: courier.v1.AssignDeliveryResponse.offer_expires_at:type_name -> google.protobuf.Timestamp
+	2,  // 10: courier.v1.GetDeliveryResponse.delivery:type_name -> courier.v1.Delivery
+	21, // 11: courier.v1.DeliveryEvent.created_at:type_name -> google.protobuf.Timestamp
+	3,  // 12: courier.v1.CourierService.GetCourier:input_type -> courier.v1.GetCourierRequest
+	5,  // 13: courier.v1.CourierService.ListCouriers:input_type -> courier.v1.ListCouriersRequest
+	7,  // 14: courier.v1.CourierService.CreateCourier:input_type -> courier.v1.CreateCourierRequest
+	9,  // 15: courier.v1.CourierService.UpdateCourier:input_type -> courier.v1.UpdateCourierRequest
+	11, // 16: courier.v1.CourierService.AssignDelivery:input_type -> courier.v1.AssignDeliveryRequest
+	13, // 17: courier.v1.CourierService.UnassignDelivery:input_type -> courier.v1.UnassignDeliveryRequest
+	15, // 18: courier.v1.CourierService.CompleteDelivery:input_type -> courier.v1.CompleteDeliveryRequest
+	17, // 19: courier.v1.CourierService.GetDelivery:input_type -> courier.v1.GetDeliveryRequest
+	19, // 20: courier.v1.CourierService.WatchDeliveryEvents:input_type -> courier.v1.WatchDeliveryEventsRequest
+	4,  // 21: courier.v1.CourierService.GetCourier:output_type -> courier.v1.GetCourierResponse
+	6,  // 22: courier.v1.CourierService.ListCouriers:output_type -> courier.v1.ListCouriersResponse
+	8,  // 23: courier.v1.CourierService.CreateCourier:output_type -> courier.v1.CreateCourierResponse
+	10, // 24: courier.v1.CourierService.UpdateCourier:output_type -> courier.v1.UpdateCourierResponse
+	12, // 25: courier.v1.CourierService.AssignDelivery:output_type -> courier.v1.AssignDeliveryResponse
+	14, // 26: courier.v1.CourierService.UnassignDelivery:output_type -> courier.v1.UnassignDeliveryResponse
+	16, // 27: courier.v1.CourierService.CompleteDelivery:output_type -> courier.v1.CompleteDeliveryResponse
+	18, // 28: courier.v1.CourierService.GetDelivery:output_type -> courier.v1.GetDeliveryResponse
+	20, // 29: courier.v1.CourierService.WatchDeliveryEvents:output_type -> courier.v1.DeliveryEvent
+	21, // [21:30] is the sub-list for method output_type
+	12, // [12:21] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_courier_proto_init() }
+func file_courier_proto_init() {
+	if File_courier_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_courier_proto_rawDesc), len(file_courier_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   21,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_courier_proto_goTypes,
+		DependencyIndexes: file_courier_proto_depIdxs,
+		MessageInfos:      file_courier_proto_msgTypes,
+	}.Build()
+	File_courier_proto = out.File
+	file_courier_proto_goTypes = nil
+	file_courier_proto_depIdxs = nil
+}
@@ -0,0 +1,439 @@
+// courier.proto
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             (unknown)
+// source: courier.proto
+
+// API сервиса курьеров для внутренних gRPC-клиентов
+
+package courier
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CourierService_GetCourier_FullMethodName          = "/courier.v1.CourierService/GetCourier"
+	CourierService_ListCouriers_FullMethodName        = "/courier.v1.CourierService/ListCouriers"
+	CourierService_CreateCourier_FullMethodName       = "/courier.v1.CourierService/CreateCourier"
+	CourierService_UpdateCourier_FullMethodName       = "/courier.v1.CourierService/UpdateCourier"
+	CourierService_AssignDelivery_FullMethodName      = "/courier.v1.CourierService/AssignDelivery"
+	CourierService_UnassignDelivery_FullMethodName    = "/courier.v1.CourierService/UnassignDelivery"
+	CourierService_CompleteDelivery_FullMethodName    = "/courier.v1.CourierService/CompleteDelivery"
+	CourierService_GetDelivery_FullMethodName         = "/courier.v1.CourierService/GetDelivery"
+	CourierService_WatchDeliveryEvents_FullMethodName = "/courier.v1.CourierService/WatchDeliveryEvents"
+)
+
+// CourierServiceClient is the client API for CourierService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Курьеры и назначение их на заказы
+type CourierServiceClient interface {
+	GetCourier(ctx context.Context, in *GetCourierRequest, opts ...grpc.CallOption) (*GetCourierResponse, error)
+	ListCouriers(ctx context.Context, in *ListCouriersRequest, opts ...grpc.CallOption) (*ListCouriersResponse, error)
+	CreateCourier(ctx context.Context, in *CreateCourierRequest, opts ...grpc.CallOption) (*CreateCourierResponse, error)
+	UpdateCourier(ctx context.Context, in *UpdateCourierRequest, opts ...grpc.CallOption) (*UpdateCourierResponse, error)
+	AssignDelivery(ctx context.Context, in *AssignDeliveryRequest, opts ...grpc.CallOption) (*AssignDeliveryResponse, error)
+	UnassignDelivery(ctx context.Context, in *UnassignDeliveryRequest, opts ...grpc.CallOption) (*UnassignDeliveryResponse, error)
+	CompleteDelivery(ctx context.Context, in *CompleteDeliveryRequest, opts ...grpc.CallOption) (*CompleteDeliveryResponse, error)
+	GetDelivery(ctx context.Context, in *GetDeliveryRequest, opts ...grpc.CallOption) (*GetDeliveryResponse, error)
+	// Поток событий доставки заказа или курьера до отмены вызова
+	WatchDeliveryEvents(ctx context.Context, in *WatchDeliveryEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DeliveryEvent], error)
+}
+
+type courierServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCourierServiceClient(cc grpc.ClientConnInterface) CourierServiceClient {
+	return &courierServiceClient{cc}
+}
+
+func (c *courierServiceClient) GetCourier(ctx context.Context, in *GetCourierRequest, opts ...grpc.CallOption) (*GetCourierResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCourierResponse)
+	err := c.cc.Invoke(ctx, CourierService_GetCourier_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *courierServiceClient) ListCouriers(ctx context.Context, in *ListCouriersRequest, opts ...grpc.CallOption) (*ListCouriersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCouriersResponse)
+	err := c.cc.Invoke(ctx, CourierService_ListCouriers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *courierServiceClient) CreateCourier(ctx context.Context, in *CreateCourierRequest, opts ...grpc.CallOption) (*CreateCourierResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateCourierResponse)
+	err := c.cc.Invoke(ctx, CourierService_CreateCourier_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *courierServiceClient) UpdateCourier(ctx context.Context, in *UpdateCourierRequest, opts ...grpc.CallOption) (*UpdateCourierResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateCourierResponse)
+	err := c.cc.Invoke(ctx, CourierService_UpdateCourier_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *courierServiceClient) AssignDelivery(ctx context.Context, in *AssignDeliveryRequest, opts ...grpc.CallOption) (*AssignDeliveryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AssignDeliveryResponse)
+	err := c.cc.Invoke(ctx, CourierService_AssignDelivery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *courierServiceClient) UnassignDelivery(ctx context.Context, in *UnassignDeliveryRequest, opts ...grpc.CallOption) (*UnassignDeliveryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnassignDeliveryResponse)
+	err := c.cc.Invoke(ctx, CourierService_UnassignDelivery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *courierServiceClient) CompleteDelivery(ctx context.Context, in *CompleteDeliveryRequest, opts ...grpc.CallOption) (*CompleteDeliveryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompleteDeliveryResponse)
+	err := c.cc.Invoke(ctx, CourierService_CompleteDelivery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *courierServiceClient) GetDelivery(ctx context.Context, in *GetDeliveryRequest, opts ...grpc.CallOption) (*GetDeliveryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDeliveryResponse)
+	err := c.cc.Invoke(ctx, CourierService_GetDelivery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *courierServiceClient) WatchDeliveryEvents(ctx context.Context, in *WatchDeliveryEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DeliveryEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CourierService_ServiceDesc.Streams[0], CourierService_WatchDeliveryEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchDeliveryEventsRequest, DeliveryEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CourierService_WatchDeliveryEventsClient = grpc.ServerStreamingClient[DeliveryEvent]
+
+// CourierServiceServer is the server API for CourierService service.
+// All implementations must embed UnimplementedCourierServiceServer
+// for forward compatibility.
+//
+// Курьеры и назначение их на заказы
+type CourierServiceServer interface {
+	GetCourier(context.Context, *GetCourierRequest) (*GetCourierResponse, error)
+	ListCouriers(context.Context, *ListCouriersRequest) (*ListCouriersResponse, error)
+	CreateCourier(context.Context, *CreateCourierRequest) (*CreateCourierResponse, error)
+	UpdateCourier(context.Context, *UpdateCourierRequest) (*UpdateCourierResponse, error)
+	AssignDelivery(context.Context, *AssignDeliveryRequest) (*AssignDeliveryResponse, error)
+	UnassignDelivery(context.Context, *UnassignDeliveryRequest) (*UnassignDeliveryResponse, error)
+	CompleteDelivery(context.Context, *CompleteDeliveryRequest) (*CompleteDeliveryResponse, error)
+	GetDelivery(context.Context, *GetDeliveryRequest) (*GetDeliveryResponse, error)
+	// Поток событий доставки заказа или курьера до отмены вызова
+	WatchDeliveryEvents(*WatchDeliveryEventsRequest, grpc.ServerStreamingServer[DeliveryEvent]) error
+	mustEmbedUnimplementedCourierServiceServer()
+}
+
+// UnimplementedCourierServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCourierServiceServer struct{}
+
+func (UnimplementedCourierServiceServer) GetCourier(context.Context, *GetCourierRequest) (*GetCourierResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCourier not implemented")
+}
+func (UnimplementedCourierServiceServer) ListCouriers(context.Context, *ListCouriersRequest) (*ListCouriersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCouriers not implemented")
+}
+func (UnimplementedCourierServiceServer) CreateCourier(context.Context, *CreateCourierRequest) (*CreateCourierResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateCourier not implemented")
+}
+func (UnimplementedCourierServiceServer) UpdateCourier(context.Context, *UpdateCourierRequest) (*UpdateCourierResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateCourier not implemented")
+}
+func (UnimplementedCourierServiceServer) AssignDelivery(context.Context, *AssignDeliveryRequest) (*AssignDeliveryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AssignDelivery not implemented")
+}
+func (UnimplementedCourierServiceServer) UnassignDelivery(context.Context, *UnassignDeliveryRequest) (*UnassignDeliveryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UnassignDelivery not implemented")
+}
+func (UnimplementedCourierServiceServer) CompleteDelivery(context.Context, *CompleteDeliveryRequest) (*CompleteDeliveryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CompleteDelivery not implemented")
+}
+func (UnimplementedCourierServiceServer) GetDelivery(context.Context, *GetDeliveryRequest) (*GetDeliveryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDelivery not implemented")
+}
+func (UnimplementedCourierServiceServer) WatchDeliveryEvents(*WatchDeliveryEventsRequest, grpc.ServerStreamingServer[DeliveryEvent]) error {
+	return status.Error(codes.Unimplemented, "method WatchDeliveryEvents not implemented")
+}
+func (UnimplementedCourierServiceServer) mustEmbedUnimplementedCourierServiceServer() {}
+func (UnimplementedCourierServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeCourierServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CourierServiceServer will
+// result in compilation errors.
+type UnsafeCourierServiceServer interface {
+	mustEmbedUnimplementedCourierServiceServer()
+}
+
+func RegisterCourierServiceServer(s grpc.ServiceRegistrar, srv CourierServiceServer) {
+	// If the following call panics, it indicates UnimplementedCourierServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CourierService_ServiceDesc, srv)
+}
+
+func _CourierService_GetCourier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCourierRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CourierServiceServer).GetCourier(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CourierService_GetCourier_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CourierServiceServer).GetCourier(ctx, req.(*GetCourierRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CourierService_ListCouriers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCouriersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CourierServiceServer).ListCouriers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CourierService_ListCouriers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CourierServiceServer).ListCouriers(ctx, req.(*ListCouriersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CourierService_CreateCourier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCourierRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CourierServiceServer).CreateCourier(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CourierService_CreateCourier_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CourierServiceServer).CreateCourier(ctx, req.(*CreateCourierRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CourierService_UpdateCourier_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCourierRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CourierServiceServer).UpdateCourier(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CourierService_UpdateCourier_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CourierServiceServer).UpdateCourier(ctx, req.(*UpdateCourierRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CourierService_AssignDelivery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignDeliveryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CourierServiceServer).AssignDelivery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CourierService_AssignDelivery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CourierServiceServer).AssignDelivery(ctx, req.(*AssignDeliveryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CourierService_UnassignDelivery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnassignDeliveryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CourierServiceServer).UnassignDelivery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CourierService_UnassignDelivery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CourierServiceServer).UnassignDelivery(ctx, req.(*UnassignDeliveryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CourierService_CompleteDelivery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteDeliveryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CourierServiceServer).CompleteDelivery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CourierService_CompleteDelivery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CourierServiceServer).CompleteDelivery(ctx, req.(*CompleteDeliveryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CourierService_GetDelivery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeliveryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CourierServiceServer).GetDelivery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CourierService_GetDelivery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CourierServiceServer).GetDelivery(ctx, req.(*GetDeliveryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CourierService_WatchDeliveryEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchDeliveryEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CourierServiceServer).WatchDeliveryEvents(m, &grpc.GenericServerStream[WatchDeliveryEventsRequest, DeliveryEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CourierService_WatchDeliveryEventsServer = grpc.ServerStreamingServer[DeliveryEvent]
+
+// CourierService_ServiceDesc is the grpc.ServiceDesc for CourierService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CourierService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "courier.v1.CourierService",
+	HandlerType: (*CourierServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetCourier",
+			Handler:    _CourierService_GetCourier_Handler,
+		},
+		{
+			MethodName: "ListCouriers",
+			Handler:    _CourierService_ListCouriers_Handler,
+		},
+		{
+			MethodName: "CreateCourier",
+			Handler:    _CourierService_CreateCourier_Handler,
+		},
+		{
+			MethodName: "UpdateCourier",
+			Handler:    _CourierService_UpdateCourier_Handler,
+		},
+		{
+			MethodName: "AssignDelivery",
+			Handler:    _CourierService_AssignDelivery_Handler,
+		},
+		{
+			MethodName: "UnassignDelivery",
+			Handler:    _CourierService_UnassignDelivery_Handler,
+		},
+		{
+			MethodName: "CompleteDelivery",
+			Handler:    _CourierService_CompleteDelivery_Handler,
+		},
+		{
+			MethodName: "GetDelivery",
+			Handler:    _CourierService_GetDelivery_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchDeliveryEvents",
+			Handler:       _CourierService_WatchDeliveryEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "courier.proto",
+}
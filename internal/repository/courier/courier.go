@@ -5,48 +5,145 @@ import (
 	"errors"
 	"fmt"
 	"service-courier/internal/model/courier"
+	"service-courier/internal/model/delivery"
+	"service-courier/internal/pkg/geo"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/Masterminds/squirrel"
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// onShift оставляет только курьеров, у которых сейчас идет смена
+var onShift = squirrel.Expr(`EXISTS (
+	SELECT 1 FROM courier_shifts s
+	WHERE s.courier_id = c.id AND s.starts_at <= NOW() AND s.ends_at > NOW()
+)`)
+
+// assignableStatuses - курьер со статусом busy остается в выборке, пока не заполнена вместимость транспорта
+var assignableStatuses = []courier.CourierStatus{courier.StatusAvailable, courier.StatusBusy}
+
+// activeDeliveries считает доставки курьера, которые еще не завершены
+func activeDeliveries() squirrel.Sqlizer {
+	statuses := delivery.ActiveStatuses()
+	placeholders := make([]string, len(statuses))
+	args := make([]interface{}, len(statuses))
+	for i, status := range statuses {
+		placeholders[i] = "?"
+		args[i] = status
+	}
+	return squirrel.Expr(
+		"COUNT(d.id) FILTER (WHERE d.deleted_at IS NULL AND d.status IN ("+strings.Join(placeholders, ",")+"))",
+		args...,
+	)
+}
+
+// underCapacity оставляет курьеров, у которых активных доставок меньше вместимости их транспорта
+func underCapacity(capacity courier.Capacity) squirrel.Sqlizer {
+	types := make([]string, 0, len(capacity))
+	for t := range capacity {
+		types = append(types, string(t))
+	}
+	sort.Strings(types)
+
+	sql, args, _ := activeDeliveries().ToSql()
+	var b strings.Builder
+	b.WriteString(sql)
+	b.WriteString(" < CASE c.transport_type")
+	for _, t := range types {
+		b.WriteString(" WHEN ? THEN ?")
+		args = append(args, t, capacity.Of(courier.TransportType(t)))
+	}
+	b.WriteString(" ELSE 1 END")
+
+	return squirrel.Expr(b.String(), args...)
+}
+
+// inZones оставляет курьеров, закрепленных хотя бы за одной из зон
+func inZones(zoneIDs []int64) squirrel.Sqlizer {
+	return squirrel.Expr(
+		"EXISTS (SELECT 1 FROM courier_zones cz WHERE cz.courier_id = c.id AND cz.zone_id = ANY(?))",
+		zoneIDs,
+	)
+}
+
+var courierColumns = []string{
+	"id",
+	"name",
+	"phone",
+	"status",
+	"transport_type",
+	"latitude",
+	"longitude",
+	"location_updated_at",
+	"created_at",
+	"updated_at",
+	"version",
+	"deactivated_at",
+}
+
+// notDeleted скрывает удаленных курьеров: для API их больше нет, но строки остаются для истории доставок
+var notDeleted = squirrel.Eq{"deleted_at": nil}
+
+// withoutActiveDeliveries оставляет курьеров, у которых нет незавершенных доставок
+func withoutActiveDeliveries() squirrel.Sqlizer {
+	return squirrel.Expr(
+		"NOT EXISTS (SELECT 1 FROM delivery d WHERE d.courier_id = couriers.id AND d.deleted_at IS NULL AND d.status = ANY(?))",
+		activeStatusNames(),
+	)
+}
+
+func activeStatusNames() []string {
+	statuses := delivery.ActiveStatuses()
+	names := make([]string, len(statuses))
+	for i, status := range statuses {
+		names[i] = string(status)
+	}
+	return names
+}
+
+// nextVersion увеличивает версию курьера; позиция курьера версию не меняет
+var nextVersion = squirrel.Expr("version + 1")
+
+// likeEscaper экранирует спецсимволы LIKE в поисковой строке
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 type Repository struct {
 	pool         *pgxpool.Pool
+	getter       *trmpgx.CtxGetter
 	queryBuilder squirrel.StatementBuilderType
 }
 
-func NewCourierRepository(pool *pgxpool.Pool) *Repository {
+func NewCourierRepository(pool *pgxpool.Pool, getter *trmpgx.CtxGetter) *Repository {
 	return &Repository{
 		pool:         pool,
+		getter:       getter,
 		queryBuilder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
 	}
 }
 
+func (r *Repository) exec(ctx context.Context) trmpgx.Tr {
+	return r.getter.DefaultTrOrDB(ctx, r.pool)
+}
+
 func (r *Repository) GetByID(ctx context.Context, id int64) (*courier.Courier, error) {
 	query := r.queryBuilder.
-		Select("id", "name", "phone", "status", "transport_type", "created_at", "updated_at").
+		Select(courierColumns...).
 		From("couriers").
-		Where(squirrel.Eq{"id": id})
+		Where(squirrel.Eq{"id": id}).
+		Where(notDeleted)
 
 	sql, args, err := query.ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("build query: %w", err)
 	}
 
-	var courierData courier.Courier
-	err = r.pool.QueryRow(ctx, sql, args...).Scan(
-		&courierData.ID,
-		&courierData.Name,
-		&courierData.Phone,
-		&courierData.Status,
-		&courierData.TransportType,
-		&courierData.CreatedAt,
-		&courierData.UpdatedAt,
-	)
-
+	courierData, err := scanCourierWithLocation(r.exec(ctx).QueryRow(ctx, sql, args...))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, courier.ErrCourierNotFound
@@ -54,22 +151,39 @@ func (r *Repository) GetByID(ctx context.Context, id int64) (*courier.Courier, e
 		return nil, fmt.Errorf("database error: %w", err)
 	}
 
-	return &courierData, nil
+	return courierData, nil
 }
 
-func (r *Repository) GetAll(ctx context.Context) ([]courier.Courier, error) {
-	query, args, err := r.queryBuilder.
-		Select("id", "name", "phone", "status", "transport_type", "created_at", "updated_at").
-		From("couriers").
-		OrderBy("id").
-		ToSql()
+// List возвращает страницу курьеров по фильтру с keyset-пагинацией после filter.After
+func (r *Repository) List(ctx context.Context, filter courier.ListFilter) ([]courier.Courier, error) {
+	direction := "ASC"
+	if filter.Desc {
+		direction = "DESC"
+	}
+
+	builder := r.queryBuilder.
+		Select(courierColumns...).
+		From("couriers c").
+		Where(listConditions(filter))
+
+	if filter.After != nil {
+		builder = builder.Where(courierKeyset(filter.Sort, filter.Desc, *filter.After))
+	}
+	if filter.Sort == courier.SortByName {
+		builder = builder.OrderBy("name "+direction, "id "+direction)
+	} else {
+		builder = builder.OrderBy("id " + direction)
+	}
+	if filter.Limit > 0 {
+		builder = builder.Limit(filter.Limit)
+	}
 
+	query, args, err := builder.ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("build query: %w", err)
 	}
 
-	rows, err := r.pool.Query(ctx, query, args...)
-
+	rows, err := r.exec(ctx).Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("database error: %w", err)
 	}
@@ -77,20 +191,11 @@ func (r *Repository) GetAll(ctx context.Context) ([]courier.Courier, error) {
 
 	couriers := make([]courier.Courier, 0)
 	for rows.Next() {
-		var courierData courier.Courier
-		err := rows.Scan(
-			&courierData.ID,
-			&courierData.Name,
-			&courierData.Phone,
-			&courierData.Status,
-			&courierData.TransportType,
-			&courierData.CreatedAt,
-			&courierData.UpdatedAt,
-		)
+		courierData, err := scanCourierWithLocation(rows)
 		if err != nil {
 			return nil, fmt.Errorf("error reading data: %w", err)
 		}
-		couriers = append(couriers, courierData)
+		couriers = append(couriers, *courierData)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -100,6 +205,57 @@ func (r *Repository) GetAll(ctx context.Context) ([]courier.Courier, error) {
 	return couriers, nil
 }
 
+// Count - сколько курьеров подходит под фильтр без учета страницы
+func (r *Repository) Count(ctx context.Context, filter courier.ListFilter) (int64, error) {
+	query, args, err := r.queryBuilder.
+		Select("COUNT(*)").
+		From("couriers c").
+		Where(listConditions(filter)).
+		ToSql()
+
+	if err != nil {
+		return 0, fmt.Errorf("build query: %w", err)
+	}
+
+	var total int64
+	if err := r.exec(ctx).QueryRow(ctx, query, args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("database error: %w", err)
+	}
+	return total, nil
+}
+
+func listConditions(filter courier.ListFilter) squirrel.And {
+	conditions := squirrel.And{notDeleted}
+	if len(filter.Statuses) > 0 {
+		conditions = append(conditions, squirrel.Eq{"status": filter.Statuses})
+	}
+	if len(filter.Transports) > 0 {
+		conditions = append(conditions, squirrel.Eq{"transport_type": filter.Transports})
+	}
+	if filter.Search != "" {
+		pattern := "%" + likeEscaper.Replace(filter.Search) + "%"
+		conditions = append(conditions, squirrel.Or{
+			squirrel.ILike{"name": pattern},
+			squirrel.Like{"phone": pattern},
+		})
+	}
+	if filter.ZoneID != nil {
+		conditions = append(conditions, inZones([]int64{*filter.ZoneID}))
+	}
+	return conditions
+}
+
+func courierKeyset(sort courier.SortField, desc bool, after courier.Cursor) squirrel.Sqlizer {
+	op := ">"
+	if desc {
+		op = "<"
+	}
+	if sort == courier.SortByName {
+		return squirrel.Expr("(name, id) "+op+" (?, ?)", after.Name, after.ID)
+	}
+	return squirrel.Expr("id "+op+" ?", after.ID)
+}
+
 func (r *Repository) Create(ctx context.Context, courierData courier.Courier) (id int64, err error) {
 	query, args, err := r.queryBuilder.
 		Insert("couriers").
@@ -112,7 +268,7 @@ func (r *Repository) Create(ctx context.Context, courierData courier.Courier) (i
 		return id, fmt.Errorf("build query: %w", err)
 	}
 
-	err = r.pool.QueryRow(ctx, query, args...).Scan(&id)
+	err = r.exec(ctx).QueryRow(ctx, query, args...).Scan(&id)
 
 	if err != nil {
 		var pgErr *pgconn.PgError
@@ -124,11 +280,120 @@ func (r *Repository) Create(ctx context.Context, courierData courier.Courier) (i
 	return id, nil
 }
 
+// CreateBatch создает курьеров одним запросом: если телефон хотя бы одного уже занят,
+// не создается никто. Возвращает id в порядке couriers.
+func (r *Repository) CreateBatch(ctx context.Context, couriers []courier.Courier) ([]int64, error) {
+	return r.createBatch(ctx, couriers, "RETURNING id, phone")
+}
+
+// CreateBatchSkipTaken создает курьеров одним запросом, пропуская тех, чей телефон уже занят,
+// в том числе успевших появиться после проверки. Возвращает id в порядке couriers, у пропущенных - 0.
+func (r *Repository) CreateBatchSkipTaken(ctx context.Context, couriers []courier.Courier) ([]int64, error) {
+	return r.createBatch(ctx, couriers, "ON CONFLICT (phone) DO NOTHING RETURNING id, phone")
+}
+
+func (r *Repository) createBatch(ctx context.Context, couriers []courier.Courier, suffix string) ([]int64, error) {
+	if len(couriers) == 0 {
+		return nil, nil
+	}
+
+	insert := r.queryBuilder.
+		Insert("couriers").
+		Columns("name", "phone", "status", "transport_type").
+		Suffix(suffix)
+
+	for _, c := range couriers {
+		insert = insert.Values(c.Name, c.Phone, c.Status, c.TransportType)
+	}
+
+	query, args, err := insert.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	rows, err := r.exec(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer rows.Close()
+
+	// порядок RETURNING не гарантирован, поэтому id сопоставляются по телефону
+	byPhone := make(map[string]int64, len(couriers))
+	for rows.Next() {
+		var (
+			id    int64
+			phone string
+		)
+		if err := rows.Scan(&id, &phone); err != nil {
+			return nil, fmt.Errorf("error reading data: %w", err)
+		}
+		byPhone[phone] = id
+	}
+	if err := rows.Err(); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return nil, courier.ErrPhoneExists
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	ids := make([]int64, len(couriers))
+	for i, c := range couriers {
+		ids[i] = byPhone[c.Phone]
+	}
+	return ids, nil
+}
+
+// ExistingPhones возвращает телефоны из списка, которые уже заняты курьерами
+func (r *Repository) ExistingPhones(ctx context.Context, phones []string) ([]string, error) {
+	if len(phones) == 0 {
+		return nil, nil
+	}
+
+	query, args, err := r.queryBuilder.
+		Select("phone").
+		From("couriers").
+		Where(squirrel.Eq{"phone": phones}).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	rows, err := r.exec(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer rows.Close()
+
+	existing := make([]string, 0)
+	for rows.Next() {
+		var phone string
+		if err := rows.Scan(&phone); err != nil {
+			return nil, fmt.Errorf("error reading data: %w", err)
+		}
+		existing = append(existing, phone)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return existing, nil
+}
+
+// Update меняет непустые поля курьера. С courierData.Version обновление проходит,
+// только если курьера не меняли после этой версии, иначе ErrVersionMismatch.
 func (r *Repository) Update(ctx context.Context, courierData courier.Courier) error {
 	updateBuilder := r.queryBuilder.
 		Update("couriers").
 		Set("updated_at", squirrel.Expr("NOW()")).
-		Where(squirrel.Eq{"id": courierData.ID})
+		Set("version", nextVersion).
+		Where(squirrel.Eq{"id": courierData.ID}).
+		Where(notDeleted)
+
+	if courierData.Version != 0 {
+		updateBuilder = updateBuilder.Where(squirrel.Eq{"version": courierData.Version})
+	}
 
 	if courierData.Name != "" {
 		updateBuilder = updateBuilder.Set("name", courierData.Name)
@@ -149,7 +414,7 @@ func (r *Repository) Update(ctx context.Context, courierData courier.Courier) er
 		return fmt.Errorf("build query: %w", err)
 	}
 
-	result, err := r.pool.Exec(ctx, query, args...)
+	result, err := r.exec(ctx).Exec(ctx, query, args...)
 
 	if err != nil {
 		var pgErr *pgconn.PgError
@@ -159,6 +424,106 @@ func (r *Repository) Update(ctx context.Context, courierData courier.Courier) er
 		return fmt.Errorf("database error: %w", err)
 	}
 
+	if result.RowsAffected() == 0 {
+		return r.missingOrModified(ctx, courierData.ID, courierData.Version)
+	}
+
+	return nil
+}
+
+// Replace перезаписывает имя, телефон, статус и транспорт курьера, если его версия
+// равна courierData.Version, и возвращает курьера после изменения. Позиция перезаписывается,
+// только если setLocation: позиция не меняет версию, и без флага патч вернул бы устаревшие координаты.
+// Время позиции обновляется, только если координаты изменились; nil Location удаляет позицию.
+func (r *Repository) Replace(ctx context.Context, courierData courier.Courier, setLocation bool) (*courier.Courier, error) {
+	updateBuilder := r.queryBuilder.
+		Update("couriers").
+		Set("name", courierData.Name).
+		Set("phone", courierData.Phone).
+		Set("status", courierData.Status).
+		Set("transport_type", courierData.TransportType).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Set("version", nextVersion).
+		Where(squirrel.Eq{"id": courierData.ID, "version": courierData.Version}).
+		Where(notDeleted).
+		Suffix("RETURNING " + strings.Join(courierColumns, ", "))
+
+	switch {
+	case !setLocation:
+	case courierData.Location == nil:
+		updateBuilder = updateBuilder.
+			Set("latitude", nil).
+			Set("longitude", nil).
+			Set("location_updated_at", nil)
+	default:
+		point := courierData.Location.Point
+		updateBuilder = updateBuilder.
+			Set("latitude", point.Lat).
+			Set("longitude", point.Lon).
+			Set("location_updated_at", squirrel.Expr(
+				"CASE WHEN latitude IS DISTINCT FROM ? OR longitude IS DISTINCT FROM ? THEN NOW() ELSE location_updated_at END",
+				point.Lat, point.Lon,
+			))
+	}
+
+	query, args, err := updateBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	updated, err := scanCourierWithLocation(r.exec(ctx).QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, r.missingOrModified(ctx, courierData.ID, courierData.Version)
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+			return nil, courier.ErrPhoneExists
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return updated, nil
+}
+
+// missingOrModified объясняет, почему обновление курьера не затронуло строк:
+// курьера нет или его версия уже не равна ожидаемой
+func (r *Repository) missingOrModified(ctx context.Context, id, version int64) error {
+	if version == 0 {
+		return courier.ErrCourierNotFound
+	}
+	if _, err := r.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return courier.ErrVersionMismatch
+}
+
+// SetDeactivated выключает курьера из назначения заказов или включает обратно.
+// Повторная деактивация не меняет время деактивации.
+func (r *Repository) SetDeactivated(ctx context.Context, id int64, deactivated bool) error {
+	var deactivatedAt interface{}
+	if deactivated {
+		deactivatedAt = squirrel.Expr("COALESCE(deactivated_at, NOW())")
+	}
+
+	query, args, err := r.queryBuilder.
+		Update("couriers").
+		Set("deactivated_at", deactivatedAt).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Set("version", nextVersion).
+		Where(squirrel.Eq{"id": id}).
+		Where(notDeleted).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+
+	result, err := r.exec(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+
 	if result.RowsAffected() == 0 {
 		return courier.ErrCourierNotFound
 	}
@@ -166,78 +531,368 @@ func (r *Repository) Update(ctx context.Context, courierData courier.Courier) er
 	return nil
 }
 
-func (r *Repository) GetAvailableWithMinDeliveries(ctx context.Context) (*courier.Courier, error) {
+// Delete мягко удаляет курьера без незавершенных доставок: строка остается, чтобы доставки
+// и история ссылались на нее, но курьер пропадает из API и назначения.
+func (r *Repository) Delete(ctx context.Context, id int64) error {
+	removed := squirrel.
+		Update("couriers").
+		Set("deleted_at", squirrel.Expr("NOW()")).
+		Set("deactivated_at", squirrel.Expr("COALESCE(deactivated_at, NOW())")).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Set("version", nextVersion).
+		Where(squirrel.Eq{"id": id}).
+		Where(notDeleted).
+		Where(withoutActiveDeliveries())
+
+	return r.remove(ctx, id, removed, false)
+}
+
+// Erase удаляет персональные данные курьера: имя и телефон заменяются заглушками, позиция стирается,
+// а сам курьер удаляется, если еще не был удален. Строка и ее id остаются, так что доставки,
+// события и смены продолжают на нее ссылаться. Телефон освобождается для нового курьера.
+func (r *Repository) Erase(ctx context.Context, id int64) error {
+	removed := squirrel.
+		Update("couriers").
+		Set("name", courier.ErasedName).
+		Set("phone", squirrel.Expr("'erased-' || id")).
+		Set("latitude", nil).
+		Set("longitude", nil).
+		Set("location_updated_at", nil).
+		Set("erased_at", squirrel.Expr("NOW()")).
+		Set("deleted_at", squirrel.Expr("COALESCE(deleted_at, NOW())")).
+		Set("deactivated_at", squirrel.Expr("COALESCE(deactivated_at, NOW())")).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Set("version", nextVersion).
+		Where(squirrel.Eq{"id": id}).
+		Where(withoutActiveDeliveries())
+
+	return r.remove(ctx, id, removed, true)
+}
+
+// remove выполняет удаление курьера removed и в том же запросе снимает его смены: еще не начатые
+// удаляются, идущая закрывается сейчас. Иначе планировщик смен продолжил бы менять статус удаленного курьера.
+func (r *Repository) remove(ctx context.Context, id int64, removed squirrel.UpdateBuilder, includeDeleted bool) error {
 	query, args, err := r.queryBuilder.
-		Select(
-			"c.id",
-			"c.name",
-			"c.phone",
-			"c.status",
-			"c.transport_type",
-			"c.created_at",
-			"c.updated_at",
-		).
+		Select("id").
+		PrefixExpr(squirrel.ConcatExpr(
+			"WITH removed AS (", removed.Suffix("RETURNING id"), "), ",
+			"future AS (DELETE FROM courier_shifts WHERE courier_id IN (SELECT id FROM removed) AND started_at IS NULL), ",
+			"running AS (UPDATE courier_shifts SET ends_at = LEAST(ends_at, NOW()), finished_at = NOW(), updated_at = NOW() ",
+			"WHERE courier_id IN (SELECT id FROM removed) AND started_at IS NOT NULL AND finished_at IS NULL)",
+		)).
+		From("removed").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+
+	var removedID int64
+	if err := r.exec(ctx).QueryRow(ctx, query, args...).Scan(&removedID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return r.missingOrBusy(ctx, id, includeDeleted)
+		}
+		return fmt.Errorf("database error: %w", err)
+	}
+
+	return nil
+}
+
+// missingOrBusy объясняет, почему удаление курьера не затронуло строк:
+// курьера нет или у него есть незавершенные доставки
+func (r *Repository) missingOrBusy(ctx context.Context, id int64, includeDeleted bool) error {
+	builder := r.queryBuilder.
+		Select("1").
+		From("couriers").
+		Where(squirrel.Eq{"id": id})
+	if !includeDeleted {
+		builder = builder.Where(notDeleted)
+	}
+
+	query, args, err := builder.Prefix("SELECT EXISTS (").Suffix(")").ToSql()
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+
+	var exists bool
+	if err := r.exec(ctx).QueryRow(ctx, query, args...).Scan(&exists); err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	if !exists {
+		return courier.ErrCourierNotFound
+	}
+	return courier.ErrHasActiveDeliveries
+}
+
+// CountActiveDeliveries возвращает количество незавершенных доставок курьера
+func (r *Repository) CountActiveDeliveries(ctx context.Context, id int64) (int64, error) {
+	query, args, err := r.queryBuilder.
+		Select("COUNT(*)").
+		From("delivery").
+		Where(squirrel.Eq{
+			"courier_id": id,
+			"status":     delivery.ActiveStatuses(),
+			"deleted_at": nil,
+		}).
+		ToSql()
+
+	if err != nil {
+		return 0, fmt.Errorf("build query: %w", err)
+	}
+
+	var count int64
+	if err := r.exec(ctx).QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("database error: %w", err)
+	}
+	return count, nil
+}
+
+// availableQuery - общая часть выборки курьеров, которым можно назначить доставку
+func (r *Repository) availableQuery(filter courier.AvailableFilter) squirrel.SelectBuilder {
+	builder := r.queryBuilder.
+		Select().
 		From("couriers c").
 		LeftJoin("delivery d ON d.courier_id = c.id").
-		Where(squirrel.Eq{"c.status": "available"}).
-		GroupBy(
+		Where(squirrel.Eq{"c.status": assignableStatuses, "c.deactivated_at": nil, "c.deleted_at": nil}).
+		Where(onShift).
+		Having(underCapacity(filter.Capacity))
+
+	if len(filter.ZoneIDs) > 0 {
+		builder = builder.Where(inZones(filter.ZoneIDs))
+	}
+
+	if len(filter.Transports) > 0 {
+		builder = builder.Where(squirrel.Eq{"c.transport_type": filter.Transports})
+	}
+
+	if len(filter.ExcludeIDs) > 0 {
+		builder = builder.Where(squirrel.NotEq{"c.id": filter.ExcludeIDs})
+	}
+
+	return builder
+}
+
+// ListAvailableWithDeliveries возвращает курьеров на смене со свободным местом в порядке загрузки
+func (r *Repository) ListAvailableWithDeliveries(ctx context.Context, filter courier.AvailableFilter) ([]courier.Candidate, error) {
+	query, args, err := r.availableQuery(filter).
+		Columns(
 			"c.id",
 			"c.name",
 			"c.phone",
 			"c.status",
 			"c.transport_type",
+			"c.latitude",
+			"c.longitude",
+			"c.location_updated_at",
 			"c.created_at",
 			"c.updated_at",
+			"COUNT(d.id)",
 		).
-		OrderBy("COUNT(d.id) ASC").
-		Limit(1).
+		Column(activeDeliveries()).
+		GroupBy("c.id").
+		OrderByClause(activeDeliveries()).
+		OrderBy("COUNT(d.id) ASC", "c.id ASC").
 		ToSql()
 
 	if err != nil {
 		return nil, fmt.Errorf("build query: %w", err)
 	}
 
-	var courierData courier.Courier
-	err = r.pool.QueryRow(ctx, query, args...).Scan(
-		&courierData.ID,
-		&courierData.Name,
-		&courierData.Phone,
-		&courierData.Status,
-		&courierData.TransportType,
-		&courierData.CreatedAt,
-		&courierData.UpdatedAt,
-	)
+	rows, err := r.exec(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer rows.Close()
+
+	candidates := make([]courier.Candidate, 0)
+	for rows.Next() {
+		var (
+			candidate         courier.Candidate
+			lat, lon          *float64
+			locationUpdatedAt *time.Time
+		)
+		err := rows.Scan(
+			&candidate.Courier.ID,
+			&candidate.Courier.Name,
+			&candidate.Courier.Phone,
+			&candidate.Courier.Status,
+			&candidate.Courier.TransportType,
+			&lat,
+			&lon,
+			&locationUpdatedAt,
+			&candidate.Courier.CreatedAt,
+			&candidate.Courier.UpdatedAt,
+			&candidate.Deliveries,
+			&candidate.Active,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error reading data: %w", err)
+		}
+		candidate.Courier.Location = toLocation(lat, lon, locationUpdatedAt)
+		candidates = append(candidates, candidate)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return candidates, nil
+}
+
+// LockAssignable блокирует строку курьера до конца транзакции, если курьера еще можно назначить на заказ.
+// Кандидаты выбираются без блокировки, поэтому перед созданием доставки курьер перечитывается под замком:
+// параллельное назначение дождется коммита и увидит уже созданную доставку.
+func (r *Repository) LockAssignable(ctx context.Context, id int64) (*courier.Courier, error) {
+	query, args, err := r.queryBuilder.
+		Select(courierColumns...).
+		From("couriers c").
+		Where(squirrel.Eq{"id": id, "status": assignableStatuses, "deactivated_at": nil}).
+		Where(notDeleted).
+		Where(onShift).
+		Suffix("FOR UPDATE").
+		ToSql()
 
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	courierData, err := scanCourierWithLocation(r.exec(ctx).QueryRow(ctx, query, args...))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, courier.ErrNoAvailableCouriers
+			return nil, courier.ErrCourierNotFound
 		}
 		return nil, fmt.Errorf("database error: %w", err)
 	}
 
-	return &courierData, nil
+	return courierData, nil
 }
 
-func (r *Repository) UpdateStatusBatch(ctx context.Context, ids []int64, status courier.CourierStatus) error {
+// UpdateStatusFrom меняет статус курьера, только если текущий статус равен from.
+// Возвращает false, если статус уже был другим или курьер удален.
+func (r *Repository) UpdateStatusFrom(ctx context.Context, id int64, from, to courier.CourierStatus) (bool, error) {
+	query, args, err := r.queryBuilder.
+		Update("couriers").
+		Set("status", to).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Set("version", nextVersion).
+		Where(squirrel.Eq{"id": id, "status": from}).
+		Where(notDeleted).
+		ToSql()
+
+	if err != nil {
+		return false, fmt.Errorf("build query: %w", err)
+	}
+
+	result, err := r.exec(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("database error: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// UpdateStatusFromBatch меняет статус тех курьеров из ids, у которых текущий статус равен from.
+// Удаленные курьеры не меняются. Возвращает id курьеров, чей статус изменился.
+func (r *Repository) UpdateStatusFromBatch(ctx context.Context, ids []int64, from, to courier.CourierStatus) ([]int64, error) {
 	if len(ids) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	query, args, err := r.queryBuilder.
 		Update("couriers").
-		Set("status", status).
+		Set("status", to).
 		Set("updated_at", squirrel.Expr("NOW()")).
-		Where(squirrel.Eq{"id": ids}).
+		Set("version", nextVersion).
+		Where(squirrel.Eq{"id": ids, "status": from}).
+		Where(notDeleted).
+		Suffix("RETURNING id").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	rows, err := r.exec(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer rows.Close()
+
+	updated := make([]int64, 0, len(ids))
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("error reading data: %w", err)
+		}
+		updated = append(updated, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return updated, nil
+}
+
+func (r *Repository) UpdateLocation(ctx context.Context, id int64, point geo.Point) error {
+	query, args, err := r.queryBuilder.
+		Update("couriers").
+		Set("latitude", point.Lat).
+		Set("longitude", point.Lon).
+		Set("location_updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": id}).
+		Where(notDeleted).
 		ToSql()
 
 	if err != nil {
 		return fmt.Errorf("build query: %w", err)
 	}
 
-	_, err = r.pool.Exec(ctx, query, args...)
+	result, err := r.exec(ctx).Exec(ctx, query, args...)
 	if err != nil {
-		return fmt.Errorf("update status batch: %w", err)
+		return fmt.Errorf("database error: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return courier.ErrCourierNotFound
 	}
 
 	return nil
 }
+
+func scanCourierWithLocation(row pgx.Row) (*courier.Courier, error) {
+	var (
+		courierData       courier.Courier
+		lat, lon          *float64
+		locationUpdatedAt *time.Time
+	)
+	err := row.Scan(
+		&courierData.ID,
+		&courierData.Name,
+		&courierData.Phone,
+		&courierData.Status,
+		&courierData.TransportType,
+		&lat,
+		&lon,
+		&locationUpdatedAt,
+		&courierData.CreatedAt,
+		&courierData.UpdatedAt,
+		&courierData.Version,
+		&courierData.DeactivatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	courierData.Location = toLocation(lat, lon, locationUpdatedAt)
+	return &courierData, nil
+}
+
+func toLocation(lat, lon *float64, updatedAt *time.Time) *courier.Location {
+	if lat == nil || lon == nil || updatedAt == nil {
+		return nil
+	}
+	return &courier.Location{
+		Point:     geo.Point{Lat: *lat, Lon: *lon},
+		UpdatedAt: *updatedAt,
+	}
+}
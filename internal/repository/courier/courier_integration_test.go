@@ -2,21 +2,37 @@ package courier_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/avito-tech/go-transaction-manager/trm/v2/manager"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"service-courier/internal/integration"
 	model "service-courier/internal/model/courier"
+	modelDelivery "service-courier/internal/model/delivery"
+	modelZone "service-courier/internal/model/zone"
+	"service-courier/internal/pkg/geo"
 	courierRepo "service-courier/internal/repository/courier"
+	deliveryRepo "service-courier/internal/repository/delivery"
+	zoneRepo "service-courier/internal/repository/zone"
 )
 
+var available = model.AvailableFilter{Capacity: model.Capacity{
+	model.TransportOnFoot:  1,
+	model.TransportScooter: 2,
+	model.TransportCar:     3,
+}}
+
 func TestCourierRepository_Create(t *testing.T) {
 	pool, cleanup := integration.SetupTestDB(t)
 	defer cleanup()
 
-	repo := courierRepo.NewCourierRepository(pool)
+	repo := courierRepo.NewCourierRepository(pool, trmpgx.DefaultCtxGetter)
 	ctx := context.Background()
 
 	courierData := model.Courier{
@@ -43,7 +59,7 @@ func TestCourierRepository_Create_DuplicatePhone(t *testing.T) {
 	pool, cleanup := integration.SetupTestDB(t)
 	defer cleanup()
 
-	repo := courierRepo.NewCourierRepository(pool)
+	repo := courierRepo.NewCourierRepository(pool, trmpgx.DefaultCtxGetter)
 	ctx := context.Background()
 
 	courierData := model.Courier{
@@ -63,11 +79,87 @@ func TestCourierRepository_Create_DuplicatePhone(t *testing.T) {
 	assert.ErrorIs(t, err, model.ErrPhoneExists)
 }
 
+func TestCourierRepository_CreateBatch(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
+
+	repo := courierRepo.NewCourierRepository(pool, trmpgx.DefaultCtxGetter)
+	ctx := context.Background()
+
+	existingID, err := repo.Create(ctx, model.Courier{
+		Name:          "Ivan",
+		Phone:         "+79990000001",
+		Status:        model.StatusAvailable,
+		TransportType: model.TransportCar,
+	})
+	require.NoError(t, err)
+
+	couriers := []model.Courier{
+		{Name: "Anna", Phone: "+79990000002", Status: model.StatusAvailable, TransportType: model.TransportOnFoot},
+		{Name: "Petr", Phone: "+79990000003", Status: model.StatusPaused, TransportType: model.TransportScooter},
+	}
+
+	existing, err := repo.ExistingPhones(ctx, []string{"+79990000001", "+79990000002"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"+79990000001"}, existing)
+
+	ids, err := repo.CreateBatch(ctx, couriers)
+	require.NoError(t, err)
+	require.Len(t, ids, 2)
+
+	for i, id := range ids {
+		created, err := repo.GetByID(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, couriers[i].Phone, created.Phone)
+		assert.NotEqual(t, existingID, id)
+	}
+
+	// Занятый телефон отменяет всю пачку
+	_, err = repo.CreateBatch(ctx, []model.Courier{
+		{Name: "Oleg", Phone: "+79990000004", Status: model.StatusAvailable, TransportType: model.TransportCar},
+		{Name: "Ivan", Phone: "+79990000001", Status: model.StatusAvailable, TransportType: model.TransportCar},
+	})
+	assert.ErrorIs(t, err, model.ErrPhoneExists)
+
+	existing, err = repo.ExistingPhones(ctx, []string{"+79990000004"})
+	require.NoError(t, err)
+	assert.Empty(t, existing)
+}
+
+func TestCourierRepository_CreateBatchSkipTaken(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
+
+	repo := courierRepo.NewCourierRepository(pool, trmpgx.DefaultCtxGetter)
+	ctx := context.Background()
+
+	_, err := repo.Create(ctx, model.Courier{
+		Name:          "Ivan",
+		Phone:         "+79990000001",
+		Status:        model.StatusAvailable,
+		TransportType: model.TransportCar,
+	})
+	require.NoError(t, err)
+
+	// занятый телефон пропускается, остальные курьеры создаются
+	ids, err := repo.CreateBatchSkipTaken(ctx, []model.Courier{
+		{Name: "Oleg", Phone: "+79990000004", Status: model.StatusAvailable, TransportType: model.TransportCar},
+		{Name: "Ivan", Phone: "+79990000001", Status: model.StatusAvailable, TransportType: model.TransportCar},
+	})
+	require.NoError(t, err)
+	require.Len(t, ids, 2)
+	assert.Zero(t, ids[1])
+
+	created, err := repo.GetByID(ctx, ids[0])
+	require.NoError(t, err)
+	assert.Equal(t, "+79990000004", created.Phone)
+}
+
 func TestCourierRepository_GetByID(t *testing.T) {
 	pool, cleanup := integration.SetupTestDB(t)
 	defer cleanup()
 
-	repo := courierRepo.NewCourierRepository(pool)
+	repo := courierRepo.NewCourierRepository(pool, trmpgx.DefaultCtxGetter)
 	ctx := context.Background()
 
 	// Создаем курьера
@@ -97,7 +189,7 @@ func TestCourierRepository_GetByID_NotFound(t *testing.T) {
 	pool, cleanup := integration.SetupTestDB(t)
 	defer cleanup()
 
-	repo := courierRepo.NewCourierRepository(pool)
+	repo := courierRepo.NewCourierRepository(pool, trmpgx.DefaultCtxGetter)
 	ctx := context.Background()
 
 	result, err := repo.GetByID(ctx, 99999)
@@ -106,60 +198,87 @@ func TestCourierRepository_GetByID_NotFound(t *testing.T) {
 	assert.Nil(t, result)
 }
 
-func TestCourierRepository_GetAll(t *testing.T) {
+func TestCourierRepository_List(t *testing.T) {
 	pool, cleanup := integration.SetupTestDB(t)
 	defer cleanup()
 
-	repo := courierRepo.NewCourierRepository(pool)
+	repo := courierRepo.NewCourierRepository(pool, trmpgx.DefaultCtxGetter)
+	zones := zoneRepo.NewZoneRepository(pool, trmpgx.DefaultCtxGetter)
 	ctx := context.Background()
 
-	// Создаем несколько курьеров
-	courier1 := model.Courier{
-		Name:          "Ivan",
-		Phone:         "+78005553535",
-		Status:        model.StatusAvailable,
-		TransportType: model.TransportCar,
-	}
-	courier2 := model.Courier{
-		Name:          "Petr",
-		Phone:         "+78005553536",
-		Status:        model.StatusBusy,
-		TransportType: model.TransportScooter,
+	ids := make(map[string]int64)
+	for i, c := range []model.Courier{
+		{Name: "Petr", Status: model.StatusAvailable, TransportType: model.TransportCar},
+		{Name: "Anna", Status: model.StatusBusy, TransportType: model.TransportScooter},
+		{Name: "Ivan", Status: model.StatusAvailable, TransportType: model.TransportOnFoot},
+		{Name: "Ivan", Status: model.StatusPaused, TransportType: model.TransportCar},
+		{Name: "100%", Status: model.StatusAvailable, TransportType: model.TransportCar},
+	} {
+		c.Phone = fmt.Sprintf("+7800555353%d", i)
+		id, err := repo.Create(ctx, c)
+		require.NoError(t, err)
+		ids[fmt.Sprintf("%s-%d", c.Name, i)] = id
 	}
 
-	id1, err := repo.Create(ctx, courier1)
+	square := geo.Polygon{{
+		{Lat: 0, Lon: 0},
+		{Lat: 0, Lon: 1},
+		{Lat: 1, Lon: 1},
+		{Lat: 0, Lon: 0},
+	}}
+	zoneID, err := zones.Create(ctx, modelZone.Zone{Name: "center", Polygon: square})
 	require.NoError(t, err)
+	require.NoError(t, zones.SetCourierZones(ctx, ids["Petr-0"], []int64{zoneID}))
 
-	id2, err := repo.Create(ctx, courier2)
+	// по имени, равные имена - по id; страница продолжается после курсора
+	filter := model.ListFilter{Sort: model.SortByName, Limit: 2}
+	first, err := repo.List(ctx, filter)
 	require.NoError(t, err)
+	require.Len(t, first, 2)
+	assert.Equal(t, ids["100%-4"], first[0].ID)
+	assert.Equal(t, ids["Anna-1"], first[1].ID)
 
-	// Получаем всех курьеров
-	results, err := repo.GetAll(ctx)
+	filter.After = &model.Cursor{Name: first[1].Name, ID: first[1].ID}
+	second, err := repo.List(ctx, filter)
 	require.NoError(t, err)
-	assert.GreaterOrEqual(t, len(results), 2)
+	require.Len(t, second, 2)
+	assert.Equal(t, ids["Ivan-2"], second[0].ID)
+	assert.Equal(t, ids["Ivan-3"], second[1].ID)
 
-	// Проверяем, что созданные курьеры есть в списке
-	found1 := false
-	found2 := false
-	for _, c := range results {
-		if c.ID == id1 {
-			found1 = true
-			assert.Equal(t, courier1.Name, c.Name)
-		}
-		if c.ID == id2 {
-			found2 = true
-			assert.Equal(t, courier2.Name, c.Name)
-		}
+	byStatus := model.ListFilter{
+		Statuses:   []model.CourierStatus{model.StatusAvailable},
+		Transports: []model.TransportType{model.TransportCar},
 	}
-	assert.True(t, found1, "courier 1 should be found")
-	assert.True(t, found2, "courier 2 should be found")
+	total, err := repo.Count(ctx, byStatus)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+
+	// поиск без учета регистра по имени и по телефону, % ищется буквально
+	search, err := repo.List(ctx, model.ListFilter{Search: "aNN"})
+	require.NoError(t, err)
+	require.Len(t, search, 1)
+	assert.Equal(t, ids["Anna-1"], search[0].ID)
+
+	search, err = repo.List(ctx, model.ListFilter{Search: "%"})
+	require.NoError(t, err)
+	require.Len(t, search, 1)
+	assert.Equal(t, ids["100%-4"], search[0].ID)
+
+	search, err = repo.List(ctx, model.ListFilter{Search: "5353"})
+	require.NoError(t, err)
+	assert.Len(t, search, 5)
+
+	inZone, err := repo.List(ctx, model.ListFilter{ZoneID: &zoneID, Desc: true})
+	require.NoError(t, err)
+	require.Len(t, inZone, 1)
+	assert.Equal(t, ids["Petr-0"], inZone[0].ID)
 }
 
 func TestCourierRepository_Update(t *testing.T) {
 	pool, cleanup := integration.SetupTestDB(t)
 	defer cleanup()
 
-	repo := courierRepo.NewCourierRepository(pool)
+	repo := courierRepo.NewCourierRepository(pool, trmpgx.DefaultCtxGetter)
 	ctx := context.Background()
 
 	// Создаем курьера
@@ -193,11 +312,68 @@ func TestCourierRepository_Update(t *testing.T) {
 	assert.EqualValues(t, courierData.TransportType, result.TransportType)
 }
 
+func TestCourierRepository_Replace(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
+
+	repo := courierRepo.NewCourierRepository(pool, trmpgx.DefaultCtxGetter)
+	ctx := context.Background()
+
+	id, err := repo.Create(ctx, model.Courier{
+		Name:          "Ivan",
+		Phone:         "+78005553535",
+		Status:        model.StatusAvailable,
+		TransportType: model.TransportCar,
+	})
+	require.NoError(t, err)
+	require.NoError(t, repo.UpdateLocation(ctx, id, geo.Point{Lat: 55.75, Lon: 37.61}))
+
+	current, err := repo.GetByID(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), current.Version, "позиция не меняет версию")
+
+	// Назначение доставки меняет статус и версию - патч по старой версии отклоняется
+	changed, err := repo.UpdateStatusFrom(ctx, id, model.StatusAvailable, model.StatusBusy)
+	require.NoError(t, err)
+	require.True(t, changed)
+
+	stale := *current
+	stale.Status = model.StatusAvailable
+	_, err = repo.Replace(ctx, stale, false)
+	assert.ErrorIs(t, err, model.ErrVersionMismatch)
+
+	fresh, err := repo.GetByID(ctx, id)
+	require.NoError(t, err)
+	assert.EqualValues(t, model.StatusBusy, fresh.Status)
+	assert.Equal(t, int64(2), fresh.Version)
+
+	// Патч без location не затирает позицию, пришедшую после чтения курьера
+	require.NoError(t, repo.UpdateLocation(ctx, id, geo.Point{Lat: 55.76, Lon: 37.62}))
+	fresh.Name = "Ivan Petrov"
+	updated, err := repo.Replace(ctx, *fresh, false)
+	require.NoError(t, err)
+	require.NotNil(t, updated.Location)
+	assert.Equal(t, geo.Point{Lat: 55.76, Lon: 37.62}, updated.Location.Point)
+	assert.Equal(t, int64(3), updated.Version)
+
+	updated.Location = nil
+	updated, err = repo.Replace(ctx, *updated, true)
+	require.NoError(t, err)
+	assert.Equal(t, "Ivan Petrov", updated.Name)
+	assert.Nil(t, updated.Location)
+	assert.Equal(t, int64(4), updated.Version)
+
+	missing := *updated
+	missing.ID = 999999
+	_, err = repo.Replace(ctx, missing, false)
+	assert.ErrorIs(t, err, model.ErrCourierNotFound)
+}
+
 func TestCourierRepository_Update_NotFound(t *testing.T) {
 	pool, cleanup := integration.SetupTestDB(t)
 	defer cleanup()
 
-	repo := courierRepo.NewCourierRepository(pool)
+	repo := courierRepo.NewCourierRepository(pool, trmpgx.DefaultCtxGetter)
 	ctx := context.Background()
 
 	updatedData := model.Courier{
@@ -211,11 +387,11 @@ func TestCourierRepository_Update_NotFound(t *testing.T) {
 	assert.ErrorIs(t, err, model.ErrCourierNotFound)
 }
 
-func TestCourierRepository_GetAvailableWithMinDeliveries(t *testing.T) {
+func TestCourierRepository_ListAvailableWithDeliveries(t *testing.T) {
 	pool, cleanup := integration.SetupTestDB(t)
 	defer cleanup()
 
-	repo := courierRepo.NewCourierRepository(pool)
+	repo := courierRepo.NewCourierRepository(pool, trmpgx.DefaultCtxGetter)
 	ctx := context.Background()
 
 	// Создаем доступного курьера
@@ -238,19 +414,31 @@ func TestCourierRepository_GetAvailableWithMinDeliveries(t *testing.T) {
 	id2, err := repo.Create(ctx, courier2)
 	require.NoError(t, err)
 
-	// Получаем доступного курьера с минимальным количеством доставок
-	result, err := repo.GetAvailableWithMinDeliveries(ctx)
+	integration.StartShift(t, pool, id1)
+	integration.StartShift(t, pool, id2)
+
+	// Кандидаты без доставок упорядочены по ID
+	candidates, err := repo.ListAvailableWithDeliveries(ctx, available)
 	require.NoError(t, err)
-	assert.NotNil(t, result)
-	assert.True(t, result.ID == id1 || result.ID == id2)
-	assert.EqualValues(t, model.StatusAvailable, result.Status)
+	require.Len(t, candidates, 2)
+	assert.Equal(t, id1, candidates[0].Courier.ID)
+	assert.Equal(t, id2, candidates[1].Courier.ID)
+	assert.EqualValues(t, model.StatusAvailable, candidates[0].Courier.Status)
+
+	// Отказавшийся от заказа курьер исключается из выборки
+	excluded := available
+	excluded.ExcludeIDs = []int64{id1}
+	candidates, err = repo.ListAvailableWithDeliveries(ctx, excluded)
+	require.NoError(t, err)
+	require.Len(t, candidates, 1)
+	assert.Equal(t, id2, candidates[0].Courier.ID)
 }
 
-func TestCourierRepository_GetAvailableWithMinDeliveries_NoAvailable(t *testing.T) {
+func TestCourierRepository_ListAvailableWithDeliveries_NoAvailable(t *testing.T) {
 	pool, cleanup := integration.SetupTestDB(t)
 	defer cleanup()
 
-	repo := courierRepo.NewCourierRepository(pool)
+	repo := courierRepo.NewCourierRepository(pool, trmpgx.DefaultCtxGetter)
 	ctx := context.Background()
 
 	// Создаем только занятого курьера
@@ -263,50 +451,405 @@ func TestCourierRepository_GetAvailableWithMinDeliveries_NoAvailable(t *testing.
 	_, err := repo.Create(ctx, courier)
 	require.NoError(t, err)
 
-	// Пытаемся получить доступного курьера
-	result, err := repo.GetAvailableWithMinDeliveries(ctx)
-	assert.Error(t, err)
-	assert.ErrorIs(t, err, model.ErrNoAvailableCouriers)
-	assert.Nil(t, result)
+	// Пытаемся получить доступных курьеров
+	candidates, err := repo.ListAvailableWithDeliveries(ctx, available)
+	require.NoError(t, err)
+	assert.Empty(t, candidates)
 }
 
-func TestCourierRepository_UpdateStatusBatch(t *testing.T) {
+func TestCourierRepository_ListAvailableWithDeliveries_OffShift(t *testing.T) {
 	pool, cleanup := integration.SetupTestDB(t)
 	defer cleanup()
 
-	repo := courierRepo.NewCourierRepository(pool)
+	repo := courierRepo.NewCourierRepository(pool, trmpgx.DefaultCtxGetter)
 	ctx := context.Background()
 
-	// Создаем несколько курьеров
-	courier1 := model.Courier{
+	// Доступный курьер без текущей смены в назначении не участвует
+	_, err := repo.Create(ctx, model.Courier{
+		Name:          "Ivan",
+		Phone:         "+78005553535",
+		Status:        model.StatusAvailable,
+		TransportType: model.TransportCar,
+	})
+	require.NoError(t, err)
+
+	candidates, err := repo.ListAvailableWithDeliveries(ctx, available)
+	require.NoError(t, err)
+	assert.Empty(t, candidates)
+}
+
+func TestCourierRepository_ListAvailableWithDeliveries_Capacity(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
+
+	repo := courierRepo.NewCourierRepository(pool, trmpgx.DefaultCtxGetter)
+	deliveries := deliveryRepo.NewDeliveryRepository(pool, trmpgx.DefaultCtxGetter)
+	ctx := context.Background()
+
+	walkerID, err := repo.Create(ctx, model.Courier{
 		Name:          "Ivan",
 		Phone:         "+78005553535",
 		Status:        model.StatusBusy,
+		TransportType: model.TransportOnFoot,
+	})
+	require.NoError(t, err)
+	driverID, err := repo.Create(ctx, model.Courier{
+		Name:          "Petr",
+		Phone:         "+78005553536",
+		Status:        model.StatusBusy,
 		TransportType: model.TransportCar,
+	})
+	require.NoError(t, err)
+	integration.StartShift(t, pool, walkerID)
+	integration.StartShift(t, pool, driverID)
+
+	// Пеший курьер заполнен одной доставкой, в машине остается место еще для двух
+	for i, courierID := range []int64{walkerID, driverID} {
+		_, err := deliveries.Create(ctx, modelDelivery.Delivery{
+			CourierID:  courierID,
+			OrderID:    fmt.Sprintf("order-%d", i),
+			AssignedAt: time.Now(),
+			Deadline:   time.Now().Add(30 * time.Minute),
+		})
+		require.NoError(t, err)
 	}
-	courier2 := model.Courier{
+
+	candidates, err := repo.ListAvailableWithDeliveries(ctx, available)
+	require.NoError(t, err)
+	require.Len(t, candidates, 1)
+	assert.Equal(t, driverID, candidates[0].Courier.ID)
+	assert.Equal(t, int64(1), candidates[0].Active)
+
+	active, err := repo.CountActiveDeliveries(ctx, walkerID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), active)
+
+	// С вместимостью машины в одну доставку свободных курьеров не остается
+	candidates, err = repo.ListAvailableWithDeliveries(ctx, model.AvailableFilter{Capacity: model.Capacity{model.TransportCar: 1}})
+	require.NoError(t, err)
+	assert.Empty(t, candidates)
+}
+
+func TestCourierRepository_ListAvailableWithDeliveries_Zones(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
+
+	repo := courierRepo.NewCourierRepository(pool, trmpgx.DefaultCtxGetter)
+	zones := zoneRepo.NewZoneRepository(pool, trmpgx.DefaultCtxGetter)
+	ctx := context.Background()
+
+	square := geo.Polygon{{
+		{Lat: 0, Lon: 0},
+		{Lat: 0, Lon: 1},
+		{Lat: 1, Lon: 1},
+		{Lat: 1, Lon: 0},
+		{Lat: 0, Lon: 0},
+	}}
+	centerID, err := zones.Create(ctx, modelZone.Zone{Name: "center", Polygon: square})
+	require.NoError(t, err)
+	northID, err := zones.Create(ctx, modelZone.Zone{Name: "north", Polygon: square})
+	require.NoError(t, err)
+
+	courierID, err := repo.Create(ctx, model.Courier{
+		Name:          "Ivan",
+		Phone:         "+78005553535",
+		Status:        model.StatusAvailable,
+		TransportType: model.TransportCar,
+	})
+	require.NoError(t, err)
+	integration.StartShift(t, pool, courierID)
+	require.NoError(t, zones.SetCourierZones(ctx, courierID, []int64{northID}))
+
+	filter := available
+	filter.ZoneIDs = []int64{centerID}
+	candidates, err := repo.ListAvailableWithDeliveries(ctx, filter)
+	require.NoError(t, err)
+	assert.Empty(t, candidates)
+
+	filter.ZoneIDs = []int64{centerID, northID}
+	candidates, err = repo.ListAvailableWithDeliveries(ctx, filter)
+	require.NoError(t, err)
+	require.Len(t, candidates, 1)
+	assert.Equal(t, courierID, candidates[0].Courier.ID)
+
+	// фильтр по транспорту
+	filter.Transports = []model.TransportType{model.TransportScooter}
+	candidates, err = repo.ListAvailableWithDeliveries(ctx, filter)
+	require.NoError(t, err)
+	assert.Empty(t, candidates)
+}
+
+func TestCourierRepository_UpdateStatusFrom(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
+
+	repo := courierRepo.NewCourierRepository(pool, trmpgx.DefaultCtxGetter)
+	ctx := context.Background()
+
+	id, err := repo.Create(ctx, model.Courier{
+		Name:          "Ivan",
+		Phone:         "+78005553535",
+		Status:        model.StatusPaused,
+		TransportType: model.TransportCar,
+	})
+	require.NoError(t, err)
+
+	updated, err := repo.UpdateStatusFrom(ctx, id, model.StatusPaused, model.StatusAvailable)
+	require.NoError(t, err)
+	assert.True(t, updated)
+
+	// Повторный переход из paused уже не применяется
+	updated, err = repo.UpdateStatusFrom(ctx, id, model.StatusPaused, model.StatusAvailable)
+	require.NoError(t, err)
+	assert.False(t, updated)
+
+	result, err := repo.GetByID(ctx, id)
+	require.NoError(t, err)
+	assert.EqualValues(t, model.StatusAvailable, result.Status)
+}
+
+func TestCourierRepository_UpdateStatusFromBatch(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
+
+	repo := courierRepo.NewCourierRepository(pool, trmpgx.DefaultCtxGetter)
+	ctx := context.Background()
+
+	busy, err := repo.Create(ctx, model.Courier{
+		Name:          "Ivan",
+		Phone:         "+78005553535",
+		Status:        model.StatusBusy,
+		TransportType: model.TransportCar,
+	})
+	require.NoError(t, err)
+
+	paused, err := repo.Create(ctx, model.Courier{
 		Name:          "Petr",
 		Phone:         "+78005553536",
-		Status:        model.StatusBusy,
+		Status:        model.StatusPaused,
 		TransportType: model.TransportScooter,
-	}
+	})
+	require.NoError(t, err)
 
-	id1, err := repo.Create(ctx, courier1)
+	deleted, err := repo.Create(ctx, model.Courier{
+		Name:          "Oleg",
+		Phone:         "+78005553537",
+		Status:        model.StatusBusy,
+		TransportType: model.TransportOnFoot,
+	})
 	require.NoError(t, err)
+	require.NoError(t, repo.Delete(ctx, deleted))
 
-	id2, err := repo.Create(ctx, courier2)
+	before, err := repo.GetByID(ctx, busy)
 	require.NoError(t, err)
 
-	// Обновляем статусы массово
-	err = repo.UpdateStatusBatch(ctx, []int64{id1, id2}, model.StatusAvailable)
+	// Меняется только занятый курьер - поставленного на паузу и удаленного не трогаем
+	updated, err := repo.UpdateStatusFromBatch(ctx, []int64{busy, paused, deleted}, model.StatusBusy, model.StatusAvailable)
 	require.NoError(t, err)
+	assert.Equal(t, []int64{busy}, updated)
 
-	// Проверяем обновление
-	result1, err := repo.GetByID(ctx, id1)
+	result, err := repo.GetByID(ctx, busy)
 	require.NoError(t, err)
-	assert.EqualValues(t, model.StatusAvailable, result1.Status)
+	assert.EqualValues(t, model.StatusAvailable, result.Status)
+	assert.Equal(t, before.Version+1, result.Version)
+
+	result, err = repo.GetByID(ctx, paused)
+	require.NoError(t, err)
+	assert.EqualValues(t, model.StatusPaused, result.Status)
+}
+
+func TestCourierRepository_JoinsTransaction(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
 
-	result2, err := repo.GetByID(ctx, id2)
+	repo := courierRepo.NewCourierRepository(pool, trmpgx.DefaultCtxGetter)
+	txManager := manager.Must(trmpgx.NewDefaultFactory(pool))
+	ctx := context.Background()
+
+	id, err := repo.Create(ctx, model.Courier{
+		Name:          "Ivan",
+		Phone:         "+78005553535",
+		Status:        model.StatusAvailable,
+		TransportType: model.TransportCar,
+	})
 	require.NoError(t, err)
-	assert.EqualValues(t, model.StatusAvailable, result2.Status)
+
+	// смена статуса внутри транзакции откатывается вместе с ней
+	rollback := errors.New("rollback")
+	err = txManager.Do(ctx, func(ctx context.Context) error {
+		updated, err := repo.UpdateStatusFrom(ctx, id, model.StatusAvailable, model.StatusBusy)
+		require.NoError(t, err)
+		require.True(t, updated)
+		return rollback
+	})
+	require.ErrorIs(t, err, rollback)
+
+	result, err := repo.GetByID(ctx, id)
+	require.NoError(t, err)
+	assert.EqualValues(t, model.StatusAvailable, result.Status)
+}
+
+func TestCourierRepository_UpdateLocation(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
+
+	repo := courierRepo.NewCourierRepository(pool, trmpgx.DefaultCtxGetter)
+	ctx := context.Background()
+
+	id, err := repo.Create(ctx, model.Courier{
+		Name:          "Ivan",
+		Phone:         "+78005553535",
+		Status:        model.StatusAvailable,
+		TransportType: model.TransportCar,
+	})
+	require.NoError(t, err)
+
+	// До первого обновления позиция неизвестна
+	created, err := repo.GetByID(ctx, id)
+	require.NoError(t, err)
+	assert.Nil(t, created.Location)
+
+	point := geo.Point{Lat: 55.7558, Lon: 37.6173}
+	err = repo.UpdateLocation(ctx, id, point)
+	require.NoError(t, err)
+
+	updated, err := repo.GetByID(ctx, id)
+	require.NoError(t, err)
+	require.NotNil(t, updated.Location)
+	assert.Equal(t, point, updated.Location.Point)
+
+	integration.StartShift(t, pool, id)
+
+	// Позиция видна и в списке доступных курьеров
+	candidates, err := repo.ListAvailableWithDeliveries(ctx, available)
+	require.NoError(t, err)
+	require.Len(t, candidates, 1)
+	require.NotNil(t, candidates[0].Courier.Location)
+	assert.Equal(t, point, candidates[0].Courier.Location.Point)
+	assert.Equal(t, int64(0), candidates[0].Deliveries)
+}
+
+func TestCourierRepository_UpdateLocation_NotFound(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
+
+	repo := courierRepo.NewCourierRepository(pool, trmpgx.DefaultCtxGetter)
+	ctx := context.Background()
+
+	err := repo.UpdateLocation(ctx, 999, geo.Point{Lat: 55.7558, Lon: 37.6173})
+	assert.ErrorIs(t, err, model.ErrCourierNotFound)
+}
+
+func TestCourierRepository_DeleteCancelsShifts(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
+
+	repo := courierRepo.NewCourierRepository(pool, trmpgx.DefaultCtxGetter)
+	ctx := context.Background()
+
+	id, err := repo.Create(ctx, model.Courier{
+		Name:          "Ivan",
+		Phone:         "+78005553535",
+		Status:        model.StatusAvailable,
+		TransportType: model.TransportCar,
+	})
+	require.NoError(t, err)
+	integration.StartShift(t, pool, id)
+	_, err = pool.Exec(ctx, `
+INSERT INTO courier_shifts (courier_id, starts_at, ends_at)
+VALUES ($1, NOW() + INTERVAL '1 day', NOW() + INTERVAL '1 day 8 hours')`, id)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Delete(ctx, id))
+
+	// Будущая смена отменена, идущая закрыта - планировщику нечего применять
+	var total, open int
+	err = pool.QueryRow(ctx, `
+SELECT COUNT(*), COUNT(*) FILTER (WHERE finished_at IS NULL OR ends_at > NOW())
+FROM courier_shifts WHERE courier_id = $1`, id).Scan(&total, &open)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, 0, open)
+
+	// Статус удаленного курьера больше не меняется
+	updated, err := repo.UpdateStatusFrom(ctx, id, model.StatusAvailable, model.StatusPaused)
+	require.NoError(t, err)
+	assert.False(t, updated)
+}
+
+func TestCourierRepository_DeactivateDeleteErase(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
+
+	repo := courierRepo.NewCourierRepository(pool, trmpgx.DefaultCtxGetter)
+	deliveries := deliveryRepo.NewDeliveryRepository(pool, trmpgx.DefaultCtxGetter)
+	ctx := context.Background()
+
+	id, err := repo.Create(ctx, model.Courier{
+		Name:          "Ivan",
+		Phone:         "+78005553535",
+		Status:        model.StatusAvailable,
+		TransportType: model.TransportCar,
+	})
+	require.NoError(t, err)
+	integration.StartShift(t, pool, id)
+	require.NoError(t, repo.UpdateLocation(ctx, id, geo.Point{Lat: 55.75, Lon: 37.61}))
+
+	// Деактивированный курьер не назначается, но остается в API
+	require.NoError(t, repo.SetDeactivated(ctx, id, true))
+	candidates, err := repo.ListAvailableWithDeliveries(ctx, available)
+	require.NoError(t, err)
+	assert.Empty(t, candidates)
+
+	deactivated, err := repo.GetByID(ctx, id)
+	require.NoError(t, err)
+	assert.NotNil(t, deactivated.DeactivatedAt)
+
+	require.NoError(t, repo.SetDeactivated(ctx, id, false))
+	candidates, err = repo.ListAvailableWithDeliveries(ctx, available)
+	require.NoError(t, err)
+	assert.Len(t, candidates, 1)
+
+	// С незавершенной доставкой курьера нельзя ни удалить, ни обезличить
+	deliveryID, err := deliveries.Create(ctx, modelDelivery.Delivery{
+		CourierID:  id,
+		OrderID:    "order-1",
+		AssignedAt: time.Now(),
+		Deadline:   time.Now().Add(30 * time.Minute),
+	})
+	require.NoError(t, err)
+	assert.ErrorIs(t, repo.Delete(ctx, id), model.ErrHasActiveDeliveries)
+	assert.ErrorIs(t, repo.Erase(ctx, id), model.ErrHasActiveDeliveries)
+
+	require.NoError(t, deliveries.UpdateStatusByIDs(ctx, []int64{deliveryID}, modelDelivery.StatusCompleted))
+
+	require.NoError(t, repo.Delete(ctx, id))
+	_, err = repo.GetByID(ctx, id)
+	assert.ErrorIs(t, err, model.ErrCourierNotFound)
+	assert.ErrorIs(t, repo.Delete(ctx, id), model.ErrCourierNotFound)
+
+	// Удаленного курьера можно обезличить, его телефон освобождается
+	require.NoError(t, repo.Erase(ctx, id))
+
+	var name, phone string
+	var lat *float64
+	err = pool.QueryRow(ctx, "SELECT name, phone, latitude FROM couriers WHERE id = $1", id).Scan(&name, &phone, &lat)
+	require.NoError(t, err)
+	assert.Equal(t, model.ErasedName, name)
+	assert.Equal(t, fmt.Sprintf("erased-%d", id), phone)
+	assert.Nil(t, lat)
+
+	erasedDelivery, err := deliveries.GetByOrderID(ctx, "order-1")
+	require.NoError(t, err)
+	assert.Equal(t, id, erasedDelivery.CourierID)
+
+	_, err = repo.Create(ctx, model.Courier{
+		Name:          "Petr",
+		Phone:         "+78005553535",
+		Status:        model.StatusAvailable,
+		TransportType: model.TransportCar,
+	})
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, repo.Erase(ctx, 999999), model.ErrCourierNotFound)
 }
@@ -0,0 +1,66 @@
+package cursor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Repository struct {
+	pool         *pgxpool.Pool
+	queryBuilder squirrel.StatementBuilderType
+}
+
+func NewCursorRepository(pool *pgxpool.Pool) *Repository {
+	return &Repository{
+		pool:         pool,
+		queryBuilder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// Get возвращает сохраненную позицию воркера или nil, если воркер еще не запускался
+func (r *Repository) Get(ctx context.Context, name string) (*time.Time, error) {
+	query, args, err := r.queryBuilder.
+		Select("position").
+		From("worker_cursors").
+		Where(squirrel.Eq{"name": name}).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	var position time.Time
+	if err := r.pool.QueryRow(ctx, query, args...).Scan(&position); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return &position, nil
+}
+
+func (r *Repository) Save(ctx context.Context, name string, position time.Time) error {
+	query, args, err := r.queryBuilder.
+		Insert("worker_cursors").
+		Columns("name", "position", "updated_at").
+		Values(name, position.UTC(), squirrel.Expr("NOW()")).
+		Suffix("ON CONFLICT (name) DO UPDATE SET position = EXCLUDED.position, updated_at = EXCLUDED.updated_at").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+
+	if _, err := r.pool.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("save cursor: %w", err)
+	}
+
+	return nil
+}
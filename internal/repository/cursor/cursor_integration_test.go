@@ -0,0 +1,36 @@
+package cursor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"service-courier/internal/integration"
+	cursorRepo "service-courier/internal/repository/cursor"
+)
+
+func TestCursorRepository_GetSave(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
+
+	repo := cursorRepo.NewCursorRepository(pool)
+	ctx := context.Background()
+
+	position, err := repo.Get(ctx, "order_poller")
+	require.NoError(t, err)
+	assert.Nil(t, position)
+
+	first := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.Save(ctx, "order_poller", first))
+
+	second := first.Add(time.Minute)
+	require.NoError(t, repo.Save(ctx, "order_poller", second))
+
+	position, err = repo.Get(ctx, "order_poller")
+	require.NoError(t, err)
+	require.NotNil(t, position)
+	assert.True(t, position.Equal(second))
+}
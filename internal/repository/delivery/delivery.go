@@ -31,14 +31,15 @@ func (r *Repository) exec(ctx context.Context) trmpgx.Tr {
 	return r.getter.DefaultTrOrDB(ctx, r.pool)
 }
 
-func (r *Repository) Create(ctx context.Context, deliveryData delivery.Delivery) error {
+func (r *Repository) Create(ctx context.Context, deliveryData delivery.Delivery) (id int64, err error) {
 	query, args, err := r.queryBuilder.
 		Insert("delivery").
-		Columns("courier_id", "order_id", "status", "assigned_at", "deadline").
+		Columns("courier_id", "order_id", "status", "priority", "assigned_at", "deadline").
 		Values(
 			deliveryData.CourierID,
 			deliveryData.OrderID,
-			delivery.StatusActive,
+			statusOrDefault(deliveryData.Status),
+			priorityOrDefault(deliveryData.Priority),
 			deliveryData.AssignedAt,
 			deliveryData.Deadline,
 		).
@@ -46,20 +47,20 @@ func (r *Repository) Create(ctx context.Context, deliveryData delivery.Delivery)
 		ToSql()
 
 	if err != nil {
-		return fmt.Errorf("build query: %w", err)
+		return 0, fmt.Errorf("build query: %w", err)
 	}
 
-	err = r.exec(ctx).QueryRow(ctx, query, args...).Scan(&deliveryData.ID)
+	err = r.exec(ctx).QueryRow(ctx, query, args...).Scan(&id)
 
 	if err != nil {
-		return fmt.Errorf("database error: %w", err)
+		return 0, fmt.Errorf("database error: %w", err)
 	}
-	return nil
+	return id, nil
 }
 
 func (r *Repository) GetByOrderID(ctx context.Context, orderID string) (*delivery.Delivery, error) {
 	query, args, err := r.queryBuilder.
-		Select("id", "courier_id", "order_id", "status", "assigned_at", "deadline").
+		Select("id", "courier_id", "order_id", "status", "priority", "assigned_at", "deadline").
 		From("delivery").
 		Where(squirrel.And{
 			squirrel.Eq{"order_id": orderID},
@@ -77,6 +78,7 @@ func (r *Repository) GetByOrderID(ctx context.Context, orderID string) (*deliver
 		&deliveryData.CourierID,
 		&deliveryData.OrderID,
 		&deliveryData.Status,
+		&deliveryData.Priority,
 		&deliveryData.AssignedAt,
 		&deliveryData.Deadline,
 	)
@@ -89,12 +91,120 @@ func (r *Repository) GetByOrderID(ctx context.Context, orderID string) (*deliver
 	return &deliveryData, nil
 }
 
+// List возвращает доставки по фильтру с keyset-пагинацией: строки после filter.After
+// в порядке filter.Sort, при равенстве значения - по id
+func (r *Repository) List(ctx context.Context, filter delivery.ListFilter) ([]delivery.Delivery, error) {
+	column := sortColumn(filter.Sort)
+	direction := "ASC"
+	if filter.Desc {
+		direction = "DESC"
+	}
+
+	builder := r.queryBuilder.
+		Select("id", "courier_id", "order_id", "status", "priority", "assigned_at", "deadline", "deleted_at").
+		From("delivery")
+
+	if !filter.IncludeDeleted {
+		builder = builder.Where(squirrel.Eq{"deleted_at": nil})
+	}
+	if filter.OrderID != "" {
+		builder = builder.Where(squirrel.Eq{"order_id": filter.OrderID})
+	}
+	if filter.CourierID != nil {
+		builder = builder.Where(squirrel.Eq{"courier_id": *filter.CourierID})
+	}
+	if len(filter.Statuses) > 0 {
+		builder = builder.Where(squirrel.Eq{"status": filter.Statuses})
+	}
+	if filter.From != nil {
+		builder = builder.Where(squirrel.GtOrEq{"assigned_at": *filter.From})
+	}
+	if filter.To != nil {
+		builder = builder.Where(squirrel.Lt{"assigned_at": *filter.To})
+	}
+	if filter.After != nil {
+		builder = builder.Where(keysetCondition(column, filter.Desc, *filter.After))
+	}
+
+	if column == "id" {
+		builder = builder.OrderBy("id " + direction)
+	} else {
+		builder = builder.OrderBy(column+" "+direction, "id "+direction)
+	}
+	if filter.Limit > 0 {
+		builder = builder.Limit(filter.Limit)
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	rows, err := r.exec(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := make([]delivery.Delivery, 0)
+	for rows.Next() {
+		var d delivery.Delivery
+		err := rows.Scan(
+			&d.ID,
+			&d.CourierID,
+			&d.OrderID,
+			&d.Status,
+			&d.Priority,
+			&d.AssignedAt,
+			&d.Deadline,
+			&d.DeletedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error reading data: %w", err)
+		}
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// sortColumn - колонка для поля сортировки; имя колонки не приходит в запрос от клиента напрямую
+func sortColumn(field delivery.SortField) string {
+	switch field {
+	case delivery.SortByAssignedAt:
+		return "assigned_at"
+	case delivery.SortByDeadline:
+		return "deadline"
+	default:
+		return "id"
+	}
+}
+
+func keysetCondition(column string, desc bool, after delivery.Cursor) squirrel.Sqlizer {
+	op := ">"
+	if desc {
+		op = "<"
+	}
+	if column == "id" {
+		return squirrel.Expr("id "+op+" ?", after.ID)
+	}
+	return squirrel.Expr("("+column+", id) "+op+" (?, ?)", after.At, after.ID)
+}
+
 func (r *Repository) DeleteByOrderID(ctx context.Context, orderID string) error {
 	query, args, err := r.queryBuilder.
 		Update("delivery").
 		Set("deleted_at", squirrel.Expr("NOW()")).
+		Set("finished_at", squirrel.Expr("NOW()")).
 		Set("status", delivery.StatusDeleted).
-		Where(squirrel.Eq{"order_id": orderID}).
+		Where(squirrel.And{
+			squirrel.Eq{"order_id": orderID},
+			squirrel.Eq{"deleted_at": nil},
+			squirrel.Eq{"status": delivery.SourcesOf(delivery.StatusDeleted)},
+		}).
 		ToSql()
 	if err != nil {
 		return fmt.Errorf("build query: %w", err)
@@ -110,13 +220,41 @@ func (r *Repository) DeleteByOrderID(ctx context.Context, orderID string) error
 	return nil
 }
 
+// CloseOffer закрывает доставку, предложенную курьеру, когда тот отказался или не ответил вовремя.
+// Заказ после этого можно предложить другому курьеру.
+func (r *Repository) CloseOffer(ctx context.Context, id int64, status delivery.DeliveryStatus) error {
+	query, args, err := r.queryBuilder.
+		Update("delivery").
+		Set("deleted_at", squirrel.Expr("NOW()")).
+		Set("finished_at", squirrel.Expr("NOW()")).
+		Set("status", status).
+		Where(squirrel.And{
+			squirrel.Eq{"id": id},
+			squirrel.Eq{"deleted_at": nil},
+			squirrel.Eq{"status": delivery.StatusOffered},
+		}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+
+	result, err := r.exec(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("close offer: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return delivery.ErrDeliveryNotFound
+	}
+	return nil
+}
+
 func (r *Repository) ListActiveExpired(ctx context.Context, now time.Time) ([]delivery.Delivery, error) {
 	query, args, err := r.queryBuilder.
-		Select("id", "courier_id", "order_id", "status", "assigned_at", "deadline").
+		Select("id", "courier_id", "order_id", "status", "priority", "assigned_at", "deadline").
 		From("delivery").
 		Where(squirrel.And{
 			squirrel.Lt{"deadline": now},
-			squirrel.Eq{"status": delivery.StatusActive},
+			squirrel.Eq{"status": delivery.ActiveStatuses()},
 			squirrel.Eq{"deleted_at": nil},
 		}).
 		ToSql()
@@ -140,6 +278,7 @@ func (r *Repository) ListActiveExpired(ctx context.Context, now time.Time) ([]de
 			&deliveryData.CourierID,
 			&deliveryData.OrderID,
 			&deliveryData.Status,
+			&deliveryData.Priority,
 			&deliveryData.AssignedAt,
 			&deliveryData.Deadline,
 		)
@@ -155,15 +294,25 @@ func (r *Repository) ListActiveExpired(ctx context.Context, now time.Time) ([]de
 	return expired, nil
 }
 
+// withFinishedAt отмечает время завершения, когда доставка переходит в конечный статус
+func withFinishedAt(builder squirrel.UpdateBuilder, status delivery.DeliveryStatus) squirrel.UpdateBuilder {
+	if status.IsTerminal() {
+		return builder.Set("finished_at", squirrel.Expr("NOW()"))
+	}
+	return builder
+}
+
 func (r *Repository) UpdateStatusByIDs(ctx context.Context, ids []int64, status delivery.DeliveryStatus) error {
 	if len(ids) == 0 {
 		return nil
 	}
 
-	query, args, err := r.queryBuilder.
-		Update("delivery").
+	query, args, err := withFinishedAt(r.queryBuilder.Update("delivery"), status).
 		Set("status", string(status)).
-		Where(squirrel.Eq{"id": ids}).
+		Where(squirrel.And{
+			squirrel.Eq{"id": ids},
+			squirrel.Eq{"status": delivery.SourcesOf(status)},
+		}).
 		ToSql()
 
 	if err != nil {
@@ -177,3 +326,233 @@ func (r *Repository) UpdateStatusByIDs(ctx context.Context, ids []int64, status
 
 	return nil
 }
+
+// UpdateStatus переводит доставку из статуса from в to, если статус не изменился с момента чтения
+// CountActiveByCourierIDs возвращает количество незавершенных доставок у каждого из курьеров.
+// Курьеров без активных доставок в ответе нет.
+func (r *Repository) CountActiveByCourierIDs(ctx context.Context, courierIDs []int64) (map[int64]int64, error) {
+	counts := make(map[int64]int64)
+	if len(courierIDs) == 0 {
+		return counts, nil
+	}
+
+	query, args, err := r.queryBuilder.
+		Select("courier_id", "COUNT(*)").
+		From("delivery").
+		Where(squirrel.And{
+			squirrel.Eq{"courier_id": courierIDs},
+			squirrel.Eq{"status": delivery.ActiveStatuses()},
+			squirrel.Eq{"deleted_at": nil},
+		}).
+		GroupBy("courier_id").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	rows, err := r.exec(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var courierID, count int64
+		if err := rows.Scan(&courierID, &count); err != nil {
+			return nil, fmt.Errorf("scan active count: %w", err)
+		}
+		counts[courierID] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return counts, nil
+}
+
+func (r *Repository) UpdateStatus(ctx context.Context, id int64, from, to delivery.DeliveryStatus) error {
+	query, args, err := withFinishedAt(r.queryBuilder.Update("delivery"), to).
+		Set("status", string(to)).
+		Where(squirrel.And{
+			squirrel.Eq{"id": id},
+			squirrel.Eq{"status": string(from)},
+			squirrel.Eq{"deleted_at": nil},
+		}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+
+	result, err := r.exec(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("update status: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%w: status changed concurrently", delivery.ErrInvalidTransition)
+	}
+
+	return nil
+}
+
+func (r *Repository) CreateEvents(ctx context.Context, events []delivery.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	insert := r.queryBuilder.
+		Insert("delivery_events").
+		Columns("delivery_id", "order_id", "courier_id", "from_status", "to_status", "actor", "reason", "created_at")
+
+	for _, e := range events {
+		var fromStatus *string
+		if e.FromStatus != "" {
+			from := string(e.FromStatus)
+			fromStatus = &from
+		}
+		insert = insert.Values(
+			e.DeliveryID,
+			e.OrderID,
+			e.CourierID,
+			fromStatus,
+			string(e.ToStatus),
+			string(e.Actor),
+			e.Reason,
+			e.CreatedAt,
+		)
+	}
+
+	query, args, err := insert.ToSql()
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+
+	if _, err := r.exec(ctx).Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("insert events: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) ListEventsByOrderID(ctx context.Context, orderID string) ([]delivery.Event, error) {
+	query, args, err := r.selectEvents().
+		Where(squirrel.Eq{"order_id": orderID}).
+		OrderBy("created_at ASC", "id ASC").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	return r.queryEvents(ctx, query, args)
+}
+
+// eventsCommitted отсекает события транзакций, которые начались не раньше самой старой незавершенной:
+// пока она открыта, ее события с меньшим id еще не видны, и курсор не должен уйти вперед них
+const eventsCommitted = "tx_id < pg_snapshot_xmin(pg_current_snapshot())"
+
+// eventsAfter - события после события filter.AfterID в порядке транзакций. Если такого события нет,
+// отсчет идет от ближайшего предыдущего.
+const eventsAfter = `(tx_id, id) > (
+	(SELECT a.tx_id, a.id FROM delivery_events a WHERE a.id <= ? ORDER BY a.id DESC LIMIT 1)
+	UNION ALL
+	SELECT '0'::xid8, 0
+	ORDER BY 2 DESC
+	LIMIT 1
+)`
+
+// ListEventsAfter возвращает события заказа или курьера после события filter.AfterID в порядке записи.
+// События незавершенных транзакций не возвращаются, поэтому продолжение с последнего полученного id
+// не пропускает события, закоммиченные позже событий с большим id.
+func (r *Repository) ListEventsAfter(ctx context.Context, filter delivery.EventFilter) ([]delivery.Event, error) {
+	builder := r.selectEvents().
+		Where(eventsCommitted).
+		OrderBy("tx_id ASC", "id ASC")
+
+	if filter.AfterID > 0 {
+		builder = builder.Where(squirrel.Expr(eventsAfter, filter.AfterID))
+	}
+
+	if filter.OrderID != "" {
+		builder = builder.Where(squirrel.Eq{"order_id": filter.OrderID})
+	}
+	if filter.CourierID != 0 {
+		builder = builder.Where(squirrel.Eq{"courier_id": filter.CourierID})
+	}
+	if filter.Limit > 0 {
+		builder = builder.Limit(filter.Limit)
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	return r.queryEvents(ctx, query, args)
+}
+
+func (r *Repository) selectEvents() squirrel.SelectBuilder {
+	return r.queryBuilder.
+		Select(
+			"id",
+			"delivery_id",
+			"order_id",
+			"courier_id",
+			"COALESCE(from_status, '')",
+			"to_status",
+			"actor",
+			"reason",
+			"created_at",
+		).
+		From("delivery_events")
+}
+
+func (r *Repository) queryEvents(ctx context.Context, query string, args []interface{}) ([]delivery.Event, error) {
+	rows, err := r.exec(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]delivery.Event, 0)
+	for rows.Next() {
+		var e delivery.Event
+		err := rows.Scan(
+			&e.ID,
+			&e.DeliveryID,
+			&e.OrderID,
+			&e.CourierID,
+			&e.FromStatus,
+			&e.ToStatus,
+			&e.Actor,
+			&e.Reason,
+			&e.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error reading data: %w", err)
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return events, nil
+}
+
+func statusOrDefault(s delivery.DeliveryStatus) delivery.DeliveryStatus {
+	if s == "" {
+		return delivery.StatusAssigned
+	}
+	return s
+}
+
+func priorityOrDefault(p delivery.Priority) delivery.Priority {
+	if p == "" {
+		return delivery.PriorityStandard
+	}
+	return p
+}
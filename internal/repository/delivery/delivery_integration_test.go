@@ -25,7 +25,7 @@ func TestDeliveryRepository_Create(t *testing.T) {
 
 	ctxGetter := trmpgx.DefaultCtxGetter
 	repo := deliveryRepo.NewDeliveryRepository(pool, ctxGetter)
-	courierRepo := courier.NewCourierRepository(pool)
+	courierRepo := courier.NewCourierRepository(pool, ctxGetter)
 	ctx := context.Background()
 
 	// Создаем курьера
@@ -46,7 +46,7 @@ func TestDeliveryRepository_Create(t *testing.T) {
 		Deadline:   time.Now().Add(30 * time.Minute),
 	}
 
-	err = repo.Create(ctx, deliveryData)
+	_, err = repo.Create(ctx, deliveryData)
 	require.NoError(t, err)
 
 	// Проверяем, что доставка создана
@@ -55,7 +55,7 @@ func TestDeliveryRepository_Create(t *testing.T) {
 	assert.Greater(t, result.ID, int64(0))
 	assert.Equal(t, deliveryData.OrderID, result.OrderID)
 	assert.Equal(t, deliveryData.CourierID, result.CourierID)
-	assert.EqualValues(t, modelDelivery.StatusActive, result.Status)
+	assert.EqualValues(t, modelDelivery.StatusAssigned, result.Status)
 }
 
 func TestDeliveryRepository_GetByOrderID(t *testing.T) {
@@ -64,7 +64,7 @@ func TestDeliveryRepository_GetByOrderID(t *testing.T) {
 
 	ctxGetter := trmpgx.DefaultCtxGetter
 	repo := deliveryRepo.NewDeliveryRepository(pool, ctxGetter)
-	courierRepo := courier.NewCourierRepository(pool)
+	courierRepo := courier.NewCourierRepository(pool, ctxGetter)
 	ctx := context.Background()
 
 	// Создаем курьера и доставку
@@ -83,7 +83,7 @@ func TestDeliveryRepository_GetByOrderID(t *testing.T) {
 		AssignedAt: time.Now(),
 		Deadline:   time.Now().Add(30 * time.Minute),
 	}
-	err = repo.Create(ctx, deliveryData)
+	_, err = repo.Create(ctx, deliveryData)
 	require.NoError(t, err)
 
 	// Получаем доставку
@@ -116,7 +116,7 @@ func TestDeliveryRepository_DeleteByOrderID(t *testing.T) {
 
 	ctxGetter := trmpgx.DefaultCtxGetter
 	repo := deliveryRepo.NewDeliveryRepository(pool, ctxGetter)
-	courierRepo := courier.NewCourierRepository(pool)
+	courierRepo := courier.NewCourierRepository(pool, ctxGetter)
 	ctx := context.Background()
 
 	// Создаем курьера и доставку
@@ -135,7 +135,7 @@ func TestDeliveryRepository_DeleteByOrderID(t *testing.T) {
 		AssignedAt: time.Now(),
 		Deadline:   time.Now().Add(30 * time.Minute),
 	}
-	err = repo.Create(ctx, deliveryData)
+	_, err = repo.Create(ctx, deliveryData)
 	require.NoError(t, err)
 
 	// Удаляем доставку
@@ -162,13 +162,66 @@ func TestDeliveryRepository_DeleteByOrderID_NotFound(t *testing.T) {
 	assert.ErrorIs(t, err, modelDelivery.ErrDeliveryNotFound)
 }
 
+func TestDeliveryRepository_CloseOffer(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
+
+	ctxGetter := trmpgx.DefaultCtxGetter
+	repo := deliveryRepo.NewDeliveryRepository(pool, ctxGetter)
+	courierRepo := courier.NewCourierRepository(pool, ctxGetter)
+	ctx := context.Background()
+
+	courierID, err := courierRepo.Create(ctx, modelCourier.Courier{
+		Name:          "Ivan",
+		Phone:         "+78005553535",
+		Status:        modelCourier.StatusAvailable,
+		TransportType: modelCourier.TransportCar,
+	})
+	require.NoError(t, err)
+
+	orderID := "f819526d-6a7c-48eb-b535-43989469d1ca"
+	offeredID, err := repo.Create(ctx, modelDelivery.Delivery{
+		CourierID:  courierID,
+		OrderID:    orderID,
+		Status:     modelDelivery.StatusOffered,
+		AssignedAt: time.Now(),
+		Deadline:   time.Now().Add(30 * time.Minute),
+	})
+	require.NoError(t, err)
+
+	offered, err := repo.GetByOrderID(ctx, orderID)
+	require.NoError(t, err)
+	assert.Equal(t, modelDelivery.DeliveryStatus(modelDelivery.StatusOffered), offered.Status)
+
+	require.NoError(t, repo.CloseOffer(ctx, offeredID, modelDelivery.StatusDeclined))
+
+	// Закрытое предложение не мешает предложить заказ снова
+	_, err = repo.GetByOrderID(ctx, orderID)
+	assert.ErrorIs(t, err, modelDelivery.ErrDeliveryNotFound)
+
+	err = repo.CloseOffer(ctx, offeredID, modelDelivery.StatusDeclined)
+	assert.ErrorIs(t, err, modelDelivery.ErrDeliveryNotFound)
+
+	// Принятый заказ закрыть как предложение нельзя
+	acceptedID, err := repo.Create(ctx, modelDelivery.Delivery{
+		CourierID:  courierID,
+		OrderID:    orderID,
+		AssignedAt: time.Now(),
+		Deadline:   time.Now().Add(30 * time.Minute),
+	})
+	require.NoError(t, err)
+
+	err = repo.CloseOffer(ctx, acceptedID, modelDelivery.StatusDeclined)
+	assert.ErrorIs(t, err, modelDelivery.ErrDeliveryNotFound)
+}
+
 func TestDeliveryRepository_ListActiveExpired(t *testing.T) {
 	pool, cleanup := integration.SetupTestDB(t)
 	defer cleanup()
 
 	ctxGetter := trmpgx.DefaultCtxGetter
 	repo := deliveryRepo.NewDeliveryRepository(pool, ctxGetter)
-	courierRepo := courier.NewCourierRepository(pool)
+	courierRepo := courier.NewCourierRepository(pool, ctxGetter)
 	ctx := context.Background()
 
 	// Создаем курьера
@@ -190,7 +243,7 @@ func TestDeliveryRepository_ListActiveExpired(t *testing.T) {
 		AssignedAt: baseTime.Add(-2 * time.Hour),
 		Deadline:   expiredDeadline,
 	}
-	err = repo.Create(ctx, expiredDelivery)
+	_, err = repo.Create(ctx, expiredDelivery)
 	require.NoError(t, err)
 
 	// Создаем активную доставку (не просроченную)
@@ -200,7 +253,7 @@ func TestDeliveryRepository_ListActiveExpired(t *testing.T) {
 		AssignedAt: baseTime,
 		Deadline:   baseTime.Add(30 * time.Minute),
 	}
-	err = repo.Create(ctx, activeDelivery)
+	_, err = repo.Create(ctx, activeDelivery)
 	require.NoError(t, err)
 
 	time.Sleep(50 * time.Millisecond)
@@ -216,7 +269,7 @@ func TestDeliveryRepository_ListActiveExpired(t *testing.T) {
 	for _, d := range expired {
 		if d.OrderID == "65ae96c6-abff-424b-83fe-92403a4678e1" {
 			found = true
-			assert.EqualValues(t, modelDelivery.StatusActive, d.Status)
+			assert.EqualValues(t, modelDelivery.StatusAssigned, d.Status)
 			assert.True(t, d.Deadline.Before(checkTime.Add(1*time.Second)),
 				"deadline should be before checkTime, got deadline=%v, checkTime=%v", d.Deadline, checkTime)
 		}
@@ -235,7 +288,7 @@ func TestDeliveryRepository_ListActiveExpired_NoExpired(t *testing.T) {
 
 	ctxGetter := trmpgx.DefaultCtxGetter
 	repo := deliveryRepo.NewDeliveryRepository(pool, ctxGetter)
-	courierRepo := courier.NewCourierRepository(pool)
+	courierRepo := courier.NewCourierRepository(pool, ctxGetter)
 	ctx := context.Background()
 
 	// Создаем курьера
@@ -257,7 +310,7 @@ func TestDeliveryRepository_ListActiveExpired_NoExpired(t *testing.T) {
 		AssignedAt: now,
 		Deadline:   now.Add(30 * time.Minute),
 	}
-	err = repo.Create(ctx, activeDelivery)
+	_, err = repo.Create(ctx, activeDelivery)
 	require.NoError(t, err)
 
 	// Получаем просроченные доставки
@@ -272,7 +325,7 @@ func TestDeliveryRepository_UpdateStatusByIDs(t *testing.T) {
 
 	ctxGetter := trmpgx.DefaultCtxGetter
 	repo := deliveryRepo.NewDeliveryRepository(pool, ctxGetter)
-	courierRepo := courier.NewCourierRepository(pool)
+	courierRepo := courier.NewCourierRepository(pool, ctxGetter)
 	ctx := context.Background()
 
 	// Создаем курьера
@@ -294,7 +347,7 @@ func TestDeliveryRepository_UpdateStatusByIDs(t *testing.T) {
 		AssignedAt: now,
 		Deadline:   now.Add(30 * time.Minute),
 	}
-	err = repo.Create(ctx, delivery1)
+	_, err = repo.Create(ctx, delivery1)
 	require.NoError(t, err)
 
 	// Получаем ID из созданной доставки
@@ -309,7 +362,7 @@ func TestDeliveryRepository_UpdateStatusByIDs(t *testing.T) {
 		AssignedAt: now,
 		Deadline:   now.Add(30 * time.Minute),
 	}
-	err = repo.Create(ctx, delivery2)
+	_, err = repo.Create(ctx, delivery2)
 	require.NoError(t, err)
 
 	// Получаем ID из созданной доставки
@@ -344,3 +397,382 @@ func TestDeliveryRepository_UpdateStatusByIDs_EmptySlice(t *testing.T) {
 	err := repo.UpdateStatusByIDs(ctx, []int64{}, modelDelivery.StatusCompleted)
 	require.NoError(t, err)
 }
+
+func TestDeliveryRepository_UpdateStatus(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
+
+	ctxGetter := trmpgx.DefaultCtxGetter
+	repo := deliveryRepo.NewDeliveryRepository(pool, ctxGetter)
+	ctx := context.Background()
+
+	now := time.Now()
+	_, err := repo.Create(ctx, modelDelivery.Delivery{
+		CourierID:  1,
+		OrderID:    "f819526d-6a7c-48eb-b535-43989469d1ca",
+		AssignedAt: now,
+		Deadline:   now.Add(30 * time.Minute),
+	})
+	require.NoError(t, err)
+
+	created, err := repo.GetByOrderID(ctx, "f819526d-6a7c-48eb-b535-43989469d1ca")
+	require.NoError(t, err)
+
+	err = repo.UpdateStatus(ctx, created.ID, modelDelivery.StatusAssigned, modelDelivery.StatusAccepted)
+	require.NoError(t, err)
+
+	// Повторный переход из устаревшего статуса отклоняется
+	err = repo.UpdateStatus(ctx, created.ID, modelDelivery.StatusAssigned, modelDelivery.StatusAccepted)
+	assert.ErrorIs(t, err, modelDelivery.ErrInvalidTransition)
+
+	result, err := repo.GetByOrderID(ctx, "f819526d-6a7c-48eb-b535-43989469d1ca")
+	require.NoError(t, err)
+	assert.EqualValues(t, modelDelivery.StatusAccepted, result.Status)
+}
+
+func TestDeliveryRepository_UpdateStatusByIDs_SkipsTerminal(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
+
+	ctxGetter := trmpgx.DefaultCtxGetter
+	repo := deliveryRepo.NewDeliveryRepository(pool, ctxGetter)
+	ctx := context.Background()
+
+	now := time.Now()
+	_, err := repo.Create(ctx, modelDelivery.Delivery{
+		CourierID:  1,
+		OrderID:    "f819526d-6a7c-48eb-b535-43989469d1ca",
+		AssignedAt: now,
+		Deadline:   now.Add(30 * time.Minute),
+	})
+	require.NoError(t, err)
+
+	created, err := repo.GetByOrderID(ctx, "f819526d-6a7c-48eb-b535-43989469d1ca")
+	require.NoError(t, err)
+
+	for _, to := range []modelDelivery.DeliveryStatus{
+		modelDelivery.StatusAccepted,
+		modelDelivery.StatusPickedUp,
+		modelDelivery.StatusInTransit,
+	} {
+		err = repo.UpdateStatusByIDs(ctx, []int64{created.ID}, to)
+		require.NoError(t, err)
+	}
+
+	err = repo.UpdateStatusByIDs(ctx, []int64{created.ID}, modelDelivery.StatusDelivered)
+	require.NoError(t, err)
+
+	// Из delivered перехода в completed нет - статус не меняется
+	err = repo.UpdateStatusByIDs(ctx, []int64{created.ID}, modelDelivery.StatusCompleted)
+	require.NoError(t, err)
+
+	result, err := repo.GetByOrderID(ctx, "f819526d-6a7c-48eb-b535-43989469d1ca")
+	require.NoError(t, err)
+	assert.EqualValues(t, modelDelivery.StatusDelivered, result.Status)
+}
+
+func TestDeliveryRepository_List(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
+
+	repo := deliveryRepo.NewDeliveryRepository(pool, trmpgx.DefaultCtxGetter)
+	ctx := context.Background()
+
+	base := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	ids := make([]int64, 0, 4)
+	for i, d := range []modelDelivery.Delivery{
+		{CourierID: 1, OrderID: "order-1", Status: modelDelivery.StatusAssigned, AssignedAt: base},
+		{CourierID: 1, OrderID: "order-2", Status: modelDelivery.StatusDelivered, AssignedAt: base.Add(time.Minute)},
+		{CourierID: 1, OrderID: "order-3", Status: modelDelivery.StatusAssigned, AssignedAt: base.Add(time.Minute)},
+		{CourierID: 2, OrderID: "order-4", Status: modelDelivery.StatusAssigned, AssignedAt: base.Add(2 * time.Minute)},
+	} {
+		d.Deadline = d.AssignedAt.Add(time.Duration(30-i) * time.Minute)
+		id, err := repo.Create(ctx, d)
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+	require.NoError(t, repo.DeleteByOrderID(ctx, "order-3"))
+
+	courierID := int64(1)
+	active, err := repo.List(ctx, modelDelivery.ListFilter{CourierID: &courierID, Sort: modelDelivery.SortByAssignedAt})
+	require.NoError(t, err)
+	require.Len(t, active, 2)
+	assert.Equal(t, ids[0], active[0].ID)
+	assert.Nil(t, active[0].DeletedAt)
+
+	// равные assigned_at различаются по id, страница продолжается строго после курсора
+	all, err := repo.List(ctx, modelDelivery.ListFilter{
+		CourierID:      &courierID,
+		IncludeDeleted: true,
+		Sort:           modelDelivery.SortByAssignedAt,
+		Desc:           true,
+		After:          &modelDelivery.Cursor{At: base.Add(time.Minute), ID: ids[2]},
+	})
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, ids[1], all[0].ID)
+	assert.Equal(t, ids[0], all[1].ID)
+
+	deleted, err := repo.List(ctx, modelDelivery.ListFilter{OrderID: "order-3", IncludeDeleted: true})
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+	assert.NotNil(t, deleted[0].DeletedAt)
+	assert.EqualValues(t, modelDelivery.StatusDeleted, deleted[0].Status)
+
+	from, to := base.Add(time.Minute), base.Add(2*time.Minute)
+	ranged, err := repo.List(ctx, modelDelivery.ListFilter{
+		Statuses: []modelDelivery.DeliveryStatus{modelDelivery.StatusDelivered, modelDelivery.StatusAssigned},
+		From:     &from,
+		To:       &to,
+		Limit:    10,
+	})
+	require.NoError(t, err)
+	require.Len(t, ranged, 1)
+	assert.Equal(t, ids[1], ranged[0].ID)
+}
+
+func TestDeliveryRepository_CreateEvents_ListEventsByOrderID(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
+
+	ctxGetter := trmpgx.DefaultCtxGetter
+	repo := deliveryRepo.NewDeliveryRepository(pool, ctxGetter)
+	ctx := context.Background()
+
+	orderID := "f819526d-6a7c-48eb-b535-43989469d1ca"
+	now := time.Now().UTC().Truncate(time.Second)
+
+	err := repo.CreateEvents(ctx, []modelDelivery.Event{
+		{DeliveryID: 1, OrderID: orderID, CourierID: 10, ToStatus: modelDelivery.StatusAssigned, Actor: modelDelivery.ActorKafka, Reason: "order created", CreatedAt: now},
+		{DeliveryID: 1, OrderID: orderID, CourierID: 10, FromStatus: modelDelivery.StatusAssigned, ToStatus: modelDelivery.StatusAccepted, Actor: modelDelivery.ActorHTTP, CreatedAt: now.Add(time.Minute)},
+		{DeliveryID: 2, OrderID: "other-order", CourierID: 20, ToStatus: modelDelivery.StatusAssigned, Actor: modelDelivery.ActorSystem, CreatedAt: now},
+	})
+	require.NoError(t, err)
+
+	events, err := repo.ListEventsByOrderID(ctx, orderID)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	assert.Empty(t, events[0].FromStatus)
+	assert.EqualValues(t, modelDelivery.StatusAssigned, events[0].ToStatus)
+	assert.Equal(t, modelDelivery.ActorKafka, events[0].Actor)
+	assert.Equal(t, "order created", events[0].Reason)
+
+	assert.EqualValues(t, modelDelivery.StatusAssigned, events[1].FromStatus)
+	assert.EqualValues(t, modelDelivery.StatusAccepted, events[1].ToStatus)
+	assert.Equal(t, modelDelivery.ActorHTTP, events[1].Actor)
+
+	empty, err := repo.ListEventsByOrderID(ctx, "missing")
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
+func TestDeliveryRepository_ListEventsAfter(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
+
+	repo := deliveryRepo.NewDeliveryRepository(pool, trmpgx.DefaultCtxGetter)
+	ctx := context.Background()
+
+	orderID := "f819526d-6a7c-48eb-b535-43989469d1ca"
+	now := time.Now().UTC().Truncate(time.Second)
+
+	err := repo.CreateEvents(ctx, []modelDelivery.Event{
+		{DeliveryID: 1, OrderID: orderID, CourierID: 10, ToStatus: modelDelivery.StatusAssigned, Actor: modelDelivery.ActorHTTP, CreatedAt: now},
+		{DeliveryID: 2, OrderID: "other-order", CourierID: 10, ToStatus: modelDelivery.StatusAssigned, Actor: modelDelivery.ActorHTTP, CreatedAt: now},
+		{DeliveryID: 1, OrderID: orderID, CourierID: 10, FromStatus: modelDelivery.StatusAssigned, ToStatus: modelDelivery.StatusDeleted, Actor: modelDelivery.ActorHTTP, CreatedAt: now},
+		{DeliveryID: 3, OrderID: orderID, CourierID: 20, ToStatus: modelDelivery.StatusAssigned, Actor: modelDelivery.ActorHTTP, CreatedAt: now},
+	})
+	require.NoError(t, err)
+
+	byOrder, err := repo.ListEventsAfter(ctx, modelDelivery.EventFilter{OrderID: orderID})
+	require.NoError(t, err)
+	require.Len(t, byOrder, 3)
+	assert.EqualValues(t, modelDelivery.StatusDeleted, byOrder[1].ToStatus)
+	assert.Equal(t, int64(20), byOrder[2].CourierID)
+
+	resumed, err := repo.ListEventsAfter(ctx, modelDelivery.EventFilter{OrderID: orderID, AfterID: byOrder[0].ID, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, resumed, 1)
+	assert.Equal(t, byOrder[1].ID, resumed[0].ID)
+
+	byCourier, err := repo.ListEventsAfter(ctx, modelDelivery.EventFilter{CourierID: 10})
+	require.NoError(t, err)
+	require.Len(t, byCourier, 3)
+	assert.Equal(t, "other-order", byCourier[1].OrderID)
+}
+
+func TestDeliveryRepository_ListEventsAfter_WaitsForOpenTransactions(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
+
+	repo := deliveryRepo.NewDeliveryRepository(pool, trmpgx.DefaultCtxGetter)
+	ctx := context.Background()
+
+	orderID := "f819526d-6a7c-48eb-b535-43989469d1ca"
+	now := time.Now().UTC().Truncate(time.Second)
+
+	// первое событие получает меньший id, но его транзакция коммитится последней
+	tx, err := pool.Begin(ctx)
+	require.NoError(t, err)
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO delivery_events (delivery_id, order_id, courier_id, to_status, actor, reason, created_at)
+		VALUES (1, $1, 10, 'assigned', 'http', '', $2)`, orderID, now)
+	require.NoError(t, err)
+
+	err = repo.CreateEvents(ctx, []modelDelivery.Event{
+		{DeliveryID: 1, OrderID: orderID, CourierID: 10, FromStatus: modelDelivery.StatusAssigned, ToStatus: modelDelivery.StatusDeleted, Actor: modelDelivery.ActorHTTP, CreatedAt: now},
+	})
+	require.NoError(t, err)
+
+	pending, err := repo.ListEventsAfter(ctx, modelDelivery.EventFilter{OrderID: orderID})
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	require.NoError(t, tx.Commit(ctx))
+
+	events, err := repo.ListEventsAfter(ctx, modelDelivery.EventFilter{OrderID: orderID})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.EqualValues(t, modelDelivery.StatusAssigned, events[0].ToStatus)
+	assert.EqualValues(t, modelDelivery.StatusDeleted, events[1].ToStatus)
+}
+
+func TestDeliveryRepository_CountActiveByCourierIDs(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
+
+	repo := deliveryRepo.NewDeliveryRepository(pool, trmpgx.DefaultCtxGetter)
+	courierRepo := courier.NewCourierRepository(pool, trmpgx.DefaultCtxGetter)
+	ctx := context.Background()
+
+	busyID, err := courierRepo.Create(ctx, modelCourier.Courier{
+		Name:          "Ivan",
+		Phone:         "+78005553535",
+		Status:        modelCourier.StatusBusy,
+		TransportType: modelCourier.TransportCar,
+	})
+	require.NoError(t, err)
+	idleID, err := courierRepo.Create(ctx, modelCourier.Courier{
+		Name:          "Petr",
+		Phone:         "+78005553536",
+		Status:        modelCourier.StatusBusy,
+		TransportType: modelCourier.TransportCar,
+	})
+	require.NoError(t, err)
+
+	for _, orderID := range []string{"order-1", "order-2"} {
+		_, err := repo.Create(ctx, modelDelivery.Delivery{
+			CourierID:  busyID,
+			OrderID:    orderID,
+			AssignedAt: time.Now(),
+			Deadline:   time.Now().Add(30 * time.Minute),
+		})
+		require.NoError(t, err)
+	}
+
+	completedID, err := repo.Create(ctx, modelDelivery.Delivery{
+		CourierID:  idleID,
+		OrderID:    "order-3",
+		AssignedAt: time.Now(),
+		Deadline:   time.Now().Add(30 * time.Minute),
+	})
+	require.NoError(t, err)
+	require.NoError(t, repo.UpdateStatusByIDs(ctx, []int64{completedID}, modelDelivery.StatusCompleted))
+
+	counts, err := repo.CountActiveByCourierIDs(ctx, []int64{busyID, idleID})
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]int64{busyID: 2}, counts)
+}
+
+func TestDeliveryRepository_Stats(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
+
+	repo := deliveryRepo.NewDeliveryRepository(pool, trmpgx.DefaultCtxGetter)
+	courierRepo := courier.NewCourierRepository(pool, trmpgx.DefaultCtxGetter)
+	ctx := context.Background()
+
+	driverID, err := courierRepo.Create(ctx, modelCourier.Courier{
+		Name:          "Ivan",
+		Phone:         "+78005553535",
+		Status:        modelCourier.StatusAvailable,
+		TransportType: modelCourier.TransportCar,
+	})
+	require.NoError(t, err)
+	scooterID, err := courierRepo.Create(ctx, modelCourier.Courier{
+		Name:          "Petr",
+		Phone:         "+78005553536",
+		Status:        modelCourier.StatusAvailable,
+		TransportType: modelCourier.TransportScooter,
+	})
+	require.NoError(t, err)
+	// смена водителя началась час назад
+	integration.StartShift(t, pool, driverID)
+
+	now := time.Now().UTC()
+	create := func(courierID int64, orderID string, status modelDelivery.DeliveryStatus, assigned, deadline time.Duration) int64 {
+		id, err := repo.Create(ctx, modelDelivery.Delivery{
+			CourierID:  courierID,
+			OrderID:    orderID,
+			Status:     status,
+			AssignedAt: now.Add(-assigned),
+			Deadline:   now.Add(deadline),
+		})
+		require.NoError(t, err)
+		return id
+	}
+
+	delivered := create(driverID, "order-1", modelDelivery.StatusInTransit, 60*time.Minute, 30*time.Minute)
+	require.NoError(t, repo.UpdateStatus(ctx, delivered, modelDelivery.StatusInTransit, modelDelivery.StatusDelivered))
+	expired := create(driverID, "order-2", modelDelivery.StatusAssigned, 50*time.Minute, -10*time.Minute)
+	require.NoError(t, repo.UpdateStatusByIDs(ctx, []int64{expired}, modelDelivery.StatusCompleted))
+	create(driverID, "order-3", modelDelivery.StatusAssigned, 40*time.Minute, 10*time.Minute)
+	require.NoError(t, repo.DeleteByOrderID(ctx, "order-3"))
+	declined := create(driverID, "order-4", modelDelivery.StatusOffered, 20*time.Minute, 10*time.Minute)
+	require.NoError(t, repo.CloseOffer(ctx, declined, modelDelivery.StatusDeclined))
+	// назначена до начала периода - в счетчики не входит, но занимает курьера
+	create(scooterID, "order-5", modelDelivery.StatusInTransit, 3*time.Hour, time.Hour)
+
+	from, to := now.Add(-2*time.Hour), now.Add(time.Hour)
+	stats, err := repo.Stats(ctx, modelDelivery.StatsFilter{From: from, To: to})
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+
+	driver := stats[0]
+	assert.Equal(t, driverID, driver.CourierID)
+	assert.Equal(t, string(modelCourier.TransportCar), driver.TransportType)
+	assert.Equal(t, int64(3), driver.Assigned)
+	assert.Equal(t, int64(1), driver.Delivered)
+	assert.Equal(t, int64(1), driver.OnTime)
+	assert.Equal(t, int64(1), driver.Expired)
+	assert.Equal(t, int64(1), driver.Unassigned)
+	assert.Equal(t, int64(1), driver.Timed)
+	assert.InDelta(t, time.Hour.Seconds(), driver.DeliveryTime.Seconds(), 5)
+	// три пересекающиеся доставки дают один час занятости
+	assert.InDelta(t, time.Hour.Seconds(), driver.BusyTime.Seconds(), 5)
+	assert.InDelta(t, (2 * time.Hour).Seconds(), driver.ShiftTime.Seconds(), 5)
+	assert.InDelta(t, 0.5, driver.OnTimeRate(), 1e-9)
+
+	scooter := stats[1]
+	assert.Equal(t, scooterID, scooter.CourierID)
+	assert.Zero(t, scooter.Assigned)
+	// открытая доставка занимает курьера с начала периода до его конца
+	assert.InDelta(t, (3 * time.Hour).Seconds(), scooter.BusyTime.Seconds(), 5)
+	assert.Zero(t, scooter.ShiftTime)
+
+	driverOnly, err := repo.Stats(ctx, modelDelivery.StatsFilter{CourierID: &driverID, From: from, To: to})
+	require.NoError(t, err)
+	require.Len(t, driverOnly, 1)
+	assert.Equal(t, driver, driverOnly[0])
+
+	byTransport, err := repo.Stats(ctx, modelDelivery.StatsFilter{From: from, To: to, ByTransport: true})
+	require.NoError(t, err)
+	require.Len(t, byTransport, 2)
+	assert.Equal(t, string(modelCourier.TransportCar), byTransport[0].TransportType)
+	assert.Zero(t, byTransport[0].CourierID)
+	assert.Equal(t, int64(3), byTransport[0].Assigned)
+	assert.Equal(t, string(modelCourier.TransportScooter), byTransport[1].TransportType)
+}
@@ -0,0 +1,171 @@
+package delivery
+
+import (
+	"context"
+	"fmt"
+	"service-courier/internal/model/delivery"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+)
+
+// deliveredCondition - доставлено курьером или закрыто заказом до дедлайна
+var deliveredCondition = squirrel.Expr(
+	"(status = ? OR (status = ? AND finished_at <= deadline))",
+	delivery.StatusDelivered, delivery.StatusCompleted,
+)
+
+// notTaken - предложения, которые курьер еще не принял или отклонил, в статистику не входят
+var notTaken = squirrel.NotEq{"status": []delivery.DeliveryStatus{delivery.StatusOffered, delivery.StatusDeclined}}
+
+// statsColumns - показатели из CTE counts (n), busy (b) и shifts (s) в порядке полей CourierStats
+var statsColumns = []struct{ value, cast string }{
+	{"n.assigned", "bigint"},
+	{"n.delivered", "bigint"},
+	{"n.on_time", "bigint"},
+	{"n.unassigned", "bigint"},
+	{"n.expired", "bigint"},
+	{"n.returned", "bigint"},
+	{"n.delivery_seconds", "float8"},
+	{"n.timed", "bigint"},
+	{"b.busy_seconds", "float8"},
+	{"s.shift_seconds", "float8"},
+}
+
+// Stats считает показатели доставок за период по каждому курьеру или по типам транспорта.
+// В ответе только курьеры, у которых в периоде были доставки или смены.
+func (r *Repository) Stats(ctx context.Context, filter delivery.StatsFilter) ([]delivery.CourierStats, error) {
+	query, args, err := r.statsQuery(filter).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	rows, err := r.exec(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer rows.Close()
+
+	stats := make([]delivery.CourierStats, 0)
+	for rows.Next() {
+		var (
+			s                                          delivery.CourierStats
+			deliverySeconds, busySeconds, shiftSeconds float64
+		)
+		err := rows.Scan(
+			&s.CourierID,
+			&s.TransportType,
+			&s.Assigned,
+			&s.Delivered,
+			&s.OnTime,
+			&s.Unassigned,
+			&s.Expired,
+			&s.Returned,
+			&deliverySeconds,
+			&s.Timed,
+			&busySeconds,
+			&shiftSeconds,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan stats: %w", err)
+		}
+		s.DeliveryTime = seconds(deliverySeconds)
+		s.BusyTime = seconds(busySeconds)
+		s.ShiftTime = seconds(shiftSeconds)
+		stats = append(stats, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return stats, nil
+}
+
+func (r *Repository) statsQuery(filter delivery.StatsFilter) squirrel.SelectBuilder {
+	counts := squirrel.Select("courier_id").
+		Column("COUNT(*) AS assigned").
+		Column(squirrel.ConcatExpr("COUNT(*) FILTER (WHERE ", deliveredCondition, ") AS delivered")).
+		Column(squirrel.Expr(
+			"COUNT(*) FILTER (WHERE status IN (?, ?) AND finished_at <= deadline) AS on_time",
+			delivery.StatusDelivered, delivery.StatusCompleted,
+		)).
+		Column(squirrel.Expr("COUNT(*) FILTER (WHERE status = ?) AS unassigned", delivery.StatusDeleted)).
+		Column(squirrel.Expr("COUNT(*) FILTER (WHERE status = ? AND finished_at > deadline) AS expired", delivery.StatusCompleted)).
+		Column(squirrel.Expr("COUNT(*) FILTER (WHERE status = ?) AS returned", delivery.StatusReturned)).
+		Column(squirrel.ConcatExpr(
+			"SUM(EXTRACT(EPOCH FROM finished_at - assigned_at)) FILTER (WHERE finished_at IS NOT NULL AND ",
+			deliveredCondition, ") AS delivery_seconds",
+		)).
+		Column(squirrel.ConcatExpr("COUNT(*) FILTER (WHERE finished_at IS NOT NULL AND ", deliveredCondition, ") AS timed")).
+		From("delivery").
+		Where(squirrel.GtOrEq{"assigned_at": filter.From}).
+		Where(squirrel.Lt{"assigned_at": filter.To}).
+		Where(notTaken).
+		GroupBy("courier_id")
+
+	// интервалы доставок, обрезанные по периоду, объединяются, чтобы параллельные доставки не считались дважды
+	periods := squirrel.Select("courier_id").
+		Column(squirrel.Expr(
+			"unnest(range_agg(tsrange(GREATEST(assigned_at, ?), LEAST(finished_at, ?)))) AS period",
+			filter.From, filter.To,
+		)).
+		From("delivery").
+		Where(squirrel.Lt{"assigned_at": filter.To}).
+		Where(squirrel.Or{
+			squirrel.And{squirrel.Eq{"finished_at": nil}, squirrel.Eq{"status": delivery.ActiveStatuses()}},
+			squirrel.And{squirrel.Gt{"finished_at": filter.From}, squirrel.Expr("finished_at >= assigned_at")},
+		}).
+		Where(notTaken).
+		GroupBy("courier_id")
+
+	shifts := squirrel.Select("courier_id").
+		Column(squirrel.Expr(
+			"SUM(EXTRACT(EPOCH FROM LEAST(ends_at, ?) - GREATEST(starts_at, ?))) AS shift_seconds",
+			filter.To, filter.From,
+		)).
+		From("courier_shifts").
+		Where(squirrel.Lt{"starts_at": filter.To}).
+		Where(squirrel.Gt{"ends_at": filter.From}).
+		GroupBy("courier_id")
+
+	if filter.CourierID != nil {
+		counts = counts.Where(squirrel.Eq{"courier_id": *filter.CourierID})
+		periods = periods.Where(squirrel.Eq{"courier_id": *filter.CourierID})
+		shifts = shifts.Where(squirrel.Eq{"courier_id": *filter.CourierID})
+	}
+
+	builder := r.queryBuilder.
+		Select().
+		PrefixExpr(squirrel.ConcatExpr(
+			"WITH counts AS (", counts, "), ",
+			"busy AS (SELECT courier_id, SUM(EXTRACT(EPOCH FROM upper(period) - lower(period))) AS busy_seconds FROM (",
+			periods, ") periods GROUP BY courier_id), ",
+			"shifts AS (", shifts, "), ",
+			"keys AS (SELECT courier_id FROM counts UNION SELECT courier_id FROM busy UNION SELECT courier_id FROM shifts)",
+		)).
+		From("keys k").
+		Join("couriers c ON c.id = k.courier_id").
+		LeftJoin("counts n ON n.courier_id = k.courier_id").
+		LeftJoin("busy b ON b.courier_id = k.courier_id").
+		LeftJoin("shifts s ON s.courier_id = k.courier_id")
+
+	if filter.ByTransport {
+		builder = builder.Columns("0::bigint", "c.transport_type").GroupBy("c.transport_type").OrderBy("c.transport_type")
+	} else {
+		builder = builder.Columns("k.courier_id", "c.transport_type").OrderBy("k.courier_id")
+	}
+	for _, column := range statsColumns {
+		value := column.value
+		if filter.ByTransport {
+			value = "SUM(" + value + ")"
+		}
+		builder = builder.Column("COALESCE(" + value + ", 0)::" + column.cast)
+	}
+
+	return builder
+}
+
+func seconds(value float64) time.Duration {
+	return time.Duration(value * float64(time.Second)).Round(time.Second)
+}
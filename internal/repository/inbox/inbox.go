@@ -0,0 +1,50 @@
+package inbox
+
+import (
+	"context"
+	"fmt"
+	"service-courier/internal/model/inbox"
+
+	"github.com/Masterminds/squirrel"
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Repository struct {
+	pool         *pgxpool.Pool
+	getter       *trmpgx.CtxGetter
+	queryBuilder squirrel.StatementBuilderType
+}
+
+func NewInboxRepository(pool *pgxpool.Pool, getter *trmpgx.CtxGetter) *Repository {
+	return &Repository{
+		pool:         pool,
+		getter:       getter,
+		queryBuilder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *Repository) exec(ctx context.Context) trmpgx.Tr {
+	return r.getter.DefaultTrOrDB(ctx, r.pool)
+}
+
+// Register отмечает сообщение обработанным. Возвращает false, если оно уже было обработано раньше.
+func (r *Repository) Register(ctx context.Context, id inbox.MessageID) (bool, error) {
+	query, args, err := r.queryBuilder.
+		Insert("inbox").
+		Columns("topic", "kafka_partition", "kafka_offset").
+		Values(id.Topic, id.Partition, id.Offset).
+		Suffix("ON CONFLICT DO NOTHING").
+		ToSql()
+
+	if err != nil {
+		return false, fmt.Errorf("build query: %w", err)
+	}
+
+	tag, err := r.exec(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("insert inbox message: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
@@ -0,0 +1,37 @@
+package inbox_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"service-courier/internal/integration"
+	modelInbox "service-courier/internal/model/inbox"
+	inboxRepo "service-courier/internal/repository/inbox"
+
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+)
+
+func TestInboxRepository_Register(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
+
+	repo := inboxRepo.NewInboxRepository(pool, trmpgx.DefaultCtxGetter)
+	ctx := context.Background()
+
+	id := modelInbox.MessageID{Topic: "order.status.changed", Partition: 0, Offset: 42}
+
+	registered, err := repo.Register(ctx, id)
+	require.NoError(t, err)
+	assert.True(t, registered)
+
+	registered, err = repo.Register(ctx, id)
+	require.NoError(t, err)
+	assert.False(t, registered)
+
+	registered, err = repo.Register(ctx, modelInbox.MessageID{Topic: "order.status.changed", Partition: 1, Offset: 42})
+	require.NoError(t, err)
+	assert.True(t, registered)
+}
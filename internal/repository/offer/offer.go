@@ -0,0 +1,183 @@
+package offer
+
+import (
+	"context"
+	"fmt"
+	"service-courier/internal/model/delivery"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var offerColumns = []string{
+	"id", "delivery_id", "order_id", "courier_id", "outcome", "offered_at", "expires_at", "responded_at",
+}
+
+type Repository struct {
+	pool         *pgxpool.Pool
+	getter       *trmpgx.CtxGetter
+	queryBuilder squirrel.StatementBuilderType
+}
+
+func NewOfferRepository(pool *pgxpool.Pool, getter *trmpgx.CtxGetter) *Repository {
+	return &Repository{
+		pool:         pool,
+		getter:       getter,
+		queryBuilder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *Repository) exec(ctx context.Context) trmpgx.Tr {
+	return r.getter.DefaultTrOrDB(ctx, r.pool)
+}
+
+func (r *Repository) Create(ctx context.Context, o delivery.Offer) (id int64, err error) {
+	query, args, err := r.queryBuilder.
+		Insert("delivery_offers").
+		Columns("delivery_id", "order_id", "courier_id", "outcome", "offered_at", "expires_at").
+		Values(o.DeliveryID, o.OrderID, o.CourierID, delivery.OfferPending, o.OfferedAt, o.ExpiresAt).
+		Suffix("RETURNING id").
+		ToSql()
+
+	if err != nil {
+		return 0, fmt.Errorf("build query: %w", err)
+	}
+
+	if err := r.exec(ctx).QueryRow(ctx, query, args...).Scan(&id); err != nil {
+		return 0, fmt.Errorf("database error: %w", err)
+	}
+	return id, nil
+}
+
+// Resolve фиксирует ответ на предложение по доставке. Возвращает false, если предложение уже закрыто.
+func (r *Repository) Resolve(ctx context.Context, deliveryID int64, outcome delivery.OfferOutcome, at time.Time) (bool, error) {
+	query, args, err := r.queryBuilder.
+		Update("delivery_offers").
+		Set("outcome", outcome).
+		Set("responded_at", at).
+		Where(squirrel.Eq{"delivery_id": deliveryID, "outcome": delivery.OfferPending}).
+		ToSql()
+
+	if err != nil {
+		return false, fmt.Errorf("build query: %w", err)
+	}
+
+	result, err := r.exec(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("database error: %w", err)
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// ListExpired возвращает до limit предложений без ответа, у которых истек срок
+func (r *Repository) ListExpired(ctx context.Context, now time.Time, limit uint64) ([]delivery.Offer, error) {
+	query, args, err := r.queryBuilder.
+		Select(offerColumns...).
+		From("delivery_offers").
+		Where(squirrel.Eq{"outcome": delivery.OfferPending}).
+		Where(squirrel.LtOrEq{"expires_at": now}).
+		OrderBy("expires_at ASC", "id ASC").
+		Limit(limit).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	rows, err := r.exec(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer rows.Close()
+
+	offers := make([]delivery.Offer, 0)
+	for rows.Next() {
+		var o delivery.Offer
+		err := rows.Scan(
+			&o.ID,
+			&o.DeliveryID,
+			&o.OrderID,
+			&o.CourierID,
+			&o.Outcome,
+			&o.OfferedAt,
+			&o.ExpiresAt,
+			&o.RespondedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan offer: %w", err)
+		}
+		offers = append(offers, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return offers, nil
+}
+
+// RejectedCourierIDs - курьеры, которые отклонили заказ или не ответили на предложение вовремя
+func (r *Repository) RejectedCourierIDs(ctx context.Context, orderID string) ([]int64, error) {
+	query, args, err := r.queryBuilder.
+		Select("DISTINCT courier_id").
+		From("delivery_offers").
+		Where(squirrel.Eq{
+			"order_id": orderID,
+			"outcome":  []delivery.OfferOutcome{delivery.OfferDeclined, delivery.OfferExpired},
+		}).
+		OrderBy("courier_id").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	rows, err := r.exec(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make([]int64, 0)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan courier id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return ids, nil
+}
+
+// StatsByCourierID считает ответы курьера на предложения. Снятые предложения не учитываются.
+func (r *Repository) StatsByCourierID(ctx context.Context, courierID int64) (*delivery.OfferStats, error) {
+	query, args, err := r.queryBuilder.
+		Select(
+			"COUNT(*) FILTER (WHERE outcome <> 'withdrawn')",
+			"COUNT(*) FILTER (WHERE outcome = 'accepted')",
+			"COUNT(*) FILTER (WHERE outcome = 'declined')",
+			"COUNT(*) FILTER (WHERE outcome = 'expired')",
+		).
+		From("delivery_offers").
+		Where(squirrel.Eq{"courier_id": courierID}).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	stats := delivery.OfferStats{CourierID: courierID}
+	err = r.exec(ctx).QueryRow(ctx, query, args...).Scan(
+		&stats.Offered,
+		&stats.Accepted,
+		&stats.Declined,
+		&stats.Expired,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query offer stats: %w", err)
+	}
+	return &stats, nil
+}
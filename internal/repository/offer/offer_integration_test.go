@@ -0,0 +1,72 @@
+package offer_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"service-courier/internal/integration"
+	model "service-courier/internal/model/delivery"
+	offerRepo "service-courier/internal/repository/offer"
+)
+
+func TestOfferRepository_Lifecycle(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
+
+	repo := offerRepo.NewOfferRepository(pool, trmpgx.DefaultCtxGetter)
+	ctx := context.Background()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	offer := func(deliveryID, courierID int64, expiresIn time.Duration) {
+		_, err := repo.Create(ctx, model.Offer{
+			DeliveryID: deliveryID,
+			OrderID:    "order-1",
+			CourierID:  courierID,
+			OfferedAt:  now,
+			ExpiresAt:  now.Add(expiresIn),
+		})
+		require.NoError(t, err)
+	}
+
+	offer(1, 10, time.Minute)
+	offer(2, 20, -time.Second)
+
+	expired, err := repo.ListExpired(ctx, now, 10)
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+	assert.Equal(t, int64(2), expired[0].DeliveryID)
+	assert.Equal(t, model.OfferPending, expired[0].Outcome)
+
+	resolved, err := repo.Resolve(ctx, 1, model.OfferDeclined, now)
+	require.NoError(t, err)
+	assert.True(t, resolved)
+
+	// Повторный ответ на закрытое предложение ничего не меняет
+	resolved, err = repo.Resolve(ctx, 1, model.OfferAccepted, now)
+	require.NoError(t, err)
+	assert.False(t, resolved)
+
+	_, err = repo.Resolve(ctx, 2, model.OfferExpired, now)
+	require.NoError(t, err)
+
+	rejected, err := repo.RejectedCourierIDs(ctx, "order-1")
+	require.NoError(t, err)
+	assert.Equal(t, []int64{10, 20}, rejected)
+
+	offer(3, 10, time.Minute)
+	_, err = repo.Resolve(ctx, 3, model.OfferAccepted, now)
+	require.NoError(t, err)
+	offer(4, 10, time.Minute)
+	_, err = repo.Resolve(ctx, 4, model.OfferWithdrawn, now)
+	require.NoError(t, err)
+
+	stats, err := repo.StatsByCourierID(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, model.OfferStats{CourierID: 10, Offered: 2, Accepted: 1, Declined: 1}, *stats)
+	assert.InDelta(t, 0.5, stats.AcceptanceRate(), 1e-9)
+}
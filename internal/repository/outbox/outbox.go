@@ -0,0 +1,141 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"service-courier/internal/model/outbox"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Repository struct {
+	pool         *pgxpool.Pool
+	getter       *trmpgx.CtxGetter
+	queryBuilder squirrel.StatementBuilderType
+}
+
+func NewOutboxRepository(pool *pgxpool.Pool, getter *trmpgx.CtxGetter) *Repository {
+	return &Repository{
+		pool:         pool,
+		getter:       getter,
+		queryBuilder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *Repository) exec(ctx context.Context) trmpgx.Tr {
+	return r.getter.DefaultTrOrDB(ctx, r.pool)
+}
+
+func (r *Repository) Create(ctx context.Context, messages []outbox.Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	insert := r.queryBuilder.
+		Insert("outbox").
+		Columns("topic", "key", "payload", "created_at")
+
+	for _, m := range messages {
+		insert = insert.Values(m.Topic, m.Key, m.Payload, m.CreatedAt)
+	}
+
+	query, args, err := insert.ToSql()
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+
+	if _, err := r.exec(ctx).Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("insert outbox messages: %w", err)
+	}
+
+	return nil
+}
+
+// FetchPending блокирует до limit неопубликованных сообщений в порядке записи.
+// Без SKIP LOCKED: параллельный relay ждет коммита и не нарушает порядок по ключу.
+func (r *Repository) FetchPending(ctx context.Context, limit uint64) ([]outbox.Message, error) {
+	query, args, err := r.queryBuilder.
+		Select("id", "topic", "key", "payload", "created_at").
+		From("outbox").
+		Where(squirrel.Eq{"published_at": nil}).
+		OrderBy("id ASC").
+		Limit(limit).
+		Suffix("FOR UPDATE").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	rows, err := r.exec(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer rows.Close()
+
+	messages := make([]outbox.Message, 0)
+	for rows.Next() {
+		var m outbox.Message
+		if err := rows.Scan(&m.ID, &m.Topic, &m.Key, &m.Payload, &m.CreatedAt); err != nil {
+			return nil, fmt.Errorf("error reading data: %w", err)
+		}
+		messages = append(messages, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+
+	return messages, nil
+}
+
+func (r *Repository) MarkPublished(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query, args, err := r.queryBuilder.
+		Update("outbox").
+		Set("published_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": ids}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+
+	if _, err := r.exec(ctx).Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("mark outbox published: %w", err)
+	}
+
+	return nil
+}
+
+// DeletePublished удаляет до limit сообщений, опубликованных раньше чем olderThan назад.
+// Неопубликованные сообщения не удаляются, сколько бы они ни ждали.
+func (r *Repository) DeletePublished(ctx context.Context, olderThan time.Duration, limit uint64) (int64, error) {
+	expired := squirrel.
+		Select("id").
+		From("outbox").
+		Where(squirrel.Expr("published_at < NOW() - make_interval(secs => ?)", olderThan.Seconds())).
+		OrderBy("published_at").
+		Limit(limit)
+
+	query, args, err := r.queryBuilder.
+		Delete("outbox").
+		Where(squirrel.ConcatExpr("id IN (", expired, ")")).
+		ToSql()
+
+	if err != nil {
+		return 0, fmt.Errorf("build query: %w", err)
+	}
+
+	result, err := r.exec(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("delete published outbox: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
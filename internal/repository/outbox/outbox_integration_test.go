@@ -0,0 +1,89 @@
+package outbox_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"service-courier/internal/integration"
+	modelOutbox "service-courier/internal/model/outbox"
+	outboxRepo "service-courier/internal/repository/outbox"
+
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+)
+
+func TestOutboxRepository_CreateFetchMarkPublished(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
+
+	repo := outboxRepo.NewOutboxRepository(pool, trmpgx.DefaultCtxGetter)
+	ctx := context.Background()
+
+	now := time.Now()
+	err := repo.Create(ctx, []modelOutbox.Message{
+		{Topic: "delivery.status.changed", Key: "order-1", Payload: []byte(`{"status":"assigned"}`), CreatedAt: now},
+		{Topic: "delivery.status.changed", Key: "order-1", Payload: []byte(`{"status":"accepted"}`), CreatedAt: now},
+		{Topic: "delivery.status.changed", Key: "order-2", Payload: []byte(`{"status":"assigned"}`), CreatedAt: now},
+	})
+	require.NoError(t, err)
+
+	pending, err := repo.FetchPending(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, "order-1", pending[0].Key)
+	assert.JSONEq(t, `{"status":"assigned"}`, string(pending[0].Payload))
+	assert.JSONEq(t, `{"status":"accepted"}`, string(pending[1].Payload))
+
+	err = repo.MarkPublished(ctx, []int64{pending[0].ID, pending[1].ID})
+	require.NoError(t, err)
+
+	pending, err = repo.FetchPending(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "order-2", pending[0].Key)
+}
+
+func TestOutboxRepository_DeletePublished(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
+
+	repo := outboxRepo.NewOutboxRepository(pool, trmpgx.DefaultCtxGetter)
+	ctx := context.Background()
+
+	now := time.Now()
+	err := repo.Create(ctx, []modelOutbox.Message{
+		{Topic: "delivery.status.changed", Key: "old", Payload: []byte(`{}`), CreatedAt: now},
+		{Topic: "delivery.status.changed", Key: "fresh", Payload: []byte(`{}`), CreatedAt: now},
+		{Topic: "delivery.status.changed", Key: "pending", Payload: []byte(`{}`), CreatedAt: now},
+	})
+	require.NoError(t, err)
+
+	pending, err := repo.FetchPending(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	require.NoError(t, repo.MarkPublished(ctx, []int64{pending[0].ID, pending[1].ID}))
+
+	_, err = pool.Exec(ctx, "UPDATE outbox SET published_at = NOW() - INTERVAL '2 hours' WHERE key = 'old'")
+	require.NoError(t, err)
+	_, err = pool.Exec(ctx, "UPDATE outbox SET created_at = NOW() - INTERVAL '2 hours' WHERE key = 'pending'")
+	require.NoError(t, err)
+
+	deleted, err := repo.DeletePublished(ctx, time.Hour, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	var keys []string
+	rows, err := pool.Query(ctx, "SELECT key FROM outbox ORDER BY id")
+	require.NoError(t, err)
+	defer rows.Close()
+	for rows.Next() {
+		var key string
+		require.NoError(t, rows.Scan(&key))
+		keys = append(keys, key)
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, []string{"fresh", "pending"}, keys)
+}
@@ -0,0 +1,182 @@
+package pending
+
+import (
+	"context"
+	"fmt"
+	"service-courier/internal/model/delivery"
+	"service-courier/internal/pkg/geo"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var pendingColumns = []string{
+	"id", "order_id", "priority", "dest_lat", "dest_lon", "attempts",
+	"last_error", "created_at", "expires_at", "last_attempt_at",
+}
+
+type Repository struct {
+	pool         *pgxpool.Pool
+	getter       *trmpgx.CtxGetter
+	queryBuilder squirrel.StatementBuilderType
+}
+
+func NewPendingRepository(pool *pgxpool.Pool, getter *trmpgx.CtxGetter) *Repository {
+	return &Repository{
+		pool:         pool,
+		getter:       getter,
+		queryBuilder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *Repository) exec(ctx context.Context) trmpgx.Tr {
+	return r.getter.DefaultTrOrDB(ctx, r.pool)
+}
+
+// Enqueue ставит заказ в очередь. Возвращает false, если заказ уже ждет назначения.
+func (r *Repository) Enqueue(ctx context.Context, p delivery.PendingAssignment) (bool, error) {
+	var lat, lon *float64
+	if p.Destination != nil {
+		lat, lon = &p.Destination.Lat, &p.Destination.Lon
+	}
+
+	query, args, err := r.queryBuilder.
+		Insert("pending_assignments").
+		Columns("order_id", "priority", "dest_lat", "dest_lon", "created_at", "expires_at").
+		Values(p.OrderID, p.Priority.Rank(), lat, lon, p.CreatedAt, p.ExpiresAt).
+		Suffix("ON CONFLICT (order_id) DO NOTHING").
+		ToSql()
+
+	if err != nil {
+		return false, fmt.Errorf("build query: %w", err)
+	}
+
+	result, err := r.exec(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("database error: %w", err)
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// ListReady возвращает до limit непросроченных заказов: сначала более приоритетные, затем по времени постановки
+func (r *Repository) ListReady(ctx context.Context, now time.Time, limit uint64) ([]delivery.PendingAssignment, error) {
+	query, args, err := r.queryBuilder.
+		Select(pendingColumns...).
+		From("pending_assignments").
+		Where(squirrel.Gt{"expires_at": now}).
+		OrderBy("priority DESC", "created_at ASC", "id ASC").
+		Limit(limit).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	return r.query(ctx, query, args...)
+}
+
+// MarkAttempt фиксирует неудачную попытку назначения
+func (r *Repository) MarkAttempt(ctx context.Context, id int64, at time.Time, lastError string) error {
+	query, args, err := r.queryBuilder.
+		Update("pending_assignments").
+		Set("attempts", squirrel.Expr("attempts + 1")).
+		Set("last_error", lastError).
+		Set("last_attempt_at", at).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+
+	if _, err := r.exec(ctx).Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	return nil
+}
+
+// DeleteByOrderID убирает заказ из очереди. Возвращает false, если заказа в очереди не было.
+func (r *Repository) DeleteByOrderID(ctx context.Context, orderID string) (bool, error) {
+	query, args, err := r.queryBuilder.
+		Delete("pending_assignments").
+		Where(squirrel.Eq{"order_id": orderID}).
+		ToSql()
+
+	if err != nil {
+		return false, fmt.Errorf("build query: %w", err)
+	}
+
+	result, err := r.exec(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("database error: %w", err)
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// DeleteExpired удаляет и возвращает заказы, у которых истек SLA ожидания
+func (r *Repository) DeleteExpired(ctx context.Context, now time.Time) ([]delivery.PendingAssignment, error) {
+	query, args, err := r.queryBuilder.
+		Delete("pending_assignments").
+		Where(squirrel.LtOrEq{"expires_at": now}).
+		Suffix("RETURNING " + strings.Join(pendingColumns, ", ")).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	return r.query(ctx, query, args...)
+}
+
+func (r *Repository) query(ctx context.Context, query string, args ...interface{}) ([]delivery.PendingAssignment, error) {
+	rows, err := r.exec(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer rows.Close()
+
+	pending := make([]delivery.PendingAssignment, 0)
+	for rows.Next() {
+		p, err := scanPending(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan pending assignment: %w", err)
+		}
+		pending = append(pending, *p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return pending, nil
+}
+
+func scanPending(row pgx.Row) (*delivery.PendingAssignment, error) {
+	var (
+		p        delivery.PendingAssignment
+		rank     int
+		lat, lon *float64
+	)
+	err := row.Scan(
+		&p.ID,
+		&p.OrderID,
+		&rank,
+		&lat,
+		&lon,
+		&p.Attempts,
+		&p.LastError,
+		&p.CreatedAt,
+		&p.ExpiresAt,
+		&p.LastAttemptAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	p.Priority = delivery.PriorityFromRank(rank)
+	if lat != nil && lon != nil {
+		p.Destination = &geo.Point{Lat: *lat, Lon: *lon}
+	}
+	return &p, nil
+}
@@ -0,0 +1,90 @@
+package pending_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"service-courier/internal/integration"
+	model "service-courier/internal/model/delivery"
+	"service-courier/internal/pkg/geo"
+	pendingRepo "service-courier/internal/repository/pending"
+)
+
+func TestPendingRepository_Queue(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
+
+	repo := pendingRepo.NewPendingRepository(pool, trmpgx.DefaultCtxGetter)
+	ctx := context.Background()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	destination := &geo.Point{Lat: 55.75, Lon: 37.61}
+
+	queued, err := repo.Enqueue(ctx, model.PendingAssignment{OrderID: "order-1", CreatedAt: now, ExpiresAt: now.Add(time.Hour)})
+	require.NoError(t, err)
+	assert.True(t, queued)
+
+	// Повторная постановка того же заказа ничего не меняет
+	queued, err = repo.Enqueue(ctx, model.PendingAssignment{OrderID: "order-1", CreatedAt: now, ExpiresAt: now.Add(time.Hour)})
+	require.NoError(t, err)
+	assert.False(t, queued)
+
+	_, err = repo.Enqueue(ctx, model.PendingAssignment{
+		OrderID:     "order-2",
+		Priority:    model.PriorityVIP,
+		Destination: destination,
+		CreatedAt:   now.Add(time.Minute),
+		ExpiresAt:   now.Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	ready, err := repo.ListReady(ctx, now, 10)
+	require.NoError(t, err)
+	require.Len(t, ready, 2)
+	assert.Equal(t, "order-2", ready[0].OrderID, "higher priority goes first")
+	assert.Equal(t, model.PriorityVIP, ready[0].Priority)
+	assert.Equal(t, destination, ready[0].Destination)
+	assert.Equal(t, model.PriorityStandard, ready[1].Priority)
+	assert.Nil(t, ready[1].Destination)
+
+	require.NoError(t, repo.MarkAttempt(ctx, ready[1].ID, now, "no available couriers"))
+
+	removed, err := repo.DeleteByOrderID(ctx, "order-2")
+	require.NoError(t, err)
+	assert.True(t, removed)
+
+	ready, err = repo.ListReady(ctx, now, 10)
+	require.NoError(t, err)
+	require.Len(t, ready, 1)
+	assert.Equal(t, 1, ready[0].Attempts)
+	assert.Equal(t, "no available couriers", ready[0].LastError)
+}
+
+func TestPendingRepository_DeleteExpired(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
+
+	repo := pendingRepo.NewPendingRepository(pool, trmpgx.DefaultCtxGetter)
+	ctx := context.Background()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	_, err := repo.Enqueue(ctx, model.PendingAssignment{OrderID: "order-1", CreatedAt: now.Add(-time.Hour), ExpiresAt: now})
+	require.NoError(t, err)
+	_, err = repo.Enqueue(ctx, model.PendingAssignment{OrderID: "order-2", CreatedAt: now, ExpiresAt: now.Add(time.Hour)})
+	require.NoError(t, err)
+
+	expired, err := repo.DeleteExpired(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+	assert.Equal(t, "order-1", expired[0].OrderID)
+
+	ready, err := repo.ListReady(ctx, now, 10)
+	require.NoError(t, err)
+	require.Len(t, ready, 1)
+	assert.Equal(t, "order-2", ready[0].OrderID)
+}
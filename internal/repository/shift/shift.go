@@ -0,0 +1,327 @@
+package shift
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"service-courier/internal/model/courier"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var shiftColumns = []string{
+	"id", "courier_id", "starts_at", "ends_at", "zone_id",
+	"started_at", "finished_at", "created_at", "updated_at",
+}
+
+// liveCourier - смены удаленных курьеров планировщик не применяет
+const liveCourier = "couriers c ON c.id = s.courier_id AND c.deleted_at IS NULL"
+
+// scheduledColumns - колонки смены для запросов планировщика, где смены соединяются с курьерами
+func scheduledColumns() []string {
+	columns := make([]string, len(shiftColumns))
+	for i, column := range shiftColumns {
+		columns[i] = "s." + column
+	}
+	return columns
+}
+
+type Repository struct {
+	pool         *pgxpool.Pool
+	getter       *trmpgx.CtxGetter
+	queryBuilder squirrel.StatementBuilderType
+}
+
+func NewShiftRepository(pool *pgxpool.Pool, getter *trmpgx.CtxGetter) *Repository {
+	return &Repository{
+		pool:         pool,
+		getter:       getter,
+		queryBuilder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *Repository) exec(ctx context.Context) trmpgx.Tr {
+	return r.getter.DefaultTrOrDB(ctx, r.pool)
+}
+
+func (r *Repository) Create(ctx context.Context, shiftData courier.Shift) (id int64, err error) {
+	query, args, err := r.queryBuilder.
+		Insert("courier_shifts").
+		Columns("courier_id", "starts_at", "ends_at", "zone_id").
+		Values(shiftData.CourierID, shiftData.StartsAt, shiftData.EndsAt, shiftData.ZoneID).
+		Suffix("RETURNING id").
+		ToSql()
+
+	if err != nil {
+		return 0, fmt.Errorf("build query: %w", err)
+	}
+
+	if err := r.exec(ctx).QueryRow(ctx, query, args...).Scan(&id); err != nil {
+		return 0, fmt.Errorf("database error: %w", err)
+	}
+	return id, nil
+}
+
+func (r *Repository) GetByID(ctx context.Context, id int64) (*courier.Shift, error) {
+	query, args, err := r.queryBuilder.
+		Select(shiftColumns...).
+		From("courier_shifts").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	shiftData, err := scanShift(r.exec(ctx).QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, courier.ErrShiftNotFound
+		}
+		return nil, fmt.Errorf("query shift: %w", err)
+	}
+	return shiftData, nil
+}
+
+// List возвращает смены, пересекающиеся с периодом фильтра, в порядке начала
+func (r *Repository) List(ctx context.Context, filter courier.ShiftFilter) ([]courier.Shift, error) {
+	builder := r.queryBuilder.
+		Select(shiftColumns...).
+		From("courier_shifts").
+		OrderBy("starts_at", "id")
+
+	if filter.CourierID != nil {
+		builder = builder.Where(squirrel.Eq{"courier_id": *filter.CourierID})
+	}
+	if filter.From != nil {
+		builder = builder.Where(squirrel.Gt{"ends_at": *filter.From})
+	}
+	if filter.To != nil {
+		builder = builder.Where(squirrel.Lt{"starts_at": *filter.To})
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	return r.query(ctx, query, args...)
+}
+
+// Update меняет период и зону смены вместе с отметками планировщика
+func (r *Repository) Update(ctx context.Context, shiftData courier.Shift) error {
+	query, args, err := r.queryBuilder.
+		Update("courier_shifts").
+		Set("starts_at", shiftData.StartsAt).
+		Set("ends_at", shiftData.EndsAt).
+		Set("zone_id", shiftData.ZoneID).
+		Set("started_at", shiftData.StartedAt).
+		Set("finished_at", shiftData.FinishedAt).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": shiftData.ID}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+
+	result, err := r.exec(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return courier.ErrShiftNotFound
+	}
+	return nil
+}
+
+func (r *Repository) Delete(ctx context.Context, id int64) error {
+	query, args, err := r.queryBuilder.
+		Delete("courier_shifts").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+
+	result, err := r.exec(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return courier.ErrShiftNotFound
+	}
+	return nil
+}
+
+// HasOverlap проверяет, есть ли у курьера другая смена, пересекающаяся с периодом.
+// Смена с excludeID не учитывается, чтобы при обновлении не сравнивать смену саму с собой.
+func (r *Repository) HasOverlap(ctx context.Context, courierID int64, startsAt, endsAt time.Time, excludeID int64) (bool, error) {
+	query, args, err := r.queryBuilder.
+		Select("1").
+		From("courier_shifts").
+		Where(squirrel.And{
+			squirrel.Eq{"courier_id": courierID},
+			squirrel.NotEq{"id": excludeID},
+			squirrel.Lt{"starts_at": endsAt},
+			squirrel.Gt{"ends_at": startsAt},
+		}).
+		Limit(1).
+		ToSql()
+
+	if err != nil {
+		return false, fmt.Errorf("build query: %w", err)
+	}
+
+	var one int
+	if err := r.exec(ctx).QueryRow(ctx, query, args...).Scan(&one); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("database error: %w", err)
+	}
+	return true, nil
+}
+
+// HasActiveShift проверяет, идет ли у курьера смена в момент now
+func (r *Repository) HasActiveShift(ctx context.Context, courierID int64, now time.Time) (bool, error) {
+	query, args, err := r.queryBuilder.
+		Select("1").
+		From("courier_shifts").
+		Where(squirrel.And{
+			squirrel.Eq{"courier_id": courierID},
+			squirrel.LtOrEq{"starts_at": now},
+			squirrel.Gt{"ends_at": now},
+		}).
+		Limit(1).
+		ToSql()
+
+	if err != nil {
+		return false, fmt.Errorf("build query: %w", err)
+	}
+
+	var one int
+	if err := r.exec(ctx).QueryRow(ctx, query, args...).Scan(&one); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("database error: %w", err)
+	}
+	return true, nil
+}
+
+// ListToStart возвращает начавшиеся, но еще не примененные планировщиком смены неудаленных курьеров
+func (r *Repository) ListToStart(ctx context.Context, now time.Time) ([]courier.Shift, error) {
+	query, args, err := r.queryBuilder.
+		Select(scheduledColumns()...).
+		From("courier_shifts s").
+		Join(liveCourier).
+		Where(squirrel.And{
+			squirrel.Eq{"s.started_at": nil},
+			squirrel.LtOrEq{"s.starts_at": now},
+			squirrel.Gt{"s.ends_at": now},
+		}).
+		OrderBy("s.starts_at", "s.id").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	return r.query(ctx, query, args...)
+}
+
+// ListToFinish возвращает закончившиеся смены неудаленных курьеров, конец которых планировщик еще не применил
+func (r *Repository) ListToFinish(ctx context.Context, now time.Time) ([]courier.Shift, error) {
+	query, args, err := r.queryBuilder.
+		Select(scheduledColumns()...).
+		From("courier_shifts s").
+		Join(liveCourier).
+		Where(squirrel.And{
+			squirrel.Eq{"s.finished_at": nil},
+			squirrel.LtOrEq{"s.ends_at": now},
+		}).
+		OrderBy("s.ends_at", "s.id").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	return r.query(ctx, query, args...)
+}
+
+func (r *Repository) MarkStarted(ctx context.Context, id int64, at time.Time) error {
+	return r.mark(ctx, id, "started_at", at)
+}
+
+func (r *Repository) MarkFinished(ctx context.Context, id int64, at time.Time) error {
+	return r.mark(ctx, id, "finished_at", at)
+}
+
+func (r *Repository) mark(ctx context.Context, id int64, column string, at time.Time) error {
+	query, args, err := r.queryBuilder.
+		Update("courier_shifts").
+		Set(column, at).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+
+	result, err := r.exec(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return courier.ErrShiftNotFound
+	}
+	return nil
+}
+
+func (r *Repository) query(ctx context.Context, query string, args ...interface{}) ([]courier.Shift, error) {
+	rows, err := r.exec(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer rows.Close()
+
+	shifts := make([]courier.Shift, 0)
+	for rows.Next() {
+		shiftData, err := scanShift(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan shift: %w", err)
+		}
+		shifts = append(shifts, *shiftData)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return shifts, nil
+}
+
+func scanShift(row pgx.Row) (*courier.Shift, error) {
+	var shiftData courier.Shift
+	err := row.Scan(
+		&shiftData.ID,
+		&shiftData.CourierID,
+		&shiftData.StartsAt,
+		&shiftData.EndsAt,
+		&shiftData.ZoneID,
+		&shiftData.StartedAt,
+		&shiftData.FinishedAt,
+		&shiftData.CreatedAt,
+		&shiftData.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &shiftData, nil
+}
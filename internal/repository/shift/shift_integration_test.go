@@ -0,0 +1,203 @@
+package shift_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"service-courier/internal/integration"
+	model "service-courier/internal/model/courier"
+	modelZone "service-courier/internal/model/zone"
+	"service-courier/internal/pkg/geo"
+	courierRepo "service-courier/internal/repository/courier"
+	shiftRepo "service-courier/internal/repository/shift"
+	zoneRepo "service-courier/internal/repository/zone"
+)
+
+func createCourier(t *testing.T, ctx context.Context, repo *courierRepo.Repository) int64 {
+	t.Helper()
+
+	id, err := repo.Create(ctx, model.Courier{
+		Name:          "Ivan",
+		Phone:         "+78005553535",
+		Status:        model.StatusPaused,
+		TransportType: model.TransportCar,
+	})
+	require.NoError(t, err)
+	return id
+}
+
+func createZone(t *testing.T, ctx context.Context, repo *zoneRepo.Repository, name string) int64 {
+	t.Helper()
+
+	id, err := repo.Create(ctx, modelZone.Zone{Name: name, Polygon: geo.Polygon{{
+		{Lat: 55, Lon: 37},
+		{Lat: 55, Lon: 38},
+		{Lat: 56, Lon: 38},
+		{Lat: 55, Lon: 37},
+	}}})
+	require.NoError(t, err)
+	return id
+}
+
+func TestShiftRepository_CRUD(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
+
+	repo := shiftRepo.NewShiftRepository(pool, trmpgx.DefaultCtxGetter)
+	ctx := context.Background()
+	courierID := createCourier(t, ctx, courierRepo.NewCourierRepository(pool, trmpgx.DefaultCtxGetter))
+	centerID := createZone(t, ctx, zoneRepo.NewZoneRepository(pool, trmpgx.DefaultCtxGetter), "center")
+
+	startsAt := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	id, err := repo.Create(ctx, model.Shift{
+		CourierID: courierID,
+		StartsAt:  startsAt,
+		EndsAt:    startsAt.Add(8 * time.Hour),
+		ZoneID:    &centerID,
+	})
+	require.NoError(t, err)
+
+	created, err := repo.GetByID(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, courierID, created.CourierID)
+	assert.True(t, created.StartsAt.Equal(startsAt))
+	assert.Equal(t, &centerID, created.ZoneID)
+	assert.Nil(t, created.StartedAt)
+
+	created.EndsAt = startsAt.Add(4 * time.Hour)
+	created.ZoneID = nil
+	require.NoError(t, repo.Update(ctx, *created))
+
+	shifts, err := repo.List(ctx, model.ShiftFilter{CourierID: &courierID})
+	require.NoError(t, err)
+	require.Len(t, shifts, 1)
+	assert.Nil(t, shifts[0].ZoneID)
+	assert.True(t, shifts[0].EndsAt.Equal(startsAt.Add(4*time.Hour)))
+
+	require.NoError(t, repo.Delete(ctx, id))
+
+	_, err = repo.GetByID(ctx, id)
+	assert.ErrorIs(t, err, model.ErrShiftNotFound)
+	assert.ErrorIs(t, repo.Delete(ctx, id), model.ErrShiftNotFound)
+}
+
+func TestShiftRepository_HasOverlap(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
+
+	repo := shiftRepo.NewShiftRepository(pool, trmpgx.DefaultCtxGetter)
+	ctx := context.Background()
+	courierID := createCourier(t, ctx, courierRepo.NewCourierRepository(pool, trmpgx.DefaultCtxGetter))
+
+	startsAt := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	endsAt := startsAt.Add(8 * time.Hour)
+	id, err := repo.Create(ctx, model.Shift{CourierID: courierID, StartsAt: startsAt, EndsAt: endsAt})
+	require.NoError(t, err)
+
+	overlap, err := repo.HasOverlap(ctx, courierID, endsAt.Add(-time.Hour), endsAt.Add(time.Hour), 0)
+	require.NoError(t, err)
+	assert.True(t, overlap)
+
+	// Смена, начинающаяся ровно в конце предыдущей, не пересекается с ней
+	overlap, err = repo.HasOverlap(ctx, courierID, endsAt, endsAt.Add(time.Hour), 0)
+	require.NoError(t, err)
+	assert.False(t, overlap)
+
+	overlap, err = repo.HasOverlap(ctx, courierID, startsAt, endsAt, id)
+	require.NoError(t, err)
+	assert.False(t, overlap)
+}
+
+func TestShiftRepository_ListToStartAndFinish(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
+
+	repo := shiftRepo.NewShiftRepository(pool, trmpgx.DefaultCtxGetter)
+	ctx := context.Background()
+	courierID := createCourier(t, ctx, courierRepo.NewCourierRepository(pool, trmpgx.DefaultCtxGetter))
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	finishedID, err := repo.Create(ctx, model.Shift{
+		CourierID: courierID,
+		StartsAt:  now.Add(-10 * time.Hour),
+		EndsAt:    now.Add(-2 * time.Hour),
+	})
+	require.NoError(t, err)
+	currentID, err := repo.Create(ctx, model.Shift{
+		CourierID: courierID,
+		StartsAt:  now.Add(-time.Hour),
+		EndsAt:    now.Add(time.Hour),
+	})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, model.Shift{
+		CourierID: courierID,
+		StartsAt:  now.Add(2 * time.Hour),
+		EndsAt:    now.Add(4 * time.Hour),
+	})
+	require.NoError(t, err)
+
+	toStart, err := repo.ListToStart(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, toStart, 1)
+	assert.Equal(t, currentID, toStart[0].ID)
+
+	toFinish, err := repo.ListToFinish(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, toFinish, 1)
+	assert.Equal(t, finishedID, toFinish[0].ID)
+
+	active, err := repo.HasActiveShift(ctx, courierID, now)
+	require.NoError(t, err)
+	assert.True(t, active)
+
+	require.NoError(t, repo.MarkStarted(ctx, currentID, now))
+	require.NoError(t, repo.MarkFinished(ctx, finishedID, now))
+
+	toStart, err = repo.ListToStart(ctx, now)
+	require.NoError(t, err)
+	assert.Empty(t, toStart)
+
+	toFinish, err = repo.ListToFinish(ctx, now)
+	require.NoError(t, err)
+	assert.Empty(t, toFinish)
+}
+
+func TestShiftRepository_ListSkipsDeletedCouriers(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
+
+	repo := shiftRepo.NewShiftRepository(pool, trmpgx.DefaultCtxGetter)
+	ctx := context.Background()
+	courierID := createCourier(t, ctx, courierRepo.NewCourierRepository(pool, trmpgx.DefaultCtxGetter))
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	_, err := repo.Create(ctx, model.Shift{
+		CourierID: courierID,
+		StartsAt:  now.Add(-10 * time.Hour),
+		EndsAt:    now.Add(-2 * time.Hour),
+	})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, model.Shift{
+		CourierID: courierID,
+		StartsAt:  now.Add(-time.Hour),
+		EndsAt:    now.Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	// смены остались от курьера, удаленного в обход репозитория
+	_, err = pool.Exec(ctx, "UPDATE couriers SET deleted_at = NOW() WHERE id = $1", courierID)
+	require.NoError(t, err)
+
+	toStart, err := repo.ListToStart(ctx, now)
+	require.NoError(t, err)
+	assert.Empty(t, toStart)
+
+	toFinish, err := repo.ListToFinish(ctx, now)
+	require.NoError(t, err)
+	assert.Empty(t, toFinish)
+}
@@ -0,0 +1,407 @@
+package zone
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"service-courier/internal/model/zone"
+	"service-courier/internal/pkg/geo"
+	"service-courier/internal/pkg/geojson"
+
+	"github.com/Masterminds/squirrel"
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var zoneColumns = []string{"id", "name", "city", "polygon", "neighbor_ids", "created_at", "updated_at"}
+
+type Repository struct {
+	pool         *pgxpool.Pool
+	getter       *trmpgx.CtxGetter
+	queryBuilder squirrel.StatementBuilderType
+}
+
+func NewZoneRepository(pool *pgxpool.Pool, getter *trmpgx.CtxGetter) *Repository {
+	return &Repository{
+		pool:         pool,
+		getter:       getter,
+		queryBuilder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *Repository) exec(ctx context.Context) trmpgx.Tr {
+	return r.getter.DefaultTrOrDB(ctx, r.pool)
+}
+
+func (r *Repository) Create(ctx context.Context, zoneData zone.Zone) (id int64, err error) {
+	polygon, err := json.Marshal(geojson.NewPolygon(zoneData.Polygon))
+	if err != nil {
+		return 0, fmt.Errorf("marshal polygon: %w", err)
+	}
+	min, max := zoneData.Polygon.Bounds()
+
+	query, args, err := r.queryBuilder.
+		Insert("zones").
+		Columns("name", "city", "polygon", "min_lat", "min_lon", "max_lat", "max_lon", "neighbor_ids").
+		Values(zoneData.Name, zoneData.City, polygon, min.Lat, min.Lon, max.Lat, max.Lon, neighborIDs(zoneData.Neighbors)).
+		Suffix("RETURNING id").
+		ToSql()
+
+	if err != nil {
+		return 0, fmt.Errorf("build query: %w", err)
+	}
+
+	if err := r.exec(ctx).QueryRow(ctx, query, args...).Scan(&id); err != nil {
+		if isUniqueViolation(err) {
+			return 0, zone.ErrZoneNameExists
+		}
+		return 0, fmt.Errorf("database error: %w", err)
+	}
+	return id, nil
+}
+
+// UpsertByName создает зону или обновляет границы существующей зоны с тем же именем.
+// Соседи не меняются - их задают отдельно, когда известны идентификаторы всех зон.
+func (r *Repository) UpsertByName(ctx context.Context, zoneData zone.Zone) (id int64, err error) {
+	polygon, err := json.Marshal(geojson.NewPolygon(zoneData.Polygon))
+	if err != nil {
+		return 0, fmt.Errorf("marshal polygon: %w", err)
+	}
+	min, max := zoneData.Polygon.Bounds()
+
+	query, args, err := r.queryBuilder.
+		Insert("zones").
+		Columns("name", "city", "polygon", "min_lat", "min_lon", "max_lat", "max_lon").
+		Values(zoneData.Name, zoneData.City, polygon, min.Lat, min.Lon, max.Lat, max.Lon).
+		Suffix(`ON CONFLICT (name) DO UPDATE SET
+			city = EXCLUDED.city,
+			polygon = EXCLUDED.polygon,
+			min_lat = EXCLUDED.min_lat,
+			min_lon = EXCLUDED.min_lon,
+			max_lat = EXCLUDED.max_lat,
+			max_lon = EXCLUDED.max_lon,
+			updated_at = NOW()
+		RETURNING id`).
+		ToSql()
+
+	if err != nil {
+		return 0, fmt.Errorf("build query: %w", err)
+	}
+
+	if err := r.exec(ctx).QueryRow(ctx, query, args...).Scan(&id); err != nil {
+		return 0, fmt.Errorf("database error: %w", err)
+	}
+	return id, nil
+}
+
+func (r *Repository) GetByID(ctx context.Context, id int64) (*zone.Zone, error) {
+	query, args, err := r.queryBuilder.
+		Select(zoneColumns...).
+		From("zones").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	zoneData, err := scanZone(r.exec(ctx).QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, zone.ErrZoneNotFound
+		}
+		return nil, fmt.Errorf("query zone: %w", err)
+	}
+	return zoneData, nil
+}
+
+func (r *Repository) GetAll(ctx context.Context) ([]zone.Zone, error) {
+	query, args, err := r.queryBuilder.
+		Select(zoneColumns...).
+		From("zones").
+		OrderBy("id").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	return r.query(ctx, query, args...)
+}
+
+// ListByPoint возвращает зоны, в описанный прямоугольник которых попадает точка.
+// Точное попадание в многоугольник проверяет вызывающий.
+func (r *Repository) ListByPoint(ctx context.Context, point geo.Point) ([]zone.Zone, error) {
+	query, args, err := r.queryBuilder.
+		Select(zoneColumns...).
+		From("zones").
+		Where(squirrel.And{
+			squirrel.LtOrEq{"min_lat": point.Lat},
+			squirrel.GtOrEq{"max_lat": point.Lat},
+			squirrel.LtOrEq{"min_lon": point.Lon},
+			squirrel.GtOrEq{"max_lon": point.Lon},
+		}).
+		OrderBy("id").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	return r.query(ctx, query, args...)
+}
+
+func (r *Repository) Update(ctx context.Context, zoneData zone.Zone) error {
+	polygon, err := json.Marshal(geojson.NewPolygon(zoneData.Polygon))
+	if err != nil {
+		return fmt.Errorf("marshal polygon: %w", err)
+	}
+	min, max := zoneData.Polygon.Bounds()
+
+	query, args, err := r.queryBuilder.
+		Update("zones").
+		Set("name", zoneData.Name).
+		Set("city", zoneData.City).
+		Set("polygon", polygon).
+		Set("min_lat", min.Lat).
+		Set("min_lon", min.Lon).
+		Set("max_lat", max.Lat).
+		Set("max_lon", max.Lon).
+		Set("neighbor_ids", neighborIDs(zoneData.Neighbors)).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": zoneData.ID}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+
+	result, err := r.exec(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return zone.ErrZoneNameExists
+		}
+		return fmt.Errorf("database error: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return zone.ErrZoneNotFound
+	}
+	return nil
+}
+
+func (r *Repository) SetNeighbors(ctx context.Context, id int64, neighbors []int64) error {
+	query, args, err := r.queryBuilder.
+		Update("zones").
+		Set("neighbor_ids", neighborIDs(neighbors)).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+
+	result, err := r.exec(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return zone.ErrZoneNotFound
+	}
+	return nil
+}
+
+// Delete удаляет зону и убирает ее из списков соседей других зон
+func (r *Repository) Delete(ctx context.Context, id int64) error {
+	query, args, err := r.queryBuilder.
+		Delete("zones").
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+
+	result, err := r.exec(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return zone.ErrZoneNotFound
+	}
+
+	query, args, err = r.queryBuilder.
+		Update("zones").
+		Set("neighbor_ids", squirrel.Expr("array_remove(neighbor_ids, ?)", id)).
+		Where(squirrel.Expr("? = ANY(neighbor_ids)", id)).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+
+	if _, err := r.exec(ctx).Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	return nil
+}
+
+// CountByIDs возвращает, сколько из перечисленных зон существует
+func (r *Repository) CountByIDs(ctx context.Context, ids []int64) (int, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	query, args, err := r.queryBuilder.
+		Select("COUNT(*)").
+		From("zones").
+		Where(squirrel.Eq{"id": ids}).
+		ToSql()
+
+	if err != nil {
+		return 0, fmt.Errorf("build query: %w", err)
+	}
+
+	var count int
+	if err := r.exec(ctx).QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("database error: %w", err)
+	}
+	return count, nil
+}
+
+// SetCourierZones заменяет список зон курьера
+func (r *Repository) SetCourierZones(ctx context.Context, courierID int64, zoneIDs []int64) error {
+	query, args, err := r.queryBuilder.
+		Delete("courier_zones").
+		Where(squirrel.Eq{"courier_id": courierID}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+
+	if _, err := r.exec(ctx).Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+
+	if len(zoneIDs) == 0 {
+		return nil
+	}
+
+	builder := r.queryBuilder.
+		Insert("courier_zones").
+		Columns("courier_id", "zone_id")
+	for _, zoneID := range zoneIDs {
+		builder = builder.Values(courierID, zoneID)
+	}
+
+	query, args, err = builder.Suffix("ON CONFLICT DO NOTHING").ToSql()
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+
+	if _, err := r.exec(ctx).Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) GetCourierZoneIDs(ctx context.Context, courierID int64) ([]int64, error) {
+	query, args, err := r.queryBuilder.
+		Select("zone_id").
+		From("courier_zones").
+		Where(squirrel.Eq{"courier_id": courierID}).
+		OrderBy("zone_id").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	rows, err := r.exec(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make([]int64, 0)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan zone id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return ids, nil
+}
+
+func (r *Repository) query(ctx context.Context, query string, args ...interface{}) ([]zone.Zone, error) {
+	rows, err := r.exec(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer rows.Close()
+
+	zones := make([]zone.Zone, 0)
+	for rows.Next() {
+		zoneData, err := scanZone(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan zone: %w", err)
+		}
+		zones = append(zones, *zoneData)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return zones, nil
+}
+
+func scanZone(row pgx.Row) (*zone.Zone, error) {
+	var (
+		zoneData zone.Zone
+		polygon  []byte
+	)
+	err := row.Scan(
+		&zoneData.ID,
+		&zoneData.Name,
+		&zoneData.City,
+		&polygon,
+		&zoneData.Neighbors,
+		&zoneData.CreatedAt,
+		&zoneData.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var geometry geojson.Geometry
+	if err := json.Unmarshal(polygon, &geometry); err != nil {
+		return nil, fmt.Errorf("unmarshal polygon: %w", err)
+	}
+	zoneData.Polygon, err = geometry.Polygon()
+	if err != nil {
+		return nil, fmt.Errorf("parse polygon: %w", err)
+	}
+
+	return &zoneData, nil
+}
+
+// neighborIDs не дает записать NULL вместо пустого массива
+func neighborIDs(ids []int64) []int64 {
+	if ids == nil {
+		return []int64{}
+	}
+	return ids
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation
+}
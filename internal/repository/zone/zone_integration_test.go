@@ -0,0 +1,135 @@
+package zone_test
+
+import (
+	"context"
+	"testing"
+
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"service-courier/internal/integration"
+	modelCourier "service-courier/internal/model/courier"
+	model "service-courier/internal/model/zone"
+	"service-courier/internal/pkg/geo"
+	courierRepo "service-courier/internal/repository/courier"
+	zoneRepo "service-courier/internal/repository/zone"
+)
+
+func square(lat, lon float64) geo.Polygon {
+	return geo.Polygon{{
+		{Lat: lat, Lon: lon},
+		{Lat: lat, Lon: lon + 1},
+		{Lat: lat + 1, Lon: lon + 1},
+		{Lat: lat + 1, Lon: lon},
+		{Lat: lat, Lon: lon},
+	}}
+}
+
+func TestZoneRepository_CRUD(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
+
+	repo := zoneRepo.NewZoneRepository(pool, trmpgx.DefaultCtxGetter)
+	ctx := context.Background()
+
+	centerID, err := repo.Create(ctx, model.Zone{Name: "center", City: "Moscow", Polygon: square(55, 37)})
+	require.NoError(t, err)
+	northID, err := repo.Create(ctx, model.Zone{Name: "north", City: "Moscow", Polygon: square(56, 37), Neighbors: []int64{centerID}})
+	require.NoError(t, err)
+
+	_, err = repo.Create(ctx, model.Zone{Name: "center", Polygon: square(0, 0)})
+	assert.ErrorIs(t, err, model.ErrZoneNameExists)
+
+	require.NoError(t, repo.SetNeighbors(ctx, centerID, []int64{northID}))
+
+	center, err := repo.GetByID(ctx, centerID)
+	require.NoError(t, err)
+	assert.Equal(t, "Moscow", center.City)
+	assert.Equal(t, square(55, 37), center.Polygon)
+	assert.Equal(t, []int64{northID}, center.Neighbors)
+
+	count, err := repo.CountByIDs(ctx, []int64{centerID, northID, northID + 100})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	// Удаленная зона пропадает из соседей остальных
+	require.NoError(t, repo.Delete(ctx, centerID))
+	north, err := repo.GetByID(ctx, northID)
+	require.NoError(t, err)
+	assert.Empty(t, north.Neighbors)
+
+	_, err = repo.GetByID(ctx, centerID)
+	assert.ErrorIs(t, err, model.ErrZoneNotFound)
+}
+
+func TestZoneRepository_ListByPoint(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
+
+	repo := zoneRepo.NewZoneRepository(pool, trmpgx.DefaultCtxGetter)
+	ctx := context.Background()
+
+	centerID, err := repo.Create(ctx, model.Zone{Name: "center", Polygon: square(55, 37)})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, model.Zone{Name: "north", Polygon: square(56, 37)})
+	require.NoError(t, err)
+
+	zones, err := repo.ListByPoint(ctx, geo.Point{Lat: 55.5, Lon: 37.5})
+	require.NoError(t, err)
+	require.Len(t, zones, 1)
+	assert.Equal(t, centerID, zones[0].ID)
+
+	zones, err = repo.ListByPoint(ctx, geo.Point{Lat: 10, Lon: 10})
+	require.NoError(t, err)
+	assert.Empty(t, zones)
+}
+
+func TestZoneRepository_UpsertByName(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
+
+	repo := zoneRepo.NewZoneRepository(pool, trmpgx.DefaultCtxGetter)
+	ctx := context.Background()
+
+	id, err := repo.UpsertByName(ctx, model.Zone{Name: "center", Polygon: square(55, 37)})
+	require.NoError(t, err)
+
+	updatedID, err := repo.UpsertByName(ctx, model.Zone{Name: "center", City: "Moscow", Polygon: square(10, 10)})
+	require.NoError(t, err)
+	assert.Equal(t, id, updatedID)
+
+	updated, err := repo.GetByID(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "Moscow", updated.City)
+	assert.Equal(t, square(10, 10), updated.Polygon)
+}
+
+func TestZoneRepository_CourierZones(t *testing.T) {
+	pool, cleanup := integration.SetupTestDB(t)
+	defer cleanup()
+
+	repo := zoneRepo.NewZoneRepository(pool, trmpgx.DefaultCtxGetter)
+	couriers := courierRepo.NewCourierRepository(pool, trmpgx.DefaultCtxGetter)
+	ctx := context.Background()
+
+	courierID, err := couriers.Create(ctx, modelCourier.Courier{
+		Name:          "Ivan",
+		Phone:         "+78005553535",
+		Status:        modelCourier.StatusAvailable,
+		TransportType: modelCourier.TransportCar,
+	})
+	require.NoError(t, err)
+
+	centerID, err := repo.Create(ctx, model.Zone{Name: "center", Polygon: square(55, 37)})
+	require.NoError(t, err)
+	northID, err := repo.Create(ctx, model.Zone{Name: "north", Polygon: square(56, 37)})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.SetCourierZones(ctx, courierID, []int64{centerID, northID}))
+	require.NoError(t, repo.SetCourierZones(ctx, courierID, []int64{northID}))
+
+	ids, err := repo.GetCourierZoneIDs(ctx, courierID)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{northID}, ids)
+}
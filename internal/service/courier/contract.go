@@ -4,11 +4,26 @@ package courier
 import (
 	"context"
 	"service-courier/internal/model/courier"
+	"service-courier/internal/pkg/geo"
 )
 
 type courierRepository interface {
 	GetByID(ctx context.Context, id int64) (*courier.Courier, error)
-	GetAll(ctx context.Context) ([]courier.Courier, error)
+	List(ctx context.Context, filter courier.ListFilter) ([]courier.Courier, error)
+	Count(ctx context.Context, filter courier.ListFilter) (int64, error)
 	Create(ctx context.Context, courierData courier.Courier) (int64, error)
+	CreateBatch(ctx context.Context, couriers []courier.Courier) ([]int64, error)
+	CreateBatchSkipTaken(ctx context.Context, couriers []courier.Courier) ([]int64, error)
+	ExistingPhones(ctx context.Context, phones []string) ([]string, error)
 	Update(ctx context.Context, courierData courier.Courier) error
+	Replace(ctx context.Context, courierData courier.Courier, setLocation bool) (*courier.Courier, error)
+	CountActiveDeliveries(ctx context.Context, id int64) (int64, error)
+	SetDeactivated(ctx context.Context, id int64, deactivated bool) error
+	Delete(ctx context.Context, id int64) error
+	Erase(ctx context.Context, id int64) error
+	UpdateLocation(ctx context.Context, id int64, point geo.Point) error
+}
+
+type availabilityNotifier interface {
+	NotifyCourierFreed()
 }
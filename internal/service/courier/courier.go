@@ -2,32 +2,128 @@ package courier
 
 import (
 	"context"
+	"fmt"
 	"service-courier/internal/metrics"
 	"service-courier/internal/model/courier"
+	"service-courier/internal/pkg/geo"
+)
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 500
 )
 
 type Service struct {
-	repo courierRepository
+	repo     courierRepository
+	notifier availabilityNotifier
+}
+
+type Option func(*Service)
+
+// WithAvailabilityNotifier сообщает о курьерах, вручную переведенных в available,
+// чтобы заказы из очереди ожидания получили их без задержки
+func WithAvailabilityNotifier(notifier availabilityNotifier) Option {
+	return func(s *Service) {
+		s.notifier = notifier
+	}
 }
 
-func NewCourierService(repo courierRepository) *Service {
-	return &Service{repo: repo}
+func NewCourierService(repo courierRepository, opts ...Option) *Service {
+	s := &Service{repo: repo}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 func (s *Service) GetCourier(ctx context.Context, id int64) (*courier.Courier, error) {
 	return s.repo.GetByID(ctx, id)
 }
 
-func (s *Service) GetAllCouriers(ctx context.Context) ([]courier.Courier, error) {
-	return s.repo.GetAll(ctx)
+// ListCouriers возвращает страницу курьеров по фильтру и общее число подходящих курьеров.
+// Next указывает на последнего курьера страницы и передается в filter.After за следующей.
+func (s *Service) ListCouriers(ctx context.Context, filter courier.ListFilter) (*courier.Page, error) {
+	if filter.Limit == 0 || filter.Limit > maxListLimit {
+		filter.Limit = defaultListLimit
+	}
+	if !filter.Sort.IsValid() {
+		filter.Sort = courier.SortByID
+	}
+
+	total, err := s.repo.Count(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("count couriers: %w", err)
+	}
+
+	limit := filter.Limit
+	// одна лишняя строка показывает, есть ли следующая страница
+	filter.Limit++
+
+	couriers, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list couriers: %w", err)
+	}
+
+	page := &courier.Page{Couriers: couriers, Total: total}
+	if uint64(len(couriers)) > limit {
+		page.Couriers = couriers[:limit]
+		last := page.Couriers[limit-1]
+		page.Next = &courier.Cursor{Name: last.Name, ID: last.ID}
+	}
+	return page, nil
 }
 
+// CreateCourier создает курьера; новый курьер не может быть busy - у него еще нет доставок
 func (s *Service) CreateCourier(ctx context.Context, courierData courier.Courier) (id int64, err error) {
 	metrics.OpsCounter.Inc()
+	if courierData.Status == courier.StatusBusy {
+		return 0, courier.ErrBusyStatusManaged
+	}
 	return s.repo.Create(ctx, courierData)
 }
 
+// UpdateCourier меняет непустые поля курьера. Смена статуса проверяется по правилам
+// checkStatusChange для текущей версии курьера.
 func (s *Service) UpdateCourier(ctx context.Context, courierData courier.Courier) error {
 	metrics.OpsCounter.Inc()
-	return s.repo.Update(ctx, courierData)
+	if courierData.Status != "" {
+		version, err := s.guardStatusChange(ctx, courierData)
+		if err != nil {
+			return err
+		}
+		courierData.Version = version
+	}
+
+	if err := s.repo.Update(ctx, courierData); err != nil {
+		return err
+	}
+
+	if s.notifier != nil && courierData.Status == courier.StatusAvailable {
+		s.notifier.NotifyCourierFreed()
+	}
+	return nil
+}
+
+// PatchCourier сохраняет курьера целиком, если он не менялся после версии courierData.Version,
+// и возвращает его новое состояние. Иначе - ErrVersionMismatch.
+// Позиция сохраняется, только если setLocation: патч ее задает или удаляет.
+func (s *Service) PatchCourier(ctx context.Context, courierData courier.Courier, setLocation bool) (*courier.Courier, error) {
+	metrics.OpsCounter.Inc()
+	if _, err := s.guardStatusChange(ctx, courierData); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.Replace(ctx, courierData, setLocation)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.notifier != nil && updated.Status == courier.StatusAvailable {
+		s.notifier.NotifyCourierFreed()
+	}
+	return updated, nil
+}
+
+func (s *Service) UpdateLocation(ctx context.Context, id int64, point geo.Point) error {
+	return s.repo.UpdateLocation(ctx, id, point)
 }
@@ -7,6 +7,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	trmpgx "github.com/avito-tech/go-transaction-manager/drivers/pgxv5/v2"
+
 	courierService "service-courier/internal/service/courier"
 	courierRepo "service-courier/internal/repository/courier"
 	"service-courier/internal/integration"
@@ -17,7 +19,7 @@ func TestCourierService_Integration(t *testing.T) {
 	pool, cleanup := integration.SetupTestDB(t)
 	defer cleanup()
 
-	repo := courierRepo.NewCourierRepository(pool)
+	repo := courierRepo.NewCourierRepository(pool, trmpgx.DefaultCtxGetter)
 	service := courierService.NewCourierService(repo)
 	ctx := context.Background()
 
@@ -46,7 +48,7 @@ func TestCourierService_Integration(t *testing.T) {
 	updatedData := model.Courier{
 		ID:     id,
 		Name:   "Ivan Updated",
-		Status: model.StatusBusy,
+		Status: model.StatusPaused,
 	}
 	err = service.UpdateCourier(ctx, updatedData)
 	require.NoError(t, err)
@@ -55,23 +57,23 @@ func TestCourierService_Integration(t *testing.T) {
 	updated, err := service.GetCourier(ctx, id)
 	require.NoError(t, err)
 	assert.Equal(t, "Ivan Updated", updated.Name)
-	assert.EqualValues(t, model.StatusBusy, updated.Status)
+	assert.EqualValues(t, model.StatusPaused, updated.Status)
 	// Phone и TransportType не должны измениться
 	assert.Equal(t, courierData.Phone, updated.Phone)
 	assert.EqualValues(t, courierData.TransportType, updated.TransportType)
 
-	// Тест получения всех курьеров
-	allCouriers, err := service.GetAllCouriers(ctx)
+	// Тест списка курьеров
+	page, err := service.ListCouriers(ctx, model.ListFilter{})
 	require.NoError(t, err)
-	assert.GreaterOrEqual(t, len(allCouriers), 1)
+	assert.GreaterOrEqual(t, len(page.Couriers), 1)
 
 	// Проверяем, что созданный курьер есть в списке
 	found := false
-	for _, c := range allCouriers {
+	for _, c := range page.Couriers {
 		if c.ID == id {
 			found = true
 			assert.Equal(t, "Ivan Updated", c.Name)
-			assert.EqualValues(t, model.StatusBusy, c.Status)
+			assert.EqualValues(t, model.StatusPaused, c.Status)
 		}
 	}
 	assert.True(t, found, "created courier should be in the list")
@@ -81,7 +83,7 @@ func TestCourierService_Integration_PhoneExists(t *testing.T) {
 	pool, cleanup := integration.SetupTestDB(t)
 	defer cleanup()
 
-	repo := courierRepo.NewCourierRepository(pool)
+	repo := courierRepo.NewCourierRepository(pool, trmpgx.DefaultCtxGetter)
 	service := courierService.NewCourierService(repo)
 	ctx := context.Background()
 
@@ -107,7 +109,7 @@ func TestCourierService_Integration_NotFound(t *testing.T) {
 	pool, cleanup := integration.SetupTestDB(t)
 	defer cleanup()
 
-	repo := courierRepo.NewCourierRepository(pool)
+	repo := courierRepo.NewCourierRepository(pool, trmpgx.DefaultCtxGetter)
 	service := courierService.NewCourierService(repo)
 	ctx := context.Background()
 
@@ -9,6 +9,7 @@ import (
 	"go.uber.org/mock/gomock"
 
 	model "service-courier/internal/model/courier"
+	"service-courier/internal/pkg/geo"
 	courierService "service-courier/internal/service/courier"
 	"service-courier/internal/service/courier/mocks"
 )
@@ -109,7 +110,7 @@ func TestGetCourier_RepositoryError(t *testing.T) {
 	}
 }
 
-func TestGetAllCouriers_Success(t *testing.T) {
+func TestListCouriers_NextCursorAndTotal(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -117,50 +118,43 @@ func TestGetAllCouriers_Success(t *testing.T) {
 	mockRepo := mocks.NewMockcourierRepository(ctrl)
 	service := courierService.NewCourierService(mockRepo)
 
-	expectedCouriers := []model.Courier{
-		{
-			ID:            1,
-			Name:          "Ivan",
-			Phone:         "+78005553535",
-			Status:        model.StatusAvailable,
-			TransportType: model.TransportCar,
-			CreatedAt:     time.Now(),
-			UpdatedAt:     time.Now(),
-		},
-		{
-			ID:            2,
-			Name:          "Petr",
-			Phone:         "+78005553536",
-			Status:        model.StatusBusy,
-			TransportType: model.TransportScooter,
-			CreatedAt:     time.Now(),
-			UpdatedAt:     time.Now(),
-		},
+	filter := model.ListFilter{
+		Statuses: []model.CourierStatus{model.StatusAvailable},
+		Sort:     model.SortByName,
+		Limit:    2,
 	}
 
 	mockRepo.EXPECT().
-		GetAll(gomock.Any()).
-		Return(expectedCouriers, nil)
+		Count(gomock.Any(), filter).
+		Return(int64(5), nil)
 
-	result, err := service.GetAllCouriers(context.Background())
+	paged := filter
+	paged.Limit = 3
+	mockRepo.EXPECT().
+		List(gomock.Any(), paged).
+		Return([]model.Courier{
+			{ID: 4, Name: "Anna"},
+			{ID: 2, Name: "Boris"},
+			{ID: 9, Name: "Ivan"},
+		}, nil)
+
+	page, err := service.ListCouriers(context.Background(), filter)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
-	if result == nil {
-		t.Fatal("expected result, got nil")
-	}
-	if len(result) != 2 {
-		t.Fatalf("expected 2 couriers, got %d", len(result))
+
+	if len(page.Couriers) != 2 {
+		t.Fatalf("expected 2 couriers, got %d", len(page.Couriers))
 	}
-	if result[0].ID != 1 {
-		t.Fatalf("expected first courier ID=1, got %d", result[0].ID)
+	if page.Total != 5 {
+		t.Errorf("expected total 5, got %d", page.Total)
 	}
-	if result[1].ID != 2 {
-		t.Fatalf("expected second courier ID=2, got %d", result[1].ID)
+	if page.Next == nil || page.Next.ID != 2 || page.Next.Name != "Boris" {
+		t.Errorf("expected cursor at Boris, got %v", page.Next)
 	}
 }
 
-func TestGetAllCouriers_EmptyList(t *testing.T) {
+func TestListCouriers_CapsLimit(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -169,43 +163,18 @@ func TestGetAllCouriers_EmptyList(t *testing.T) {
 	service := courierService.NewCourierService(mockRepo)
 
 	mockRepo.EXPECT().
-		GetAll(gomock.Any()).
-		Return([]model.Courier{}, nil)
+		Count(gomock.Any(), gomock.Any()).
+		Return(int64(1), nil)
+	mockRepo.EXPECT().
+		List(gomock.Any(), model.ListFilter{Sort: model.SortByID, Limit: 51}).
+		Return([]model.Courier{{ID: 1}}, nil)
 
-	result, err := service.GetAllCouriers(context.Background())
+	page, err := service.ListCouriers(context.Background(), model.ListFilter{Limit: 100000})
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
-	if result == nil {
-		t.Fatal("expected empty slice, got nil")
-	}
-	if len(result) != 0 {
-		t.Fatalf("expected 0 couriers, got %d", len(result))
-	}
-}
-
-func TestGetAllCouriers_RepositoryError(t *testing.T) {
-	t.Parallel()
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	mockRepo := mocks.NewMockcourierRepository(ctrl)
-	service := courierService.NewCourierService(mockRepo)
-
-	repoErr := errors.New("database query error")
-	mockRepo.EXPECT().
-		GetAll(gomock.Any()).
-		Return(nil, repoErr)
-
-	result, err := service.GetAllCouriers(context.Background())
-	if err == nil {
-		t.Fatal("expected error, got nil")
-	}
-	if !errors.Is(err, repoErr) {
-		t.Fatalf("expected database error, got %v", err)
-	}
-	if result != nil {
-		t.Fatalf("expected nil result, got %v", result)
+	if page.Next != nil {
+		t.Errorf("expected no next page, got %v", page.Next)
 	}
 }
 
@@ -324,10 +293,9 @@ func TestUpdateCourier_Success(t *testing.T) {
 	service := courierService.NewCourierService(mockRepo)
 
 	courierData := model.Courier{
-		ID:     1,
-		Name:   "Updated Name",
-		Phone:  "+78005553535",
-		Status: model.StatusBusy,
+		ID:    1,
+		Name:  "Updated Name",
+		Phone: "+78005553535",
 	}
 
 	mockRepo.EXPECT().
@@ -429,3 +397,111 @@ func TestUpdateCourier_RepositoryError(t *testing.T) {
 		t.Fatalf("expected database error, got %v", err)
 	}
 }
+
+func TestPatchCourier_VersionMismatch(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockcourierRepository(ctrl)
+	mockNotifier := mocks.NewMockavailabilityNotifier(ctrl)
+	service := courierService.NewCourierService(mockRepo, courierService.WithAvailabilityNotifier(mockNotifier))
+
+	courierData := model.Courier{ID: 1, Name: "Ivan", Status: model.StatusAvailable, Version: 3}
+	mockRepo.EXPECT().
+		GetByID(gomock.Any(), int64(1)).
+		Return(&model.Courier{ID: 1, Status: model.StatusBusy, Version: 4}, nil)
+
+	_, err := service.PatchCourier(context.Background(), courierData, false)
+	if !errors.Is(err, model.ErrVersionMismatch) {
+		t.Fatalf("expected ErrVersionMismatch, got %v", err)
+	}
+}
+
+func TestPatchCourier_NotifiesAvailable(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockcourierRepository(ctrl)
+	mockNotifier := mocks.NewMockavailabilityNotifier(ctrl)
+	service := courierService.NewCourierService(mockRepo, courierService.WithAvailabilityNotifier(mockNotifier))
+
+	courierData := model.Courier{ID: 1, Name: "Ivan", Status: model.StatusAvailable, Version: 3}
+	mockRepo.EXPECT().
+		GetByID(gomock.Any(), int64(1)).
+		Return(&model.Courier{ID: 1, Status: model.StatusAvailable, Version: 3}, nil)
+	mockRepo.EXPECT().
+		Replace(gomock.Any(), courierData, false).
+		Return(&model.Courier{ID: 1, Name: "Ivan", Status: model.StatusAvailable, Version: 4}, nil)
+	mockNotifier.EXPECT().NotifyCourierFreed().Times(1)
+
+	updated, err := service.PatchCourier(context.Background(), courierData, false)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if updated.Version != 4 {
+		t.Errorf("expected version 4, got %d", updated.Version)
+	}
+}
+
+func TestUpdateLocation_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockcourierRepository(ctrl)
+	service := courierService.NewCourierService(mockRepo)
+
+	point := geo.Point{Lat: 55.75, Lon: 37.61}
+	mockRepo.EXPECT().
+		UpdateLocation(gomock.Any(), int64(1), point).
+		Return(nil)
+
+	if err := service.UpdateLocation(context.Background(), 1, point); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestUpdateLocation_NotFound(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockcourierRepository(ctrl)
+	service := courierService.NewCourierService(mockRepo)
+
+	mockRepo.EXPECT().
+		UpdateLocation(gomock.Any(), int64(99), gomock.Any()).
+		Return(model.ErrCourierNotFound)
+
+	err := service.UpdateLocation(context.Background(), 99, geo.Point{Lat: 55.75, Lon: 37.61})
+	if !errors.Is(err, model.ErrCourierNotFound) {
+		t.Fatalf("expected ErrCourierNotFound, got %v", err)
+	}
+}
+
+func TestUpdateCourier_NotifiesWhenAvailable(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockcourierRepository(ctrl)
+	mockNotifier := mocks.NewMockavailabilityNotifier(ctrl)
+	service := courierService.NewCourierService(mockRepo, courierService.WithAvailabilityNotifier(mockNotifier))
+
+	mockRepo.EXPECT().
+		GetByID(gomock.Any(), int64(1)).
+		Return(&model.Courier{ID: 1, Status: model.StatusPaused, Version: 1}, nil).
+		Times(2)
+	mockRepo.EXPECT().CountActiveDeliveries(gomock.Any(), int64(1)).Return(int64(0), nil)
+	mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	mockNotifier.EXPECT().NotifyCourierFreed().Times(1)
+
+	if err := service.UpdateCourier(context.Background(), model.Courier{ID: 1, Status: model.StatusAvailable}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := service.UpdateCourier(context.Background(), model.Courier{ID: 1, Status: model.StatusPaused}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+package courier
+
+import (
+	"context"
+	"fmt"
+	"service-courier/internal/metrics"
+	"service-courier/internal/model/courier"
+)
+
+// ImportCouriers создает курьеров из файла импорта и возвращает отчет по каждой строке.
+// Строки с ошибкой разбора, телефоном, который уже занят или повторяется в файле, в базу не попадают.
+// В режиме atomic любая такая строка отменяет весь импорт, в partial создаются остальные.
+func (s *Service) ImportCouriers(ctx context.Context, rows []courier.ImportRow, mode courier.ImportMode) (*courier.ImportReport, error) {
+	metrics.OpsCounter.Inc()
+	if !mode.IsValid() {
+		mode = courier.ImportAtomic
+	}
+
+	report := &courier.ImportReport{
+		Mode: mode,
+		Rows: make([]courier.ImportResult, len(rows)),
+	}
+
+	phones := make([]string, 0, len(rows))
+	for i, row := range rows {
+		if row.Err == nil && row.Courier.Status == courier.StatusBusy {
+			row.Err = courier.ErrBusyStatusManaged
+		}
+		report.Rows[i] = courier.ImportResult{Line: row.Line, Phone: row.Courier.Phone, Err: row.Err}
+		if row.Err == nil {
+			phones = append(phones, row.Courier.Phone)
+		}
+	}
+
+	existing, err := s.repo.ExistingPhones(ctx, phones)
+	if err != nil {
+		return nil, fmt.Errorf("check existing phones: %w", err)
+	}
+
+	taken := make(map[string]bool, len(existing)+len(phones))
+	for _, phone := range existing {
+		taken[phone] = true
+	}
+
+	seen := make(map[string]bool, len(phones))
+	valid := make([]int, 0, len(rows))
+	for i := range report.Rows {
+		result := &report.Rows[i]
+		switch {
+		case result.Err != nil:
+		case taken[result.Phone]:
+			result.Err = courier.ErrPhoneExists
+		case seen[result.Phone]:
+			result.Err = courier.ErrDuplicatePhone
+		default:
+			seen[result.Phone] = true
+			valid = append(valid, i)
+		}
+	}
+	report.Failed = len(rows) - len(valid)
+
+	if len(valid) == 0 || (mode == courier.ImportAtomic && report.Failed > 0) {
+		return report, nil
+	}
+
+	couriers := make([]courier.Courier, len(valid))
+	for j, i := range valid {
+		couriers[j] = rows[i].Courier
+	}
+
+	// телефон могут занять между проверкой и вставкой: в partial такая строка
+	// пропускается, а не отменяет весь импорт
+	create := s.repo.CreateBatch
+	if mode == courier.ImportPartial {
+		create = s.repo.CreateBatchSkipTaken
+	}
+
+	ids, err := create(ctx, couriers)
+	if err != nil {
+		return nil, fmt.Errorf("create couriers: %w", err)
+	}
+
+	for j, i := range valid {
+		if ids[j] == 0 {
+			report.Rows[i].Err = courier.ErrPhoneExists
+			report.Failed++
+			continue
+		}
+		report.Rows[i].ID = ids[j]
+		report.Created++
+	}
+	report.Committed = true
+	return report, nil
+}
@@ -0,0 +1,133 @@
+package courier_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	model "service-courier/internal/model/courier"
+	courierService "service-courier/internal/service/courier"
+	"service-courier/internal/service/courier/mocks"
+)
+
+func importRows() []model.ImportRow {
+	return []model.ImportRow{
+		{Line: 2, Courier: model.Courier{Name: "Ivan", Phone: "+79990000001", Status: model.StatusAvailable}},
+		{Line: 3, Courier: model.Courier{Name: "Anna", Phone: "+79990000002", Status: model.StatusAvailable}},
+		{Line: 4, Courier: model.Courier{Name: "Petr", Phone: "+79990000001", Status: model.StatusAvailable}},
+		{Line: 5, Err: errors.New("invalid phone")},
+		{Line: 6, Courier: model.Courier{Name: "Oleg", Phone: "+79990000003", Status: model.StatusAvailable}},
+	}
+}
+
+func TestImportCouriers_AtomicAbortsOnAnyError(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockcourierRepository(ctrl)
+	service := courierService.NewCourierService(mockRepo)
+
+	mockRepo.EXPECT().
+		ExistingPhones(gomock.Any(), []string{"+79990000001", "+79990000002", "+79990000001", "+79990000003"}).
+		Return([]string{"+79990000003"}, nil)
+
+	report, err := service.ImportCouriers(context.Background(), importRows(), model.ImportAtomic)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if report.Committed || report.Created != 0 {
+		t.Fatalf("expected nothing committed, got %+v", report)
+	}
+	if report.Failed != 3 {
+		t.Errorf("expected 3 failed rows, got %d", report.Failed)
+	}
+	if !errors.Is(report.Rows[2].Err, model.ErrDuplicatePhone) {
+		t.Errorf("expected duplicate phone on line 4, got %v", report.Rows[2].Err)
+	}
+	if !errors.Is(report.Rows[4].Err, model.ErrPhoneExists) {
+		t.Errorf("expected existing phone on line 6, got %v", report.Rows[4].Err)
+	}
+}
+
+func TestImportCouriers_PartialCreatesValidRows(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockcourierRepository(ctrl)
+	service := courierService.NewCourierService(mockRepo)
+
+	rows := importRows()
+	mockRepo.EXPECT().
+		ExistingPhones(gomock.Any(), gomock.Any()).
+		Return([]string{"+79990000003"}, nil)
+	mockRepo.EXPECT().
+		CreateBatchSkipTaken(gomock.Any(), []model.Courier{rows[0].Courier, rows[1].Courier}).
+		Return([]int64{11, 12}, nil)
+
+	report, err := service.ImportCouriers(context.Background(), rows, model.ImportPartial)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !report.Committed || report.Created != 2 || report.Failed != 3 {
+		t.Fatalf("expected 2 created and 3 failed, got %+v", report)
+	}
+	if report.Rows[0].ID != 11 || report.Rows[1].ID != 12 {
+		t.Errorf("expected ids 11 and 12, got %d and %d", report.Rows[0].ID, report.Rows[1].ID)
+	}
+	if report.Rows[3].ID != 0 || report.Rows[3].Err == nil {
+		t.Errorf("expected invalid row to keep its error, got %+v", report.Rows[3])
+	}
+}
+
+func TestImportCouriers_PhoneTakenConcurrently(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockcourierRepository(ctrl)
+	service := courierService.NewCourierService(mockRepo)
+
+	rows := importRows()[:2]
+	mockRepo.EXPECT().ExistingPhones(gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockRepo.EXPECT().CreateBatch(gomock.Any(), gomock.Any()).Return(nil, model.ErrPhoneExists)
+
+	_, err := service.ImportCouriers(context.Background(), rows, model.ImportAtomic)
+	if !errors.Is(err, model.ErrPhoneExists) {
+		t.Fatalf("expected ErrPhoneExists, got %v", err)
+	}
+}
+
+func TestImportCouriers_PartialSkipsPhoneTakenConcurrently(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockcourierRepository(ctrl)
+	service := courierService.NewCourierService(mockRepo)
+
+	rows := importRows()[:2]
+	mockRepo.EXPECT().ExistingPhones(gomock.Any(), gomock.Any()).Return(nil, nil)
+	// второй телефон заняли после проверки
+	mockRepo.EXPECT().CreateBatchSkipTaken(gomock.Any(), gomock.Any()).Return([]int64{11, 0}, nil)
+
+	report, err := service.ImportCouriers(context.Background(), rows, model.ImportPartial)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !report.Committed || report.Created != 1 || report.Failed != 1 {
+		t.Fatalf("expected 1 created and 1 failed, got %+v", report)
+	}
+	if report.Rows[0].ID != 11 {
+		t.Errorf("expected id 11, got %d", report.Rows[0].ID)
+	}
+	if report.Rows[1].ID != 0 || !errors.Is(report.Rows[1].Err, model.ErrPhoneExists) {
+		t.Errorf("expected existing phone on line 3, got %+v", report.Rows[1])
+	}
+}
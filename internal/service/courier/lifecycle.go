@@ -0,0 +1,59 @@
+package courier
+
+import (
+	"context"
+	"fmt"
+	"service-courier/internal/metrics"
+	"service-courier/internal/model/courier"
+)
+
+// DeactivateCourier выключает курьера из назначения новых заказов. Текущие доставки он завершает,
+// история и смены сохраняются.
+func (s *Service) DeactivateCourier(ctx context.Context, id int64) error {
+	metrics.OpsCounter.Inc()
+	return s.repo.SetDeactivated(ctx, id, true)
+}
+
+// ActivateCourier возвращает деактивированного курьера в назначение заказов
+func (s *Service) ActivateCourier(ctx context.Context, id int64) error {
+	metrics.OpsCounter.Inc()
+	if err := s.repo.SetDeactivated(ctx, id, false); err != nil {
+		return err
+	}
+
+	if s.notifier != nil {
+		s.notifier.NotifyCourierFreed()
+	}
+	return nil
+}
+
+// DeleteCourier мягко удаляет курьера. Курьера с незавершенными доставками удалить нельзя -
+// сначала доставки должны завершиться или быть сняты.
+func (s *Service) DeleteCourier(ctx context.Context, id int64) error {
+	metrics.OpsCounter.Inc()
+	if err := s.checkNoActiveDeliveries(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id)
+}
+
+// EraseCourier удаляет персональные данные курьера по запросу субъекта данных. Курьер удаляется,
+// если еще не удален; его доставки остаются и ссылаются на обезличенную запись.
+func (s *Service) EraseCourier(ctx context.Context, id int64) error {
+	metrics.OpsCounter.Inc()
+	if err := s.checkNoActiveDeliveries(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.Erase(ctx, id)
+}
+
+func (s *Service) checkNoActiveDeliveries(ctx context.Context, id int64) error {
+	active, err := s.repo.CountActiveDeliveries(ctx, id)
+	if err != nil {
+		return fmt.Errorf("count active deliveries: %w", err)
+	}
+	if active > 0 {
+		return courier.ErrHasActiveDeliveries
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package courier_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	model "service-courier/internal/model/courier"
+	courierService "service-courier/internal/service/courier"
+	"service-courier/internal/service/courier/mocks"
+)
+
+func TestDeleteCourier_ActiveDeliveries(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockcourierRepository(ctrl)
+	service := courierService.NewCourierService(mockRepo)
+
+	mockRepo.EXPECT().CountActiveDeliveries(gomock.Any(), int64(1)).Return(int64(2), nil)
+
+	if err := service.DeleteCourier(context.Background(), 1); !errors.Is(err, model.ErrHasActiveDeliveries) {
+		t.Fatalf("expected ErrHasActiveDeliveries, got %v", err)
+	}
+}
+
+func TestEraseCourier_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockcourierRepository(ctrl)
+	service := courierService.NewCourierService(mockRepo)
+
+	gomock.InOrder(
+		mockRepo.EXPECT().CountActiveDeliveries(gomock.Any(), int64(1)).Return(int64(0), nil),
+		mockRepo.EXPECT().Erase(gomock.Any(), int64(1)).Return(nil),
+	)
+
+	if err := service.EraseCourier(context.Background(), 1); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestActivateCourier_Notifies(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockcourierRepository(ctrl)
+	mockNotifier := mocks.NewMockavailabilityNotifier(ctrl)
+	service := courierService.NewCourierService(mockRepo, courierService.WithAvailabilityNotifier(mockNotifier))
+
+	mockRepo.EXPECT().SetDeactivated(gomock.Any(), int64(1), true).Return(nil)
+	mockRepo.EXPECT().SetDeactivated(gomock.Any(), int64(1), false).Return(nil)
+	mockNotifier.EXPECT().NotifyCourierFreed().Times(1)
+
+	if err := service.DeactivateCourier(context.Background(), 1); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := service.ActivateCourier(context.Background(), 1); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 	courier "service-courier/internal/model/courier"
+	geo "service-courier/internal/pkg/geo"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -41,6 +42,36 @@ func (m *MockcourierRepository) EXPECT() *MockcourierRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Count mocks base method.
+func (m *MockcourierRepository) Count(ctx context.Context, filter courier.ListFilter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockcourierRepositoryMockRecorder) Count(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockcourierRepository)(nil).Count), ctx, filter)
+}
+
+// CountActiveDeliveries mocks base method.
+func (m *MockcourierRepository) CountActiveDeliveries(ctx context.Context, id int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountActiveDeliveries", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountActiveDeliveries indicates an expected call of CountActiveDeliveries.
+func (mr *MockcourierRepositoryMockRecorder) CountActiveDeliveries(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountActiveDeliveries", reflect.TypeOf((*MockcourierRepository)(nil).CountActiveDeliveries), ctx, id)
+}
+
 // Create mocks base method.
 func (m *MockcourierRepository) Create(ctx context.Context, courierData courier.Courier) (int64, error) {
 	m.ctrl.T.Helper()
@@ -56,19 +87,77 @@ func (mr *MockcourierRepositoryMockRecorder) Create(ctx, courierData any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockcourierRepository)(nil).Create), ctx, courierData)
 }
 
-// GetAll mocks base method.
-func (m *MockcourierRepository) GetAll(ctx context.Context) ([]courier.Courier, error) {
+// CreateBatch mocks base method.
+func (m *MockcourierRepository) CreateBatch(ctx context.Context, couriers []courier.Courier) ([]int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", ctx)
-	ret0, _ := ret[0].([]courier.Courier)
+	ret := m.ctrl.Call(m, "CreateBatch", ctx, couriers)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBatch indicates an expected call of CreateBatch.
+func (mr *MockcourierRepositoryMockRecorder) CreateBatch(ctx, couriers any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatch", reflect.TypeOf((*MockcourierRepository)(nil).CreateBatch), ctx, couriers)
+}
+
+// CreateBatchSkipTaken mocks base method.
+func (m *MockcourierRepository) CreateBatchSkipTaken(ctx context.Context, couriers []courier.Courier) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBatchSkipTaken", ctx, couriers)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBatchSkipTaken indicates an expected call of CreateBatchSkipTaken.
+func (mr *MockcourierRepositoryMockRecorder) CreateBatchSkipTaken(ctx, couriers any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatchSkipTaken", reflect.TypeOf((*MockcourierRepository)(nil).CreateBatchSkipTaken), ctx, couriers)
+}
+
+// Delete mocks base method.
+func (m *MockcourierRepository) Delete(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockcourierRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockcourierRepository)(nil).Delete), ctx, id)
+}
+
+// Erase mocks base method.
+func (m *MockcourierRepository) Erase(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Erase", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Erase indicates an expected call of Erase.
+func (mr *MockcourierRepositoryMockRecorder) Erase(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Erase", reflect.TypeOf((*MockcourierRepository)(nil).Erase), ctx, id)
+}
+
+// ExistingPhones mocks base method.
+func (m *MockcourierRepository) ExistingPhones(ctx context.Context, phones []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistingPhones", ctx, phones)
+	ret0, _ := ret[0].([]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAll indicates an expected call of GetAll.
-func (mr *MockcourierRepositoryMockRecorder) GetAll(ctx any) *gomock.Call {
+// ExistingPhones indicates an expected call of ExistingPhones.
+func (mr *MockcourierRepositoryMockRecorder) ExistingPhones(ctx, phones any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockcourierRepository)(nil).GetAll), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistingPhones", reflect.TypeOf((*MockcourierRepository)(nil).ExistingPhones), ctx, phones)
 }
 
 // GetByID mocks base method.
@@ -86,6 +175,50 @@ func (mr *MockcourierRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockcourierRepository)(nil).GetByID), ctx, id)
 }
 
+// List mocks base method.
+func (m *MockcourierRepository) List(ctx context.Context, filter courier.ListFilter) ([]courier.Courier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]courier.Courier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockcourierRepositoryMockRecorder) List(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockcourierRepository)(nil).List), ctx, filter)
+}
+
+// Replace mocks base method.
+func (m *MockcourierRepository) Replace(ctx context.Context, courierData courier.Courier, setLocation bool) (*courier.Courier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replace", ctx, courierData, setLocation)
+	ret0, _ := ret[0].(*courier.Courier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Replace indicates an expected call of Replace.
+func (mr *MockcourierRepositoryMockRecorder) Replace(ctx, courierData, setLocation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replace", reflect.TypeOf((*MockcourierRepository)(nil).Replace), ctx, courierData, setLocation)
+}
+
+// SetDeactivated mocks base method.
+func (m *MockcourierRepository) SetDeactivated(ctx context.Context, id int64, deactivated bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDeactivated", ctx, id, deactivated)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDeactivated indicates an expected call of SetDeactivated.
+func (mr *MockcourierRepositoryMockRecorder) SetDeactivated(ctx, id, deactivated any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDeactivated", reflect.TypeOf((*MockcourierRepository)(nil).SetDeactivated), ctx, id, deactivated)
+}
+
 // Update mocks base method.
 func (m *MockcourierRepository) Update(ctx context.Context, courierData courier.Courier) error {
 	m.ctrl.T.Helper()
@@ -99,3 +232,53 @@ func (mr *MockcourierRepositoryMockRecorder) Update(ctx, courierData any) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockcourierRepository)(nil).Update), ctx, courierData)
 }
+
+// UpdateLocation mocks base method.
+func (m *MockcourierRepository) UpdateLocation(ctx context.Context, id int64, point geo.Point) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLocation", ctx, id, point)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLocation indicates an expected call of UpdateLocation.
+func (mr *MockcourierRepositoryMockRecorder) UpdateLocation(ctx, id, point any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLocation", reflect.TypeOf((*MockcourierRepository)(nil).UpdateLocation), ctx, id, point)
+}
+
+// MockavailabilityNotifier is a mock of availabilityNotifier interface.
+type MockavailabilityNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockavailabilityNotifierMockRecorder
+	isgomock struct{}
+}
+
+// MockavailabilityNotifierMockRecorder is the mock recorder for MockavailabilityNotifier.
+type MockavailabilityNotifierMockRecorder struct {
+	mock *MockavailabilityNotifier
+}
+
+// NewMockavailabilityNotifier creates a new mock instance.
+func NewMockavailabilityNotifier(ctrl *gomock.Controller) *MockavailabilityNotifier {
+	mock := &MockavailabilityNotifier{ctrl: ctrl}
+	mock.recorder = &MockavailabilityNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockavailabilityNotifier) EXPECT() *MockavailabilityNotifierMockRecorder {
+	return m.recorder
+}
+
+// NotifyCourierFreed mocks base method.
+func (m *MockavailabilityNotifier) NotifyCourierFreed() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyCourierFreed")
+}
+
+// NotifyCourierFreed indicates an expected call of NotifyCourierFreed.
+func (mr *MockavailabilityNotifierMockRecorder) NotifyCourierFreed() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyCourierFreed", reflect.TypeOf((*MockavailabilityNotifier)(nil).NotifyCourierFreed))
+}
@@ -0,0 +1,47 @@
+package courier
+
+import (
+	"context"
+	"fmt"
+	"service-courier/internal/model/courier"
+)
+
+// checkStatusChange проверяет смену статуса курьера вручную. busy ставит и снимает только
+// сервис доставок, а пока у курьера есть незавершенные доставки, статус вручную не меняется:
+// курьер с заказом в available попал бы под новые назначения сверх вместимости, в paused -
+// пропал бы из выборки, не вернувшись после доставки. Снять зависший busy с курьера
+// без доставок можно.
+func (s *Service) checkStatusChange(ctx context.Context, id int64, from, to courier.CourierStatus) error {
+	if to == "" || to == from {
+		return nil
+	}
+	if to == courier.StatusBusy {
+		return courier.ErrBusyStatusManaged
+	}
+
+	active, err := s.repo.CountActiveDeliveries(ctx, id)
+	if err != nil {
+		return fmt.Errorf("count active deliveries: %w", err)
+	}
+	if active > 0 {
+		return courier.ErrHasActiveDeliveries
+	}
+	return nil
+}
+
+// guardStatusChange читает курьера и проверяет смену его статуса. Возвращает версию, для которой
+// проверка сделана: если курьера тем временем займут, обновление по ней не пройдет.
+func (s *Service) guardStatusChange(ctx context.Context, courierData courier.Courier) (int64, error) {
+	current, err := s.repo.GetByID(ctx, courierData.ID)
+	if err != nil {
+		return 0, err
+	}
+	if courierData.Version != 0 && courierData.Version != current.Version {
+		return 0, courier.ErrVersionMismatch
+	}
+
+	if err := s.checkStatusChange(ctx, current.ID, current.Status, courierData.Status); err != nil {
+		return 0, err
+	}
+	return current.Version, nil
+}
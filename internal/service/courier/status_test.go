@@ -0,0 +1,94 @@
+package courier_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	model "service-courier/internal/model/courier"
+	courierService "service-courier/internal/service/courier"
+	"service-courier/internal/service/courier/mocks"
+)
+
+func TestUpdateCourier_StatusGuards(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		current model.CourierStatus
+		to      model.CourierStatus
+		active  int64
+		wantErr error
+	}{
+		{"set busy manually", model.StatusAvailable, model.StatusBusy, -1, model.ErrBusyStatusManaged},
+		{"pause with active deliveries", model.StatusBusy, model.StatusPaused, 1, model.ErrHasActiveDeliveries},
+		{"free with active deliveries", model.StatusBusy, model.StatusAvailable, 2, model.ErrHasActiveDeliveries},
+		{"pause with pending offer", model.StatusAvailable, model.StatusPaused, 1, model.ErrHasActiveDeliveries},
+		{"release stuck busy", model.StatusBusy, model.StatusAvailable, 0, nil},
+		{"pause idle courier", model.StatusAvailable, model.StatusPaused, 0, nil},
+		{"keep busy", model.StatusBusy, model.StatusBusy, -1, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mocks.NewMockcourierRepository(ctrl)
+			service := courierService.NewCourierService(mockRepo)
+
+			mockRepo.EXPECT().
+				GetByID(gomock.Any(), int64(1)).
+				Return(&model.Courier{ID: 1, Status: tt.current, Version: 7}, nil)
+			if tt.active >= 0 {
+				mockRepo.EXPECT().CountActiveDeliveries(gomock.Any(), int64(1)).Return(tt.active, nil)
+			}
+			if tt.wantErr == nil {
+				// статус проверен для версии 7 - обновление пройдет, только если курьера не заняли
+				mockRepo.EXPECT().
+					Update(gomock.Any(), model.Courier{ID: 1, Status: tt.to, Version: 7}).
+					Return(nil)
+			}
+
+			err := service.UpdateCourier(context.Background(), model.Courier{ID: 1, Status: tt.to})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCreateCourier_BusyRejected(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := courierService.NewCourierService(mocks.NewMockcourierRepository(ctrl))
+
+	_, err := service.CreateCourier(context.Background(), model.Courier{Name: "Ivan", Status: model.StatusBusy})
+	if !errors.Is(err, model.ErrBusyStatusManaged) {
+		t.Fatalf("expected ErrBusyStatusManaged, got %v", err)
+	}
+}
+
+func TestPatchCourier_ActiveDeliveries(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockcourierRepository(ctrl)
+	service := courierService.NewCourierService(mockRepo)
+
+	mockRepo.EXPECT().
+		GetByID(gomock.Any(), int64(1)).
+		Return(&model.Courier{ID: 1, Status: model.StatusBusy, Version: 3}, nil)
+	mockRepo.EXPECT().CountActiveDeliveries(gomock.Any(), int64(1)).Return(int64(1), nil)
+
+	_, err := service.PatchCourier(context.Background(), model.Courier{ID: 1, Status: model.StatusPaused, Version: 3}, false)
+	if !errors.Is(err, model.ErrHasActiveDeliveries) {
+		t.Fatalf("expected ErrHasActiveDeliveries, got %v", err)
+	}
+}
@@ -4,70 +4,207 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"service-courier/internal/metrics"
 	"service-courier/internal/model/courier"
 	"service-courier/internal/model/delivery"
+	"service-courier/internal/pkg/geo"
 )
 
-func (s *Service) AssignCourier(ctx context.Context, orderID string) (*AssignResult, error) {
-	var result *AssignResult
+// maxPickAttempts - сколько раз выбирать другого курьера, если выбранного заняли параллельно
+const maxPickAttempts = 3
+
+// AssignCourier назначает курьера на заказ с учетом его приоритета. Если свободных курьеров нет
+// и включена очередь, заказ ставится в очередь и возвращается delivery.ErrAssignmentPending.
+// При пакетном назначении в очередь попадает каждый заказ.
+func (s *Service) AssignCourier(ctx context.Context, orderID string, priority delivery.Priority) (*AssignResult, error) {
+	return s.AssignCourierAt(ctx, orderID, priority, s.locateOrder(ctx, orderID))
+}
+
+// AssignCourierAt - AssignCourier с точкой доставки, найденной заранее через LocateOrder.
+// Нужен, когда назначение идет внутри транзакции вызывающего: запрос к сервису заказов
+// и геокодеру не должен держать ее открытой. nil destination - точка неизвестна.
+func (s *Service) AssignCourierAt(ctx context.Context, orderID string, priority delivery.Priority, destination *geo.Point) (*AssignResult, error) {
+	var (
+		result *AssignResult
+		queued bool
+	)
 
 	err := s.txManager.Do(ctx, func(ctx context.Context) error {
-		existingDelivery, err := s.deliveryRepo.GetByOrderID(ctx, orderID)
-		if err != nil && !errors.Is(err, delivery.ErrDeliveryNotFound) {
-			return fmt.Errorf("check existing delivery: %w", err)
+		if s.batchEnabled() {
+			queued = true
+			return s.deferToBatch(ctx, orderID, destination, priority)
 		}
 
-		if existingDelivery != nil {
-			return delivery.ErrOrderAlreadyAssigned
+		var err error
+		result, err = s.assign(ctx, orderID, destination, priority)
+		if errors.Is(err, courier.ErrNoAvailableCouriers) && s.pending != nil {
+			queued = true
+			added, err := s.enqueuePending(ctx, orderID, destination, priority)
+			if added {
+				log.Printf("[AssignCourier] No available couriers, order %s is queued for assignment", orderID)
+			}
+			return err
 		}
+		return err
+	})
 
-		availableCourier, err := s.courierRepo.GetAvailableWithMinDeliveries(ctx)
-		if err != nil {
-			if errors.Is(err, courier.ErrNoAvailableCouriers) {
-				return courier.ErrNoAvailableCouriers
-			}
-			return fmt.Errorf("get available courier: %w", err)
+	if err != nil {
+		return nil, fmt.Errorf("assign courier transaction: %w", err)
+	}
+
+	if queued {
+		if s.batchEnabled() {
+			s.notifyFreed(ctx)
 		}
+		return nil, delivery.ErrAssignmentPending
+	}
 
-		assignedAt := s.clock.Now()
+	metrics.OpsCounter.Inc()
+	return result, nil
+}
 
-		transport := s.transportFactory.Create(availableCourier.TransportType)
+// assign выбирает курьера и создает на него доставку. Вызывается внутри транзакции.
+func (s *Service) assign(ctx context.Context, orderID string, destination *geo.Point, priority delivery.Priority) (*AssignResult, error) {
+	existingDelivery, err := s.deliveryRepo.GetByOrderID(ctx, orderID)
+	if err != nil && !errors.Is(err, delivery.ErrDeliveryNotFound) {
+		return nil, fmt.Errorf("check existing delivery: %w", err)
+	}
 
-		deadline := assignedAt.Add(transport.DeliveryDuration())
+	if existingDelivery != nil {
+		return nil, delivery.ErrOrderAlreadyAssigned
+	}
 
-		deliveryData := delivery.Delivery{
-			CourierID:  availableCourier.ID,
-			OrderID:    orderID,
-			AssignedAt: assignedAt,
-			Deadline:   deadline,
+	// кандидаты читаются без блокировки: если курьера успели занять, выбирается следующий
+	var taken []int64
+	for attempt := 0; attempt < maxPickAttempts; attempt++ {
+		availableCourier, err := s.pickCourier(ctx, orderID, destination, priority, taken)
+		if err != nil {
+			if errors.Is(err, courier.ErrNoAvailableCouriers) {
+				return nil, courier.ErrNoAvailableCouriers
+			}
+			return nil, fmt.Errorf("get available courier: %w", err)
 		}
 
-		if err := s.deliveryRepo.Create(ctx, deliveryData); err != nil {
-			return fmt.Errorf("create delivery: %w", err)
+		result, err := s.createDelivery(ctx, orderID, availableCourier, destination, priority)
+		if !errors.Is(err, courier.ErrCourierTaken) {
+			return result, err
 		}
+		taken = append(taken, availableCourier.ID)
+	}
 
-		availableCourier.Status = courier.StatusBusy
-		if err := s.courierRepo.Update(ctx, *availableCourier); err != nil {
-			if errors.Is(err, courier.ErrCourierNotFound) {
-				return courier.ErrCourierNotFound
-			}
-			return fmt.Errorf("update courier status: %w", err)
+	return nil, courier.ErrNoAvailableCouriers
+}
+
+// reserveCourier блокирует курьера до конца транзакции и перепроверяет, что у него осталось место.
+// Возвращает courier.ErrCourierTaken, если курьера заняли параллельным назначением или вывели из работы.
+// held - сколько активных доставок курьера уже относятся к этому заказу и не занимают новое место.
+func (s *Service) reserveCourier(ctx context.Context, id int64, held int64) (*courier.Courier, error) {
+	locked, err := s.courierRepo.LockAssignable(ctx, id)
+	if err != nil {
+		if errors.Is(err, courier.ErrCourierNotFound) {
+			return nil, courier.ErrCourierTaken
 		}
+		return nil, fmt.Errorf("lock courier: %w", err)
+	}
+
+	active, err := s.deliveryRepo.CountActiveByCourierIDs(ctx, []int64{id})
+	if err != nil {
+		return nil, fmt.Errorf("count active deliveries: %w", err)
+	}
+	if active[id]-held >= int64(s.capacity().Of(locked.TransportType)) {
+		return nil, courier.ErrCourierTaken
+	}
+	return locked, nil
+}
 
-		result = &AssignResult{
-			CourierID:     availableCourier.ID,
-			OrderID:       orderID,
-			TransportType: availableCourier.TransportType,
-			Deadline:      deadline,
+// createDelivery создает доставку на выбранного курьера и занимает его, а если включены предложения -
+// предлагает заказ курьеру. Вызывается внутри транзакции.
+func (s *Service) createDelivery(
+	ctx context.Context,
+	orderID string,
+	availableCourier *courier.Courier,
+	destination *geo.Point,
+	priority delivery.Priority,
+) (*AssignResult, error) {
+	locked, err := s.reserveCourier(ctx, availableCourier.ID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// курьер займется заказом, только когда примет предложение
+	if s.offers == nil {
+		if err := s.occupyCourier(ctx, locked); err != nil {
+			return nil, err
 		}
-		return nil
-	})
+	}
+
+	assignedAt := s.clock.Now()
+
+	transport := s.transportFactory.Create(availableCourier.TransportType)
 
+	deadline := s.deliveryDeadline(transport, availableCourier, destination, assignedAt)
+	deadline = tightenDeadline(assignedAt, deadline, s.policy(priority).DeadlineFactor)
+
+	status := delivery.DeliveryStatus(delivery.StatusAssigned)
+	if s.offers != nil {
+		status = delivery.StatusOffered
+	}
+
+	deliveryData := delivery.Delivery{
+		CourierID:  availableCourier.ID,
+		OrderID:    orderID,
+		Status:     status,
+		Priority:   priority,
+		AssignedAt: assignedAt,
+		Deadline:   deadline,
+	}
+
+	deliveryID, err := s.deliveryRepo.Create(ctx, deliveryData)
 	if err != nil {
-		return nil, fmt.Errorf("assign courier transaction: %w", err)
+		return nil, fmt.Errorf("create delivery: %w", err)
+	}
+	deliveryData.ID = deliveryID
+
+	result := &AssignResult{
+		CourierID:     availableCourier.ID,
+		OrderID:       orderID,
+		TransportType: availableCourier.TransportType,
+		Deadline:      deadline,
+		Status:        status,
+	}
+
+	// у новой доставки нет предыдущего статуса
+	event := s.newEvent(ctx, deliveryData, status, "courier "+string(status))
+	event.FromStatus = ""
+	if err := s.recordEvents(ctx, event); err != nil {
+		return nil, err
+	}
+
+	if s.offers != nil {
+		expiresAt, err := s.offer(ctx, deliveryData)
+		if err != nil {
+			return nil, err
+		}
+		result.OfferExpiresAt = &expiresAt
 	}
 
-	metrics.OpsCounter.Inc()
 	return result, nil
 }
+
+// occupyCourier переводит заблокированного курьера в busy. Меняется только статус,
+// остальные поля курьера могли обновиться после выбора кандидата.
+func (s *Service) occupyCourier(ctx context.Context, locked *courier.Courier) error {
+	if locked.Status == courier.StatusBusy {
+		return nil
+	}
+
+	updated, err := s.courierRepo.UpdateStatusFrom(ctx, locked.ID, locked.Status, courier.StatusBusy)
+	if err != nil {
+		return fmt.Errorf("update courier status: %w", err)
+	}
+	if !updated {
+		return courier.ErrCourierTaken
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package delivery
+
+import (
+	"context"
+	"fmt"
+	"service-courier/internal/model/courier"
+)
+
+var transportTypes = []courier.TransportType{
+	courier.TransportOnFoot,
+	courier.TransportScooter,
+	courier.TransportCar,
+}
+
+// capacity собирает вместимость всех видов транспорта из фабрики
+func (s *Service) capacity() courier.Capacity {
+	capacity := make(courier.Capacity, len(transportTypes))
+	for _, t := range transportTypes {
+		if transport := s.transportFactory.Create(t); transport != nil {
+			capacity[t] = transport.Capacity()
+		}
+	}
+	return capacity
+}
+
+// releaseIdleCouriers возвращает в available busy-курьеров, у которых не осталось активных доставок.
+// Курьер с другими доставками в пути остается busy, а статус курьера, которого успели поставить
+// на паузу или удалить, не меняется. freed - у кого-то из курьеров освободилось место для заказа.
+func (s *Service) releaseIdleCouriers(ctx context.Context, courierIDs ...int64) (freed bool, err error) {
+	active, err := s.deliveryRepo.CountActiveByCourierIDs(ctx, courierIDs)
+	if err != nil {
+		return false, fmt.Errorf("count active deliveries: %w", err)
+	}
+
+	idle := make([]int64, 0, len(courierIDs))
+	for _, id := range courierIDs {
+		if active[id] == 0 {
+			idle = append(idle, id)
+		} else {
+			// курьер остается busy, но у него стало меньше доставок
+			freed = true
+		}
+	}
+
+	if len(idle) == 0 {
+		return freed, nil
+	}
+
+	released, err := s.courierRepo.UpdateStatusFromBatch(ctx, idle, courier.StatusBusy, courier.StatusAvailable)
+	if err != nil {
+		return false, fmt.Errorf("release idle couriers: %w", err)
+	}
+	return freed || len(released) > 0, nil
+}
@@ -0,0 +1,210 @@
+package delivery_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	modelCourier "service-courier/internal/model/courier"
+	modelDelivery "service-courier/internal/model/delivery"
+	deliveryService "service-courier/internal/service/delivery"
+	"service-courier/internal/service/delivery/mocks"
+)
+
+func newCapacityService(t *testing.T) (*deliveryService.Service, *mocks.MockdeliveryRepository, *mocks.MockcourierRepository) {
+	ctrl := gomock.NewController(t)
+
+	deliveryRepo := mocks.NewMockdeliveryRepository(ctrl)
+	courierRepo := mocks.NewMockcourierRepository(ctrl)
+	txManager := mocks.NewMocktransactionManager(ctrl)
+	txManager.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		}).
+		AnyTimes()
+
+	service := deliveryService.NewDeliveryService(
+		deliveryRepo,
+		courierRepo,
+		deliveryService.NewTransportFactory(),
+		txManager,
+		deliveryService.NewFixedClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)),
+	)
+	return service, deliveryRepo, courierRepo
+}
+
+// expectReserved - выбранный курьер под замком все еще свободен и может взять заказ
+func expectReserved(courierRepo *mocks.MockcourierRepository, deliveryRepo *mocks.MockdeliveryRepository, c modelCourier.Courier) {
+	courierRepo.EXPECT().LockAssignable(gomock.Any(), c.ID).Return(&c, nil)
+	deliveryRepo.EXPECT().CountActiveByCourierIDs(gomock.Any(), []int64{c.ID}).Return(map[int64]int64{}, nil)
+}
+
+func TestAssignCourier_PassesTransportCapacity(t *testing.T) {
+	t.Parallel()
+	service, deliveryRepo, courierRepo := newCapacityService(t)
+
+	orderID := "f819526d-6a7c-48eb-b535-43989469d1ca"
+
+	deliveryRepo.EXPECT().
+		GetByOrderID(gomock.Any(), orderID).
+		Return(nil, modelDelivery.ErrDeliveryNotFound)
+
+	// Курьер уже везет заказ, но вместимость машины позволяет взять еще
+	courierRepo.EXPECT().
+		ListAvailableWithDeliveries(gomock.Any(), modelCourier.AvailableFilter{Capacity: modelCourier.Capacity{
+			modelCourier.TransportOnFoot:  1,
+			modelCourier.TransportScooter: 2,
+			modelCourier.TransportCar:     3,
+		}}).
+		Return([]modelCourier.Candidate{{Courier: modelCourier.Courier{ID: 10, Status: modelCourier.StatusBusy, TransportType: modelCourier.TransportCar}}}, nil)
+
+	courierRepo.EXPECT().
+		LockAssignable(gomock.Any(), int64(10)).
+		Return(&modelCourier.Courier{ID: 10, Status: modelCourier.StatusBusy, TransportType: modelCourier.TransportCar}, nil)
+	deliveryRepo.EXPECT().CountActiveByCourierIDs(gomock.Any(), []int64{10}).Return(map[int64]int64{10: 1}, nil)
+	deliveryRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(int64(2), nil)
+	deliveryRepo.EXPECT().CreateEvents(gomock.Any(), gomock.Any()).Return(nil)
+	// курьер уже busy, статус не переписывается
+
+	result, err := service.AssignCourier(context.Background(), orderID, modelDelivery.PriorityStandard)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), result.CourierID)
+}
+
+func TestUnassignCourier_KeepsCourierBusyWithOtherDeliveries(t *testing.T) {
+	t.Parallel()
+	service, deliveryRepo, _ := newCapacityService(t)
+
+	orderID := "f819526d-6a7c-48eb-b535-43989469d1ca"
+
+	deliveryRepo.EXPECT().
+		GetByOrderID(gomock.Any(), orderID).
+		Return(&modelDelivery.Delivery{ID: 1, OrderID: orderID, CourierID: 10, Status: modelDelivery.StatusAssigned}, nil)
+	deliveryRepo.EXPECT().DeleteByOrderID(gomock.Any(), orderID).Return(nil)
+	deliveryRepo.EXPECT().CreateEvents(gomock.Any(), gomock.Any()).Return(nil)
+
+	// У курьера осталась еще одна доставка - статус не меняется
+	deliveryRepo.EXPECT().
+		CountActiveByCourierIDs(gomock.Any(), []int64{10}).
+		Return(map[int64]int64{10: 1}, nil)
+
+	result, err := service.UnassignCourier(context.Background(), orderID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), result.CourierID)
+}
+
+func TestReleaseExpiredCouriers_ReleasesOnlyIdleCouriers(t *testing.T) {
+	t.Parallel()
+	service, deliveryRepo, courierRepo := newCapacityService(t)
+
+	deliveryRepo.EXPECT().
+		ListActiveExpired(gomock.Any(), gomock.Any()).
+		Return([]modelDelivery.Delivery{
+			{ID: 1, CourierID: 10, OrderID: "order-1", Status: modelDelivery.StatusAssigned},
+			{ID: 2, CourierID: 20, OrderID: "order-2", Status: modelDelivery.StatusAssigned},
+		}, nil)
+	deliveryRepo.EXPECT().UpdateStatusByIDs(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	deliveryRepo.EXPECT().CreateEvents(gomock.Any(), gomock.Any()).Return(nil)
+	deliveryRepo.EXPECT().
+		CountActiveByCourierIDs(gomock.Any(), gomock.Any()).
+		Return(map[int64]int64{20: 2}, nil)
+
+	courierRepo.EXPECT().
+		UpdateStatusFromBatch(gomock.Any(), []int64{10}, modelCourier.CourierStatus(modelCourier.StatusBusy), modelCourier.CourierStatus(modelCourier.StatusAvailable)).
+		Return([]int64{10}, nil)
+
+	require.NoError(t, service.ReleaseExpiredCouriers(context.Background()))
+}
+
+func TestAssignCourier_PicksAnotherCourierWhenTaken(t *testing.T) {
+	t.Parallel()
+	service, deliveryRepo, courierRepo := newCapacityService(t)
+
+	orderID := "f819526d-6a7c-48eb-b535-43989469d1ca"
+	taken := modelCourier.Courier{ID: 10, Status: modelCourier.StatusBusy, TransportType: modelCourier.TransportCar}
+	free := modelCourier.Courier{ID: 11, Status: modelCourier.StatusAvailable, TransportType: modelCourier.TransportCar}
+
+	deliveryRepo.EXPECT().GetByOrderID(gomock.Any(), orderID).Return(nil, modelDelivery.ErrDeliveryNotFound)
+	gomock.InOrder(
+		courierRepo.EXPECT().
+			ListAvailableWithDeliveries(gomock.Any(), gomock.Any()).
+			Return([]modelCourier.Candidate{{Courier: taken, Active: 2}}, nil),
+		courierRepo.EXPECT().
+			ListAvailableWithDeliveries(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, filter modelCourier.AvailableFilter) ([]modelCourier.Candidate, error) {
+				assert.Equal(t, []int64{10}, filter.ExcludeIDs)
+				return []modelCourier.Candidate{{Courier: free}}, nil
+			}),
+	)
+	// пока курьер 10 выбирался, параллельное назначение заполнило его машину
+	courierRepo.EXPECT().LockAssignable(gomock.Any(), int64(10)).Return(&taken, nil)
+	deliveryRepo.EXPECT().CountActiveByCourierIDs(gomock.Any(), []int64{10}).Return(map[int64]int64{10: 3}, nil)
+	expectReserved(courierRepo, deliveryRepo, free)
+	deliveryRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+	deliveryRepo.EXPECT().CreateEvents(gomock.Any(), gomock.Any()).Return(nil)
+	courierRepo.EXPECT().UpdateStatusFrom(gomock.Any(), int64(11), modelCourier.CourierStatus(modelCourier.StatusAvailable), modelCourier.CourierStatus(modelCourier.StatusBusy)).Return(true, nil)
+
+	result, err := service.AssignCourier(context.Background(), orderID, modelDelivery.PriorityStandard)
+	require.NoError(t, err)
+	assert.Equal(t, int64(11), result.CourierID)
+}
+
+func TestAssignCourier_AllPickedCouriersTaken(t *testing.T) {
+	t.Parallel()
+	service, deliveryRepo, courierRepo := newCapacityService(t)
+
+	orderID := "f819526d-6a7c-48eb-b535-43989469d1ca"
+
+	deliveryRepo.EXPECT().GetByOrderID(gomock.Any(), orderID).Return(nil, modelDelivery.ErrDeliveryNotFound)
+	courierRepo.EXPECT().
+		ListAvailableWithDeliveries(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, filter modelCourier.AvailableFilter) ([]modelCourier.Candidate, error) {
+			id := int64(len(filter.ExcludeIDs) + 1)
+			return []modelCourier.Candidate{{Courier: modelCourier.Courier{ID: id, TransportType: modelCourier.TransportCar}}}, nil
+		}).
+		Times(3)
+	// курьеров ставят на паузу или выводят из назначения быстрее, чем под них создается доставка
+	courierRepo.EXPECT().LockAssignable(gomock.Any(), gomock.Any()).Return(nil, modelCourier.ErrCourierNotFound).Times(3)
+
+	_, err := service.AssignCourier(context.Background(), orderID, modelDelivery.PriorityStandard)
+	assert.ErrorIs(t, err, modelCourier.ErrNoAvailableCouriers)
+}
+
+func TestAssignCourier_PicksAnotherCourierWhenStatusChanged(t *testing.T) {
+	t.Parallel()
+	service, deliveryRepo, courierRepo := newCapacityService(t)
+
+	orderID := "f819526d-6a7c-48eb-b535-43989469d1ca"
+	paused := modelCourier.Courier{ID: 10, Status: modelCourier.StatusAvailable, TransportType: modelCourier.TransportCar}
+	free := modelCourier.Courier{ID: 11, Status: modelCourier.StatusAvailable, TransportType: modelCourier.TransportCar}
+
+	deliveryRepo.EXPECT().GetByOrderID(gomock.Any(), orderID).Return(nil, modelDelivery.ErrDeliveryNotFound)
+	gomock.InOrder(
+		courierRepo.EXPECT().
+			ListAvailableWithDeliveries(gomock.Any(), gomock.Any()).
+			Return([]modelCourier.Candidate{{Courier: paused}}, nil),
+		courierRepo.EXPECT().
+			ListAvailableWithDeliveries(gomock.Any(), gomock.Any()).
+			Return([]modelCourier.Candidate{{Courier: free}}, nil),
+	)
+	expectReserved(courierRepo, deliveryRepo, paused)
+	// статус курьера сменился мимо блокировки: доставка на него не создается
+	courierRepo.EXPECT().
+		UpdateStatusFrom(gomock.Any(), int64(10), modelCourier.CourierStatus(modelCourier.StatusAvailable), modelCourier.CourierStatus(modelCourier.StatusBusy)).
+		Return(false, nil)
+	expectReserved(courierRepo, deliveryRepo, free)
+	courierRepo.EXPECT().
+		UpdateStatusFrom(gomock.Any(), int64(11), modelCourier.CourierStatus(modelCourier.StatusAvailable), modelCourier.CourierStatus(modelCourier.StatusBusy)).
+		Return(true, nil)
+	deliveryRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+	deliveryRepo.EXPECT().CreateEvents(gomock.Any(), gomock.Any()).Return(nil)
+
+	result, err := service.AssignCourier(context.Background(), orderID, modelDelivery.PriorityStandard)
+	require.NoError(t, err)
+	assert.Equal(t, int64(11), result.CourierID)
+}
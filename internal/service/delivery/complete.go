@@ -5,12 +5,16 @@ import (
 	"errors"
 	"fmt"
 	"service-courier/internal/metrics"
-	"service-courier/internal/model/courier"
 	modelDelivery "service-courier/internal/model/delivery"
 )
 
 func (s *Service) CompleteDelivery(ctx context.Context, orderID string) error {
-	return s.txManager.Do(ctx, func(ctx context.Context) error {
+	var (
+		completed *modelDelivery.Delivery
+		freed     bool
+	)
+
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
 		deliveryData, err := s.deliveryRepo.GetByOrderID(ctx, orderID)
 		if err != nil {
 			if errors.Is(err, modelDelivery.ErrDeliveryNotFound) {
@@ -19,17 +23,33 @@ func (s *Service) CompleteDelivery(ctx context.Context, orderID string) error {
 			return fmt.Errorf("get delivery: %w", err)
 		}
 
+		// доставка уже закрыта курьером - заказу больше нечего завершать
+		if deliveryData.Status.IsTerminal() {
+			return nil
+		}
+
 		if err := s.deliveryRepo.UpdateStatusByIDs(ctx, []int64{deliveryData.ID}, modelDelivery.StatusCompleted); err != nil {
 			return fmt.Errorf("update delivery status: %w", err)
 		}
 
-		if err := s.courierRepo.UpdateStatusBatch(ctx, []int64{deliveryData.CourierID}, courier.StatusAvailable); err != nil {
-			if errors.Is(err, courier.ErrCourierNotFound) {
-				return courier.ErrCourierNotFound
-			}
-			return fmt.Errorf("update courier status: %w", err)
+		if err := s.recordEvents(ctx, s.newEvent(ctx, *deliveryData, modelDelivery.StatusCompleted, "order completed")); err != nil {
+			return err
 		}
+
+		freed, err = s.releaseIdleCouriers(ctx, deliveryData.CourierID)
+		if err != nil {
+			return err
+		}
+		completed = deliveryData
 		metrics.OpsCounter.Inc()
 		return nil
 	})
+
+	if completed != nil {
+		observeSLA(*completed, s.clock.Now())
+	}
+	if freed {
+		s.notifyFreed(ctx)
+	}
+	return err
 }
@@ -1,30 +1,87 @@
 //go:generate mockgen -destination=./mocks/delivery_repository_mock.go -package=mocks service-courier/internal/service/delivery deliveryRepository
 //go:generate mockgen -destination=./mocks/courier_repository_mock.go -package=mocks service-courier/internal/service/delivery courierRepository
 //go:generate mockgen -destination=./mocks/transaction_manager_mock.go -package=mocks service-courier/internal/service/delivery transactionManager
+//go:generate mockgen -destination=./mocks/order_provider_mock.go -package=mocks service-courier/internal/service/delivery orderProvider
+//go:generate mockgen -destination=./mocks/geocoder_mock.go -package=mocks service-courier/internal/service/delivery geocoder
+//go:generate mockgen -destination=./mocks/outbox_repository_mock.go -package=mocks service-courier/internal/service/delivery outboxRepository
+//go:generate mockgen -destination=./mocks/order_source_mock.go -package=mocks service-courier/internal/service/delivery orderSource
+//go:generate mockgen -destination=./mocks/cursor_repository_mock.go -package=mocks service-courier/internal/service/delivery cursorRepository
+//go:generate mockgen -destination=./mocks/zone_resolver_mock.go -package=mocks service-courier/internal/service/delivery ZoneResolver
+//go:generate mockgen -destination=./mocks/pending_repository_mock.go -package=mocks service-courier/internal/service/delivery pendingRepository
+//go:generate mockgen -destination=./mocks/offer_repository_mock.go -package=mocks service-courier/internal/service/delivery offerRepository
 package delivery
 
 import (
 	"context"
 	"service-courier/internal/model/courier"
 	"service-courier/internal/model/delivery"
+	"service-courier/internal/model/order"
+	"service-courier/internal/model/outbox"
+	"service-courier/internal/pkg/geo"
 	"time"
 )
 
 type deliveryRepository interface {
-	Create(ctx context.Context, deliveryData delivery.Delivery) error
+	Create(ctx context.Context, deliveryData delivery.Delivery) (int64, error)
 	GetByOrderID(ctx context.Context, orderID string) (*delivery.Delivery, error)
+	List(ctx context.Context, filter delivery.ListFilter) ([]delivery.Delivery, error)
 	DeleteByOrderID(ctx context.Context, orderID string) error
+	CloseOffer(ctx context.Context, id int64, status delivery.DeliveryStatus) error
 	ListActiveExpired(ctx context.Context, now time.Time) ([]delivery.Delivery, error)
 	UpdateStatusByIDs(ctx context.Context, ids []int64, status delivery.DeliveryStatus) error
+	UpdateStatus(ctx context.Context, id int64, from, to delivery.DeliveryStatus) error
+	CountActiveByCourierIDs(ctx context.Context, courierIDs []int64) (map[int64]int64, error)
+	CreateEvents(ctx context.Context, events []delivery.Event) error
+	ListEventsByOrderID(ctx context.Context, orderID string) ([]delivery.Event, error)
+	ListEventsAfter(ctx context.Context, filter delivery.EventFilter) ([]delivery.Event, error)
+	Stats(ctx context.Context, filter delivery.StatsFilter) ([]delivery.CourierStats, error)
 }
 
 type courierRepository interface {
 	GetByID(ctx context.Context, id int64) (*courier.Courier, error)
-	GetAvailableWithMinDeliveries(ctx context.Context) (*courier.Courier, error)
-	Update(ctx context.Context, courierData courier.Courier) error
-	UpdateStatusBatch(ctx context.Context, ids []int64, status courier.CourierStatus) error
+	ListAvailableWithDeliveries(ctx context.Context, filter courier.AvailableFilter) ([]courier.Candidate, error)
+	LockAssignable(ctx context.Context, id int64) (*courier.Courier, error)
+	UpdateStatusFrom(ctx context.Context, id int64, from, to courier.CourierStatus) (bool, error)
+	UpdateStatusFromBatch(ctx context.Context, ids []int64, from, to courier.CourierStatus) ([]int64, error)
 }
 
 type transactionManager interface {
 	Do(ctx context.Context, fn func(ctx context.Context) error) error
 }
+
+type orderProvider interface {
+	GetOrderByID(ctx context.Context, id string) (*order.Order, error)
+}
+
+type geocoder interface {
+	Geocode(ctx context.Context, address order.Address) (*geo.Point, error)
+}
+
+type outboxRepository interface {
+	Create(ctx context.Context, messages []outbox.Message) error
+}
+
+type orderSource interface {
+	GetOrders(ctx context.Context, from time.Time) ([]order.Order, error)
+}
+
+type cursorRepository interface {
+	Get(ctx context.Context, name string) (*time.Time, error)
+	Save(ctx context.Context, name string, position time.Time) error
+}
+
+type pendingRepository interface {
+	Enqueue(ctx context.Context, p delivery.PendingAssignment) (bool, error)
+	ListReady(ctx context.Context, now time.Time, limit uint64) ([]delivery.PendingAssignment, error)
+	MarkAttempt(ctx context.Context, id int64, at time.Time, lastError string) error
+	DeleteByOrderID(ctx context.Context, orderID string) (bool, error)
+	DeleteExpired(ctx context.Context, now time.Time) ([]delivery.PendingAssignment, error)
+}
+
+type offerRepository interface {
+	Create(ctx context.Context, o delivery.Offer) (int64, error)
+	Resolve(ctx context.Context, deliveryID int64, outcome delivery.OfferOutcome, at time.Time) (bool, error)
+	ListExpired(ctx context.Context, now time.Time, limit uint64) ([]delivery.Offer, error)
+	RejectedCourierIDs(ctx context.Context, orderID string) ([]int64, error)
+	StatsByCourierID(ctx context.Context, courierID int64) (*delivery.OfferStats, error)
+}
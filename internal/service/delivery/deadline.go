@@ -0,0 +1,27 @@
+package delivery
+
+import (
+	"service-courier/internal/model/courier"
+	"service-courier/internal/pkg/geo"
+	"time"
+)
+
+// deliveryDeadline считает дедлайн по расстоянию от курьера до точки доставки.
+// Если расстояние неизвестно - по фиксированному времени для транспорта.
+func (s *Service) deliveryDeadline(
+	transport Transport,
+	c *courier.Courier,
+	destination *geo.Point,
+	assignedAt time.Time,
+) time.Time {
+	if destination == nil {
+		return assignedAt.Add(transport.DeliveryDuration())
+	}
+
+	location := s.freshLocation(c, assignedAt)
+	if location == nil {
+		return assignedAt.Add(transport.DeliveryDuration())
+	}
+
+	return transport.Deadline(assignedAt, s.distance.Distance(*location, *destination))
+}
@@ -1,11 +1,62 @@
 package delivery
 
+import (
+	"service-courier/internal/pkg/geo"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const defaultLocationMaxAge = 10 * time.Minute
+
 type Service struct {
 	deliveryRepo     deliveryRepository
 	courierRepo      courierRepository
 	transportFactory TransportFactory
 	txManager        transactionManager
 	clock            Clock
+	locator          Locator
+	distance         geo.DistanceStrategy
+	locationMaxAge   time.Duration
+	outbox           outboxRepository
+	outboxTopic      string
+	zones            ZoneResolver
+	zoneFallback     bool
+	pending          pendingRepository
+	pendingSLA       time.Duration
+	pendingTopic     string
+	pendingWake      chan struct{}
+	priorities       PriorityPolicies
+	strategy         AssignmentStrategy
+	batch            *BatchConfig
+	offers           offerRepository
+	offerTimeout     time.Duration
+	eventStream      EventStreamConfig
+	streams          atomic.Int64
+	streamsDone      chan struct{}
+	stopStreams      sync.Once
+}
+
+type Option func(*Service)
+
+// WithLocator включает выбор ближайшего курьера к адресу доставки
+func WithLocator(locator Locator) Option {
+	return func(s *Service) {
+		s.locator = locator
+	}
+}
+
+func WithDistanceStrategy(distance geo.DistanceStrategy) Option {
+	return func(s *Service) {
+		s.distance = distance
+	}
+}
+
+// WithLocationMaxAge задает, как долго позиция курьера считается актуальной
+func WithLocationMaxAge(age time.Duration) Option {
+	return func(s *Service) {
+		s.locationMaxAge = age
+	}
 }
 
 func NewDeliveryService(
@@ -14,13 +65,25 @@ func NewDeliveryService(
 	transportFactory TransportFactory,
 	txManager transactionManager,
 	clock Clock,
+	opts ...Option,
 ) *Service {
-	return &Service{
+	s := &Service{
 		deliveryRepo:     deliveryRepo,
 		courierRepo:      courierRepo,
 		transportFactory: transportFactory,
 		txManager:        txManager,
 		clock:            clock,
+		distance:         geo.Haversine{},
+		locationMaxAge:   defaultLocationMaxAge,
+		priorities:       DefaultPriorityPolicies(),
+		strategy:         NewNearestStrategy(),
+		eventStream:      DefaultEventStreamConfig(),
+		streamsDone:      make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(s)
 	}
 
+	return s
 }
@@ -25,7 +25,7 @@ func TestDeliveryService_AssignCourier_Integration(t *testing.T) {
 
 	ctxGetter := trmpgx.DefaultCtxGetter
 	deliveryRepository := deliveryRepo.NewDeliveryRepository(pool, ctxGetter)
-	courierRepository := courierRepo.NewCourierRepository(pool)
+	courierRepository := courierRepo.NewCourierRepository(pool, ctxGetter)
 	transportFactory := deliveryService.NewTransportFactory()
 	txManager := manager.Must(trmpgx.NewDefaultFactory(pool))
 
@@ -50,10 +50,11 @@ func TestDeliveryService_AssignCourier_Integration(t *testing.T) {
 	}
 	courierID, err := courierRepository.Create(ctx, courierData)
 	require.NoError(t, err)
+	integration.StartShift(t, pool, courierID)
 
 	// Назначаем курьера на заказ
 	orderID := "f819526d-6a7c-48eb-b535-43989469d1ca"
-	result, err := service.AssignCourier(ctx, orderID)
+	result, err := service.AssignCourier(ctx, orderID, modelDelivery.PriorityStandard)
 	require.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, orderID, result.OrderID)
@@ -66,7 +67,7 @@ func TestDeliveryService_AssignCourier_Integration(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, orderID, delivery.OrderID)
 	assert.Equal(t, courierID, delivery.CourierID)
-	assert.EqualValues(t, modelDelivery.StatusActive, delivery.Status)
+	assert.EqualValues(t, modelDelivery.StatusAssigned, delivery.Status)
 
 	// Проверяем, что курьер стал занятым
 	courier, err := courierRepository.GetByID(ctx, courierID)
@@ -80,7 +81,7 @@ func TestDeliveryService_AssignCourier_OrderAlreadyAssigned(t *testing.T) {
 
 	ctxGetter := trmpgx.DefaultCtxGetter
 	deliveryRepository := deliveryRepo.NewDeliveryRepository(pool, ctxGetter)
-	courierRepository := courierRepo.NewCourierRepository(pool)
+	courierRepository := courierRepo.NewCourierRepository(pool, ctxGetter)
 	transportFactory := deliveryService.NewTransportFactory()
 	txManager := manager.Must(trmpgx.NewDefaultFactory(pool))
 
@@ -113,11 +114,11 @@ func TestDeliveryService_AssignCourier_OrderAlreadyAssigned(t *testing.T) {
 		AssignedAt: time.Now().UTC(),
 		Deadline:   time.Now().UTC().Add(30 * time.Minute),
 	}
-	err = deliveryRepository.Create(ctx, deliveryData)
+	_, err = deliveryRepository.Create(ctx, deliveryData)
 	require.NoError(t, err)
 
 	// Пытаемся назначить курьера на тот же заказ
-	result, err := service.AssignCourier(ctx, orderID)
+	result, err := service.AssignCourier(ctx, orderID, modelDelivery.PriorityStandard)
 	assert.Error(t, err)
 	assert.ErrorIs(t, err, modelDelivery.ErrOrderAlreadyAssigned)
 	assert.Nil(t, result)
@@ -129,7 +130,7 @@ func TestDeliveryService_AssignCourier_NoAvailableCouriers(t *testing.T) {
 
 	ctxGetter := trmpgx.DefaultCtxGetter
 	deliveryRepository := deliveryRepo.NewDeliveryRepository(pool, ctxGetter)
-	courierRepository := courierRepo.NewCourierRepository(pool)
+	courierRepository := courierRepo.NewCourierRepository(pool, ctxGetter)
 	transportFactory := deliveryService.NewTransportFactory()
 	txManager := manager.Must(trmpgx.NewDefaultFactory(pool))
 
@@ -157,7 +158,7 @@ func TestDeliveryService_AssignCourier_NoAvailableCouriers(t *testing.T) {
 
 	// Пытаемся назначить курьера на заказ
 	orderID := "f819526d-6a7c-48eb-b535-43989469d1ca"
-	result, err := service.AssignCourier(ctx, orderID)
+	result, err := service.AssignCourier(ctx, orderID, modelDelivery.PriorityStandard)
 	assert.Error(t, err)
 	assert.ErrorIs(t, err, modelCourier.ErrNoAvailableCouriers)
 	assert.Nil(t, result)
@@ -169,7 +170,7 @@ func TestDeliveryService_UnassignCourier_Integration(t *testing.T) {
 
 	ctxGetter := trmpgx.DefaultCtxGetter
 	deliveryRepository := deliveryRepo.NewDeliveryRepository(pool, ctxGetter)
-	courierRepository := courierRepo.NewCourierRepository(pool)
+	courierRepository := courierRepo.NewCourierRepository(pool, ctxGetter)
 	transportFactory := deliveryService.NewTransportFactory()
 	txManager := manager.Must(trmpgx.NewDefaultFactory(pool))
 
@@ -202,7 +203,7 @@ func TestDeliveryService_UnassignCourier_Integration(t *testing.T) {
 		AssignedAt: time.Now().UTC(),
 		Deadline:   time.Now().UTC().Add(30 * time.Minute),
 	}
-	err = deliveryRepository.Create(ctx, deliveryData)
+	_, err = deliveryRepository.Create(ctx, deliveryData)
 	require.NoError(t, err)
 
 	// Снимаем курьера с заказа
@@ -230,7 +231,7 @@ func TestDeliveryService_UnassignCourier_DeliveryNotFound(t *testing.T) {
 
 	ctxGetter := trmpgx.DefaultCtxGetter
 	deliveryRepository := deliveryRepo.NewDeliveryRepository(pool, ctxGetter)
-	courierRepository := courierRepo.NewCourierRepository(pool)
+	courierRepository := courierRepo.NewCourierRepository(pool, ctxGetter)
 	transportFactory := deliveryService.NewTransportFactory()
 	txManager := manager.Must(trmpgx.NewDefaultFactory(pool))
 
@@ -259,7 +260,7 @@ func TestDeliveryService_Transaction_RollbackOnError(t *testing.T) {
 
 	ctxGetter := trmpgx.DefaultCtxGetter
 	deliveryRepository := deliveryRepo.NewDeliveryRepository(pool, ctxGetter)
-	courierRepository := courierRepo.NewCourierRepository(pool)
+	courierRepository := courierRepo.NewCourierRepository(pool, ctxGetter)
 	transportFactory := deliveryService.NewTransportFactory()
 	txManager := manager.Must(trmpgx.NewDefaultFactory(pool))
 
@@ -284,10 +285,11 @@ func TestDeliveryService_Transaction_RollbackOnError(t *testing.T) {
 	}
 	courierID, err := courierRepository.Create(ctx, courierData)
 	require.NoError(t, err)
+	integration.StartShift(t, pool, courierID)
 
 	// Назначаем курьера на заказ
 	orderID := "f819526d-6a7c-48eb-b535-43989469d1ca"
-	result1, err := service.AssignCourier(ctx, orderID)
+	result1, err := service.AssignCourier(ctx, orderID, modelDelivery.PriorityStandard)
 	require.NoError(t, err)
 	assert.NotNil(t, result1)
 
@@ -297,7 +299,7 @@ func TestDeliveryService_Transaction_RollbackOnError(t *testing.T) {
 	assert.EqualValues(t, modelCourier.StatusBusy, courier.Status)
 
 	// Пытаемся назначить курьера на тот же заказ
-	result2, err := service.AssignCourier(ctx, orderID)
+	result2, err := service.AssignCourier(ctx, orderID, modelDelivery.PriorityStandard)
 	assert.Error(t, err)
 	assert.ErrorIs(t, err, modelDelivery.ErrOrderAlreadyAssigned)
 	assert.Nil(t, result2)
@@ -54,18 +54,24 @@ func TestAssignCourier_Success(t *testing.T) {
 		Return(nil, modelDelivery.ErrDeliveryNotFound)
 
 	mockCourierRepo.EXPECT().
-		GetAvailableWithMinDeliveries(gomock.Any()).
-		Return(availableCourier, nil)
+		ListAvailableWithDeliveries(gomock.Any(), gomock.Any()).
+		Return([]modelCourier.Candidate{{Courier: *availableCourier}}, nil)
+
+	expectReserved(mockCourierRepo, mockDeliveryRepo, *availableCourier)
 
 	mockDeliveryRepo.EXPECT().
 		Create(gomock.Any(), gomock.Any()).
+		Return(int64(1), nil)
+
+	mockDeliveryRepo.EXPECT().
+		CreateEvents(gomock.Any(), gomock.Any()).
 		Return(nil)
 
 	mockCourierRepo.EXPECT().
-		Update(gomock.Any(), gomock.Any()).
-		Return(nil)
+		UpdateStatusFrom(gomock.Any(), int64(10), modelCourier.CourierStatus(modelCourier.StatusAvailable), modelCourier.CourierStatus(modelCourier.StatusBusy)).
+		Return(true, nil)
 
-	result, err := service.AssignCourier(context.Background(), orderID)
+	result, err := service.AssignCourier(context.Background(), orderID, modelDelivery.PriorityStandard)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -113,7 +119,7 @@ func TestAssignCourier_OrderAlreadyAssigned(t *testing.T) {
 		ID:        1,
 		OrderID:   orderID,
 		CourierID: 5,
-		Status:    modelDelivery.StatusActive,
+		Status:    modelDelivery.StatusAssigned,
 	}
 
 	mockTxManager.EXPECT().
@@ -126,7 +132,7 @@ func TestAssignCourier_OrderAlreadyAssigned(t *testing.T) {
 		GetByOrderID(gomock.Any(), orderID).
 		Return(existingDelivery, nil)
 
-	result, err := service.AssignCourier(context.Background(), orderID)
+	result, err := service.AssignCourier(context.Background(), orderID, modelDelivery.PriorityStandard)
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -172,10 +178,10 @@ func TestAssignCourier_NoAvailableCouriers(t *testing.T) {
 		Return(nil, modelDelivery.ErrDeliveryNotFound)
 
 	mockCourierRepo.EXPECT().
-		GetAvailableWithMinDeliveries(gomock.Any()).
-		Return(nil, modelCourier.ErrNoAvailableCouriers)
+		ListAvailableWithDeliveries(gomock.Any(), gomock.Any()).
+		Return(nil, nil)
 
-	result, err := service.AssignCourier(context.Background(), orderID)
+	result, err := service.AssignCourier(context.Background(), orderID, modelDelivery.PriorityStandard)
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -221,7 +227,7 @@ func TestAssignCourier_GetByOrderIDError(t *testing.T) {
 		GetByOrderID(gomock.Any(), orderID).
 		Return(nil, repoErr)
 
-	result, err := service.AssignCourier(context.Background(), orderID)
+	result, err := service.AssignCourier(context.Background(), orderID, modelDelivery.PriorityStandard)
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -270,14 +276,20 @@ func TestAssignCourier_CreateDeliveryError(t *testing.T) {
 		Return(nil, modelDelivery.ErrDeliveryNotFound)
 
 	mockCourierRepo.EXPECT().
-		GetAvailableWithMinDeliveries(gomock.Any()).
-		Return(availableCourier, nil)
+		ListAvailableWithDeliveries(gomock.Any(), gomock.Any()).
+		Return([]modelCourier.Candidate{{Courier: *availableCourier}}, nil)
+
+	expectReserved(mockCourierRepo, mockDeliveryRepo, *availableCourier)
+
+	mockCourierRepo.EXPECT().
+		UpdateStatusFrom(gomock.Any(), int64(10), modelCourier.CourierStatus(modelCourier.StatusAvailable), modelCourier.CourierStatus(modelCourier.StatusBusy)).
+		Return(true, nil)
 
 	mockDeliveryRepo.EXPECT().
 		Create(gomock.Any(), gomock.Any()).
-		Return(repoErr)
+		Return(int64(0), repoErr)
 
-	result, err := service.AssignCourier(context.Background(), orderID)
+	result, err := service.AssignCourier(context.Background(), orderID, modelDelivery.PriorityStandard)
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -326,18 +338,16 @@ func TestAssignCourier_UpdateCourierError(t *testing.T) {
 		Return(nil, modelDelivery.ErrDeliveryNotFound)
 
 	mockCourierRepo.EXPECT().
-		GetAvailableWithMinDeliveries(gomock.Any()).
-		Return(availableCourier, nil)
+		ListAvailableWithDeliveries(gomock.Any(), gomock.Any()).
+		Return([]modelCourier.Candidate{{Courier: *availableCourier}}, nil)
 
-	mockDeliveryRepo.EXPECT().
-		Create(gomock.Any(), gomock.Any()).
-		Return(nil)
+	expectReserved(mockCourierRepo, mockDeliveryRepo, *availableCourier)
 
 	mockCourierRepo.EXPECT().
-		Update(gomock.Any(), gomock.Any()).
-		Return(repoErr)
+		UpdateStatusFrom(gomock.Any(), int64(10), modelCourier.CourierStatus(modelCourier.StatusAvailable), modelCourier.CourierStatus(modelCourier.StatusBusy)).
+		Return(false, repoErr)
 
-	result, err := service.AssignCourier(context.Background(), orderID)
+	result, err := service.AssignCourier(context.Background(), orderID, modelDelivery.PriorityStandard)
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -372,13 +382,7 @@ func TestUnassignCourier_Success(t *testing.T) {
 		ID:        1,
 		OrderID:   orderID,
 		CourierID: 10,
-		Status:    modelDelivery.StatusActive,
-	}
-
-	courierData := &modelCourier.Courier{
-		ID:     10,
-		Name:   "Ivan",
-		Status: modelCourier.StatusBusy,
+		Status:    modelDelivery.StatusAssigned,
 	}
 
 	mockTxManager.EXPECT().
@@ -395,13 +399,17 @@ func TestUnassignCourier_Success(t *testing.T) {
 		DeleteByOrderID(gomock.Any(), orderID).
 		Return(nil)
 
-	mockCourierRepo.EXPECT().
-		GetByID(gomock.Any(), int64(10)).
-		Return(courierData, nil)
+	mockDeliveryRepo.EXPECT().
+		CreateEvents(gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	mockDeliveryRepo.EXPECT().
+		CountActiveByCourierIDs(gomock.Any(), gomock.Any()).
+		Return(map[int64]int64{}, nil)
 
 	mockCourierRepo.EXPECT().
-		Update(gomock.Any(), gomock.Any()).
-		Return(nil)
+		UpdateStatusFromBatch(gomock.Any(), []int64{10}, modelCourier.CourierStatus(modelCourier.StatusBusy), modelCourier.CourierStatus(modelCourier.StatusAvailable)).
+		Return([]int64{10}, nil)
 
 	result, err := service.UnassignCourier(context.Background(), orderID)
 	if err != nil {
@@ -536,7 +544,7 @@ func TestUnassignCourier_DeleteError(t *testing.T) {
 		ID:        1,
 		OrderID:   orderID,
 		CourierID: 10,
-		Status:    modelDelivery.StatusActive,
+		Status:    modelDelivery.StatusAssigned,
 	}
 	repoErr := errors.New("delete error")
 
@@ -563,7 +571,7 @@ func TestUnassignCourier_DeleteError(t *testing.T) {
 	}
 }
 
-func TestUnassignCourier_GetCourierError(t *testing.T) {
+func TestUnassignCourier_CountActiveError(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -589,9 +597,9 @@ func TestUnassignCourier_GetCourierError(t *testing.T) {
 		ID:        1,
 		OrderID:   orderID,
 		CourierID: 10,
-		Status:    modelDelivery.StatusActive,
+		Status:    modelDelivery.StatusAssigned,
 	}
-	repoErr := errors.New("get courier error")
+	repoErr := errors.New("count active error")
 
 	mockTxManager.EXPECT().
 		Do(gomock.Any(), gomock.Any()).
@@ -607,8 +615,12 @@ func TestUnassignCourier_GetCourierError(t *testing.T) {
 		DeleteByOrderID(gomock.Any(), orderID).
 		Return(nil)
 
-	mockCourierRepo.EXPECT().
-		GetByID(gomock.Any(), int64(10)).
+	mockDeliveryRepo.EXPECT().
+		CreateEvents(gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	mockDeliveryRepo.EXPECT().
+		CountActiveByCourierIDs(gomock.Any(), []int64{10}).
 		Return(nil, repoErr)
 
 	result, err := service.UnassignCourier(context.Background(), orderID)
@@ -646,11 +658,7 @@ func TestUnassignCourier_UpdateCourierError(t *testing.T) {
 		ID:        1,
 		OrderID:   orderID,
 		CourierID: 10,
-		Status:    modelDelivery.StatusActive,
-	}
-	courierData := &modelCourier.Courier{
-		ID:     10,
-		Status: modelCourier.StatusBusy,
+		Status:    modelDelivery.StatusAssigned,
 	}
 	repoErr := errors.New("update courier error")
 
@@ -668,13 +676,17 @@ func TestUnassignCourier_UpdateCourierError(t *testing.T) {
 		DeleteByOrderID(gomock.Any(), orderID).
 		Return(nil)
 
-	mockCourierRepo.EXPECT().
-		GetByID(gomock.Any(), int64(10)).
-		Return(courierData, nil)
+	mockDeliveryRepo.EXPECT().
+		CreateEvents(gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	mockDeliveryRepo.EXPECT().
+		CountActiveByCourierIDs(gomock.Any(), gomock.Any()).
+		Return(map[int64]int64{}, nil)
 
 	mockCourierRepo.EXPECT().
-		Update(gomock.Any(), gomock.Any()).
-		Return(repoErr)
+		UpdateStatusFromBatch(gomock.Any(), []int64{10}, gomock.Any(), gomock.Any()).
+		Return(nil, repoErr)
 
 	result, err := service.UnassignCourier(context.Background(), orderID)
 	if err == nil {
@@ -707,9 +719,9 @@ func TestReleaseExpiredCouriers_Success(t *testing.T) {
 	)
 
 	expiredDeliveries := []modelDelivery.Delivery{
-		{ID: 1, CourierID: 10, OrderID: "f819526d-6a7c-48eb-b535-43989469d1ca", Status: modelDelivery.StatusActive},
-		{ID: 2, CourierID: 10, OrderID: "e00b99da-4812-4401-8f54-af2cba66b819", Status: modelDelivery.StatusActive},
-		{ID: 3, CourierID: 20, OrderID: "83f6bfb1-b6e9-48b5-b562-d090ee0e1df4", Status: modelDelivery.StatusActive},
+		{ID: 1, CourierID: 10, OrderID: "f819526d-6a7c-48eb-b535-43989469d1ca", Status: modelDelivery.StatusAssigned},
+		{ID: 2, CourierID: 10, OrderID: "e00b99da-4812-4401-8f54-af2cba66b819", Status: modelDelivery.StatusAssigned},
+		{ID: 3, CourierID: 20, OrderID: "83f6bfb1-b6e9-48b5-b562-d090ee0e1df4", Status: modelDelivery.StatusAssigned},
 	}
 
 	mockTxManager.EXPECT().
@@ -726,10 +738,18 @@ func TestReleaseExpiredCouriers_Success(t *testing.T) {
 		UpdateStatusByIDs(gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(nil)
 
-	mockCourierRepo.EXPECT().
-		UpdateStatusBatch(gomock.Any(), gomock.Any(), gomock.Any()).
+	mockDeliveryRepo.EXPECT().
+		CreateEvents(gomock.Any(), gomock.Any()).
 		Return(nil)
 
+	mockDeliveryRepo.EXPECT().
+		CountActiveByCourierIDs(gomock.Any(), gomock.Any()).
+		Return(map[int64]int64{}, nil)
+
+	mockCourierRepo.EXPECT().
+		UpdateStatusFromBatch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, nil)
+
 	err := service.ReleaseExpiredCouriers(context.Background())
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
@@ -834,7 +854,7 @@ func TestReleaseExpiredCouriers_UpdateDeliveryStatusError(t *testing.T) {
 	)
 
 	expiredDeliveries := []modelDelivery.Delivery{
-		{ID: 1, CourierID: 10, OrderID: "f819526d-6a7c-48eb-b535-43989469d1ca", Status: modelDelivery.StatusActive},
+		{ID: 1, CourierID: 10, OrderID: "f819526d-6a7c-48eb-b535-43989469d1ca", Status: modelDelivery.StatusAssigned},
 	}
 	repoErr := errors.New("update delivery status error")
 
@@ -880,7 +900,7 @@ func TestReleaseExpiredCouriers_UpdateCourierStatusError(t *testing.T) {
 	)
 
 	expiredDeliveries := []modelDelivery.Delivery{
-		{ID: 1, CourierID: 10, OrderID: "f819526d-6a7c-48eb-b535-43989469d1ca", Status: modelDelivery.StatusActive},
+		{ID: 1, CourierID: 10, OrderID: "f819526d-6a7c-48eb-b535-43989469d1ca", Status: modelDelivery.StatusAssigned},
 	}
 	repoErr := errors.New("update courier status error")
 
@@ -898,9 +918,17 @@ func TestReleaseExpiredCouriers_UpdateCourierStatusError(t *testing.T) {
 		UpdateStatusByIDs(gomock.Any(), []int64{1}, gomock.Any()).
 		Return(nil)
 
+	mockDeliveryRepo.EXPECT().
+		CreateEvents(gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	mockDeliveryRepo.EXPECT().
+		CountActiveByCourierIDs(gomock.Any(), gomock.Any()).
+		Return(map[int64]int64{}, nil)
+
 	mockCourierRepo.EXPECT().
-		UpdateStatusBatch(gomock.Any(), []int64{10}, gomock.Any()).
-		Return(repoErr)
+		UpdateStatusFromBatch(gomock.Any(), []int64{10}, gomock.Any(), gomock.Any()).
+		Return(nil, repoErr)
 
 	err := service.ReleaseExpiredCouriers(context.Background())
 	if err == nil {
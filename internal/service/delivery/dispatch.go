@@ -0,0 +1,320 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"service-courier/internal/metrics"
+	"service-courier/internal/model/courier"
+	"service-courier/internal/model/delivery"
+	"service-courier/internal/pkg/geo"
+	"service-courier/internal/pkg/matching"
+	"sort"
+	"time"
+)
+
+// BatchConfig - параметры пакетного назначения
+type BatchConfig struct {
+	// Window - сколько собирать заказы после сигнала, прежде чем распределять их
+	Window time.Duration
+	// LoadPenalty - надбавка к стоимости за каждую доставку, которая уже есть у курьера
+	LoadPenalty time.Duration
+	// PriorityBonus - скидка за каждую ступень приоритета: при нехватке курьеров их получают старшие заказы
+	PriorityBonus time.Duration
+}
+
+func DefaultBatchConfig() BatchConfig {
+	return BatchConfig{
+		Window:        2 * time.Second,
+		LoadPenalty:   10 * time.Minute,
+		PriorityBonus: 30 * time.Minute,
+	}
+}
+
+// LoadBatchConfig читает параметры пакетного назначения из окружения поверх значений по умолчанию
+func LoadBatchConfig() BatchConfig {
+	cfg := DefaultBatchConfig()
+	cfg.Window = time.Duration(envInt("DISPATCH_WINDOW_MS", int(cfg.Window.Milliseconds()))) * time.Millisecond
+	cfg.LoadPenalty = envMinutes("DISPATCH_LOAD_PENALTY_MINUTES", cfg.LoadPenalty)
+	cfg.PriorityBonus = envMinutes("DISPATCH_PRIORITY_BONUS_MINUTES", cfg.PriorityBonus)
+	return cfg
+}
+
+// WithBatchDispatch включает пакетное назначение: заказы копятся в очереди ожидания,
+// а курьеры распределяются между ними разом по минимуму суммарного времени доставки.
+// Работает только вместе с WithPendingQueue.
+func WithBatchDispatch(cfg BatchConfig) Option {
+	return func(s *Service) {
+		s.batch = &cfg
+	}
+}
+
+func (s *Service) batchEnabled() bool {
+	return s.batch != nil && s.pending != nil
+}
+
+// batchWindow - сколько обработчику очереди ждать заказы перед распределением
+func (s *Service) batchWindow() time.Duration {
+	if !s.batchEnabled() {
+		return 0
+	}
+	return s.batch.Window
+}
+
+// deferToBatch ставит заказ в очередь пакетного назначения
+func (s *Service) deferToBatch(ctx context.Context, orderID string, destination *geo.Point, priority delivery.Priority) error {
+	existingDelivery, err := s.deliveryRepo.GetByOrderID(ctx, orderID)
+	if err != nil && !errors.Is(err, delivery.ErrDeliveryNotFound) {
+		return fmt.Errorf("check existing delivery: %w", err)
+	}
+	if existingDelivery != nil {
+		return delivery.ErrOrderAlreadyAssigned
+	}
+
+	_, err = s.enqueuePending(ctx, orderID, destination, priority)
+	return err
+}
+
+// dispatchOrder - заказ из очереди и курьеры, которые ему подходят
+type dispatchOrder struct {
+	pending delivery.PendingAssignment
+	allowed map[int64]bool
+	err     error
+}
+
+// dispatchSlot - свободное место у курьера, load - сколько доставок у него будет до этой
+type dispatchSlot struct {
+	courier courier.Courier
+	load    int64
+}
+
+// DispatchPending снимает с очереди заказы с истекшим SLA и распределяет курьеров между остальными
+// разом - решением задачи о назначениях. Все назначения пачки коммитятся в одной транзакции.
+func (s *Service) DispatchPending(ctx context.Context, limit uint64) error {
+	if s.pending == nil {
+		return nil
+	}
+
+	if err := s.expirePending(ctx); err != nil {
+		return err
+	}
+
+	var assigned []delivery.PendingAssignment
+
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		assigned = nil
+
+		pending, err := s.pending.ListReady(ctx, s.clock.Now(), limit)
+		if err != nil {
+			return fmt.Errorf("list pending assignments: %w", err)
+		}
+
+		if len(pending) == 0 {
+			return nil
+		}
+
+		orders, slots, err := s.collectDispatch(ctx, pending)
+		if err != nil {
+			return err
+		}
+
+		assigned, err = s.applyDispatch(ctx, orders, slots, matching.Solve(s.dispatchCosts(orders, slots)))
+		return err
+	})
+
+	if err != nil {
+		return fmt.Errorf("dispatch pending transaction: %w", err)
+	}
+
+	if len(assigned) > 0 {
+		log.Printf("[DispatchPending] Assigned couriers for %d orders", len(assigned))
+	}
+
+	now := s.clock.Now()
+	for _, p := range assigned {
+		metrics.PendingAssignmentsTotal.WithLabelValues(string(p.Priority), "assigned").Inc()
+		metrics.AssignmentWaitSeconds.WithLabelValues(string(p.Priority)).Observe(now.Sub(p.CreatedAt).Seconds())
+		metrics.CourierAssignmentsTotal.WithLabelValues("batch").Inc()
+		metrics.OpsCounter.Inc()
+	}
+
+	return nil
+}
+
+// collectDispatch убирает из очереди уже назначенные заказы, находит подходящих каждому заказу курьеров
+// и раскладывает свободную вместимость курьеров на отдельные места
+func (s *Service) collectDispatch(ctx context.Context, pending []delivery.PendingAssignment) ([]dispatchOrder, []dispatchSlot, error) {
+	orders := make([]dispatchOrder, 0, len(pending))
+	candidates := make(map[int64]courier.Candidate)
+	listed := make(map[string][]courier.Candidate)
+
+	for _, p := range pending {
+		existingDelivery, err := s.deliveryRepo.GetByOrderID(ctx, p.OrderID)
+		if err != nil && !errors.Is(err, delivery.ErrDeliveryNotFound) {
+			return nil, nil, fmt.Errorf("check existing delivery: %w", err)
+		}
+		// курьер мог быть назначен в обход очереди - заказ просто убирается из нее
+		if existingDelivery != nil {
+			if _, err := s.pending.DeleteByOrderID(ctx, p.OrderID); err != nil {
+				return nil, nil, fmt.Errorf("delete pending assignment: %w", err)
+			}
+			continue
+		}
+
+		order := dispatchOrder{pending: p, allowed: make(map[int64]bool)}
+
+		filter, err := s.dispatchFilter(ctx, p)
+		if err != nil {
+			order.err = err
+			orders = append(orders, order)
+			continue
+		}
+
+		// у заказов одного приоритета и одной зоны без отказов одинаковый набор курьеров
+		key := fmt.Sprint(filter.Transports, filter.ZoneIDs, filter.ExcludeIDs)
+		available, ok := listed[key]
+		if !ok {
+			available, err = s.courierRepo.ListAvailableWithDeliveries(ctx, filter)
+			if err != nil {
+				return nil, nil, fmt.Errorf("list available couriers: %w", err)
+			}
+			listed[key] = available
+		}
+
+		for _, c := range available {
+			order.allowed[c.Courier.ID] = true
+			candidates[c.Courier.ID] = c
+		}
+		orders = append(orders, order)
+	}
+
+	ids := make([]int64, 0, len(candidates))
+	for id := range candidates {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	capacity := s.capacity()
+	slots := make([]dispatchSlot, 0)
+	for _, id := range ids {
+		c := candidates[id]
+		free := int64(capacity.Of(c.Courier.TransportType)) - c.Active
+		// больше мест, чем заказов в пачке, курьеру не понадобится
+		free = min(free, int64(len(orders)))
+		for k := int64(0); k < free; k++ {
+			slots = append(slots, dispatchSlot{courier: c.Courier, load: c.Active + k})
+		}
+	}
+
+	return orders, slots, nil
+}
+
+// dispatchFilter - те же ограничения, что и при назначении по одному: вместимость, транспорт приоритета, зоны, отказы
+func (s *Service) dispatchFilter(ctx context.Context, p delivery.PendingAssignment) (courier.AvailableFilter, error) {
+	rejected, err := s.rejectedCouriers(ctx, p.OrderID)
+	if err != nil {
+		return courier.AvailableFilter{}, err
+	}
+
+	filter := courier.AvailableFilter{
+		Capacity:   s.capacity(),
+		Transports: s.policy(p.Priority).Transports,
+		ExcludeIDs: rejected,
+	}
+
+	if s.zones == nil {
+		return filter, nil
+	}
+
+	zoneData, err := s.resolveZone(ctx, p.Destination)
+	if err != nil {
+		return filter, err
+	}
+
+	filter.ZoneIDs = []int64{zoneData.ID}
+	if s.zoneFallback {
+		filter.ZoneIDs = append(filter.ZoneIDs, zoneData.Neighbors...)
+	}
+	return filter, nil
+}
+
+// dispatchCosts строит матрицу стоимости: минуты до дедлайна по модели транспорта,
+// надбавка за загрузку курьера и скидка за приоритет заказа
+func (s *Service) dispatchCosts(orders []dispatchOrder, slots []dispatchSlot) [][]float64 {
+	now := s.clock.Now()
+
+	costs := make([][]float64, len(orders))
+	for i, order := range orders {
+		costs[i] = make([]float64, len(slots))
+		bonus := s.batch.PriorityBonus.Minutes() * float64(order.pending.Priority.Rank())
+
+		for j, slot := range slots {
+			if order.err != nil || !order.allowed[slot.courier.ID] {
+				costs[i][j] = matching.Forbidden
+				continue
+			}
+
+			transport := s.transportFactory.Create(slot.courier.TransportType)
+			deadline := s.deliveryDeadline(transport, &slot.courier, order.pending.Destination, now)
+
+			costs[i][j] = deadline.Sub(now).Minutes() +
+				s.batch.LoadPenalty.Minutes()*float64(slot.load) -
+				bonus
+		}
+	}
+	return costs
+}
+
+// applyDispatch создает доставки по найденному назначению, остальным заказам засчитывает попытку
+func (s *Service) applyDispatch(
+	ctx context.Context,
+	orders []dispatchOrder,
+	slots []dispatchSlot,
+	assignment []int,
+) ([]delivery.PendingAssignment, error) {
+	now := s.clock.Now()
+	assigned := make([]delivery.PendingAssignment, 0, len(orders))
+
+	for i, order := range orders {
+		p := order.pending
+
+		if assignment[i] < 0 {
+			reason := courier.ErrNoAvailableCouriers
+			if order.err != nil {
+				reason = order.err
+			}
+			if err := s.pending.MarkAttempt(ctx, p.ID, now, reason.Error()); err != nil {
+				return nil, fmt.Errorf("mark pending attempt: %w", err)
+			}
+			continue
+		}
+
+		slotCourier := slots[assignment[i]].courier
+		if _, err := s.createDelivery(ctx, p.OrderID, &slotCourier, p.Destination, p.Priority); err != nil {
+			// курьера заняли в обход пачки - заказ остается в очереди до следующего прохода
+			if !errors.Is(err, courier.ErrCourierTaken) {
+				return nil, err
+			}
+			if err := s.pending.MarkAttempt(ctx, p.ID, now, err.Error()); err != nil {
+				return nil, fmt.Errorf("mark pending attempt: %w", err)
+			}
+			continue
+		}
+
+		if _, err := s.pending.DeleteByOrderID(ctx, p.OrderID); err != nil {
+			return nil, fmt.Errorf("delete pending assignment: %w", err)
+		}
+		assigned = append(assigned, p)
+	}
+
+	return assigned, nil
+}
+
+// processPending - один проход обработчика очереди: пакетом или по одному заказу
+func (s *Service) processPending(ctx context.Context, limit uint64) error {
+	if s.batchEnabled() {
+		return s.DispatchPending(ctx, limit)
+	}
+	return s.ProcessPendingAssignments(ctx, limit)
+}
@@ -0,0 +1,195 @@
+package delivery_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	modelCourier "service-courier/internal/model/courier"
+	modelDelivery "service-courier/internal/model/delivery"
+	"service-courier/internal/pkg/geo"
+	deliveryService "service-courier/internal/service/delivery"
+	"service-courier/internal/service/delivery/mocks"
+)
+
+// kmEast - точка на экваторе в km километрах к востоку от нулевого меридиана
+func kmEast(km float64) *geo.Point {
+	return &geo.Point{Lat: 0, Lon: km / 111.195}
+}
+
+func newBatchService(t *testing.T) (*deliveryService.Service, pendingMocks) {
+	ctrl := gomock.NewController(t)
+
+	m := pendingMocks{
+		deliveryRepo: mocks.NewMockdeliveryRepository(ctrl),
+		courierRepo:  mocks.NewMockcourierRepository(ctrl),
+		pending:      mocks.NewMockpendingRepository(ctrl),
+	}
+	txManager := mocks.NewMocktransactionManager(ctrl)
+	txManager.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		}).
+		AnyTimes()
+
+	service := deliveryService.NewDeliveryService(
+		m.deliveryRepo,
+		m.courierRepo,
+		deliveryService.NewTransportFactory(),
+		txManager,
+		deliveryService.NewFixedClock(pendingNow),
+		deliveryService.WithPendingQueue(m.pending, 15*time.Minute, "delivery.assignment.expired"),
+		deliveryService.WithBatchDispatch(deliveryService.DefaultBatchConfig()),
+	)
+	return service, m
+}
+
+func locatedCandidate(id int64, transport modelCourier.TransportType, at *geo.Point) modelCourier.Candidate {
+	c := candidate(id, transport, 0, 0)
+	c.Courier.Status = modelCourier.StatusAvailable
+	c.Courier.Location = &modelCourier.Location{Point: *at, UpdatedAt: pendingNow}
+	return c
+}
+
+// expectCreated запоминает, какому курьеру досталась доставка каждого заказа
+func expectCreated(m pendingMocks, times int) map[string]int64 {
+	created := make(map[string]int64)
+
+	m.deliveryRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, d modelDelivery.Delivery) (int64, error) {
+			created[d.OrderID] = d.CourierID
+			return int64(len(created)), nil
+		}).
+		Times(times)
+	m.deliveryRepo.EXPECT().CreateEvents(gomock.Any(), gomock.Any()).Return(nil).Times(times)
+	m.courierRepo.EXPECT().
+		LockAssignable(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, id int64) (*modelCourier.Courier, error) {
+			return &modelCourier.Courier{ID: id, Status: modelCourier.StatusAvailable, TransportType: modelCourier.TransportCar}, nil
+		}).
+		Times(times)
+	m.deliveryRepo.EXPECT().CountActiveByCourierIDs(gomock.Any(), gomock.Any()).Return(map[int64]int64{}, nil).Times(times)
+	m.courierRepo.EXPECT().UpdateStatusFrom(gomock.Any(), gomock.Any(), gomock.Any(), modelCourier.CourierStatus(modelCourier.StatusBusy)).Return(true, nil).Times(times)
+	return created
+}
+
+func TestDispatchPending_MinimizesTotalDeliveryTime(t *testing.T) {
+	t.Parallel()
+	service, m := newBatchService(t)
+
+	// Пеший курьер в точке 0, машина в 1.5 км. Первый заказ в 0.5 км - по одному он забрал бы машину,
+	// и второму заказу в 7.5 км достался бы пеший курьер.
+	pending := []modelDelivery.PendingAssignment{
+		{ID: 1, OrderID: "order-1", Destination: kmEast(0.5), CreatedAt: pendingNow.Add(-2 * time.Minute)},
+		{ID: 2, OrderID: "order-2", Destination: kmEast(7.5), CreatedAt: pendingNow.Add(-time.Minute)},
+	}
+
+	m.pending.EXPECT().DeleteExpired(gomock.Any(), pendingNow).Return(nil, nil)
+	m.pending.EXPECT().ListReady(gomock.Any(), pendingNow, uint64(10)).Return(pending, nil)
+	m.deliveryRepo.EXPECT().GetByOrderID(gomock.Any(), gomock.Any()).Return(nil, modelDelivery.ErrDeliveryNotFound).Times(2)
+	m.courierRepo.EXPECT().
+		ListAvailableWithDeliveries(gomock.Any(), gomock.Any()).
+		Return([]modelCourier.Candidate{
+			locatedCandidate(1, modelCourier.TransportCar, kmEast(1.5)),
+			locatedCandidate(2, modelCourier.TransportOnFoot, kmEast(0)),
+		}, nil)
+	created := expectCreated(m, 2)
+	m.pending.EXPECT().DeleteByOrderID(gomock.Any(), "order-1").Return(true, nil)
+	m.pending.EXPECT().DeleteByOrderID(gomock.Any(), "order-2").Return(true, nil)
+
+	require.NoError(t, service.DispatchPending(context.Background(), 10))
+	assert.Equal(t, map[string]int64{"order-1": 2, "order-2": 1}, created)
+}
+
+func TestDispatchPending_HigherPriorityWinsScarceCourier(t *testing.T) {
+	t.Parallel()
+	service, m := newBatchService(t)
+
+	// У курьера одно свободное место, он ближе к стандартному заказу, но достается VIP
+	pending := []modelDelivery.PendingAssignment{
+		{ID: 1, OrderID: "order-standard", Destination: kmEast(1)},
+		{ID: 2, OrderID: "order-vip", Priority: modelDelivery.PriorityVIP, Destination: kmEast(5)},
+	}
+
+	busy := locatedCandidate(1, modelCourier.TransportCar, kmEast(0))
+	busy.Active = 2
+
+	m.pending.EXPECT().DeleteExpired(gomock.Any(), pendingNow).Return(nil, nil)
+	m.pending.EXPECT().ListReady(gomock.Any(), pendingNow, uint64(10)).Return(pending, nil)
+	m.deliveryRepo.EXPECT().GetByOrderID(gomock.Any(), gomock.Any()).Return(nil, modelDelivery.ErrDeliveryNotFound).Times(2)
+	m.courierRepo.EXPECT().
+		ListAvailableWithDeliveries(gomock.Any(), gomock.Any()).
+		Return([]modelCourier.Candidate{busy}, nil).
+		Times(2)
+	created := expectCreated(m, 1)
+	m.pending.EXPECT().DeleteByOrderID(gomock.Any(), "order-vip").Return(true, nil)
+	m.pending.EXPECT().
+		MarkAttempt(gomock.Any(), int64(1), pendingNow, modelCourier.ErrNoAvailableCouriers.Error()).
+		Return(nil)
+
+	require.NoError(t, service.DispatchPending(context.Background(), 10))
+	assert.Equal(t, map[string]int64{"order-vip": 1}, created)
+}
+
+func TestDispatchPending_DropsAlreadyAssignedOrders(t *testing.T) {
+	t.Parallel()
+	service, m := newBatchService(t)
+
+	m.pending.EXPECT().DeleteExpired(gomock.Any(), pendingNow).Return(nil, nil)
+	m.pending.EXPECT().
+		ListReady(gomock.Any(), pendingNow, uint64(10)).
+		Return([]modelDelivery.PendingAssignment{{ID: 1, OrderID: "order-1"}}, nil)
+	m.deliveryRepo.EXPECT().GetByOrderID(gomock.Any(), "order-1").Return(&modelDelivery.Delivery{ID: 5}, nil)
+	m.pending.EXPECT().DeleteByOrderID(gomock.Any(), "order-1").Return(true, nil)
+
+	require.NoError(t, service.DispatchPending(context.Background(), 10))
+}
+
+func TestAssignCourier_BatchModeQueuesOrder(t *testing.T) {
+	t.Parallel()
+	service, m := newBatchService(t)
+
+	m.deliveryRepo.EXPECT().GetByOrderID(gomock.Any(), "order-1").Return(nil, modelDelivery.ErrDeliveryNotFound)
+	m.pending.EXPECT().
+		Enqueue(gomock.Any(), modelDelivery.PendingAssignment{
+			OrderID:   "order-1",
+			Priority:  modelDelivery.PriorityStandard,
+			CreatedAt: pendingNow,
+			ExpiresAt: pendingNow.Add(15 * time.Minute),
+		}).
+		Return(true, nil)
+
+	result, err := service.AssignCourier(context.Background(), "order-1", modelDelivery.PriorityStandard)
+	assert.ErrorIs(t, err, modelDelivery.ErrAssignmentPending)
+	assert.Nil(t, result)
+}
+
+func TestDispatchPending_KeepsOrderWhenCourierTaken(t *testing.T) {
+	t.Parallel()
+	service, m := newBatchService(t)
+
+	pending := []modelDelivery.PendingAssignment{{ID: 1, OrderID: "order-1", Destination: kmEast(1)}}
+
+	m.pending.EXPECT().DeleteExpired(gomock.Any(), pendingNow).Return(nil, nil)
+	m.pending.EXPECT().ListReady(gomock.Any(), pendingNow, uint64(10)).Return(pending, nil)
+	m.deliveryRepo.EXPECT().GetByOrderID(gomock.Any(), "order-1").Return(nil, modelDelivery.ErrDeliveryNotFound)
+	m.courierRepo.EXPECT().
+		ListAvailableWithDeliveries(gomock.Any(), gomock.Any()).
+		Return([]modelCourier.Candidate{locatedCandidate(1, modelCourier.TransportOnFoot, kmEast(0))}, nil)
+	// курьер уже взял заказ в обход пачки: пеший курьер везет одну доставку
+	m.courierRepo.EXPECT().
+		LockAssignable(gomock.Any(), int64(1)).
+		Return(&modelCourier.Courier{ID: 1, Status: modelCourier.StatusBusy, TransportType: modelCourier.TransportOnFoot}, nil)
+	m.deliveryRepo.EXPECT().CountActiveByCourierIDs(gomock.Any(), []int64{1}).Return(map[int64]int64{1: 1}, nil)
+	m.pending.EXPECT().
+		MarkAttempt(gomock.Any(), int64(1), pendingNow, modelCourier.ErrCourierTaken.Error()).
+		Return(nil)
+
+	require.NoError(t, service.DispatchPending(context.Background(), 10))
+}
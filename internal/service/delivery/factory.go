@@ -8,20 +8,26 @@ type TransportFactory interface {
 	Create(t courier.TransportType) Transport
 }
 
-type DefaultTransportFactory struct{}
+type DefaultTransportFactory struct {
+	config TransportConfig
+}
 
 func NewTransportFactory() TransportFactory {
-	return &DefaultTransportFactory{}
+	return NewTransportFactoryFromConfig(DefaultTransportConfig())
+}
+
+func NewTransportFactoryFromConfig(config TransportConfig) TransportFactory {
+	return &DefaultTransportFactory{config: config}
 }
 
 func (f *DefaultTransportFactory) Create(t courier.TransportType) Transport {
 	switch t {
 	case courier.TransportOnFoot:
-		return OnFoot{}
+		return OnFoot{speedTransport{profile: f.config.OnFoot, traffic: f.config.Traffic}}
 	case courier.TransportScooter:
-		return Scooter{}
+		return Scooter{speedTransport{profile: f.config.Scooter, traffic: f.config.Traffic}}
 	case courier.TransportCar:
-		return Car{}
+		return Car{speedTransport{profile: f.config.Car, traffic: f.config.Traffic}}
 	default:
 		return nil
 	}
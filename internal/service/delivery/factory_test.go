@@ -48,3 +48,115 @@ func TestDeliveryTimeFactory_CalculateDeadline_Car(t *testing.T) {
 	expected := baseTime.Add(5 * time.Minute)
 	assert.Equal(t, expected, deadline)
 }
+
+func TestTransport_Deadline_ByDistance(t *testing.T) {
+	t.Parallel()
+
+	cfg := deliveryService.DefaultTransportConfig()
+	cfg.Car.AverageSpeedKmh = 36 // 10 м/с
+	cfg.Car.HandoverBuffer = 5 * time.Minute
+
+	factory := deliveryService.NewTransportFactoryFromConfig(cfg)
+	baseTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	transport := factory.Create(modelCourier.TransportCar)
+	deadline := transport.Deadline(baseTime, 6000)
+
+	expected := baseTime.Add(5*time.Minute + 10*time.Minute)
+	assert.Equal(t, expected, deadline)
+}
+
+func TestTransport_Deadline_TrafficMultiplier(t *testing.T) {
+	t.Parallel()
+
+	cfg := deliveryService.DefaultTransportConfig()
+	cfg.Car.AverageSpeedKmh = 36
+	cfg.Car.HandoverBuffer = 0
+	cfg.Traffic.Windows = []deliveryService.TrafficWindow{
+		{FromHour: 17, ToHour: 20, Multiplier: 2},
+	}
+
+	factory := deliveryService.NewTransportFactoryFromConfig(cfg)
+	transport := factory.Create(modelCourier.TransportCar)
+
+	rush := time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC)
+	assert.Equal(t, rush.Add(20*time.Minute), transport.Deadline(rush, 6000))
+
+	calm := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, calm.Add(10*time.Minute), transport.Deadline(calm, 6000))
+}
+
+func TestTransport_Deadline_OnFootIgnoresTraffic(t *testing.T) {
+	t.Parallel()
+
+	cfg := deliveryService.DefaultTransportConfig()
+	cfg.OnFoot.AverageSpeedKmh = 6 // 100 м/мин
+	cfg.OnFoot.HandoverBuffer = 0
+	cfg.Traffic.Windows = []deliveryService.TrafficWindow{
+		{FromHour: 0, ToHour: 24, Multiplier: 3},
+	}
+
+	factory := deliveryService.NewTransportFactoryFromConfig(cfg)
+	baseTime := time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC)
+
+	transport := factory.Create(modelCourier.TransportOnFoot)
+
+	assert.Equal(t, baseTime.Add(10*time.Minute), transport.Deadline(baseTime, 1000))
+}
+
+func TestTransport_Deadline_UnknownDistance(t *testing.T) {
+	t.Parallel()
+
+	factory := deliveryService.NewTransportFactory()
+	baseTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	transport := factory.Create(modelCourier.TransportScooter)
+
+	assert.Equal(t, baseTime.Add(15*time.Minute), transport.Deadline(baseTime, -1))
+}
+
+func TestTrafficConfig_MultiplierOverMidnight(t *testing.T) {
+	t.Parallel()
+
+	cfg := deliveryService.TrafficConfig{
+		Windows: []deliveryService.TrafficWindow{{FromHour: 22, ToHour: 2, Multiplier: 1.3}},
+	}
+
+	assert.Equal(t, 1.3, cfg.Multiplier(time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 1.3, cfg.Multiplier(time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 1.0, cfg.Multiplier(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)))
+}
+
+func TestParseTrafficWindows(t *testing.T) {
+	t.Parallel()
+
+	windows, err := deliveryService.ParseTrafficWindows("8-10:1.5, 17-20:1.7")
+	assert.NoError(t, err)
+	assert.Equal(t, []deliveryService.TrafficWindow{
+		{FromHour: 8, ToHour: 10, Multiplier: 1.5},
+		{FromHour: 17, ToHour: 20, Multiplier: 1.7},
+	}, windows)
+
+	_, err = deliveryService.ParseTrafficWindows("8-10")
+	assert.Error(t, err)
+
+	_, err = deliveryService.ParseTrafficWindows("25-10:1.5")
+	assert.Error(t, err)
+}
+
+func TestTransport_Capacity(t *testing.T) {
+	t.Parallel()
+
+	factory := deliveryService.NewTransportFactory()
+
+	assert.Equal(t, 1, factory.Create(modelCourier.TransportOnFoot).Capacity())
+	assert.Equal(t, 2, factory.Create(modelCourier.TransportScooter).Capacity())
+	assert.Equal(t, 3, factory.Create(modelCourier.TransportCar).Capacity())
+
+	cfg := deliveryService.DefaultTransportConfig()
+	cfg.Car.Capacity = 0
+	factory = deliveryService.NewTransportFactoryFromConfig(cfg)
+
+	// Вместимость не бывает меньше одной доставки
+	assert.Equal(t, 1, factory.Create(modelCourier.TransportCar).Capacity())
+}
@@ -0,0 +1,83 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"service-courier/internal/model/delivery"
+	"time"
+)
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 500
+)
+
+// GetDelivery возвращает текущую доставку заказа
+func (s *Service) GetDelivery(ctx context.Context, orderID string) (*delivery.Delivery, error) {
+	deliveryData, err := s.deliveryRepo.GetByOrderID(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, delivery.ErrDeliveryNotFound) {
+			return nil, delivery.ErrDeliveryNotFound
+		}
+		return nil, fmt.Errorf("get delivery: %w", err)
+	}
+	return deliveryData, nil
+}
+
+// GetLatestDelivery возвращает последнюю доставку заказа, даже если курьера с нее уже сняли
+func (s *Service) GetLatestDelivery(ctx context.Context, orderID string) (*delivery.Delivery, error) {
+	deliveries, err := s.deliveryRepo.List(ctx, delivery.ListFilter{
+		OrderID:        orderID,
+		IncludeDeleted: true,
+		Sort:           delivery.SortByID,
+		Desc:           true,
+		Limit:          1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list deliveries: %w", err)
+	}
+	if len(deliveries) == 0 {
+		return nil, delivery.ErrDeliveryNotFound
+	}
+	return &deliveries[0], nil
+}
+
+// ListDeliveries возвращает страницу доставок по фильтру. Next указывает на последнюю строку
+// страницы и передается в filter.After за следующей страницей.
+func (s *Service) ListDeliveries(ctx context.Context, filter delivery.ListFilter) (*delivery.Page, error) {
+	if filter.Limit == 0 || filter.Limit > maxListLimit {
+		filter.Limit = defaultListLimit
+	}
+	if !filter.Sort.IsValid() {
+		filter.Sort = delivery.SortByID
+	}
+
+	limit := filter.Limit
+	// одна лишняя строка показывает, есть ли следующая страница
+	filter.Limit++
+
+	deliveries, err := s.deliveryRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list deliveries: %w", err)
+	}
+
+	page := &delivery.Page{Deliveries: deliveries}
+	if uint64(len(deliveries)) > limit {
+		page.Deliveries = deliveries[:limit]
+		last := page.Deliveries[limit-1]
+		page.Next = &delivery.Cursor{ID: last.ID, At: sortValue(last, filter.Sort)}
+	}
+	return page, nil
+}
+
+func sortValue(d delivery.Delivery, field delivery.SortField) time.Time {
+	switch field {
+	case delivery.SortByAssignedAt:
+		return d.AssignedAt
+	case delivery.SortByDeadline:
+		return d.Deadline
+	default:
+		return time.Time{}
+	}
+}
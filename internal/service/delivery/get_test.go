@@ -0,0 +1,115 @@
+package delivery_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	modelDelivery "service-courier/internal/model/delivery"
+	deliveryService "service-courier/internal/service/delivery"
+	"service-courier/internal/service/delivery/mocks"
+)
+
+func newReadService(ctrl *gomock.Controller) (*deliveryService.Service, *mocks.MockdeliveryRepository) {
+	mockDeliveryRepo := mocks.NewMockdeliveryRepository(ctrl)
+	service := deliveryService.NewDeliveryService(
+		mockDeliveryRepo,
+		mocks.NewMockcourierRepository(ctrl),
+		deliveryService.NewTransportFactory(),
+		mocks.NewMocktransactionManager(ctrl),
+		deliveryService.NewFixedClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)),
+	)
+	return service, mockDeliveryRepo
+}
+
+func TestListDeliveries_NextCursorFromLastRow(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockDeliveryRepo := newReadService(ctrl)
+
+	assignedAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	courierID := int64(10)
+
+	mockDeliveryRepo.EXPECT().
+		List(gomock.Any(), modelDelivery.ListFilter{
+			CourierID: &courierID,
+			Sort:      modelDelivery.SortByAssignedAt,
+			Desc:      true,
+			Limit:     3,
+		}).
+		Return([]modelDelivery.Delivery{
+			{ID: 7, AssignedAt: assignedAt.Add(2 * time.Minute)},
+			{ID: 5, AssignedAt: assignedAt.Add(time.Minute)},
+			{ID: 3, AssignedAt: assignedAt},
+		}, nil)
+
+	page, err := service.ListDeliveries(context.Background(), modelDelivery.ListFilter{
+		CourierID: &courierID,
+		Sort:      modelDelivery.SortByAssignedAt,
+		Desc:      true,
+		Limit:     2,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(page.Deliveries) != 2 {
+		t.Fatalf("expected 2 deliveries, got %d", len(page.Deliveries))
+	}
+	if page.Next == nil {
+		t.Fatal("expected next cursor")
+	}
+	if page.Next.ID != 5 || !page.Next.At.Equal(assignedAt.Add(time.Minute)) {
+		t.Errorf("expected cursor at delivery 5, got %+v", *page.Next)
+	}
+}
+
+func TestListDeliveries_LastPage(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockDeliveryRepo := newReadService(ctrl)
+
+	after := &modelDelivery.Cursor{ID: 5}
+	mockDeliveryRepo.EXPECT().
+		List(gomock.Any(), modelDelivery.ListFilter{Sort: modelDelivery.SortByID, After: after, Limit: 51}).
+		Return([]modelDelivery.Delivery{{ID: 6}}, nil)
+
+	page, err := service.ListDeliveries(context.Background(), modelDelivery.ListFilter{After: after})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(page.Deliveries) != 1 || page.Next != nil {
+		t.Errorf("expected single last page, got %d deliveries and cursor %v", len(page.Deliveries), page.Next)
+	}
+}
+
+func TestGetLatestDelivery_NotFound(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockDeliveryRepo := newReadService(ctrl)
+
+	orderID := "f819526d-6a7c-48eb-b535-43989469d1ca"
+	mockDeliveryRepo.EXPECT().
+		List(gomock.Any(), modelDelivery.ListFilter{
+			OrderID:        orderID,
+			IncludeDeleted: true,
+			Sort:           modelDelivery.SortByID,
+			Desc:           true,
+			Limit:          1,
+		}).
+		Return([]modelDelivery.Delivery{}, nil)
+
+	_, err := service.GetLatestDelivery(context.Background(), orderID)
+	if !errors.Is(err, modelDelivery.ErrDeliveryNotFound) {
+		t.Fatalf("expected ErrDeliveryNotFound, got %v", err)
+	}
+}
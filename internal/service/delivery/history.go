@@ -0,0 +1,65 @@
+package delivery
+
+import (
+	"context"
+	"fmt"
+	"service-courier/internal/model/delivery"
+)
+
+type changeSourceKey struct{}
+
+type changeSource struct {
+	actor  delivery.Actor
+	reason string
+}
+
+// WithChangeSource помечает контекст инициатором изменения и причиной для истории доставки
+func WithChangeSource(ctx context.Context, actor delivery.Actor, reason string) context.Context {
+	return context.WithValue(ctx, changeSourceKey{}, changeSource{actor: actor, reason: reason})
+}
+
+func changeSourceFrom(ctx context.Context, defaultReason string) (delivery.Actor, string) {
+	src, ok := ctx.Value(changeSourceKey{}).(changeSource)
+	if !ok {
+		return delivery.ActorSystem, defaultReason
+	}
+	if src.reason == "" {
+		src.reason = defaultReason
+	}
+	return src.actor, src.reason
+}
+
+func (s *Service) newEvent(ctx context.Context, d delivery.Delivery, to delivery.DeliveryStatus, defaultReason string) delivery.Event {
+	actor, reason := changeSourceFrom(ctx, defaultReason)
+	return delivery.Event{
+		DeliveryID: d.ID,
+		OrderID:    d.OrderID,
+		CourierID:  d.CourierID,
+		FromStatus: d.Status,
+		ToStatus:   to,
+		Actor:      actor,
+		Reason:     reason,
+		CreatedAt:  s.clock.Now(),
+	}
+}
+
+func (s *Service) recordEvents(ctx context.Context, events ...delivery.Event) error {
+	if err := s.deliveryRepo.CreateEvents(ctx, events); err != nil {
+		return fmt.Errorf("record delivery events: %w", err)
+	}
+	return s.enqueueEvents(ctx, events)
+}
+
+// GetDeliveryHistory возвращает историю изменений доставки заказа в хронологическом порядке
+func (s *Service) GetDeliveryHistory(ctx context.Context, orderID string) ([]delivery.Event, error) {
+	events, err := s.deliveryRepo.ListEventsByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("list delivery events: %w", err)
+	}
+
+	if len(events) == 0 {
+		return nil, delivery.ErrDeliveryNotFound
+	}
+
+	return events, nil
+}
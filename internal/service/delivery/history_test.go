@@ -0,0 +1,326 @@
+package delivery_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"service-courier/internal/dto/queues/delivery/changed"
+	modelDelivery "service-courier/internal/model/delivery"
+	modelOutbox "service-courier/internal/model/outbox"
+	deliveryService "service-courier/internal/service/delivery"
+	"service-courier/internal/service/delivery/mocks"
+)
+
+func TestTransitionDelivery_RecordsEventWithChangeSource(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDeliveryRepo := mocks.NewMockdeliveryRepository(ctrl)
+	mockCourierRepo := mocks.NewMockcourierRepository(ctrl)
+	mockTxManager := mocks.NewMocktransactionManager(ctrl)
+
+	fixed := time.Date(2024, 1, 1, 12, 00, 00, 0, time.UTC)
+	service := deliveryService.NewDeliveryService(
+		mockDeliveryRepo,
+		mockCourierRepo,
+		deliveryService.NewTransportFactory(),
+		mockTxManager,
+		deliveryService.NewFixedClock(fixed),
+	)
+
+	orderID := "f819526d-6a7c-48eb-b535-43989469d1ca"
+
+	mockTxManager.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+
+	mockDeliveryRepo.EXPECT().
+		GetByOrderID(gomock.Any(), orderID).
+		Return(&modelDelivery.Delivery{ID: 1, OrderID: orderID, CourierID: 10, Status: modelDelivery.StatusAssigned}, nil)
+
+	mockDeliveryRepo.EXPECT().
+		UpdateStatus(gomock.Any(), int64(1), gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	var recorded []modelDelivery.Event
+	mockDeliveryRepo.EXPECT().
+		CreateEvents(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, events []modelDelivery.Event) error {
+			recorded = events
+			return nil
+		})
+
+	ctx := deliveryService.WithChangeSource(context.Background(), modelDelivery.ActorHTTP, "courier is on the way")
+	if _, err := service.TransitionDelivery(ctx, orderID, modelDelivery.StatusAccepted); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(recorded) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(recorded))
+	}
+	e := recorded[0]
+	if e.DeliveryID != 1 || e.OrderID != orderID || e.CourierID != 10 {
+		t.Errorf("unexpected event identity: %+v", e)
+	}
+	if e.FromStatus != modelDelivery.StatusAssigned || e.ToStatus != modelDelivery.StatusAccepted {
+		t.Errorf("unexpected event statuses %s -> %s", e.FromStatus, e.ToStatus)
+	}
+	if e.Actor != modelDelivery.ActorHTTP || e.Reason != "courier is on the way" {
+		t.Errorf("unexpected event source %s %q", e.Actor, e.Reason)
+	}
+	if !e.CreatedAt.Equal(fixed) {
+		t.Errorf("expected created_at %v, got %v", fixed, e.CreatedAt)
+	}
+}
+
+func TestReleaseExpiredCouriers_RecordsSystemEventsByDefault(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDeliveryRepo := mocks.NewMockdeliveryRepository(ctrl)
+	mockCourierRepo := mocks.NewMockcourierRepository(ctrl)
+	mockTxManager := mocks.NewMocktransactionManager(ctrl)
+
+	fixed := time.Date(2024, 1, 1, 12, 00, 00, 0, time.UTC)
+	service := deliveryService.NewDeliveryService(
+		mockDeliveryRepo,
+		mockCourierRepo,
+		deliveryService.NewTransportFactory(),
+		mockTxManager,
+		deliveryService.NewFixedClock(fixed),
+	)
+
+	mockTxManager.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+
+	mockDeliveryRepo.EXPECT().
+		ListActiveExpired(gomock.Any(), fixed).
+		Return([]modelDelivery.Delivery{
+			{ID: 1, OrderID: "order-1", CourierID: 10, Status: modelDelivery.StatusAssigned},
+			{ID: 2, OrderID: "order-2", CourierID: 20, Status: modelDelivery.StatusInTransit},
+		}, nil)
+
+	mockDeliveryRepo.EXPECT().
+		UpdateStatusByIDs(gomock.Any(), []int64{1, 2}, gomock.Any()).
+		Return(nil)
+
+	var recorded []modelDelivery.Event
+	mockDeliveryRepo.EXPECT().
+		CreateEvents(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, events []modelDelivery.Event) error {
+			recorded = events
+			return nil
+		})
+
+	mockDeliveryRepo.EXPECT().
+		CountActiveByCourierIDs(gomock.Any(), gomock.Any()).
+		Return(map[int64]int64{}, nil)
+
+	mockCourierRepo.EXPECT().
+		UpdateStatusFromBatch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, nil)
+
+	if err := service.ReleaseExpiredCouriers(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(recorded) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(recorded))
+	}
+	for _, e := range recorded {
+		if e.ToStatus != modelDelivery.StatusCompleted {
+			t.Errorf("expected to_status completed, got %s", e.ToStatus)
+		}
+		if e.Actor != modelDelivery.ActorSystem || e.Reason != "deadline expired" {
+			t.Errorf("unexpected event source %s %q", e.Actor, e.Reason)
+		}
+	}
+	if recorded[1].FromStatus != modelDelivery.StatusInTransit {
+		t.Errorf("expected from_status in_transit, got %s", recorded[1].FromStatus)
+	}
+}
+
+func TestTransitionDelivery_RecordEventError(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDeliveryRepo := mocks.NewMockdeliveryRepository(ctrl)
+	mockCourierRepo := mocks.NewMockcourierRepository(ctrl)
+	mockTxManager := mocks.NewMocktransactionManager(ctrl)
+
+	service := deliveryService.NewDeliveryService(
+		mockDeliveryRepo,
+		mockCourierRepo,
+		deliveryService.NewTransportFactory(),
+		mockTxManager,
+		deliveryService.NewFixedClock(time.Date(2024, 1, 1, 12, 00, 00, 0, time.UTC)),
+	)
+
+	orderID := "f819526d-6a7c-48eb-b535-43989469d1ca"
+	repoErr := errors.New("insert failed")
+
+	mockTxManager.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+
+	mockDeliveryRepo.EXPECT().
+		GetByOrderID(gomock.Any(), orderID).
+		Return(&modelDelivery.Delivery{ID: 1, OrderID: orderID, CourierID: 10, Status: modelDelivery.StatusAssigned}, nil)
+
+	mockDeliveryRepo.EXPECT().
+		UpdateStatus(gomock.Any(), int64(1), gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	mockDeliveryRepo.EXPECT().
+		CreateEvents(gomock.Any(), gomock.Any()).
+		Return(repoErr)
+
+	_, err := service.TransitionDelivery(context.Background(), orderID, modelDelivery.StatusAccepted)
+	if !errors.Is(err, repoErr) {
+		t.Fatalf("expected %v, got %v", repoErr, err)
+	}
+}
+
+func TestGetDeliveryHistory_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDeliveryRepo := mocks.NewMockdeliveryRepository(ctrl)
+
+	service := deliveryService.NewDeliveryService(
+		mockDeliveryRepo,
+		mocks.NewMockcourierRepository(ctrl),
+		deliveryService.NewTransportFactory(),
+		mocks.NewMocktransactionManager(ctrl),
+		deliveryService.NewFixedClock(time.Date(2024, 1, 1, 12, 00, 00, 0, time.UTC)),
+	)
+
+	orderID := "f819526d-6a7c-48eb-b535-43989469d1ca"
+	events := []modelDelivery.Event{
+		{ID: 1, OrderID: orderID, ToStatus: modelDelivery.StatusAssigned, Actor: modelDelivery.ActorKafka},
+		{ID: 2, OrderID: orderID, FromStatus: modelDelivery.StatusAssigned, ToStatus: modelDelivery.StatusAccepted, Actor: modelDelivery.ActorHTTP},
+	}
+
+	mockDeliveryRepo.EXPECT().
+		ListEventsByOrderID(gomock.Any(), orderID).
+		Return(events, nil)
+
+	history, err := service.GetDeliveryHistory(context.Background(), orderID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(history))
+	}
+}
+
+func TestGetDeliveryHistory_NotFound(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDeliveryRepo := mocks.NewMockdeliveryRepository(ctrl)
+
+	service := deliveryService.NewDeliveryService(
+		mockDeliveryRepo,
+		mocks.NewMockcourierRepository(ctrl),
+		deliveryService.NewTransportFactory(),
+		mocks.NewMocktransactionManager(ctrl),
+		deliveryService.NewFixedClock(time.Date(2024, 1, 1, 12, 00, 00, 0, time.UTC)),
+	)
+
+	mockDeliveryRepo.EXPECT().
+		ListEventsByOrderID(gomock.Any(), "missing").
+		Return([]modelDelivery.Event{}, nil)
+
+	_, err := service.GetDeliveryHistory(context.Background(), "missing")
+	if !errors.Is(err, modelDelivery.ErrDeliveryNotFound) {
+		t.Fatalf("expected ErrDeliveryNotFound, got %v", err)
+	}
+}
+
+func TestTransitionDelivery_EnqueuesOutboxMessage(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDeliveryRepo := mocks.NewMockdeliveryRepository(ctrl)
+	mockCourierRepo := mocks.NewMockcourierRepository(ctrl)
+	mockTxManager := mocks.NewMocktransactionManager(ctrl)
+	mockOutbox := mocks.NewMockoutboxRepository(ctrl)
+
+	service := deliveryService.NewDeliveryService(
+		mockDeliveryRepo,
+		mockCourierRepo,
+		deliveryService.NewTransportFactory(),
+		mockTxManager,
+		deliveryService.NewFixedClock(time.Date(2024, 1, 1, 12, 00, 00, 0, time.UTC)),
+		deliveryService.WithOutbox(mockOutbox, "delivery.status.changed"),
+	)
+
+	orderID := "f819526d-6a7c-48eb-b535-43989469d1ca"
+
+	mockTxManager.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+
+	mockDeliveryRepo.EXPECT().
+		GetByOrderID(gomock.Any(), orderID).
+		Return(&modelDelivery.Delivery{ID: 1, OrderID: orderID, CourierID: 10, Status: modelDelivery.StatusAssigned}, nil)
+
+	mockDeliveryRepo.EXPECT().
+		UpdateStatus(gomock.Any(), int64(1), gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	mockDeliveryRepo.EXPECT().
+		CreateEvents(gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	var enqueued []modelOutbox.Message
+	mockOutbox.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, messages []modelOutbox.Message) error {
+			enqueued = messages
+			return nil
+		})
+
+	if _, err := service.TransitionDelivery(context.Background(), orderID, modelDelivery.StatusAccepted); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(enqueued) != 1 {
+		t.Fatalf("expected 1 outbox message, got %d", len(enqueued))
+	}
+	if enqueued[0].Topic != "delivery.status.changed" || enqueued[0].Key != orderID {
+		t.Fatalf("unexpected outbox message %s/%s", enqueued[0].Topic, enqueued[0].Key)
+	}
+
+	var payload changed.Message
+	if err := json.Unmarshal(enqueued[0].Payload, &payload); err != nil {
+		t.Fatal(err)
+	}
+	if payload.Status != modelDelivery.StatusAccepted || payload.PreviousStatus == nil || *payload.PreviousStatus != modelDelivery.StatusAssigned {
+		t.Fatalf("unexpected payload %+v", payload)
+	}
+	if payload.CourierID != 10 || payload.DeliveryID != 1 {
+		t.Fatalf("unexpected payload identity %+v", payload)
+	}
+}
@@ -0,0 +1,38 @@
+package delivery
+
+import (
+	"context"
+	"fmt"
+	"service-courier/internal/pkg/geo"
+)
+
+// Locator определяет координаты точки доставки заказа
+type Locator interface {
+	Locate(ctx context.Context, orderID string) (*geo.Point, error)
+}
+
+type OrderLocator struct {
+	orders   orderProvider
+	geocoder geocoder
+}
+
+func NewOrderLocator(orders orderProvider, geocoder geocoder) *OrderLocator {
+	return &OrderLocator{
+		orders:   orders,
+		geocoder: geocoder,
+	}
+}
+
+func (l *OrderLocator) Locate(ctx context.Context, orderID string) (*geo.Point, error) {
+	orderData, err := l.orders.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("get order: %w", err)
+	}
+
+	point, err := l.geocoder.Geocode(ctx, orderData.Address)
+	if err != nil {
+		return nil, fmt.Errorf("geocode address: %w", err)
+	}
+
+	return point, nil
+}
@@ -41,60 +41,77 @@ func (m *MockcourierRepository) EXPECT() *MockcourierRepositoryMockRecorder {
 	return m.recorder
 }
 
-// GetAvailableWithMinDeliveries mocks base method.
-func (m *MockcourierRepository) GetAvailableWithMinDeliveries(ctx context.Context) (*courier.Courier, error) {
+// GetByID mocks base method.
+func (m *MockcourierRepository) GetByID(ctx context.Context, id int64) (*courier.Courier, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAvailableWithMinDeliveries", ctx)
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
 	ret0, _ := ret[0].(*courier.Courier)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAvailableWithMinDeliveries indicates an expected call of GetAvailableWithMinDeliveries.
-func (mr *MockcourierRepositoryMockRecorder) GetAvailableWithMinDeliveries(ctx any) *gomock.Call {
+// GetByID indicates an expected call of GetByID.
+func (mr *MockcourierRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvailableWithMinDeliveries", reflect.TypeOf((*MockcourierRepository)(nil).GetAvailableWithMinDeliveries), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockcourierRepository)(nil).GetByID), ctx, id)
 }
 
-// GetByID mocks base method.
-func (m *MockcourierRepository) GetByID(ctx context.Context, id int64) (*courier.Courier, error) {
+// ListAvailableWithDeliveries mocks base method.
+func (m *MockcourierRepository) ListAvailableWithDeliveries(ctx context.Context, filter courier.AvailableFilter) ([]courier.Candidate, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret := m.ctrl.Call(m, "ListAvailableWithDeliveries", ctx, filter)
+	ret0, _ := ret[0].([]courier.Candidate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAvailableWithDeliveries indicates an expected call of ListAvailableWithDeliveries.
+func (mr *MockcourierRepositoryMockRecorder) ListAvailableWithDeliveries(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAvailableWithDeliveries", reflect.TypeOf((*MockcourierRepository)(nil).ListAvailableWithDeliveries), ctx, filter)
+}
+
+// LockAssignable mocks base method.
+func (m *MockcourierRepository) LockAssignable(ctx context.Context, id int64) (*courier.Courier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockAssignable", ctx, id)
 	ret0, _ := ret[0].(*courier.Courier)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetByID indicates an expected call of GetByID.
-func (mr *MockcourierRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+// LockAssignable indicates an expected call of LockAssignable.
+func (mr *MockcourierRepositoryMockRecorder) LockAssignable(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockcourierRepository)(nil).GetByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockAssignable", reflect.TypeOf((*MockcourierRepository)(nil).LockAssignable), ctx, id)
 }
 
-// Update mocks base method.
-func (m *MockcourierRepository) Update(ctx context.Context, courierData courier.Courier) error {
+// UpdateStatusFrom mocks base method.
+func (m *MockcourierRepository) UpdateStatusFrom(ctx context.Context, id int64, from, to courier.CourierStatus) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, courierData)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "UpdateStatusFrom", ctx, id, from, to)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// Update indicates an expected call of Update.
-func (mr *MockcourierRepositoryMockRecorder) Update(ctx, courierData any) *gomock.Call {
+// UpdateStatusFrom indicates an expected call of UpdateStatusFrom.
+func (mr *MockcourierRepositoryMockRecorder) UpdateStatusFrom(ctx, id, from, to any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockcourierRepository)(nil).Update), ctx, courierData)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatusFrom", reflect.TypeOf((*MockcourierRepository)(nil).UpdateStatusFrom), ctx, id, from, to)
 }
 
-// UpdateStatusBatch mocks base method.
-func (m *MockcourierRepository) UpdateStatusBatch(ctx context.Context, ids []int64, status courier.CourierStatus) error {
+// UpdateStatusFromBatch mocks base method.
+func (m *MockcourierRepository) UpdateStatusFromBatch(ctx context.Context, ids []int64, from, to courier.CourierStatus) ([]int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateStatusBatch", ctx, ids, status)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "UpdateStatusFromBatch", ctx, ids, from, to)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// UpdateStatusBatch indicates an expected call of UpdateStatusBatch.
-func (mr *MockcourierRepositoryMockRecorder) UpdateStatusBatch(ctx, ids, status any) *gomock.Call {
+// UpdateStatusFromBatch indicates an expected call of UpdateStatusFromBatch.
+func (mr *MockcourierRepositoryMockRecorder) UpdateStatusFromBatch(ctx, ids, from, to any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatusBatch", reflect.TypeOf((*MockcourierRepository)(nil).UpdateStatusBatch), ctx, ids, status)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatusFromBatch", reflect.TypeOf((*MockcourierRepository)(nil).UpdateStatusFromBatch), ctx, ids, from, to)
 }
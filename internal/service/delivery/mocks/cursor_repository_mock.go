@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service-courier/internal/service/delivery (interfaces: cursorRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/cursor_repository_mock.go -package=mocks service-courier/internal/service/delivery cursorRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockcursorRepository is a mock of cursorRepository interface.
+type MockcursorRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockcursorRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockcursorRepositoryMockRecorder is the mock recorder for MockcursorRepository.
+type MockcursorRepositoryMockRecorder struct {
+	mock *MockcursorRepository
+}
+
+// NewMockcursorRepository creates a new mock instance.
+func NewMockcursorRepository(ctrl *gomock.Controller) *MockcursorRepository {
+	mock := &MockcursorRepository{ctrl: ctrl}
+	mock.recorder = &MockcursorRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcursorRepository) EXPECT() *MockcursorRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockcursorRepository) Get(ctx context.Context, name string) (*time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, name)
+	ret0, _ := ret[0].(*time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockcursorRepositoryMockRecorder) Get(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockcursorRepository)(nil).Get), ctx, name)
+}
+
+// Save mocks base method.
+func (m *MockcursorRepository) Save(ctx context.Context, name string, position time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, name, position)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockcursorRepositoryMockRecorder) Save(ctx, name, position any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockcursorRepository)(nil).Save), ctx, name, position)
+}
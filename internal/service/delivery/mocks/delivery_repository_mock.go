@@ -42,20 +42,64 @@ func (m *MockdeliveryRepository) EXPECT() *MockdeliveryRepositoryMockRecorder {
 	return m.recorder
 }
 
-// Create mocks base method.
-func (m *MockdeliveryRepository) Create(ctx context.Context, deliveryData delivery.Delivery) error {
+// CloseOffer mocks base method.
+func (m *MockdeliveryRepository) CloseOffer(ctx context.Context, id int64, status delivery.DeliveryStatus) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", ctx, deliveryData)
+	ret := m.ctrl.Call(m, "CloseOffer", ctx, id, status)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// CloseOffer indicates an expected call of CloseOffer.
+func (mr *MockdeliveryRepositoryMockRecorder) CloseOffer(ctx, id, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseOffer", reflect.TypeOf((*MockdeliveryRepository)(nil).CloseOffer), ctx, id, status)
+}
+
+// CountActiveByCourierIDs mocks base method.
+func (m *MockdeliveryRepository) CountActiveByCourierIDs(ctx context.Context, courierIDs []int64) (map[int64]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountActiveByCourierIDs", ctx, courierIDs)
+	ret0, _ := ret[0].(map[int64]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountActiveByCourierIDs indicates an expected call of CountActiveByCourierIDs.
+func (mr *MockdeliveryRepositoryMockRecorder) CountActiveByCourierIDs(ctx, courierIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountActiveByCourierIDs", reflect.TypeOf((*MockdeliveryRepository)(nil).CountActiveByCourierIDs), ctx, courierIDs)
+}
+
+// Create mocks base method.
+func (m *MockdeliveryRepository) Create(ctx context.Context, deliveryData delivery.Delivery) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, deliveryData)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // Create indicates an expected call of Create.
 func (mr *MockdeliveryRepositoryMockRecorder) Create(ctx, deliveryData any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockdeliveryRepository)(nil).Create), ctx, deliveryData)
 }
 
+// CreateEvents mocks base method.
+func (m *MockdeliveryRepository) CreateEvents(ctx context.Context, events []delivery.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEvents", ctx, events)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEvents indicates an expected call of CreateEvents.
+func (mr *MockdeliveryRepositoryMockRecorder) CreateEvents(ctx, events any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvents", reflect.TypeOf((*MockdeliveryRepository)(nil).CreateEvents), ctx, events)
+}
+
 // DeleteByOrderID mocks base method.
 func (m *MockdeliveryRepository) DeleteByOrderID(ctx context.Context, orderID string) error {
 	m.ctrl.T.Helper()
@@ -85,6 +129,21 @@ func (mr *MockdeliveryRepositoryMockRecorder) GetByOrderID(ctx, orderID any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOrderID", reflect.TypeOf((*MockdeliveryRepository)(nil).GetByOrderID), ctx, orderID)
 }
 
+// List mocks base method.
+func (m *MockdeliveryRepository) List(ctx context.Context, filter delivery.ListFilter) ([]delivery.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]delivery.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockdeliveryRepositoryMockRecorder) List(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockdeliveryRepository)(nil).List), ctx, filter)
+}
+
 // ListActiveExpired mocks base method.
 func (m *MockdeliveryRepository) ListActiveExpired(ctx context.Context, now time.Time) ([]delivery.Delivery, error) {
 	m.ctrl.T.Helper()
@@ -100,6 +159,65 @@ func (mr *MockdeliveryRepositoryMockRecorder) ListActiveExpired(ctx, now any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveExpired", reflect.TypeOf((*MockdeliveryRepository)(nil).ListActiveExpired), ctx, now)
 }
 
+// ListEventsAfter mocks base method.
+func (m *MockdeliveryRepository) ListEventsAfter(ctx context.Context, filter delivery.EventFilter) ([]delivery.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEventsAfter", ctx, filter)
+	ret0, _ := ret[0].([]delivery.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEventsAfter indicates an expected call of ListEventsAfter.
+func (mr *MockdeliveryRepositoryMockRecorder) ListEventsAfter(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEventsAfter", reflect.TypeOf((*MockdeliveryRepository)(nil).ListEventsAfter), ctx, filter)
+}
+
+// ListEventsByOrderID mocks base method.
+func (m *MockdeliveryRepository) ListEventsByOrderID(ctx context.Context, orderID string) ([]delivery.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEventsByOrderID", ctx, orderID)
+	ret0, _ := ret[0].([]delivery.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEventsByOrderID indicates an expected call of ListEventsByOrderID.
+func (mr *MockdeliveryRepositoryMockRecorder) ListEventsByOrderID(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEventsByOrderID", reflect.TypeOf((*MockdeliveryRepository)(nil).ListEventsByOrderID), ctx, orderID)
+}
+
+// Stats mocks base method.
+func (m *MockdeliveryRepository) Stats(ctx context.Context, filter delivery.StatsFilter) ([]delivery.CourierStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats", ctx, filter)
+	ret0, _ := ret[0].([]delivery.CourierStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockdeliveryRepositoryMockRecorder) Stats(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockdeliveryRepository)(nil).Stats), ctx, filter)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockdeliveryRepository) UpdateStatus(ctx context.Context, id int64, from, to delivery.DeliveryStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, id, from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockdeliveryRepositoryMockRecorder) UpdateStatus(ctx, id, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockdeliveryRepository)(nil).UpdateStatus), ctx, id, from, to)
+}
+
 // UpdateStatusByIDs mocks base method.
 func (m *MockdeliveryRepository) UpdateStatusByIDs(ctx context.Context, ids []int64, status delivery.DeliveryStatus) error {
 	m.ctrl.T.Helper()
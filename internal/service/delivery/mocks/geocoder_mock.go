@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service-courier/internal/service/delivery (interfaces: geocoder)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/geocoder_mock.go -package=mocks service-courier/internal/service/delivery geocoder
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	order "service-courier/internal/model/order"
+	geo "service-courier/internal/pkg/geo"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockgeocoder is a mock of geocoder interface.
+type Mockgeocoder struct {
+	ctrl     *gomock.Controller
+	recorder *MockgeocoderMockRecorder
+	isgomock struct{}
+}
+
+// MockgeocoderMockRecorder is the mock recorder for Mockgeocoder.
+type MockgeocoderMockRecorder struct {
+	mock *Mockgeocoder
+}
+
+// NewMockgeocoder creates a new mock instance.
+func NewMockgeocoder(ctrl *gomock.Controller) *Mockgeocoder {
+	mock := &Mockgeocoder{ctrl: ctrl}
+	mock.recorder = &MockgeocoderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockgeocoder) EXPECT() *MockgeocoderMockRecorder {
+	return m.recorder
+}
+
+// Geocode mocks base method.
+func (m *Mockgeocoder) Geocode(ctx context.Context, address order.Address) (*geo.Point, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Geocode", ctx, address)
+	ret0, _ := ret[0].(*geo.Point)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Geocode indicates an expected call of Geocode.
+func (mr *MockgeocoderMockRecorder) Geocode(ctx, address any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Geocode", reflect.TypeOf((*Mockgeocoder)(nil).Geocode), ctx, address)
+}
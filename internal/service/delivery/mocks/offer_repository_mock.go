@@ -0,0 +1,118 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service-courier/internal/service/delivery (interfaces: offerRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/offer_repository_mock.go -package=mocks service-courier/internal/service/delivery offerRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	delivery "service-courier/internal/model/delivery"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockofferRepository is a mock of offerRepository interface.
+type MockofferRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockofferRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockofferRepositoryMockRecorder is the mock recorder for MockofferRepository.
+type MockofferRepositoryMockRecorder struct {
+	mock *MockofferRepository
+}
+
+// NewMockofferRepository creates a new mock instance.
+func NewMockofferRepository(ctrl *gomock.Controller) *MockofferRepository {
+	mock := &MockofferRepository{ctrl: ctrl}
+	mock.recorder = &MockofferRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockofferRepository) EXPECT() *MockofferRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockofferRepository) Create(ctx context.Context, o delivery.Offer) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, o)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockofferRepositoryMockRecorder) Create(ctx, o any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockofferRepository)(nil).Create), ctx, o)
+}
+
+// ListExpired mocks base method.
+func (m *MockofferRepository) ListExpired(ctx context.Context, now time.Time, limit uint64) ([]delivery.Offer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpired", ctx, now, limit)
+	ret0, _ := ret[0].([]delivery.Offer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpired indicates an expected call of ListExpired.
+func (mr *MockofferRepositoryMockRecorder) ListExpired(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpired", reflect.TypeOf((*MockofferRepository)(nil).ListExpired), ctx, now, limit)
+}
+
+// RejectedCourierIDs mocks base method.
+func (m *MockofferRepository) RejectedCourierIDs(ctx context.Context, orderID string) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectedCourierIDs", ctx, orderID)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectedCourierIDs indicates an expected call of RejectedCourierIDs.
+func (mr *MockofferRepositoryMockRecorder) RejectedCourierIDs(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectedCourierIDs", reflect.TypeOf((*MockofferRepository)(nil).RejectedCourierIDs), ctx, orderID)
+}
+
+// Resolve mocks base method.
+func (m *MockofferRepository) Resolve(ctx context.Context, deliveryID int64, outcome delivery.OfferOutcome, at time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", ctx, deliveryID, outcome, at)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockofferRepositoryMockRecorder) Resolve(ctx, deliveryID, outcome, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockofferRepository)(nil).Resolve), ctx, deliveryID, outcome, at)
+}
+
+// StatsByCourierID mocks base method.
+func (m *MockofferRepository) StatsByCourierID(ctx context.Context, courierID int64) (*delivery.OfferStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StatsByCourierID", ctx, courierID)
+	ret0, _ := ret[0].(*delivery.OfferStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StatsByCourierID indicates an expected call of StatsByCourierID.
+func (mr *MockofferRepositoryMockRecorder) StatsByCourierID(ctx, courierID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StatsByCourierID", reflect.TypeOf((*MockofferRepository)(nil).StatsByCourierID), ctx, courierID)
+}
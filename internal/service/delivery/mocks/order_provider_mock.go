@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service-courier/internal/service/delivery (interfaces: orderProvider)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/order_provider_mock.go -package=mocks service-courier/internal/service/delivery orderProvider
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	order "service-courier/internal/model/order"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockorderProvider is a mock of orderProvider interface.
+type MockorderProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockorderProviderMockRecorder
+	isgomock struct{}
+}
+
+// MockorderProviderMockRecorder is the mock recorder for MockorderProvider.
+type MockorderProviderMockRecorder struct {
+	mock *MockorderProvider
+}
+
+// NewMockorderProvider creates a new mock instance.
+func NewMockorderProvider(ctrl *gomock.Controller) *MockorderProvider {
+	mock := &MockorderProvider{ctrl: ctrl}
+	mock.recorder = &MockorderProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockorderProvider) EXPECT() *MockorderProviderMockRecorder {
+	return m.recorder
+}
+
+// GetOrderByID mocks base method.
+func (m *MockorderProvider) GetOrderByID(ctx context.Context, id string) (*order.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderByID", ctx, id)
+	ret0, _ := ret[0].(*order.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderByID indicates an expected call of GetOrderByID.
+func (mr *MockorderProviderMockRecorder) GetOrderByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderByID", reflect.TypeOf((*MockorderProvider)(nil).GetOrderByID), ctx, id)
+}
@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service-courier/internal/service/delivery (interfaces: orderSource)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/order_source_mock.go -package=mocks service-courier/internal/service/delivery orderSource
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	order "service-courier/internal/model/order"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockorderSource is a mock of orderSource interface.
+type MockorderSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockorderSourceMockRecorder
+	isgomock struct{}
+}
+
+// MockorderSourceMockRecorder is the mock recorder for MockorderSource.
+type MockorderSourceMockRecorder struct {
+	mock *MockorderSource
+}
+
+// NewMockorderSource creates a new mock instance.
+func NewMockorderSource(ctrl *gomock.Controller) *MockorderSource {
+	mock := &MockorderSource{ctrl: ctrl}
+	mock.recorder = &MockorderSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockorderSource) EXPECT() *MockorderSourceMockRecorder {
+	return m.recorder
+}
+
+// GetOrders mocks base method.
+func (m *MockorderSource) GetOrders(ctx context.Context, from time.Time) ([]order.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrders", ctx, from)
+	ret0, _ := ret[0].([]order.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrders indicates an expected call of GetOrders.
+func (mr *MockorderSourceMockRecorder) GetOrders(ctx, from any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrders", reflect.TypeOf((*MockorderSource)(nil).GetOrders), ctx, from)
+}
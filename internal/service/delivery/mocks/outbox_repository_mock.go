@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service-courier/internal/service/delivery (interfaces: outboxRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/outbox_repository_mock.go -package=mocks service-courier/internal/service/delivery outboxRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	outbox "service-courier/internal/model/outbox"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockoutboxRepository is a mock of outboxRepository interface.
+type MockoutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockoutboxRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockoutboxRepositoryMockRecorder is the mock recorder for MockoutboxRepository.
+type MockoutboxRepositoryMockRecorder struct {
+	mock *MockoutboxRepository
+}
+
+// NewMockoutboxRepository creates a new mock instance.
+func NewMockoutboxRepository(ctrl *gomock.Controller) *MockoutboxRepository {
+	mock := &MockoutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockoutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockoutboxRepository) EXPECT() *MockoutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockoutboxRepository) Create(ctx context.Context, messages []outbox.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, messages)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockoutboxRepositoryMockRecorder) Create(ctx, messages any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockoutboxRepository)(nil).Create), ctx, messages)
+}
@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service-courier/internal/service/delivery (interfaces: pendingRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/pending_repository_mock.go -package=mocks service-courier/internal/service/delivery pendingRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	delivery "service-courier/internal/model/delivery"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockpendingRepository is a mock of pendingRepository interface.
+type MockpendingRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockpendingRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockpendingRepositoryMockRecorder is the mock recorder for MockpendingRepository.
+type MockpendingRepositoryMockRecorder struct {
+	mock *MockpendingRepository
+}
+
+// NewMockpendingRepository creates a new mock instance.
+func NewMockpendingRepository(ctrl *gomock.Controller) *MockpendingRepository {
+	mock := &MockpendingRepository{ctrl: ctrl}
+	mock.recorder = &MockpendingRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpendingRepository) EXPECT() *MockpendingRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteByOrderID mocks base method.
+func (m *MockpendingRepository) DeleteByOrderID(ctx context.Context, orderID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByOrderID", ctx, orderID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByOrderID indicates an expected call of DeleteByOrderID.
+func (mr *MockpendingRepositoryMockRecorder) DeleteByOrderID(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByOrderID", reflect.TypeOf((*MockpendingRepository)(nil).DeleteByOrderID), ctx, orderID)
+}
+
+// DeleteExpired mocks base method.
+func (m *MockpendingRepository) DeleteExpired(ctx context.Context, now time.Time) ([]delivery.PendingAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", ctx, now)
+	ret0, _ := ret[0].([]delivery.PendingAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockpendingRepositoryMockRecorder) DeleteExpired(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockpendingRepository)(nil).DeleteExpired), ctx, now)
+}
+
+// Enqueue mocks base method.
+func (m *MockpendingRepository) Enqueue(ctx context.Context, p delivery.PendingAssignment) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", ctx, p)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockpendingRepositoryMockRecorder) Enqueue(ctx, p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockpendingRepository)(nil).Enqueue), ctx, p)
+}
+
+// ListReady mocks base method.
+func (m *MockpendingRepository) ListReady(ctx context.Context, now time.Time, limit uint64) ([]delivery.PendingAssignment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReady", ctx, now, limit)
+	ret0, _ := ret[0].([]delivery.PendingAssignment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReady indicates an expected call of ListReady.
+func (mr *MockpendingRepositoryMockRecorder) ListReady(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReady", reflect.TypeOf((*MockpendingRepository)(nil).ListReady), ctx, now, limit)
+}
+
+// MarkAttempt mocks base method.
+func (m *MockpendingRepository) MarkAttempt(ctx context.Context, id int64, at time.Time, lastError string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAttempt", ctx, id, at, lastError)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAttempt indicates an expected call of MarkAttempt.
+func (mr *MockpendingRepositoryMockRecorder) MarkAttempt(ctx, id, at, lastError any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAttempt", reflect.TypeOf((*MockpendingRepository)(nil).MarkAttempt), ctx, id, at, lastError)
+}
@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service-courier/internal/service/delivery (interfaces: ZoneResolver)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/zone_resolver_mock.go -package=mocks service-courier/internal/service/delivery ZoneResolver
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	zone "service-courier/internal/model/zone"
+	geo "service-courier/internal/pkg/geo"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockZoneResolver is a mock of ZoneResolver interface.
+type MockZoneResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockZoneResolverMockRecorder
+	isgomock struct{}
+}
+
+// MockZoneResolverMockRecorder is the mock recorder for MockZoneResolver.
+type MockZoneResolverMockRecorder struct {
+	mock *MockZoneResolver
+}
+
+// NewMockZoneResolver creates a new mock instance.
+func NewMockZoneResolver(ctrl *gomock.Controller) *MockZoneResolver {
+	mock := &MockZoneResolver{ctrl: ctrl}
+	mock.recorder = &MockZoneResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockZoneResolver) EXPECT() *MockZoneResolverMockRecorder {
+	return m.recorder
+}
+
+// Resolve mocks base method.
+func (m *MockZoneResolver) Resolve(ctx context.Context, point geo.Point) (*zone.Zone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", ctx, point)
+	ret0, _ := ret[0].(*zone.Zone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockZoneResolverMockRecorder) Resolve(ctx, point any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockZoneResolver)(nil).Resolve), ctx, point)
+}
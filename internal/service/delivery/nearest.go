@@ -0,0 +1,126 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"service-courier/internal/metrics"
+	"service-courier/internal/model/courier"
+	"service-courier/internal/model/delivery"
+	"service-courier/internal/pkg/geo"
+	"time"
+)
+
+// LocateOrder - точка доставки заказа для AssignCourierAt; nil, если ее не удалось определить
+func (s *Service) LocateOrder(ctx context.Context, orderID string) *geo.Point {
+	return s.locateOrder(ctx, orderID)
+}
+
+func (s *Service) locateOrder(ctx context.Context, orderID string) *geo.Point {
+	if s.locator == nil {
+		return nil
+	}
+
+	point, err := s.locator.Locate(ctx, orderID)
+	if err != nil {
+		log.Printf("[AssignCourier] Failed to locate order %s, falling back to least loaded courier: %v", orderID, err)
+		return nil
+	}
+
+	return point
+}
+
+// pickCourier выбирает курьера для доставки в точку destination.
+// Если включены зоны, курьер ищется только в зоне заказа, а затем, если разрешено, в соседних зонах.
+// Приоритет заказа ограничивает допустимые виды транспорта, отказавшимся от заказа курьерам он не предлагается.
+func (s *Service) pickCourier(
+	ctx context.Context,
+	orderID string,
+	destination *geo.Point,
+	priority delivery.Priority,
+	taken []int64,
+) (*courier.Courier, error) {
+	rejected, err := s.rejectedCouriers(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := courier.AvailableFilter{
+		Capacity:   s.capacity(),
+		Transports: s.policy(priority).Transports,
+		ExcludeIDs: append(rejected, taken...),
+	}
+	order := AssignmentOrder{
+		OrderID:     orderID,
+		Destination: destination,
+		Priority:    priority,
+	}
+
+	if s.zones == nil {
+		return s.pickFrom(ctx, order, filter)
+	}
+
+	zoneData, err := s.resolveZone(ctx, destination)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.ZoneIDs = []int64{zoneData.ID}
+	picked, err := s.pickFrom(ctx, order, filter)
+	if !errors.Is(err, courier.ErrNoAvailableCouriers) || !s.zoneFallback || len(zoneData.Neighbors) == 0 {
+		return picked, err
+	}
+
+	filter.ZoneIDs = zoneData.Neighbors
+	return s.pickFrom(ctx, order, filter)
+}
+
+// pickFrom выбирает курьера со свободным местом стратегией назначения
+func (s *Service) pickFrom(ctx context.Context, order AssignmentOrder, filter courier.AvailableFilter) (*courier.Courier, error) {
+	candidates, err := s.courierRepo.ListAvailableWithDeliveries(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list available couriers: %w", err)
+	}
+
+	if len(candidates) == 0 {
+		return nil, courier.ErrNoAvailableCouriers
+	}
+
+	order.Distances = s.distances(candidates, order.Destination)
+
+	picked := s.strategy.Pick(candidates, order)
+	if picked == nil {
+		return nil, courier.ErrNoAvailableCouriers
+	}
+
+	metrics.CourierAssignmentsTotal.WithLabelValues(strategyName(s.strategy, order.OrderID)).Inc()
+	return picked, nil
+}
+
+// distances считает расстояние до точки доставки для кандидатов с актуальной позицией
+func (s *Service) distances(candidates []courier.Candidate, destination *geo.Point) map[int64]float64 {
+	distances := make(map[int64]float64, len(candidates))
+	if destination == nil {
+		return distances
+	}
+
+	now := s.clock.Now()
+	for i := range candidates {
+		location := s.freshLocation(&candidates[i].Courier, now)
+		if location == nil {
+			continue
+		}
+		distances[candidates[i].Courier.ID] = s.distance.Distance(*location, *destination)
+	}
+
+	return distances
+}
+
+// freshLocation возвращает позицию курьера, если она не старше locationMaxAge
+func (s *Service) freshLocation(c *courier.Courier, now time.Time) *geo.Point {
+	if c.Location == nil || now.Sub(c.Location.UpdatedAt) > s.locationMaxAge {
+		return nil
+	}
+	return &c.Location.Point
+}
@@ -0,0 +1,322 @@
+package delivery_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	modelCourier "service-courier/internal/model/courier"
+	modelDelivery "service-courier/internal/model/delivery"
+	"service-courier/internal/model/order"
+	"service-courier/internal/pkg/geo"
+	deliveryService "service-courier/internal/service/delivery"
+	"service-courier/internal/service/delivery/mocks"
+)
+
+func TestAssignCourier_PicksNearestCourier(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDeliveryRepo := mocks.NewMockdeliveryRepository(ctrl)
+	mockCourierRepo := mocks.NewMockcourierRepository(ctrl)
+	mockTxManager := mocks.NewMocktransactionManager(ctrl)
+	mockOrders := mocks.NewMockorderProvider(ctrl)
+	mockGeocoder := mocks.NewMockgeocoder(ctrl)
+
+	fixed := time.Date(2024, 1, 1, 12, 00, 00, 0, time.UTC)
+	clock := deliveryService.NewFixedClock(fixed)
+
+	service := deliveryService.NewDeliveryService(
+		mockDeliveryRepo,
+		mockCourierRepo,
+		deliveryService.NewTransportFactory(),
+		mockTxManager,
+		clock,
+		deliveryService.WithLocator(deliveryService.NewOrderLocator(mockOrders, mockGeocoder)),
+	)
+
+	orderID := "f819526d-6a7c-48eb-b535-43989469d1ca"
+	address := order.Address{Street: "Тверская", House: "1"}
+	destination := geo.Point{Lat: 55.7580, Lon: 37.6130}
+
+	mockOrders.EXPECT().
+		GetOrderByID(gomock.Any(), orderID).
+		Return(&order.Order{ID: orderID, Address: address}, nil)
+
+	mockGeocoder.EXPECT().
+		Geocode(gomock.Any(), address).
+		Return(&destination, nil)
+
+	mockTxManager.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+
+	mockDeliveryRepo.EXPECT().
+		GetByOrderID(gomock.Any(), orderID).
+		Return(nil, modelDelivery.ErrDeliveryNotFound)
+
+	mockCourierRepo.EXPECT().
+		ListAvailableWithDeliveries(gomock.Any(), gomock.Any()).
+		Return([]modelCourier.Candidate{
+			{
+				// наименее загруженный, но далеко
+				Courier: modelCourier.Courier{
+					ID:            1,
+					TransportType: modelCourier.TransportCar,
+					Location: &modelCourier.Location{
+						Point:     geo.Point{Lat: 55.6000, Lon: 37.5000},
+						UpdatedAt: fixed.Add(-time.Minute),
+					},
+				},
+				Deliveries: 0,
+			},
+			{
+				Courier: modelCourier.Courier{
+					ID:            2,
+					TransportType: modelCourier.TransportOnFoot,
+					Location: &modelCourier.Location{
+						Point:     geo.Point{Lat: 55.7585, Lon: 37.6140},
+						UpdatedAt: fixed.Add(-time.Minute),
+					},
+				},
+				Deliveries: 5,
+			},
+		}, nil)
+
+	expectReserved(mockCourierRepo, mockDeliveryRepo, modelCourier.Courier{Status: modelCourier.StatusAvailable, ID: 2, TransportType: modelCourier.TransportOnFoot})
+
+	mockDeliveryRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		Return(int64(1), nil)
+
+	mockDeliveryRepo.EXPECT().
+		CreateEvents(gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	mockCourierRepo.EXPECT().
+		UpdateStatusFrom(gomock.Any(), int64(2), modelCourier.CourierStatus(modelCourier.StatusAvailable), modelCourier.CourierStatus(modelCourier.StatusBusy)).
+		Return(true, nil)
+
+	result, err := service.AssignCourier(context.Background(), orderID, modelDelivery.PriorityStandard)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result.CourierID != 2 {
+		t.Fatalf("expected nearest CourierID=2, got %d", result.CourierID)
+	}
+	// ~120м пешком плюс буфер передачи - быстрее фиксированных 30 минут
+	if !result.Deadline.Before(fixed.Add(30 * time.Minute)) {
+		t.Fatalf("expected distance based deadline, got %s", result.Deadline)
+	}
+}
+
+func TestAssignCourier_StaleLocationsFallbackToLeastLoaded(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDeliveryRepo := mocks.NewMockdeliveryRepository(ctrl)
+	mockCourierRepo := mocks.NewMockcourierRepository(ctrl)
+	mockTxManager := mocks.NewMocktransactionManager(ctrl)
+	mockOrders := mocks.NewMockorderProvider(ctrl)
+	mockGeocoder := mocks.NewMockgeocoder(ctrl)
+
+	fixed := time.Date(2024, 1, 1, 12, 00, 00, 0, time.UTC)
+	clock := deliveryService.NewFixedClock(fixed)
+
+	service := deliveryService.NewDeliveryService(
+		mockDeliveryRepo,
+		mockCourierRepo,
+		deliveryService.NewTransportFactory(),
+		mockTxManager,
+		clock,
+		deliveryService.WithLocator(deliveryService.NewOrderLocator(mockOrders, mockGeocoder)),
+		deliveryService.WithLocationMaxAge(5*time.Minute),
+	)
+
+	orderID := "f819526d-6a7c-48eb-b535-43989469d1ca"
+	destination := geo.Point{Lat: 55.7580, Lon: 37.6130}
+
+	mockOrders.EXPECT().
+		GetOrderByID(gomock.Any(), orderID).
+		Return(&order.Order{ID: orderID}, nil)
+
+	mockGeocoder.EXPECT().
+		Geocode(gomock.Any(), gomock.Any()).
+		Return(&destination, nil)
+
+	mockTxManager.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+
+	mockDeliveryRepo.EXPECT().
+		GetByOrderID(gomock.Any(), orderID).
+		Return(nil, modelDelivery.ErrDeliveryNotFound)
+
+	mockCourierRepo.EXPECT().
+		ListAvailableWithDeliveries(gomock.Any(), gomock.Any()).
+		Return([]modelCourier.Candidate{
+			{Courier: modelCourier.Courier{ID: 1, TransportType: modelCourier.TransportCar}, Deliveries: 0},
+			{
+				Courier: modelCourier.Courier{
+					ID:            2,
+					TransportType: modelCourier.TransportCar,
+					Location: &modelCourier.Location{
+						Point:     destination,
+						UpdatedAt: fixed.Add(-time.Hour),
+					},
+				},
+				Deliveries: 3,
+			},
+		}, nil)
+
+	expectReserved(mockCourierRepo, mockDeliveryRepo, modelCourier.Courier{Status: modelCourier.StatusAvailable, ID: 1, TransportType: modelCourier.TransportCar})
+
+	mockDeliveryRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		Return(int64(1), nil)
+
+	mockDeliveryRepo.EXPECT().
+		CreateEvents(gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	mockCourierRepo.EXPECT().
+		UpdateStatusFrom(gomock.Any(), int64(1), modelCourier.CourierStatus(modelCourier.StatusAvailable), modelCourier.CourierStatus(modelCourier.StatusBusy)).
+		Return(true, nil)
+
+	result, err := service.AssignCourier(context.Background(), orderID, modelDelivery.PriorityStandard)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result.CourierID != 1 {
+		t.Fatalf("expected least loaded CourierID=1, got %d", result.CourierID)
+	}
+}
+
+func TestAssignCourier_LocateErrorFallbackToMinDeliveries(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDeliveryRepo := mocks.NewMockdeliveryRepository(ctrl)
+	mockCourierRepo := mocks.NewMockcourierRepository(ctrl)
+	mockTxManager := mocks.NewMocktransactionManager(ctrl)
+	mockOrders := mocks.NewMockorderProvider(ctrl)
+	mockGeocoder := mocks.NewMockgeocoder(ctrl)
+
+	fixed := time.Date(2024, 1, 1, 12, 00, 00, 0, time.UTC)
+	clock := deliveryService.NewFixedClock(fixed)
+
+	service := deliveryService.NewDeliveryService(
+		mockDeliveryRepo,
+		mockCourierRepo,
+		deliveryService.NewTransportFactory(),
+		mockTxManager,
+		clock,
+		deliveryService.WithLocator(deliveryService.NewOrderLocator(mockOrders, mockGeocoder)),
+	)
+
+	orderID := "f819526d-6a7c-48eb-b535-43989469d1ca"
+
+	mockOrders.EXPECT().
+		GetOrderByID(gomock.Any(), orderID).
+		Return(nil, errors.New("order service unavailable"))
+
+	mockTxManager.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+
+	mockDeliveryRepo.EXPECT().
+		GetByOrderID(gomock.Any(), orderID).
+		Return(nil, modelDelivery.ErrDeliveryNotFound)
+
+	mockCourierRepo.EXPECT().
+		ListAvailableWithDeliveries(gomock.Any(), gomock.Any()).
+		Return([]modelCourier.Candidate{{Courier: modelCourier.Courier{ID: 7, TransportType: modelCourier.TransportScooter}}}, nil)
+
+	expectReserved(mockCourierRepo, mockDeliveryRepo, modelCourier.Courier{Status: modelCourier.StatusAvailable, ID: 7, TransportType: modelCourier.TransportScooter})
+
+	mockDeliveryRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		Return(int64(1), nil)
+
+	mockDeliveryRepo.EXPECT().
+		CreateEvents(gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	mockCourierRepo.EXPECT().
+		UpdateStatusFrom(gomock.Any(), int64(7), modelCourier.CourierStatus(modelCourier.StatusAvailable), modelCourier.CourierStatus(modelCourier.StatusBusy)).
+		Return(true, nil)
+
+	result, err := service.AssignCourier(context.Background(), orderID, modelDelivery.PriorityStandard)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result.CourierID != 7 {
+		t.Fatalf("expected CourierID=7, got %d", result.CourierID)
+	}
+}
+
+func TestAssignCourier_NearestNoAvailableCouriers(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDeliveryRepo := mocks.NewMockdeliveryRepository(ctrl)
+	mockCourierRepo := mocks.NewMockcourierRepository(ctrl)
+	mockTxManager := mocks.NewMocktransactionManager(ctrl)
+	mockOrders := mocks.NewMockorderProvider(ctrl)
+	mockGeocoder := mocks.NewMockgeocoder(ctrl)
+
+	fixed := time.Date(2024, 1, 1, 12, 00, 00, 0, time.UTC)
+	clock := deliveryService.NewFixedClock(fixed)
+
+	service := deliveryService.NewDeliveryService(
+		mockDeliveryRepo,
+		mockCourierRepo,
+		deliveryService.NewTransportFactory(),
+		mockTxManager,
+		clock,
+		deliveryService.WithLocator(deliveryService.NewOrderLocator(mockOrders, mockGeocoder)),
+	)
+
+	orderID := "f819526d-6a7c-48eb-b535-43989469d1ca"
+	destination := geo.Point{Lat: 55.7580, Lon: 37.6130}
+
+	mockOrders.EXPECT().
+		GetOrderByID(gomock.Any(), orderID).
+		Return(&order.Order{ID: orderID}, nil)
+
+	mockGeocoder.EXPECT().
+		Geocode(gomock.Any(), gomock.Any()).
+		Return(&destination, nil)
+
+	mockTxManager.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+
+	mockDeliveryRepo.EXPECT().
+		GetByOrderID(gomock.Any(), orderID).
+		Return(nil, modelDelivery.ErrDeliveryNotFound)
+
+	mockCourierRepo.EXPECT().
+		ListAvailableWithDeliveries(gomock.Any(), gomock.Any()).
+		Return([]modelCourier.Candidate{}, nil)
+
+	_, err := service.AssignCourier(context.Background(), orderID, modelDelivery.PriorityStandard)
+	if !errors.Is(err, modelCourier.ErrNoAvailableCouriers) {
+		t.Fatalf("expected ErrNoAvailableCouriers, got %v", err)
+	}
+}
@@ -0,0 +1,270 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"service-courier/internal/metrics"
+	"service-courier/internal/model/courier"
+	"service-courier/internal/model/delivery"
+	"service-courier/internal/pkg/geo"
+	"time"
+)
+
+const defaultOfferTimeout = time.Minute
+
+// WithOffers включает предложение заказа курьеру: доставка создается в статусе offered,
+// и курьер становится занят только после того, как примет ее. Если курьер отказался
+// или не ответил за timeout, заказ предлагается следующему курьеру без отказавшихся.
+func WithOffers(repo offerRepository, timeout time.Duration) Option {
+	return func(s *Service) {
+		s.offers = repo
+		s.offerTimeout = timeout
+	}
+}
+
+// LoadOfferTimeout читает DELIVERY_OFFER_TIMEOUT_SECONDS - сколько курьер думает над предложением
+func LoadOfferTimeout() time.Duration {
+	seconds := envInt("DELIVERY_OFFER_TIMEOUT_SECONDS", int(defaultOfferTimeout.Seconds()))
+	return time.Duration(seconds) * time.Second
+}
+
+// rejectedCouriers - курьеры, которым заказ уже предлагался и которые от него отказались
+func (s *Service) rejectedCouriers(ctx context.Context, orderID string) ([]int64, error) {
+	if s.offers == nil {
+		return nil, nil
+	}
+
+	ids, err := s.offers.RejectedCourierIDs(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("list rejected couriers: %w", err)
+	}
+	return ids, nil
+}
+
+// offer предлагает созданную доставку курьеру. Вызывается внутри транзакции.
+func (s *Service) offer(ctx context.Context, d delivery.Delivery) (time.Time, error) {
+	expiresAt := d.AssignedAt.Add(s.offerTimeout)
+
+	_, err := s.offers.Create(ctx, delivery.Offer{
+		DeliveryID: d.ID,
+		OrderID:    d.OrderID,
+		CourierID:  d.CourierID,
+		OfferedAt:  d.AssignedAt,
+		ExpiresAt:  expiresAt,
+	})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("create offer: %w", err)
+	}
+
+	metrics.DeliveryOffersTotal.WithLabelValues("offered").Inc()
+	return expiresAt, nil
+}
+
+// acceptOffer закрывает предложение принятием и занимает курьера так же, как назначение.
+// Если курьера за время предложения вывели из работы или заполнили его машину,
+// возвращает delivery.ErrCourierUnavailable. Вызывается внутри транзакции.
+func (s *Service) acceptOffer(ctx context.Context, d delivery.Delivery) error {
+	accepted, err := s.offers.Resolve(ctx, d.ID, delivery.OfferAccepted, s.clock.Now())
+	if err != nil {
+		return fmt.Errorf("resolve offer: %w", err)
+	}
+	if !accepted {
+		return delivery.ErrOfferExpired
+	}
+
+	// предложенная доставка уже учтена среди активных доставок курьера
+	locked, err := s.reserveCourier(ctx, d.CourierID, 1)
+	if err == nil {
+		err = s.occupyCourier(ctx, locked)
+	}
+	if errors.Is(err, courier.ErrCourierTaken) {
+		return delivery.ErrCourierUnavailable
+	}
+	return err
+}
+
+// withdrawOffer закрывает предложение, если доставку сняли или завершили раньше ответа курьера
+func (s *Service) withdrawOffer(ctx context.Context, d delivery.Delivery) error {
+	if s.offers == nil || d.Status != delivery.StatusOffered {
+		return nil
+	}
+
+	if _, err := s.offers.Resolve(ctx, d.ID, delivery.OfferWithdrawn, s.clock.Now()); err != nil {
+		return fmt.Errorf("resolve offer: %w", err)
+	}
+	return nil
+}
+
+// DeclineOffer - курьер отказывается от предложенного заказа. Заказ сразу предлагается следующему курьеру,
+// а если подходящих нет - ставится в очередь ожидания, когда она включена.
+func (s *Service) DeclineOffer(ctx context.Context, orderID string) (*DeclineResult, error) {
+	var result *DeclineResult
+
+	destination := s.locateOrder(ctx, orderID)
+
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		deliveryData, err := s.deliveryRepo.GetByOrderID(ctx, orderID)
+		if err != nil {
+			if errors.Is(err, delivery.ErrDeliveryNotFound) {
+				return delivery.ErrDeliveryNotFound
+			}
+			return fmt.Errorf("get delivery: %w", err)
+		}
+
+		if err := delivery.ValidateTransition(deliveryData.Status, delivery.StatusDeclined); err != nil {
+			return err
+		}
+
+		result, err = s.rejectOffer(ctx, *deliveryData, delivery.OfferDeclined, destination)
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("decline offer transaction: %w", err)
+	}
+
+	// у отказавшегося курьера освободилось место, а заказ мог попасть в очередь
+	s.notifyFreed(ctx)
+	metrics.DeliveryOffersTotal.WithLabelValues(string(delivery.OfferDeclined)).Inc()
+	metrics.OpsCounter.Inc()
+
+	return result, nil
+}
+
+// ExpireOffers закрывает до limit предложений, на которые курьеры не ответили вовремя,
+// и предлагает эти заказы следующим курьерам
+func (s *Service) ExpireOffers(ctx context.Context, limit uint64) error {
+	if s.offers == nil {
+		return nil
+	}
+
+	expired, err := s.offers.ListExpired(ctx, s.clock.Now(), limit)
+	if err != nil {
+		return fmt.Errorf("list expired offers: %w", err)
+	}
+
+	for _, o := range expired {
+		result, err := s.expireOffer(ctx, o)
+		if err != nil {
+			log.Printf("[ExpireOffers] Failed to expire offer for order %s: %v", o.OrderID, err)
+			continue
+		}
+		if result == nil {
+			continue
+		}
+
+		s.notifyFreed(ctx)
+		metrics.DeliveryOffersTotal.WithLabelValues(string(delivery.OfferExpired)).Inc()
+	}
+
+	return nil
+}
+
+// expireOffer возвращает nil без ошибки, если доставку уже сняли или закрыли - предложение просто закрывается
+func (s *Service) expireOffer(ctx context.Context, o delivery.Offer) (*DeclineResult, error) {
+	var result *DeclineResult
+
+	destination := s.locateOrder(ctx, o.OrderID)
+
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		deliveryData, err := s.deliveryRepo.GetByOrderID(ctx, o.OrderID)
+		if err != nil && !errors.Is(err, delivery.ErrDeliveryNotFound) {
+			return fmt.Errorf("get delivery: %w", err)
+		}
+
+		if deliveryData == nil || deliveryData.ID != o.DeliveryID || deliveryData.Status != delivery.StatusOffered {
+			if _, err := s.offers.Resolve(ctx, o.DeliveryID, delivery.OfferWithdrawn, s.clock.Now()); err != nil {
+				return fmt.Errorf("resolve offer: %w", err)
+			}
+			return nil
+		}
+
+		result, err = s.rejectOffer(ctx, *deliveryData, delivery.OfferExpired, destination)
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("expire offer transaction: %w", err)
+	}
+	return result, nil
+}
+
+// rejectOffer закрывает предложение отказом и предлагает заказ дальше. Вызывается внутри транзакции.
+func (s *Service) rejectOffer(
+	ctx context.Context,
+	d delivery.Delivery,
+	outcome delivery.OfferOutcome,
+	destination *geo.Point,
+) (*DeclineResult, error) {
+	if _, err := s.offers.Resolve(ctx, d.ID, outcome, s.clock.Now()); err != nil {
+		return nil, fmt.Errorf("resolve offer: %w", err)
+	}
+
+	if err := s.deliveryRepo.CloseOffer(ctx, d.ID, delivery.StatusDeclined); err != nil {
+		return nil, fmt.Errorf("close offer: %w", err)
+	}
+
+	reason := "courier declined offer"
+	if outcome == delivery.OfferExpired {
+		reason = "offer expired"
+	}
+	if err := s.recordEvents(ctx, s.newEvent(ctx, d, delivery.StatusDeclined, reason)); err != nil {
+		return nil, err
+	}
+
+	// у курьера могли быть другие доставки - без этого он остался бы busy
+	if _, err := s.releaseIdleCouriers(ctx, d.CourierID); err != nil {
+		return nil, err
+	}
+
+	result := &DeclineResult{
+		OrderID:   d.OrderID,
+		CourierID: d.CourierID,
+	}
+
+	if s.batchEnabled() {
+		result.Queued = true
+		_, err := s.enqueuePending(ctx, d.OrderID, destination, d.Priority)
+		return result, err
+	}
+
+	next, err := s.assign(ctx, d.OrderID, destination, d.Priority)
+	if !errors.Is(err, courier.ErrNoAvailableCouriers) {
+		result.Next = next
+		return result, err
+	}
+
+	if s.pending == nil {
+		log.Printf("[Offers] No couriers left to offer order %s", d.OrderID)
+		return result, nil
+	}
+
+	result.Queued = true
+	if _, err := s.enqueuePending(ctx, d.OrderID, destination, d.Priority); err != nil {
+		return nil, err
+	}
+	log.Printf("[Offers] No couriers left to offer order %s, order is queued for assignment", d.OrderID)
+	return result, nil
+}
+
+// GetCourierOfferStats возвращает ответы курьера на предложения заказов
+func (s *Service) GetCourierOfferStats(ctx context.Context, courierID int64) (*delivery.OfferStats, error) {
+	if _, err := s.courierRepo.GetByID(ctx, courierID); err != nil {
+		if errors.Is(err, courier.ErrCourierNotFound) {
+			return nil, courier.ErrCourierNotFound
+		}
+		return nil, fmt.Errorf("get courier: %w", err)
+	}
+
+	if s.offers == nil {
+		return &delivery.OfferStats{CourierID: courierID}, nil
+	}
+
+	stats, err := s.offers.StatsByCourierID(ctx, courierID)
+	if err != nil {
+		return nil, fmt.Errorf("get offer stats: %w", err)
+	}
+	return stats, nil
+}
@@ -0,0 +1,258 @@
+package delivery_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	modelCourier "service-courier/internal/model/courier"
+	modelDelivery "service-courier/internal/model/delivery"
+	deliveryService "service-courier/internal/service/delivery"
+	"service-courier/internal/service/delivery/mocks"
+)
+
+const offerOrderID = "f819526d-6a7c-48eb-b535-43989469d1ca"
+
+type offerMocks struct {
+	deliveryRepo *mocks.MockdeliveryRepository
+	courierRepo  *mocks.MockcourierRepository
+	offers       *mocks.MockofferRepository
+}
+
+func newOfferService(t *testing.T) (*deliveryService.Service, offerMocks) {
+	ctrl := gomock.NewController(t)
+
+	m := offerMocks{
+		deliveryRepo: mocks.NewMockdeliveryRepository(ctrl),
+		courierRepo:  mocks.NewMockcourierRepository(ctrl),
+		offers:       mocks.NewMockofferRepository(ctrl),
+	}
+	txManager := mocks.NewMocktransactionManager(ctrl)
+	txManager.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		}).
+		AnyTimes()
+
+	service := deliveryService.NewDeliveryService(
+		m.deliveryRepo,
+		m.courierRepo,
+		deliveryService.NewTransportFactory(),
+		txManager,
+		deliveryService.NewFixedClock(pendingNow),
+		deliveryService.WithOffers(m.offers, 30*time.Second),
+	)
+	return service, m
+}
+
+func offeredDelivery(courierID int64) *modelDelivery.Delivery {
+	return &modelDelivery.Delivery{
+		ID:         7,
+		CourierID:  courierID,
+		OrderID:    offerOrderID,
+		Status:     modelDelivery.StatusOffered,
+		Priority:   modelDelivery.PriorityStandard,
+		AssignedAt: pendingNow.Add(-time.Minute),
+		Deadline:   pendingNow.Add(time.Hour),
+	}
+}
+
+func TestAssignCourier_OffersOrderWithoutTakingCourier(t *testing.T) {
+	t.Parallel()
+	service, m := newOfferService(t)
+
+	m.deliveryRepo.EXPECT().GetByOrderID(gomock.Any(), offerOrderID).Return(nil, modelDelivery.ErrDeliveryNotFound)
+	m.offers.EXPECT().RejectedCourierIDs(gomock.Any(), offerOrderID).Return(nil, nil)
+	m.courierRepo.EXPECT().
+		ListAvailableWithDeliveries(gomock.Any(), gomock.Any()).
+		Return([]modelCourier.Candidate{candidate(1, modelCourier.TransportCar, 0, 0)}, nil)
+	expectReserved(m.courierRepo, m.deliveryRepo, candidate(1, modelCourier.TransportCar, 0, 0).Courier)
+	m.deliveryRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, d modelDelivery.Delivery) (int64, error) {
+			assert.Equal(t, modelDelivery.DeliveryStatus(modelDelivery.StatusOffered), d.Status)
+			return 7, nil
+		})
+	m.deliveryRepo.EXPECT().
+		CreateEvents(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, events []modelDelivery.Event) error {
+			require.Len(t, events, 1)
+			assert.Empty(t, events[0].FromStatus)
+			assert.Equal(t, modelDelivery.DeliveryStatus(modelDelivery.StatusOffered), events[0].ToStatus)
+			return nil
+		})
+	m.offers.EXPECT().Create(gomock.Any(), modelDelivery.Offer{
+		DeliveryID: 7,
+		OrderID:    offerOrderID,
+		CourierID:  1,
+		OfferedAt:  pendingNow,
+		ExpiresAt:  pendingNow.Add(30 * time.Second),
+	}).Return(int64(1), nil)
+
+	result, err := service.AssignCourier(context.Background(), offerOrderID, modelDelivery.PriorityStandard)
+	require.NoError(t, err)
+	assert.Equal(t, modelDelivery.DeliveryStatus(modelDelivery.StatusOffered), result.Status)
+	require.NotNil(t, result.OfferExpiresAt)
+	assert.Equal(t, pendingNow.Add(30*time.Second), *result.OfferExpiresAt)
+}
+
+func TestTransitionDelivery_AcceptOfferTakesCourier(t *testing.T) {
+	t.Parallel()
+	service, m := newOfferService(t)
+
+	m.deliveryRepo.EXPECT().GetByOrderID(gomock.Any(), offerOrderID).Return(offeredDelivery(1), nil)
+	m.offers.EXPECT().Resolve(gomock.Any(), int64(7), modelDelivery.OfferAccepted, pendingNow).Return(true, nil)
+	// пешему курьеру хватает места: единственная активная доставка - сам предложенный заказ
+	m.courierRepo.EXPECT().LockAssignable(gomock.Any(), int64(1)).Return(&modelCourier.Courier{
+		ID:            1,
+		Status:        modelCourier.StatusAvailable,
+		TransportType: modelCourier.TransportOnFoot,
+	}, nil)
+	m.deliveryRepo.EXPECT().CountActiveByCourierIDs(gomock.Any(), []int64{1}).Return(map[int64]int64{1: 1}, nil)
+	m.courierRepo.EXPECT().UpdateStatusFrom(gomock.Any(), int64(1), modelCourier.CourierStatus(modelCourier.StatusAvailable), modelCourier.CourierStatus(modelCourier.StatusBusy)).Return(true, nil)
+	m.deliveryRepo.EXPECT().UpdateStatus(gomock.Any(), int64(7), modelDelivery.DeliveryStatus(modelDelivery.StatusOffered), modelDelivery.DeliveryStatus(modelDelivery.StatusAccepted)).Return(nil)
+	m.deliveryRepo.EXPECT().CreateEvents(gomock.Any(), gomock.Any()).Return(nil)
+
+	result, err := service.TransitionDelivery(context.Background(), offerOrderID, modelDelivery.StatusAccepted)
+	require.NoError(t, err)
+	assert.Equal(t, modelDelivery.DeliveryStatus(modelDelivery.StatusOffered), result.From)
+}
+
+func TestTransitionDelivery_AcceptExpiredOffer(t *testing.T) {
+	t.Parallel()
+	service, m := newOfferService(t)
+
+	m.deliveryRepo.EXPECT().GetByOrderID(gomock.Any(), offerOrderID).Return(offeredDelivery(1), nil)
+	m.offers.EXPECT().Resolve(gomock.Any(), int64(7), modelDelivery.OfferAccepted, pendingNow).Return(false, nil)
+
+	_, err := service.TransitionDelivery(context.Background(), offerOrderID, modelDelivery.StatusAccepted)
+	assert.ErrorIs(t, err, modelDelivery.ErrOfferExpired)
+}
+
+func TestTransitionDelivery_AcceptOfferCourierUnavailable(t *testing.T) {
+	t.Parallel()
+	service, m := newOfferService(t)
+
+	m.deliveryRepo.EXPECT().GetByOrderID(gomock.Any(), offerOrderID).Return(offeredDelivery(1), nil)
+	m.offers.EXPECT().Resolve(gomock.Any(), int64(7), modelDelivery.OfferAccepted, pendingNow).Return(true, nil)
+	// пока курьер думал, его поставили на паузу
+	m.courierRepo.EXPECT().LockAssignable(gomock.Any(), int64(1)).Return(nil, modelCourier.ErrCourierNotFound)
+
+	_, err := service.TransitionDelivery(context.Background(), offerOrderID, modelDelivery.StatusAccepted)
+	assert.ErrorIs(t, err, modelDelivery.ErrCourierUnavailable)
+}
+
+func TestTransitionDelivery_AcceptOfferCourierFull(t *testing.T) {
+	t.Parallel()
+	service, m := newOfferService(t)
+
+	m.deliveryRepo.EXPECT().GetByOrderID(gomock.Any(), offerOrderID).Return(offeredDelivery(1), nil)
+	m.offers.EXPECT().Resolve(gomock.Any(), int64(7), modelDelivery.OfferAccepted, pendingNow).Return(true, nil)
+	// пока курьер думал, ему назначили другой заказ и место кончилось
+	m.courierRepo.EXPECT().LockAssignable(gomock.Any(), int64(1)).Return(&modelCourier.Courier{
+		ID:            1,
+		Status:        modelCourier.StatusBusy,
+		TransportType: modelCourier.TransportOnFoot,
+	}, nil)
+	m.deliveryRepo.EXPECT().CountActiveByCourierIDs(gomock.Any(), []int64{1}).Return(map[int64]int64{1: 2}, nil)
+
+	_, err := service.TransitionDelivery(context.Background(), offerOrderID, modelDelivery.StatusAccepted)
+	assert.ErrorIs(t, err, modelDelivery.ErrCourierUnavailable)
+}
+
+func TestDeclineOffer_OffersNextCourierExcludingRejected(t *testing.T) {
+	t.Parallel()
+	service, m := newOfferService(t)
+
+	m.deliveryRepo.EXPECT().GetByOrderID(gomock.Any(), offerOrderID).Return(offeredDelivery(1), nil)
+	m.offers.EXPECT().Resolve(gomock.Any(), int64(7), modelDelivery.OfferDeclined, pendingNow).Return(true, nil)
+	m.deliveryRepo.EXPECT().CloseOffer(gomock.Any(), int64(7), modelDelivery.DeliveryStatus(modelDelivery.StatusDeclined)).Return(nil)
+	m.deliveryRepo.EXPECT().CreateEvents(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	m.deliveryRepo.EXPECT().CountActiveByCourierIDs(gomock.Any(), []int64{1}).Return(map[int64]int64{}, nil)
+	m.courierRepo.EXPECT().UpdateStatusFromBatch(gomock.Any(), []int64{1}, modelCourier.CourierStatus(modelCourier.StatusBusy), modelCourier.CourierStatus(modelCourier.StatusAvailable)).Return([]int64{1}, nil)
+
+	// заказ предлагается следующему курьеру, отказавшийся исключен из выборки
+	m.deliveryRepo.EXPECT().GetByOrderID(gomock.Any(), offerOrderID).Return(nil, modelDelivery.ErrDeliveryNotFound)
+	m.offers.EXPECT().RejectedCourierIDs(gomock.Any(), offerOrderID).Return([]int64{1}, nil)
+	m.courierRepo.EXPECT().
+		ListAvailableWithDeliveries(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, filter modelCourier.AvailableFilter) ([]modelCourier.Candidate, error) {
+			assert.Equal(t, []int64{1}, filter.ExcludeIDs)
+			return []modelCourier.Candidate{candidate(2, modelCourier.TransportScooter, 0, 0)}, nil
+		})
+	expectReserved(m.courierRepo, m.deliveryRepo, candidate(2, modelCourier.TransportScooter, 0, 0).Courier)
+	m.deliveryRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(int64(8), nil)
+	m.offers.EXPECT().Create(gomock.Any(), gomock.Any()).Return(int64(2), nil)
+
+	result, err := service.DeclineOffer(context.Background(), offerOrderID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.CourierID)
+	require.NotNil(t, result.Next)
+	assert.Equal(t, int64(2), result.Next.CourierID)
+	assert.False(t, result.Queued)
+}
+
+func TestDeclineOffer_NotOffered(t *testing.T) {
+	t.Parallel()
+	service, m := newOfferService(t)
+
+	accepted := offeredDelivery(1)
+	accepted.Status = modelDelivery.StatusAccepted
+	m.deliveryRepo.EXPECT().GetByOrderID(gomock.Any(), offerOrderID).Return(accepted, nil)
+
+	_, err := service.DeclineOffer(context.Background(), offerOrderID)
+	assert.ErrorIs(t, err, modelDelivery.ErrInvalidTransition)
+}
+
+func TestExpireOffers(t *testing.T) {
+	t.Parallel()
+	service, m := newOfferService(t)
+
+	m.offers.EXPECT().ListExpired(gomock.Any(), pendingNow, uint64(10)).Return([]modelDelivery.Offer{
+		{DeliveryID: 7, OrderID: offerOrderID, CourierID: 1},
+		{DeliveryID: 3, OrderID: "order-unassigned", CourierID: 2},
+	}, nil)
+
+	// курьер не ответил - других курьеров нет, заказ остается без курьера
+	m.deliveryRepo.EXPECT().GetByOrderID(gomock.Any(), offerOrderID).Return(offeredDelivery(1), nil)
+	m.offers.EXPECT().Resolve(gomock.Any(), int64(7), modelDelivery.OfferExpired, pendingNow).Return(true, nil)
+	m.deliveryRepo.EXPECT().CloseOffer(gomock.Any(), int64(7), modelDelivery.DeliveryStatus(modelDelivery.StatusDeclined)).Return(nil)
+	m.deliveryRepo.EXPECT().CreateEvents(gomock.Any(), gomock.Any()).Return(nil)
+	m.deliveryRepo.EXPECT().CountActiveByCourierIDs(gomock.Any(), []int64{1}).Return(map[int64]int64{}, nil)
+	m.courierRepo.EXPECT().UpdateStatusFromBatch(gomock.Any(), []int64{1}, modelCourier.CourierStatus(modelCourier.StatusBusy), modelCourier.CourierStatus(modelCourier.StatusAvailable)).Return([]int64{1}, nil)
+	m.deliveryRepo.EXPECT().GetByOrderID(gomock.Any(), offerOrderID).Return(nil, modelDelivery.ErrDeliveryNotFound)
+	m.offers.EXPECT().RejectedCourierIDs(gomock.Any(), offerOrderID).Return([]int64{1}, nil)
+	m.courierRepo.EXPECT().ListAvailableWithDeliveries(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	// заказ сняли раньше ответа курьера - предложение просто закрывается
+	m.deliveryRepo.EXPECT().GetByOrderID(gomock.Any(), "order-unassigned").Return(nil, modelDelivery.ErrDeliveryNotFound)
+	m.offers.EXPECT().Resolve(gomock.Any(), int64(3), modelDelivery.OfferWithdrawn, pendingNow).Return(true, nil)
+
+	require.NoError(t, service.ExpireOffers(context.Background(), 10))
+}
+
+func TestGetCourierOfferStats(t *testing.T) {
+	t.Parallel()
+	service, m := newOfferService(t)
+
+	m.courierRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&modelCourier.Courier{ID: 1}, nil)
+	m.offers.EXPECT().StatsByCourierID(gomock.Any(), int64(1)).Return(&modelDelivery.OfferStats{
+		CourierID: 1,
+		Offered:   4,
+		Accepted:  3,
+		Declined:  1,
+	}, nil)
+
+	stats, err := service.GetCourierOfferStats(context.Background(), 1)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.75, stats.AcceptanceRate(), 1e-9)
+
+	m.courierRepo.EXPECT().GetByID(gomock.Any(), int64(2)).Return(nil, modelCourier.ErrCourierNotFound)
+	_, err = service.GetCourierOfferStats(context.Background(), 2)
+	assert.ErrorIs(t, err, modelCourier.ErrCourierNotFound)
+}
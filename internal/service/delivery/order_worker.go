@@ -2,23 +2,35 @@ package delivery
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
 	"time"
 
-	orderGateway "service-courier/internal/gateway/order"
+	"service-courier/internal/model/delivery"
+	"service-courier/internal/model/order"
 )
 
+// OrderWorkerName - ключ позиции воркера в хранилище курсоров
+const OrderWorkerName = "order_poller"
+
 type OrderWorker struct {
-	service *Service
-	gateway *orderGateway.Gateway
-	clock   Clock
+	service  *Service
+	orders   orderSource
+	cursors  cursorRepository
+	clock    Clock
+	lookback time.Duration
 }
 
-func NewOrderWorker(service *Service, gateway *orderGateway.Gateway, clock Clock) *OrderWorker {
+// NewOrderWorker создает воркер опроса заказов. lookback ограничивает, насколько далеко
+// в прошлое воркер догоняет пропущенные заказы после простоя.
+func NewOrderWorker(service *Service, orders orderSource, cursors cursorRepository, clock Clock, lookback time.Duration) *OrderWorker {
 	return &OrderWorker{
-		service: service,
-		gateway: gateway,
-		clock:   clock,
+		service:  service,
+		orders:   orders,
+		cursors:  cursors,
+		clock:    clock,
+		lookback: lookback,
 	}
 }
 
@@ -26,7 +38,9 @@ func (w *OrderWorker) Start(ctx context.Context) {
 	ticker := time.NewTicker(5 * time.Second)
 	defer ticker.Stop()
 
-	log.Println("[OrderWorker] Starting order polling worker (interval: 5s)")
+	log.Printf("[OrderWorker] Starting order polling worker (interval: 5s, max lookback: %v)", w.lookback)
+
+	w.poll(ctx)
 
 	for {
 		select {
@@ -34,37 +48,108 @@ func (w *OrderWorker) Start(ctx context.Context) {
 			log.Println("[OrderWorker] Stopping order polling worker...")
 			return
 		case <-ticker.C:
-			w.process(ctx)
+			w.poll(ctx)
 		}
 	}
 }
 
-func (w *OrderWorker) process(ctx context.Context) {
-	cursor := w.clock.Now().Add(-5 * time.Second)
+func (w *OrderWorker) poll(ctx context.Context) {
+	if err := w.Poll(ctx); err != nil {
+		log.Printf("[OrderWorker] %v", err)
+	}
+}
+
+// Poll забирает заказы начиная с сохраненного курсора и назначает курьеров новым заказам.
+// Курсор сдвигается до последнего заказа пачки, а при ошибках - только до первого
+// неудавшегося заказа, чтобы следующий опрос повторил его.
+func (w *OrderWorker) Poll(ctx context.Context) error {
+	cursor, err := w.cursor(ctx)
+	if err != nil {
+		return err
+	}
 
-	orders, err := w.gateway.GetOrders(ctx, cursor)
+	orders, err := w.orders.GetOrders(ctx, cursor)
 	if err != nil {
-		log.Printf("[OrderWorker] Failed to fetch orders: %v", err)
-		return
+		return fmt.Errorf("fetch orders: %w", err)
 	}
 
 	if len(orders) == 0 {
 		log.Println("[OrderWorker] No new orders")
-		return
+		return nil
 	}
 
 	latest := cursor
+	var (
+		failed         int
+		earliestFailed time.Time
+	)
 	for _, o := range orders {
-		if _, err := w.service.AssignCourier(ctx, o.ID); err != nil {
-			log.Printf("[OrderWorker] Failed to assign courier for order %s: %v", o.ID, err)
-		} else {
+		if o.CreatedAt.After(latest) {
+			latest = o.CreatedAt
+		}
+
+		// отмененным и завершенным заказам курьер не нужен
+		if o.Status != order.StatusCreated {
+			continue
+		}
+
+		assignCtx := WithChangeSource(ctx, delivery.ActorOrderPoller, "order "+o.Status)
+		priority, err := delivery.ParsePriority(o.Priority)
+		if err != nil {
+			log.Printf("[OrderWorker] Order %s has unknown priority %q, using standard", o.ID, o.Priority)
+			priority = delivery.PriorityStandard
+		}
+
+		_, err = w.service.AssignCourier(assignCtx, o.ID, priority)
+		switch {
+		case err == nil:
 			log.Printf("[OrderWorker] Assigned courier for order %s", o.ID)
+		case errors.Is(err, delivery.ErrOrderAlreadyAssigned):
+		case errors.Is(err, delivery.ErrAssignmentPending):
+			log.Printf("[OrderWorker] No available couriers, order %s is queued", o.ID)
+		default:
+			failed++
+			if earliestFailed.IsZero() || o.CreatedAt.Before(earliestFailed) {
+				earliestFailed = o.CreatedAt
+			}
+			log.Printf("[OrderWorker] Failed to assign courier for order %s: %v", o.ID, err)
 		}
+	}
 
-		if o.CreatedAt.After(latest) {
-			latest = o.CreatedAt
+	if failed == 0 {
+		if err := w.cursors.Save(ctx, OrderWorkerName, latest); err != nil {
+			return fmt.Errorf("save cursor: %w", err)
+		}
+
+		log.Printf("[OrderWorker] Processed %d orders, cursor updated to %s", len(orders), latest.Format(time.RFC3339))
+		return nil
+	}
+
+	// курсор хранится с точностью до микросекунды: отступ меньше округлился бы обратно к заказу.
+	// Уже назначенные заказы после неудавшегося повторно отсекаются как ErrOrderAlreadyAssigned.
+	position := earliestFailed.Add(-time.Microsecond)
+	if position.After(cursor) {
+		if err := w.cursors.Save(ctx, OrderWorkerName, position); err != nil {
+			return fmt.Errorf("save cursor: %w", err)
 		}
+	} else {
+		position = cursor
+	}
+
+	return fmt.Errorf("%d of %d orders failed, cursor kept at %s", failed, len(orders), position.Format(time.RFC3339))
+}
+
+func (w *OrderWorker) cursor(ctx context.Context) (time.Time, error) {
+	oldest := w.clock.Now().Add(-w.lookback)
+
+	stored, err := w.cursors.Get(ctx, OrderWorkerName)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("load cursor: %w", err)
+	}
+
+	if stored == nil || stored.Before(oldest) {
+		return oldest, nil
 	}
 
-	log.Printf("[OrderWorker] Processed %d orders, cursor updated to %s", len(orders), latest.Format(time.RFC3339))
+	return *stored, nil
 }
@@ -0,0 +1,259 @@
+package delivery_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	modelDelivery "service-courier/internal/model/delivery"
+	modelOrder "service-courier/internal/model/order"
+	deliveryService "service-courier/internal/service/delivery"
+	"service-courier/internal/service/delivery/mocks"
+)
+
+type orderWorkerMocks struct {
+	deliveryRepo *mocks.MockdeliveryRepository
+	orders       *mocks.MockorderSource
+	cursors      *mocks.MockcursorRepository
+}
+
+func newOrderWorker(ctrl *gomock.Controller, now time.Time, lookback time.Duration) (*deliveryService.OrderWorker, orderWorkerMocks) {
+	m := orderWorkerMocks{
+		deliveryRepo: mocks.NewMockdeliveryRepository(ctrl),
+		orders:       mocks.NewMockorderSource(ctrl),
+		cursors:      mocks.NewMockcursorRepository(ctrl),
+	}
+
+	mockTxManager := mocks.NewMocktransactionManager(ctrl)
+	mockTxManager.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		}).
+		AnyTimes()
+
+	clock := deliveryService.NewFixedClock(now)
+	service := deliveryService.NewDeliveryService(
+		m.deliveryRepo,
+		mocks.NewMockcourierRepository(ctrl),
+		deliveryService.NewTransportFactory(),
+		mockTxManager,
+		clock,
+	)
+
+	return deliveryService.NewOrderWorker(service, m.orders, m.cursors, clock, lookback), m
+}
+
+func TestOrderWorker_Poll_AdvancesStoredCursor(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	worker, m := newOrderWorker(ctrl, now, time.Hour)
+
+	stored := now.Add(-10 * time.Minute)
+	latest := now.Add(-2 * time.Minute)
+
+	m.cursors.EXPECT().
+		Get(gomock.Any(), deliveryService.OrderWorkerName).
+		Return(&stored, nil)
+
+	m.orders.EXPECT().
+		GetOrders(gomock.Any(), stored).
+		Return([]modelOrder.Order{
+			{ID: "order-1", Status: modelOrder.StatusCreated, CreatedAt: now.Add(-5 * time.Minute)},
+			{ID: "order-2", Status: modelOrder.StatusCreated, CreatedAt: latest},
+		}, nil)
+
+	// оба заказа уже назначены ранее - это считается успешной обработкой
+	m.deliveryRepo.EXPECT().
+		GetByOrderID(gomock.Any(), gomock.Any()).
+		Return(&modelDelivery.Delivery{ID: 1}, nil).
+		Times(2)
+
+	m.cursors.EXPECT().
+		Save(gomock.Any(), deliveryService.OrderWorkerName, latest).
+		Return(nil)
+
+	if err := worker.Poll(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestOrderWorker_Poll_ClampsToMaxLookback(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	worker, m := newOrderWorker(ctrl, now, time.Hour)
+
+	stored := now.Add(-24 * time.Hour)
+
+	m.cursors.EXPECT().
+		Get(gomock.Any(), gomock.Any()).
+		Return(&stored, nil)
+
+	m.orders.EXPECT().
+		GetOrders(gomock.Any(), now.Add(-time.Hour)).
+		Return(nil, nil)
+
+	if err := worker.Poll(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestOrderWorker_Poll_StartsFromLookbackWithoutCursor(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	worker, m := newOrderWorker(ctrl, now, 30*time.Minute)
+
+	m.cursors.EXPECT().
+		Get(gomock.Any(), gomock.Any()).
+		Return(nil, nil)
+
+	m.orders.EXPECT().
+		GetOrders(gomock.Any(), now.Add(-30*time.Minute)).
+		Return(nil, nil)
+
+	if err := worker.Poll(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestOrderWorker_Poll_AdvancesCursorToFirstFailure(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	worker, m := newOrderWorker(ctrl, now, time.Hour)
+
+	stored := now.Add(-10 * time.Minute)
+	failedAt := now.Add(-3 * time.Minute)
+
+	m.cursors.EXPECT().
+		Get(gomock.Any(), gomock.Any()).
+		Return(&stored, nil)
+
+	m.orders.EXPECT().
+		GetOrders(gomock.Any(), stored).
+		Return([]modelOrder.Order{
+			{ID: "order-1", Status: modelOrder.StatusCreated, CreatedAt: now.Add(-5 * time.Minute)},
+			{ID: "order-2", Status: modelOrder.StatusCreated, CreatedAt: failedAt},
+			{ID: "order-3", Status: modelOrder.StatusCreated, CreatedAt: now.Add(-time.Minute)},
+		}, nil)
+
+	m.deliveryRepo.EXPECT().
+		GetByOrderID(gomock.Any(), "order-1").
+		Return(&modelDelivery.Delivery{ID: 1}, nil)
+	m.deliveryRepo.EXPECT().
+		GetByOrderID(gomock.Any(), "order-2").
+		Return(nil, errors.New("db timeout"))
+	m.deliveryRepo.EXPECT().
+		GetByOrderID(gomock.Any(), "order-3").
+		Return(&modelDelivery.Delivery{ID: 3}, nil)
+
+	// курсор встает перед неудавшимся заказом, следующий опрос начнет с него
+	m.cursors.EXPECT().
+		Save(gomock.Any(), deliveryService.OrderWorkerName, failedAt.Add(-time.Microsecond)).
+		Return(nil)
+
+	if err := worker.Poll(context.Background()); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestOrderWorker_Poll_KeepsCursorWhenFirstOrderFails(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	worker, m := newOrderWorker(ctrl, now, time.Hour)
+
+	stored := now.Add(-10 * time.Minute)
+
+	m.cursors.EXPECT().
+		Get(gomock.Any(), gomock.Any()).
+		Return(&stored, nil)
+
+	m.orders.EXPECT().
+		GetOrders(gomock.Any(), stored).
+		Return([]modelOrder.Order{
+			{ID: "order-1", Status: modelOrder.StatusCreated, CreatedAt: stored},
+			{ID: "order-2", Status: modelOrder.StatusCreated, CreatedAt: now.Add(-time.Minute)},
+		}, nil)
+
+	m.deliveryRepo.EXPECT().
+		GetByOrderID(gomock.Any(), "order-1").
+		Return(nil, errors.New("db timeout"))
+	m.deliveryRepo.EXPECT().
+		GetByOrderID(gomock.Any(), "order-2").
+		Return(&modelDelivery.Delivery{ID: 2}, nil)
+
+	// Save не ожидается: курсор не сдвигается назад
+	if err := worker.Poll(context.Background()); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestOrderWorker_Poll_SkipsNotCreatedOrders(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	worker, m := newOrderWorker(ctrl, now, time.Hour)
+
+	stored := now.Add(-10 * time.Minute)
+	latest := now.Add(-time.Minute)
+
+	m.cursors.EXPECT().
+		Get(gomock.Any(), gomock.Any()).
+		Return(&stored, nil)
+
+	m.orders.EXPECT().
+		GetOrders(gomock.Any(), stored).
+		Return([]modelOrder.Order{
+			{ID: "order-1", Status: modelOrder.StatusCancelled, CreatedAt: now.Add(-5 * time.Minute)},
+			{ID: "order-2", Status: modelOrder.StatusCompleted, CreatedAt: latest},
+		}, nil)
+
+	// назначение не запускается, но курсор проходит мимо этих заказов
+	m.cursors.EXPECT().
+		Save(gomock.Any(), deliveryService.OrderWorkerName, latest).
+		Return(nil)
+
+	if err := worker.Poll(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestOrderWorker_Poll_FetchError(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	worker, m := newOrderWorker(ctrl, now, time.Hour)
+
+	m.cursors.EXPECT().
+		Get(gomock.Any(), gomock.Any()).
+		Return(nil, nil)
+
+	m.orders.EXPECT().
+		GetOrders(gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("unavailable"))
+
+	if err := worker.Poll(context.Background()); err == nil {
+		t.Fatal("expected error")
+	}
+}
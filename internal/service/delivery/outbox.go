@@ -0,0 +1,64 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"service-courier/internal/dto/queues/delivery/changed"
+	"service-courier/internal/model/delivery"
+	"service-courier/internal/model/outbox"
+	"time"
+)
+
+// WithOutbox включает запись событий доставки в outbox для публикации в Kafka
+func WithOutbox(repo outboxRepository, topic string) Option {
+	return func(s *Service) {
+		s.outbox = repo
+		s.outboxTopic = topic
+	}
+}
+
+func (s *Service) enqueueEvents(ctx context.Context, events []delivery.Event) error {
+	if s.outbox == nil || len(events) == 0 {
+		return nil
+	}
+
+	messages := make([]outbox.Message, 0, len(events))
+	for _, e := range events {
+		payload, err := json.Marshal(toChangedMessage(e))
+		if err != nil {
+			return fmt.Errorf("marshal delivery event: %w", err)
+		}
+		// ключ - заказ, чтобы все события одной доставки попадали в одну партицию
+		messages = append(messages, outbox.Message{
+			Topic:     s.outboxTopic,
+			Key:       e.OrderID,
+			Payload:   payload,
+			CreatedAt: e.CreatedAt,
+		})
+	}
+
+	if err := s.outbox.Create(ctx, messages); err != nil {
+		return fmt.Errorf("enqueue delivery events: %w", err)
+	}
+
+	return nil
+}
+
+func toChangedMessage(e delivery.Event) changed.Message {
+	var previous *string
+	if e.FromStatus != "" {
+		status := string(e.FromStatus)
+		previous = &status
+	}
+	return changed.Message{
+		DeliveryID:     e.DeliveryID,
+		OrderID:        e.OrderID,
+		CourierID:      e.CourierID,
+		PreviousStatus: previous,
+		Status:         string(e.ToStatus),
+		Actor:          string(e.Actor),
+		Reason:         e.Reason,
+		OccurredAt:     e.CreatedAt.Format(time.RFC3339),
+	}
+}
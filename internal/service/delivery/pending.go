@@ -0,0 +1,198 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"service-courier/internal/dto/queues/delivery/expired"
+	"service-courier/internal/metrics"
+	"service-courier/internal/model/courier"
+	"service-courier/internal/model/delivery"
+	"service-courier/internal/model/outbox"
+	"service-courier/internal/pkg/geo"
+	"sync/atomic"
+	"time"
+)
+
+// WithPendingQueue включает очередь заказов, которым не хватило свободного курьера.
+// Заказ ждет курьера не дольше sla, затем снимается с очереди с событием в topic.
+func WithPendingQueue(repo pendingRepository, sla time.Duration, topic string) Option {
+	return func(s *Service) {
+		s.pending = repo
+		s.pendingSLA = sla
+		s.pendingTopic = topic
+		s.pendingWake = make(chan struct{}, 1)
+	}
+}
+
+// NotifyCourierFreed будит обработчик очереди: у курьера освободилось место
+func (s *Service) NotifyCourierFreed() {
+	s.wakePending()
+}
+
+type deferredWakeupsKey struct{}
+
+// deferredWakeups - пробуждение очереди, отложенное до коммита внешней транзакции
+type deferredWakeups struct {
+	requested atomic.Bool
+}
+
+// DeferWakeups откладывает пробуждения обработчика очереди из методов сервиса, вызванных с ctx, до вызова wake.
+// Нужен, когда методы работают внутри транзакции вызывающего: проснувшись до ее коммита,
+// обработчик не увидел бы освободившихся курьеров.
+func (s *Service) DeferWakeups(ctx context.Context) (context.Context, func()) {
+	deferred := &deferredWakeups{}
+	return context.WithValue(ctx, deferredWakeupsKey{}, deferred), func() {
+		if deferred.requested.Load() {
+			s.wakePending()
+		}
+	}
+}
+
+// notifyFreed будит обработчик очереди сразу или, если пробуждения отложены, после коммита вызывающего
+func (s *Service) notifyFreed(ctx context.Context) {
+	if deferred, ok := ctx.Value(deferredWakeupsKey{}).(*deferredWakeups); ok {
+		deferred.requested.Store(true)
+		return
+	}
+	s.wakePending()
+}
+
+// wakePending не блокирует - если сигнал еще не обработан, второй не нужен
+func (s *Service) wakePending() {
+	if s.pendingWake == nil {
+		return
+	}
+	select {
+	case s.pendingWake <- struct{}{}:
+	default:
+	}
+}
+
+// enqueuePending ставит заказ в очередь, false - заказ уже в ней
+func (s *Service) enqueuePending(ctx context.Context, orderID string, destination *geo.Point, priority delivery.Priority) (bool, error) {
+	now := s.clock.Now()
+
+	queued, err := s.pending.Enqueue(ctx, delivery.PendingAssignment{
+		OrderID:     orderID,
+		Priority:    priority,
+		Destination: destination,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.pendingSLA),
+	})
+	if err != nil {
+		return false, fmt.Errorf("enqueue pending assignment: %w", err)
+	}
+
+	if queued {
+		metrics.PendingAssignmentsTotal.WithLabelValues(string(priority), "queued").Inc()
+	}
+	return queued, nil
+}
+
+// ProcessPendingAssignments снимает с очереди заказы с истекшим SLA и назначает курьеров остальным
+// в порядке приоритета и времени постановки.
+func (s *Service) ProcessPendingAssignments(ctx context.Context, limit uint64) error {
+	if s.pending == nil {
+		return nil
+	}
+
+	if err := s.expirePending(ctx); err != nil {
+		return err
+	}
+
+	pending, err := s.pending.ListReady(ctx, s.clock.Now(), limit)
+	if err != nil {
+		return fmt.Errorf("list pending assignments: %w", err)
+	}
+
+	for _, p := range pending {
+		err := s.assignPending(ctx, p)
+		if err == nil {
+			log.Printf("[PendingAssignments] Assigned courier for order %s after %d attempts", p.OrderID, p.Attempts+1)
+			metrics.PendingAssignmentsTotal.WithLabelValues(string(p.Priority), "assigned").Inc()
+			metrics.AssignmentWaitSeconds.WithLabelValues(string(p.Priority)).Observe(s.clock.Now().Sub(p.CreatedAt).Seconds())
+			continue
+		}
+
+		// у заказов разные ограничения по транспорту и отказавшимся курьерам:
+		// отсутствие курьера для одного не значит, что его нет для следующих
+		if !errors.Is(err, courier.ErrNoAvailableCouriers) {
+			log.Printf("[PendingAssignments] Failed to assign courier for order %s: %v", p.OrderID, err)
+		}
+
+		if err := s.pending.MarkAttempt(ctx, p.ID, s.clock.Now(), err.Error()); err != nil {
+			return fmt.Errorf("mark pending attempt: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) assignPending(ctx context.Context, p delivery.PendingAssignment) error {
+	return s.txManager.Do(ctx, func(ctx context.Context) error {
+		_, err := s.assign(ctx, p.OrderID, p.Destination, p.Priority)
+		// курьер мог быть назначен в обход очереди - заказ просто убирается из нее
+		if err != nil && !errors.Is(err, delivery.ErrOrderAlreadyAssigned) {
+			return err
+		}
+
+		if _, err := s.pending.DeleteByOrderID(ctx, p.OrderID); err != nil {
+			return fmt.Errorf("delete pending assignment: %w", err)
+		}
+		return nil
+	})
+}
+
+// expirePending удаляет заказы с истекшим SLA и в той же транзакции пишет событие в outbox
+func (s *Service) expirePending(ctx context.Context) error {
+	return s.txManager.Do(ctx, func(ctx context.Context) error {
+		now := s.clock.Now()
+
+		expiredAssignments, err := s.pending.DeleteExpired(ctx, now)
+		if err != nil {
+			return fmt.Errorf("delete expired pending assignments: %w", err)
+		}
+
+		if len(expiredAssignments) == 0 {
+			return nil
+		}
+
+		log.Printf("[PendingAssignments] %d orders got no courier within SLA", len(expiredAssignments))
+		for _, p := range expiredAssignments {
+			metrics.PendingAssignmentsTotal.WithLabelValues(string(p.Priority), "expired").Inc()
+		}
+
+		if s.outbox == nil {
+			return nil
+		}
+
+		messages := make([]outbox.Message, 0, len(expiredAssignments))
+		for _, p := range expiredAssignments {
+			payload, err := json.Marshal(expired.Message{
+				OrderID:   p.OrderID,
+				Priority:  string(p.Priority),
+				Attempts:  p.Attempts,
+				LastError: p.LastError,
+				QueuedAt:  p.CreatedAt.Format(time.RFC3339),
+				ExpiredAt: now.Format(time.RFC3339),
+			})
+			if err != nil {
+				return fmt.Errorf("marshal expired assignment: %w", err)
+			}
+			messages = append(messages, outbox.Message{
+				Topic:     s.pendingTopic,
+				Key:       p.OrderID,
+				Payload:   payload,
+				CreatedAt: now,
+			})
+		}
+
+		if err := s.outbox.Create(ctx, messages); err != nil {
+			return fmt.Errorf("enqueue expired assignments: %w", err)
+		}
+		return nil
+	})
+}
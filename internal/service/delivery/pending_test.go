@@ -0,0 +1,218 @@
+package delivery_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"service-courier/internal/dto/queues/delivery/expired"
+	modelCourier "service-courier/internal/model/courier"
+	modelDelivery "service-courier/internal/model/delivery"
+	"service-courier/internal/model/outbox"
+	deliveryService "service-courier/internal/service/delivery"
+	"service-courier/internal/service/delivery/mocks"
+)
+
+var pendingNow = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+type pendingMocks struct {
+	deliveryRepo *mocks.MockdeliveryRepository
+	courierRepo  *mocks.MockcourierRepository
+	pending      *mocks.MockpendingRepository
+	outbox       *mocks.MockoutboxRepository
+}
+
+func newPendingService(t *testing.T) (*deliveryService.Service, pendingMocks) {
+	ctrl := gomock.NewController(t)
+
+	m := pendingMocks{
+		deliveryRepo: mocks.NewMockdeliveryRepository(ctrl),
+		courierRepo:  mocks.NewMockcourierRepository(ctrl),
+		pending:      mocks.NewMockpendingRepository(ctrl),
+		outbox:       mocks.NewMockoutboxRepository(ctrl),
+	}
+	txManager := mocks.NewMocktransactionManager(ctrl)
+	txManager.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		}).
+		AnyTimes()
+
+	service := deliveryService.NewDeliveryService(
+		m.deliveryRepo,
+		m.courierRepo,
+		deliveryService.NewTransportFactory(),
+		txManager,
+		deliveryService.NewFixedClock(pendingNow),
+		deliveryService.WithOutbox(m.outbox, "delivery.status.changed"),
+		deliveryService.WithPendingQueue(m.pending, 15*time.Minute, "delivery.assignment.expired"),
+	)
+	return service, m
+}
+
+func TestAssignCourier_QueuesWhenNoCouriers(t *testing.T) {
+	t.Parallel()
+	service, m := newPendingService(t)
+
+	orderID := "order-1"
+	m.deliveryRepo.EXPECT().GetByOrderID(gomock.Any(), orderID).Return(nil, modelDelivery.ErrDeliveryNotFound)
+	m.courierRepo.EXPECT().
+		ListAvailableWithDeliveries(gomock.Any(), gomock.Any()).
+		Return(nil, nil)
+	m.pending.EXPECT().
+		Enqueue(gomock.Any(), modelDelivery.PendingAssignment{
+			OrderID:   orderID,
+			Priority:  modelDelivery.PriorityExpress,
+			CreatedAt: pendingNow,
+			ExpiresAt: pendingNow.Add(15 * time.Minute),
+		}).
+		Return(true, nil)
+
+	result, err := service.AssignCourier(context.Background(), orderID, modelDelivery.PriorityExpress)
+	assert.ErrorIs(t, err, modelDelivery.ErrAssignmentPending)
+	assert.Nil(t, result)
+}
+
+func TestProcessPendingAssignments_AssignsAndDequeues(t *testing.T) {
+	t.Parallel()
+	service, m := newPendingService(t)
+
+	m.pending.EXPECT().DeleteExpired(gomock.Any(), pendingNow).Return(nil, nil)
+	m.pending.EXPECT().
+		ListReady(gomock.Any(), pendingNow, uint64(10)).
+		Return([]modelDelivery.PendingAssignment{{ID: 1, OrderID: "order-1"}}, nil)
+
+	m.deliveryRepo.EXPECT().GetByOrderID(gomock.Any(), "order-1").Return(nil, modelDelivery.ErrDeliveryNotFound)
+	m.courierRepo.EXPECT().
+		ListAvailableWithDeliveries(gomock.Any(), gomock.Any()).
+		Return([]modelCourier.Candidate{{Courier: modelCourier.Courier{ID: 7, TransportType: modelCourier.TransportCar}}}, nil)
+	expectReserved(m.courierRepo, m.deliveryRepo, modelCourier.Courier{ID: 7, TransportType: modelCourier.TransportCar})
+	m.deliveryRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(int64(3), nil)
+	m.deliveryRepo.EXPECT().CreateEvents(gomock.Any(), gomock.Any()).Return(nil)
+	m.outbox.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	m.courierRepo.EXPECT().UpdateStatusFrom(gomock.Any(), gomock.Any(), gomock.Any(), modelCourier.CourierStatus(modelCourier.StatusBusy)).Return(true, nil)
+	m.pending.EXPECT().DeleteByOrderID(gomock.Any(), "order-1").Return(true, nil)
+
+	require.NoError(t, service.ProcessPendingAssignments(context.Background(), 10))
+}
+
+func TestProcessPendingAssignments_ContinuesAfterRestrictedOrder(t *testing.T) {
+	t.Parallel()
+	service, m := newPendingService(t)
+
+	m.pending.EXPECT().DeleteExpired(gomock.Any(), pendingNow).Return(nil, nil)
+	m.pending.EXPECT().
+		ListReady(gomock.Any(), pendingNow, uint64(10)).
+		Return([]modelDelivery.PendingAssignment{
+			{ID: 1, OrderID: "order-vip", Priority: modelDelivery.PriorityVIP},
+			{ID: 2, OrderID: "order-standard", Priority: modelDelivery.PriorityStandard},
+		}, nil)
+
+	// для VIP-заказа нет курьера на машине, но пеший курьер подходит стандартному заказу
+	m.deliveryRepo.EXPECT().GetByOrderID(gomock.Any(), "order-vip").Return(nil, modelDelivery.ErrDeliveryNotFound)
+	m.courierRepo.EXPECT().
+		ListAvailableWithDeliveries(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, filter modelCourier.AvailableFilter) ([]modelCourier.Candidate, error) {
+			assert.Equal(t, []modelCourier.TransportType{modelCourier.TransportCar}, filter.Transports)
+			return nil, nil
+		})
+	m.pending.EXPECT().MarkAttempt(gomock.Any(), int64(1), pendingNow, gomock.Any()).Return(nil)
+
+	onFoot := modelCourier.Courier{ID: 7, Status: modelCourier.StatusAvailable, TransportType: modelCourier.TransportOnFoot}
+	m.deliveryRepo.EXPECT().GetByOrderID(gomock.Any(), "order-standard").Return(nil, modelDelivery.ErrDeliveryNotFound)
+	m.courierRepo.EXPECT().
+		ListAvailableWithDeliveries(gomock.Any(), gomock.Any()).
+		Return([]modelCourier.Candidate{{Courier: onFoot}}, nil)
+	expectReserved(m.courierRepo, m.deliveryRepo, onFoot)
+	m.courierRepo.EXPECT().
+		UpdateStatusFrom(gomock.Any(), int64(7), modelCourier.CourierStatus(modelCourier.StatusAvailable), modelCourier.CourierStatus(modelCourier.StatusBusy)).
+		Return(true, nil)
+	m.deliveryRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(int64(3), nil)
+	m.deliveryRepo.EXPECT().CreateEvents(gomock.Any(), gomock.Any()).Return(nil)
+	m.outbox.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	m.pending.EXPECT().DeleteByOrderID(gomock.Any(), "order-standard").Return(true, nil)
+
+	require.NoError(t, service.ProcessPendingAssignments(context.Background(), 10))
+}
+
+func TestProcessPendingAssignments_AlreadyAssignedDequeued(t *testing.T) {
+	t.Parallel()
+	service, m := newPendingService(t)
+
+	m.pending.EXPECT().DeleteExpired(gomock.Any(), pendingNow).Return(nil, nil)
+	m.pending.EXPECT().
+		ListReady(gomock.Any(), pendingNow, uint64(10)).
+		Return([]modelDelivery.PendingAssignment{{ID: 1, OrderID: "order-1"}}, nil)
+	m.deliveryRepo.EXPECT().
+		GetByOrderID(gomock.Any(), "order-1").
+		Return(&modelDelivery.Delivery{ID: 3, OrderID: "order-1"}, nil)
+	m.pending.EXPECT().DeleteByOrderID(gomock.Any(), "order-1").Return(true, nil)
+
+	require.NoError(t, service.ProcessPendingAssignments(context.Background(), 10))
+}
+
+func TestProcessPendingAssignments_ExpiresWithEvent(t *testing.T) {
+	t.Parallel()
+	service, m := newPendingService(t)
+
+	queuedAt := pendingNow.Add(-20 * time.Minute)
+	m.pending.EXPECT().
+		DeleteExpired(gomock.Any(), pendingNow).
+		Return([]modelDelivery.PendingAssignment{{ID: 1, OrderID: "order-1", Attempts: 4, CreatedAt: queuedAt}}, nil)
+	m.outbox.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, messages []outbox.Message) error {
+			require.Len(t, messages, 1)
+			assert.Equal(t, "delivery.assignment.expired", messages[0].Topic)
+			assert.Equal(t, "order-1", messages[0].Key)
+
+			var msg expired.Message
+			require.NoError(t, json.Unmarshal(messages[0].Payload, &msg))
+			assert.Equal(t, 4, msg.Attempts)
+			assert.Equal(t, queuedAt.Format(time.RFC3339), msg.QueuedAt)
+			return nil
+		})
+	m.pending.EXPECT().ListReady(gomock.Any(), pendingNow, uint64(10)).Return(nil, nil)
+
+	require.NoError(t, service.ProcessPendingAssignments(context.Background(), 10))
+}
+
+func TestProcessPendingAssignments_ExpireError(t *testing.T) {
+	t.Parallel()
+	service, m := newPendingService(t)
+
+	m.pending.EXPECT().DeleteExpired(gomock.Any(), pendingNow).Return(nil, errors.New("db error"))
+
+	assert.Error(t, service.ProcessPendingAssignments(context.Background(), 10))
+}
+
+func TestUnassignCourier_DropsQueuedOrder(t *testing.T) {
+	t.Parallel()
+	service, m := newPendingService(t)
+
+	m.deliveryRepo.EXPECT().GetByOrderID(gomock.Any(), "order-1").Return(nil, modelDelivery.ErrDeliveryNotFound)
+	m.pending.EXPECT().DeleteByOrderID(gomock.Any(), "order-1").Return(true, nil)
+
+	result, err := service.UnassignCourier(context.Background(), "order-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), result.CourierID)
+	assert.Equal(t, modelDelivery.StatusUnassigned, result.Status)
+}
+
+func TestUnassignCourier_NotQueued(t *testing.T) {
+	t.Parallel()
+	service, m := newPendingService(t)
+
+	m.deliveryRepo.EXPECT().GetByOrderID(gomock.Any(), "order-1").Return(nil, modelDelivery.ErrDeliveryNotFound)
+	m.pending.EXPECT().DeleteByOrderID(gomock.Any(), "order-1").Return(false, nil)
+
+	_, err := service.UnassignCourier(context.Background(), "order-1")
+	assert.ErrorIs(t, err, modelDelivery.ErrDeliveryNotFound)
+}
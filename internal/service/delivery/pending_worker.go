@@ -0,0 +1,57 @@
+package delivery
+
+import (
+	"context"
+	"log"
+	"service-courier/internal/model/delivery"
+	"time"
+)
+
+const pendingBatchSize = 100
+
+// PendingWorker назначает курьеров заказам из очереди ожидания.
+// Просыпается по таймеру и сразу, когда в этом процессе освобождается курьер.
+// При пакетном назначении после сигнала еще ждет окно, собирая заказы в пачку.
+type PendingWorker struct {
+	service  *Service
+	interval time.Duration
+}
+
+func NewPendingWorker(service *Service, interval time.Duration) *PendingWorker {
+	return &PendingWorker{
+		service:  service,
+		interval: interval,
+	}
+}
+
+func (w *PendingWorker) Start(ctx context.Context) {
+	ctx = WithChangeSource(ctx, delivery.ActorPending, "courier freed")
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	log.Printf("[PendingWorker] Starting pending assignment worker (interval: %v)", w.interval)
+
+	for {
+		if err := w.service.processPending(ctx, pendingBatchSize); err != nil {
+			log.Printf("[PendingWorker] Failed to process pending assignments: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Println("[PendingWorker] Stopping pending assignment worker...")
+			return
+		case <-ticker.C:
+		case <-w.service.pendingWake:
+		}
+
+		if window := w.service.batchWindow(); window > 0 {
+			select {
+			case <-ctx.Done():
+				log.Println("[PendingWorker] Stopping pending assignment worker...")
+				return
+			case <-time.After(window):
+			}
+		}
+	}
+}
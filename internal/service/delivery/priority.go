@@ -0,0 +1,117 @@
+package delivery
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"service-courier/internal/metrics"
+	"service-courier/internal/model/courier"
+	"service-courier/internal/model/delivery"
+	"strings"
+	"time"
+)
+
+// PriorityPolicy - ограничения назначения для уровня приоритета заказа
+type PriorityPolicy struct {
+	// Transports - допустимые виды транспорта, пусто - любой
+	Transports []courier.TransportType
+	// DeadlineFactor - доля стандартного времени на доставку, 0.5 - вдвое быстрее
+	DeadlineFactor float64
+}
+
+type PriorityPolicies map[delivery.Priority]PriorityPolicy
+
+func DefaultPriorityPolicies() PriorityPolicies {
+	return PriorityPolicies{
+		delivery.PriorityStandard: {DeadlineFactor: 1},
+		delivery.PriorityExpress: {
+			Transports:     []courier.TransportType{courier.TransportScooter, courier.TransportCar},
+			DeadlineFactor: 0.7,
+		},
+		delivery.PriorityVIP: {
+			Transports:     []courier.TransportType{courier.TransportCar},
+			DeadlineFactor: 0.5,
+		},
+	}
+}
+
+// LoadPriorityPolicies читает ограничения приоритетов из окружения поверх значений по умолчанию
+func LoadPriorityPolicies() PriorityPolicies {
+	policies := DefaultPriorityPolicies()
+
+	for _, priority := range delivery.Priorities {
+		prefix := "PRIORITY_" + strings.ToUpper(string(priority))
+		policy := policies[priority]
+
+		policy.DeadlineFactor = envFloat(prefix+"_DEADLINE_FACTOR", policy.DeadlineFactor)
+
+		if value := os.Getenv(prefix + "_TRANSPORTS"); value != "" {
+			transports, err := ParseTransports(value)
+			if err != nil {
+				log.Printf("invalid %s_TRANSPORTS %q, ignoring: %v", prefix, value, err)
+			} else {
+				policy.Transports = transports
+			}
+		}
+
+		policies[priority] = policy
+	}
+
+	return policies
+}
+
+// ParseTransports разбирает строку вида "scooter,car"
+func ParseTransports(value string) ([]courier.TransportType, error) {
+	transports := make([]courier.TransportType, 0)
+
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		switch item {
+		case "":
+			continue
+		case courier.TransportOnFoot, courier.TransportScooter, courier.TransportCar:
+			transports = append(transports, courier.TransportType(item))
+		default:
+			return nil, fmt.Errorf("unknown transport type: %s", item)
+		}
+	}
+
+	return transports, nil
+}
+
+// WithPriorityPolicies задает ограничения транспорта и дедлайна по приоритетам заказа
+func WithPriorityPolicies(policies PriorityPolicies) Option {
+	return func(s *Service) {
+		s.priorities = policies
+	}
+}
+
+// policy возвращает ограничения для приоритета, для неизвестного - стандартные
+func (s *Service) policy(priority delivery.Priority) PriorityPolicy {
+	if policy, ok := s.priorities[priority]; ok {
+		return policy
+	}
+	return s.priorities[delivery.PriorityStandard]
+}
+
+// tightenDeadline сокращает время на доставку согласно приоритету заказа
+func tightenDeadline(assignedAt, deadline time.Time, factor float64) time.Time {
+	if factor <= 0 || factor >= 1 {
+		return deadline
+	}
+	return assignedAt.Add(time.Duration(float64(deadline.Sub(assignedAt)) * factor))
+}
+
+// observeSLA учитывает, уложилась ли завершенная доставка в дедлайн своего приоритета
+func observeSLA(d delivery.Delivery, finishedAt time.Time) {
+	priority := d.Priority
+	if priority == "" {
+		priority = delivery.PriorityStandard
+	}
+
+	outcome := "on_time"
+	if finishedAt.After(d.Deadline) {
+		outcome = "late"
+	}
+	metrics.DeliverySLATotal.WithLabelValues(string(priority), outcome).Inc()
+}
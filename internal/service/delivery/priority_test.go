@@ -0,0 +1,133 @@
+package delivery_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	modelCourier "service-courier/internal/model/courier"
+	modelDelivery "service-courier/internal/model/delivery"
+	deliveryService "service-courier/internal/service/delivery"
+)
+
+var priorityCapacity = modelCourier.Capacity{
+	modelCourier.TransportOnFoot:  1,
+	modelCourier.TransportScooter: 2,
+	modelCourier.TransportCar:     3,
+}
+
+// assignWithPriority назначает курьера на машине и возвращает результат и сохраненную доставку
+func assignWithPriority(t *testing.T, priority modelDelivery.Priority, transports []modelCourier.TransportType) (*deliveryService.AssignResult, modelDelivery.Delivery) {
+	service, deliveryRepo, courierRepo := newCapacityService(t)
+
+	orderID := "f819526d-6a7c-48eb-b535-43989469d1ca"
+	var created modelDelivery.Delivery
+
+	deliveryRepo.EXPECT().GetByOrderID(gomock.Any(), orderID).Return(nil, modelDelivery.ErrDeliveryNotFound)
+	courierRepo.EXPECT().
+		ListAvailableWithDeliveries(gomock.Any(), modelCourier.AvailableFilter{
+			Capacity:   priorityCapacity,
+			Transports: transports,
+		}).
+		Return([]modelCourier.Candidate{{Courier: modelCourier.Courier{ID: 10, Status: modelCourier.StatusAvailable, TransportType: modelCourier.TransportCar}}}, nil)
+	expectReserved(courierRepo, deliveryRepo, modelCourier.Courier{ID: 10, Status: modelCourier.StatusAvailable, TransportType: modelCourier.TransportCar})
+	deliveryRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, d modelDelivery.Delivery) (int64, error) {
+			created = d
+			return 1, nil
+		})
+	deliveryRepo.EXPECT().CreateEvents(gomock.Any(), gomock.Any()).Return(nil)
+	courierRepo.EXPECT().UpdateStatusFrom(gomock.Any(), gomock.Any(), gomock.Any(), modelCourier.CourierStatus(modelCourier.StatusBusy)).Return(true, nil)
+
+	result, err := service.AssignCourier(context.Background(), orderID, priority)
+	require.NoError(t, err)
+	return result, created
+}
+
+func TestAssignCourier_PriorityLimitsTransportAndDeadline(t *testing.T) {
+	t.Parallel()
+
+	standard, standardDelivery := assignWithPriority(t, modelDelivery.PriorityStandard, nil)
+	vip, vipDelivery := assignWithPriority(t, modelDelivery.PriorityVIP, []modelCourier.TransportType{modelCourier.TransportCar})
+
+	assert.Equal(t, modelDelivery.PriorityStandard, standardDelivery.Priority)
+	assert.Equal(t, modelDelivery.PriorityVIP, vipDelivery.Priority)
+
+	// VIP получает половину стандартного времени на доставку
+	standardDuration := standard.Deadline.Sub(standardDelivery.AssignedAt)
+	assert.Equal(t, standardDuration/2, vip.Deadline.Sub(vipDelivery.AssignedAt))
+	assert.Equal(t, vip.Deadline, vipDelivery.Deadline)
+}
+
+func TestAssignCourier_ExpressAllowsScooterAndCar(t *testing.T) {
+	t.Parallel()
+
+	result, _ := assignWithPriority(t, modelDelivery.PriorityExpress, []modelCourier.TransportType{
+		modelCourier.TransportScooter,
+		modelCourier.TransportCar,
+	})
+	assert.Equal(t, int64(10), result.CourierID)
+}
+
+func TestParseTransports(t *testing.T) {
+	t.Parallel()
+
+	transports, err := deliveryService.ParseTransports(" scooter, car ")
+	require.NoError(t, err)
+	assert.Equal(t, []modelCourier.TransportType{modelCourier.TransportScooter, modelCourier.TransportCar}, transports)
+
+	_, err = deliveryService.ParseTransports("car,bike")
+	assert.Error(t, err)
+}
+
+func TestParsePriority(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value    string
+		expected modelDelivery.Priority
+		err      error
+	}{
+		{value: "", expected: modelDelivery.PriorityStandard},
+		{value: "express", expected: modelDelivery.PriorityExpress},
+		{value: "VIP", expected: modelDelivery.PriorityVIP},
+		{value: "urgent", err: modelDelivery.ErrUnknownPriority},
+	}
+
+	for _, tt := range tests {
+		priority, err := modelDelivery.ParsePriority(tt.value)
+		if tt.err != nil {
+			assert.ErrorIs(t, err, tt.err, tt.value)
+			continue
+		}
+		require.NoError(t, err, tt.value)
+		assert.Equal(t, tt.expected, priority, tt.value)
+	}
+}
+
+func TestPriority_RankOrdersQueue(t *testing.T) {
+	t.Parallel()
+
+	assert.Greater(t, modelDelivery.PriorityVIP.Rank(), modelDelivery.PriorityExpress.Rank())
+	assert.Greater(t, modelDelivery.PriorityExpress.Rank(), modelDelivery.PriorityStandard.Rank())
+	for _, p := range modelDelivery.Priorities {
+		assert.Equal(t, p, modelDelivery.PriorityFromRank(p.Rank()))
+	}
+}
+
+func TestLoadPriorityPolicies_FromEnv(t *testing.T) {
+	t.Setenv("PRIORITY_EXPRESS_TRANSPORTS", "car")
+	t.Setenv("PRIORITY_EXPRESS_DEADLINE_FACTOR", "0.8")
+	t.Setenv("PRIORITY_VIP_TRANSPORTS", "plane")
+
+	policies := deliveryService.LoadPriorityPolicies()
+
+	assert.Equal(t, []modelCourier.TransportType{modelCourier.TransportCar}, policies[modelDelivery.PriorityExpress].Transports)
+	assert.InDelta(t, 0.8, policies[modelDelivery.PriorityExpress].DeadlineFactor, 1e-9)
+	// некорректное значение игнорируется
+	assert.Equal(t, deliveryService.DefaultPriorityPolicies()[modelDelivery.PriorityVIP], policies[modelDelivery.PriorityVIP])
+}
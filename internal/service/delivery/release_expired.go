@@ -4,11 +4,15 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"service-courier/internal/model/courier"
 	"service-courier/internal/model/delivery"
 )
 
 func (s *Service) ReleaseExpiredCouriers(ctx context.Context) error {
+	var (
+		released []delivery.Delivery
+		freed    bool
+	)
+
 	err := s.txManager.Do(ctx, func(ctx context.Context) error {
 		expired, err := s.deliveryRepo.ListActiveExpired(ctx, s.clock.Now())
 		if err != nil {
@@ -21,10 +25,12 @@ func (s *Service) ReleaseExpiredCouriers(ctx context.Context) error {
 
 		courierIDsMap := make(map[int64]bool)
 		deliveryIDs := make([]int64, len(expired))
+		events := make([]delivery.Event, len(expired))
 
 		for i, d := range expired {
 			courierIDsMap[d.CourierID] = true
 			deliveryIDs[i] = d.ID
+			events[i] = s.newEvent(ctx, d, delivery.StatusCompleted, "deadline expired")
 		}
 
 		courierIDs := make([]int64, 0, len(courierIDsMap))
@@ -39,10 +45,16 @@ func (s *Service) ReleaseExpiredCouriers(ctx context.Context) error {
 			return fmt.Errorf("update delivery status: %w", err)
 		}
 
-		if err := s.courierRepo.UpdateStatusBatch(ctx, courierIDs, courier.StatusAvailable); err != nil {
-			return fmt.Errorf("update courier statuses batch: %w", err)
+		if err := s.recordEvents(ctx, events...); err != nil {
+			return err
+		}
+
+		freed, err = s.releaseIdleCouriers(ctx, courierIDs...)
+		if err != nil {
+			return err
 		}
 
+		released = expired
 		return nil
 	})
 
@@ -50,5 +62,12 @@ func (s *Service) ReleaseExpiredCouriers(ctx context.Context) error {
 		return err
 	}
 
+	now := s.clock.Now()
+	for _, d := range released {
+		observeSLA(d, now)
+	}
+	if freed {
+		s.notifyFreed(ctx)
+	}
 	return nil
 }
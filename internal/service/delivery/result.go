@@ -2,6 +2,7 @@ package delivery
 
 import (
 	"service-courier/internal/model/courier"
+	"service-courier/internal/model/delivery"
 	"time"
 )
 
@@ -10,6 +11,10 @@ type AssignResult struct {
 	OrderID       string
 	TransportType courier.TransportType
 	Deadline      time.Time
+	// Status - assigned или offered, если курьеру нужно принять заказ
+	Status delivery.DeliveryStatus
+	// OfferExpiresAt - до какого момента курьер должен ответить на предложение
+	OfferExpiresAt *time.Time
 }
 
 type UnassignResult struct {
@@ -17,3 +22,20 @@ type UnassignResult struct {
 	Status    string
 	CourierID int64
 }
+
+type TransitionResult struct {
+	OrderID   string
+	CourierID int64
+	From      delivery.DeliveryStatus
+	To        delivery.DeliveryStatus
+}
+
+// DeclineResult - итог отказа курьера от заказа
+type DeclineResult struct {
+	OrderID   string
+	CourierID int64
+	// Next - кому заказ предложен теперь, nil если подходящих курьеров не осталось
+	Next *AssignResult
+	// Queued - заказ поставлен в очередь ожидания
+	Queued bool
+}
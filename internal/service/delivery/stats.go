@@ -0,0 +1,55 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"service-courier/internal/model/courier"
+	"service-courier/internal/model/delivery"
+)
+
+// GetCourierStats возвращает показатели курьера за период; без доставок и смен - нулевые
+func (s *Service) GetCourierStats(ctx context.Context, courierID int64, filter delivery.StatsFilter) (*delivery.CourierStats, error) {
+	courierData, err := s.courierRepo.GetByID(ctx, courierID)
+	if err != nil {
+		if errors.Is(err, courier.ErrCourierNotFound) {
+			return nil, courier.ErrCourierNotFound
+		}
+		return nil, fmt.Errorf("get courier: %w", err)
+	}
+
+	filter.CourierID = &courierID
+	filter.ByTransport = false
+
+	stats, err := s.stats(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(stats) == 0 {
+		return &delivery.CourierStats{CourierID: courierID, TransportType: string(courierData.TransportType)}, nil
+	}
+	return &stats[0], nil
+}
+
+// ListCourierStats возвращает показатели за период по курьерам или, с ByTransport, по типам транспорта
+func (s *Service) ListCourierStats(ctx context.Context, filter delivery.StatsFilter) ([]delivery.CourierStats, error) {
+	filter.CourierID = nil
+	return s.stats(ctx, filter)
+}
+
+// stats обрезает период текущим временем: незавершенные доставки считаются занятостью до сейчас,
+// а будущие смены не занижают загрузку
+func (s *Service) stats(ctx context.Context, filter delivery.StatsFilter) ([]delivery.CourierStats, error) {
+	if now := s.clock.Now(); filter.To.After(now) {
+		filter.To = now
+	}
+	if !filter.From.Before(filter.To) {
+		return []delivery.CourierStats{}, nil
+	}
+
+	stats, err := s.deliveryRepo.Stats(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("get delivery stats: %w", err)
+	}
+	return stats, nil
+}
@@ -0,0 +1,112 @@
+package delivery_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	modelCourier "service-courier/internal/model/courier"
+	modelDelivery "service-courier/internal/model/delivery"
+	deliveryService "service-courier/internal/service/delivery"
+	"service-courier/internal/service/delivery/mocks"
+)
+
+var statsNow = time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+func newStatsService(t *testing.T) (*deliveryService.Service, *mocks.MockdeliveryRepository, *mocks.MockcourierRepository) {
+	ctrl := gomock.NewController(t)
+	deliveryRepo := mocks.NewMockdeliveryRepository(ctrl)
+	courierRepo := mocks.NewMockcourierRepository(ctrl)
+
+	service := deliveryService.NewDeliveryService(
+		deliveryRepo,
+		courierRepo,
+		deliveryService.NewTransportFactory(),
+		mocks.NewMocktransactionManager(ctrl),
+		deliveryService.NewFixedClock(statsNow),
+	)
+	return service, deliveryRepo, courierRepo
+}
+
+func TestGetCourierStats_ClampsPeriodToNow(t *testing.T) {
+	t.Parallel()
+	service, deliveryRepo, courierRepo := newStatsService(t)
+
+	from := statsNow.Add(-24 * time.Hour)
+	courierRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&modelCourier.Courier{ID: 1}, nil)
+	deliveryRepo.EXPECT().
+		Stats(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, filter modelDelivery.StatsFilter) ([]modelDelivery.CourierStats, error) {
+			require.NotNil(t, filter.CourierID)
+			assert.Equal(t, int64(1), *filter.CourierID)
+			assert.False(t, filter.ByTransport)
+			assert.Equal(t, from, filter.From)
+			assert.Equal(t, statsNow, filter.To)
+			return []modelDelivery.CourierStats{{
+				CourierID: 1,
+				Delivered: 3,
+				OnTime:    3,
+				Expired:   1,
+				BusyTime:  2 * time.Hour,
+				ShiftTime: 8 * time.Hour,
+			}}, nil
+		})
+
+	stats, err := service.GetCourierStats(context.Background(), 1, modelDelivery.StatsFilter{
+		From:        from,
+		To:          statsNow.Add(24 * time.Hour),
+		ByTransport: true,
+	})
+	require.NoError(t, err)
+	assert.InDelta(t, 0.75, stats.OnTimeRate(), 1e-9)
+	assert.InDelta(t, 0.25, stats.Utilisation(), 1e-9)
+}
+
+func TestGetCourierStats_NoActivity(t *testing.T) {
+	t.Parallel()
+	service, deliveryRepo, courierRepo := newStatsService(t)
+
+	courierRepo.EXPECT().GetByID(gomock.Any(), int64(1)).
+		Return(&modelCourier.Courier{ID: 1, TransportType: modelCourier.TransportCar}, nil)
+	deliveryRepo.EXPECT().Stats(gomock.Any(), gomock.Any()).Return([]modelDelivery.CourierStats{}, nil)
+
+	stats, err := service.GetCourierStats(context.Background(), 1, modelDelivery.StatsFilter{
+		From: statsNow.Add(-time.Hour),
+		To:   statsNow,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, modelDelivery.CourierStats{CourierID: 1, TransportType: "car"}, *stats)
+	assert.Zero(t, stats.OnTimeRate())
+	assert.Zero(t, stats.Utilisation())
+}
+
+func TestGetCourierStats_CourierNotFound(t *testing.T) {
+	t.Parallel()
+	service, _, courierRepo := newStatsService(t)
+
+	courierRepo.EXPECT().GetByID(gomock.Any(), int64(2)).Return(nil, modelCourier.ErrCourierNotFound)
+
+	_, err := service.GetCourierStats(context.Background(), 2, modelDelivery.StatsFilter{
+		From: statsNow.Add(-time.Hour),
+		To:   statsNow,
+	})
+	assert.ErrorIs(t, err, modelCourier.ErrCourierNotFound)
+}
+
+func TestListCourierStats_FuturePeriod(t *testing.T) {
+	t.Parallel()
+	service, _, _ := newStatsService(t)
+
+	// период целиком в будущем - в базу не ходим
+	stats, err := service.ListCourierStats(context.Background(), modelDelivery.StatsFilter{
+		From:        statsNow.Add(time.Hour),
+		To:          statsNow.Add(2 * time.Hour),
+		ByTransport: true,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, stats)
+}
@@ -0,0 +1,255 @@
+package delivery
+
+import (
+	"os"
+	"service-courier/internal/model/courier"
+	"strconv"
+	"sync"
+)
+
+// LeastLoaded выбирает курьера с наименьшим числом активных, а затем и всех доставок
+type LeastLoaded struct{}
+
+func (LeastLoaded) Name() string {
+	return StrategyLeastLoaded
+}
+
+func (LeastLoaded) Pick(candidates []courier.Candidate, _ AssignmentOrder) *courier.Courier {
+	return leastLoaded(candidates)
+}
+
+func leastLoaded(candidates []courier.Candidate) *courier.Courier {
+	var best *courier.Candidate
+	for i := range candidates {
+		if best == nil || lessLoaded(candidates[i], *best) {
+			best = &candidates[i]
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	return &best.Courier
+}
+
+func lessLoaded(a, b courier.Candidate) bool {
+	if a.Active != b.Active {
+		return a.Active < b.Active
+	}
+	if a.Deliveries != b.Deliveries {
+		return a.Deliveries < b.Deliveries
+	}
+	return a.Courier.ID < b.Courier.ID
+}
+
+// RoundRobin назначает курьеров по кругу в порядке ID, пропуская тех, у кого нет места.
+// Позиция круга хранится в памяти процесса.
+type RoundRobin struct {
+	mu   sync.Mutex
+	last int64
+}
+
+func NewRoundRobinStrategy() *RoundRobin {
+	return &RoundRobin{}
+}
+
+func (r *RoundRobin) Name() string {
+	return StrategyRoundRobin
+}
+
+func (r *RoundRobin) Pick(candidates []courier.Candidate, _ AssignmentOrder) *courier.Courier {
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var next, first *courier.Courier
+	for i := range candidates {
+		c := &candidates[i].Courier
+		if first == nil || c.ID < first.ID {
+			first = c
+		}
+		if c.ID > r.last && (next == nil || c.ID < next.ID) {
+			next = c
+		}
+	}
+
+	// круг пройден - начинаем с наименьшего ID
+	if next == nil {
+		next = first
+	}
+	r.last = next.ID
+	return next
+}
+
+// Nearest выбирает ближайшего к точке доставки курьера с актуальной позицией.
+// Если расстояния неизвестны - наименее загруженного.
+type Nearest struct{}
+
+func NewNearestStrategy() Nearest {
+	return Nearest{}
+}
+
+func (Nearest) Name() string {
+	return StrategyNearest
+}
+
+func (Nearest) Pick(candidates []courier.Candidate, order AssignmentOrder) *courier.Courier {
+	var (
+		best         *courier.Courier
+		bestDistance float64
+	)
+
+	for i := range candidates {
+		distance, ok := order.Distances[candidates[i].Courier.ID]
+		if !ok {
+			continue
+		}
+		if best == nil || distance < bestDistance {
+			best = &candidates[i].Courier
+			bestDistance = distance
+		}
+	}
+
+	if best != nil {
+		return best
+	}
+	return leastLoaded(candidates)
+}
+
+// FastestTransport выбирает курьера на самом быстром транспорте, среди равных - наименее загруженного
+type FastestTransport struct {
+	speeds map[courier.TransportType]float64
+}
+
+func NewFastestTransportStrategy(cfg TransportConfig) FastestTransport {
+	return FastestTransport{speeds: transportSpeeds(cfg)}
+}
+
+func (FastestTransport) Name() string {
+	return StrategyFastestTransport
+}
+
+func (f FastestTransport) Pick(candidates []courier.Candidate, _ AssignmentOrder) *courier.Courier {
+	var best *courier.Candidate
+	for i := range candidates {
+		if best == nil || f.before(candidates[i], *best) {
+			best = &candidates[i]
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	return &best.Courier
+}
+
+func (f FastestTransport) before(a, b courier.Candidate) bool {
+	speedA, speedB := f.speeds[a.Courier.TransportType], f.speeds[b.Courier.TransportType]
+	if speedA != speedB {
+		return speedA > speedB
+	}
+	return lessLoaded(a, b)
+}
+
+// ScoreWeights - веса слагаемых оценки кандидата: чем меньше сумма, тем лучше курьер
+type ScoreWeights struct {
+	// Load - штраф за каждую активную доставку
+	Load float64
+	// ETA - штраф за каждую минуту пути до точки доставки
+	ETA float64
+	// Fairness - штраф за каждую доставку курьера за все время, выравнивает нагрузку
+	Fairness float64
+}
+
+func DefaultScoreWeights() ScoreWeights {
+	return ScoreWeights{Load: 10, ETA: 1, Fairness: 0.1}
+}
+
+// LoadScoreWeights читает веса из окружения поверх значений по умолчанию, нулевой вес выключает слагаемое
+func LoadScoreWeights() ScoreWeights {
+	w := DefaultScoreWeights()
+	w.Load = envWeight("ASSIGNMENT_WEIGHT_LOAD", w.Load)
+	w.ETA = envWeight("ASSIGNMENT_WEIGHT_ETA", w.ETA)
+	w.Fairness = envWeight("ASSIGNMENT_WEIGHT_FAIRNESS", w.Fairness)
+	return w
+}
+
+// Weighted оценивает кандидатов по загрузке, времени пути и общей нагрузке и выбирает лучшую оценку
+type Weighted struct {
+	profiles map[courier.TransportType]TransportProfile
+	weights  ScoreWeights
+}
+
+func NewWeightedStrategy(cfg TransportConfig, weights ScoreWeights) Weighted {
+	return Weighted{
+		profiles: map[courier.TransportType]TransportProfile{
+			courier.TransportOnFoot:  cfg.OnFoot,
+			courier.TransportScooter: cfg.Scooter,
+			courier.TransportCar:     cfg.Car,
+		},
+		weights: weights,
+	}
+}
+
+func (Weighted) Name() string {
+	return StrategyWeighted
+}
+
+func (w Weighted) Pick(candidates []courier.Candidate, order AssignmentOrder) *courier.Courier {
+	var (
+		best      *courier.Candidate
+		bestScore float64
+	)
+
+	for i := range candidates {
+		score := w.Score(candidates[i], order)
+		if best == nil || score < bestScore || (score == bestScore && lessLoaded(candidates[i], *best)) {
+			best = &candidates[i]
+			bestScore = score
+		}
+	}
+
+	if best == nil {
+		return nil
+	}
+	return &best.Courier
+}
+
+// Score - оценка кандидата, меньше - лучше
+func (w Weighted) Score(c courier.Candidate, order AssignmentOrder) float64 {
+	return w.weights.Load*float64(c.Active) +
+		w.weights.ETA*w.etaMinutes(c, order) +
+		w.weights.Fairness*float64(c.Deliveries)
+}
+
+// etaMinutes - время пути до точки доставки, без позиции - стандартное время транспорта
+func (w Weighted) etaMinutes(c courier.Candidate, order AssignmentOrder) float64 {
+	profile := w.profiles[c.Courier.TransportType]
+
+	distance, ok := order.Distances[c.Courier.ID]
+	if !ok || profile.AverageSpeedKmh <= 0 {
+		return profile.DefaultDuration.Minutes()
+	}
+	return distance / 1000 / profile.AverageSpeedKmh * 60
+}
+
+func transportSpeeds(cfg TransportConfig) map[courier.TransportType]float64 {
+	return map[courier.TransportType]float64{
+		courier.TransportOnFoot:  cfg.OnFoot.AverageSpeedKmh,
+		courier.TransportScooter: cfg.Scooter.AverageSpeedKmh,
+		courier.TransportCar:     cfg.Car.AverageSpeedKmh,
+	}
+}
+
+func envWeight(key string, fallback float64) float64 {
+	env := os.Getenv(key)
+	if env == "" {
+		return fallback
+	}
+	v, err := strconv.ParseFloat(env, 64)
+	if err != nil || v < 0 {
+		return fallback
+	}
+	return v
+}
@@ -0,0 +1,184 @@
+package delivery
+
+import (
+	"fmt"
+	"hash/fnv"
+	"log"
+	"os"
+	"service-courier/internal/model/courier"
+	"service-courier/internal/model/delivery"
+	"service-courier/internal/pkg/geo"
+	"strconv"
+	"strings"
+)
+
+// AssignmentStrategy выбирает курьера для заказа среди кандидатов со свободным местом.
+// Кандидаты приходят отсортированными по загрузке: активные доставки, все доставки, ID.
+type AssignmentStrategy interface {
+	Name() string
+	// Pick возвращает выбранного курьера или nil, если ни один кандидат не подходит
+	Pick(candidates []courier.Candidate, order AssignmentOrder) *courier.Courier
+}
+
+// AssignmentOrder - то, что стратегия знает о заказе
+type AssignmentOrder struct {
+	OrderID     string
+	Destination *geo.Point
+	Priority    delivery.Priority
+	// Distances - расстояние в метрах от актуальной позиции кандидата до точки доставки по ID курьера.
+	// Кандидатов без актуальной позиции в карте нет, при неизвестной точке карта пустая.
+	Distances map[int64]float64
+}
+
+const (
+	StrategyLeastLoaded      = "least_loaded"
+	StrategyRoundRobin       = "round_robin"
+	StrategyNearest          = "nearest"
+	StrategyFastestTransport = "fastest_transport"
+	StrategyWeighted         = "weighted"
+)
+
+// WithAssignmentStrategy задает политику выбора курьера, по умолчанию - ближайший
+func WithAssignmentStrategy(strategy AssignmentStrategy) Option {
+	return func(s *Service) {
+		s.strategy = strategy
+	}
+}
+
+// StrategyConfig - параметры, нужные встроенным стратегиям
+type StrategyConfig struct {
+	Transport TransportConfig
+	Weights   ScoreWeights
+}
+
+// LoadAssignmentStrategy читает стратегию из ASSIGNMENT_STRATEGY, например "nearest"
+// или "nearest:80,weighted:20" для A/B теста. При ошибке используется nearest.
+func LoadAssignmentStrategy(transport TransportConfig) AssignmentStrategy {
+	cfg := StrategyConfig{
+		Transport: transport,
+		Weights:   LoadScoreWeights(),
+	}
+
+	value := os.Getenv("ASSIGNMENT_STRATEGY")
+	if value == "" {
+		return NewNearestStrategy()
+	}
+
+	strategy, err := ParseAssignmentStrategy(value, cfg)
+	if err != nil {
+		log.Printf("invalid ASSIGNMENT_STRATEGY %q, using %s: %v", value, StrategyNearest, err)
+		return NewNearestStrategy()
+	}
+
+	return strategy
+}
+
+// ParseAssignmentStrategy разбирает имя стратегии или список "имя:вес" для разделения заказов между стратегиями
+func ParseAssignmentStrategy(value string, cfg StrategyConfig) (AssignmentStrategy, error) {
+	if !strings.Contains(value, ":") {
+		return NewAssignmentStrategy(strings.TrimSpace(value), cfg)
+	}
+
+	variants := make([]SplitVariant, 0)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		name, weight, ok := strings.Cut(item, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid strategy variant: %s", item)
+		}
+		w, err := strconv.Atoi(strings.TrimSpace(weight))
+		if err != nil || w <= 0 {
+			return nil, fmt.Errorf("invalid strategy weight: %s", item)
+		}
+		strategy, err := NewAssignmentStrategy(strings.TrimSpace(name), cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		variants = append(variants, SplitVariant{Strategy: strategy, Weight: w})
+	}
+
+	if len(variants) == 0 {
+		return nil, fmt.Errorf("no strategy variants")
+	}
+
+	return NewSplitStrategy(variants...), nil
+}
+
+// NewAssignmentStrategy создает встроенную стратегию по имени
+func NewAssignmentStrategy(name string, cfg StrategyConfig) (AssignmentStrategy, error) {
+	switch name {
+	case StrategyLeastLoaded:
+		return LeastLoaded{}, nil
+	case StrategyRoundRobin:
+		return NewRoundRobinStrategy(), nil
+	case StrategyNearest:
+		return NewNearestStrategy(), nil
+	case StrategyFastestTransport:
+		return NewFastestTransportStrategy(cfg.Transport), nil
+	case StrategyWeighted:
+		return NewWeightedStrategy(cfg.Transport, cfg.Weights), nil
+	default:
+		return nil, fmt.Errorf("unknown assignment strategy: %s", name)
+	}
+}
+
+// SplitVariant - стратегия и ее доля заказов
+type SplitVariant struct {
+	Strategy AssignmentStrategy
+	Weight   int
+}
+
+// SplitStrategy делит заказы между стратегиями по весам. Вариант выбирается по хешу order_id,
+// поэтому повторное назначение того же заказа попадает в тот же вариант.
+type SplitStrategy struct {
+	variants []SplitVariant
+	total    int
+}
+
+func NewSplitStrategy(variants ...SplitVariant) *SplitStrategy {
+	total := 0
+	for _, v := range variants {
+		total += v.Weight
+	}
+	return &SplitStrategy{variants: variants, total: total}
+}
+
+func (s *SplitStrategy) Name() string {
+	names := make([]string, 0, len(s.variants))
+	for _, v := range s.variants {
+		names = append(names, fmt.Sprintf("%s:%d", v.Strategy.Name(), v.Weight))
+	}
+	return strings.Join(names, ",")
+}
+
+func (s *SplitStrategy) Pick(candidates []courier.Candidate, order AssignmentOrder) *courier.Courier {
+	return s.Variant(order.OrderID).Pick(candidates, order)
+}
+
+// Variant возвращает стратегию, которой достается заказ
+func (s *SplitStrategy) Variant(orderID string) AssignmentStrategy {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(orderID))
+	bucket := int(h.Sum32() % uint32(s.total))
+
+	for _, v := range s.variants {
+		if bucket < v.Weight {
+			return v.Strategy
+		}
+		bucket -= v.Weight
+	}
+	return s.variants[len(s.variants)-1].Strategy
+}
+
+// strategyName - имя стратегии, фактически выбравшей курьера для заказа
+func strategyName(strategy AssignmentStrategy, orderID string) string {
+	if split, ok := strategy.(*SplitStrategy); ok {
+		return split.Variant(orderID).Name()
+	}
+	return strategy.Name()
+}
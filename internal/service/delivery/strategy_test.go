@@ -0,0 +1,283 @@
+package delivery_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	modelCourier "service-courier/internal/model/courier"
+	modelDelivery "service-courier/internal/model/delivery"
+	"service-courier/internal/pkg/geo"
+	deliveryService "service-courier/internal/service/delivery"
+	"service-courier/internal/service/delivery/mocks"
+)
+
+func candidate(id int64, transport modelCourier.TransportType, active, deliveries int64) modelCourier.Candidate {
+	return modelCourier.Candidate{
+		Courier:    modelCourier.Courier{ID: id, TransportType: transport},
+		Active:     active,
+		Deliveries: deliveries,
+	}
+}
+
+func pickedID(c *modelCourier.Courier) int64 {
+	if c == nil {
+		return 0
+	}
+	return c.ID
+}
+
+func TestStrategies_Pick(t *testing.T) {
+	t.Parallel()
+
+	candidates := []modelCourier.Candidate{
+		candidate(1, modelCourier.TransportOnFoot, 1, 3),
+		candidate(2, modelCourier.TransportScooter, 0, 8),
+		candidate(3, modelCourier.TransportCar, 1, 1),
+		candidate(4, modelCourier.TransportOnFoot, 0, 2),
+	}
+	cfg := deliveryService.DefaultTransportConfig()
+
+	tests := []struct {
+		name     string
+		strategy deliveryService.AssignmentStrategy
+		order    deliveryService.AssignmentOrder
+		expected int64
+	}{
+		{
+			name:     "least loaded",
+			strategy: deliveryService.LeastLoaded{},
+			expected: 4,
+		},
+		{
+			name:     "nearest by known distances",
+			strategy: deliveryService.NewNearestStrategy(),
+			order:    deliveryService.AssignmentOrder{Distances: map[int64]float64{1: 300, 3: 2500}},
+			expected: 1,
+		},
+		{
+			name:     "nearest without distances falls back to least loaded",
+			strategy: deliveryService.NewNearestStrategy(),
+			expected: 4,
+		},
+		{
+			name:     "fastest transport",
+			strategy: deliveryService.NewFastestTransportStrategy(cfg),
+			expected: 3,
+		},
+		{
+			name:     "weighted prefers idle courier close to destination",
+			strategy: deliveryService.NewWeightedStrategy(cfg, deliveryService.DefaultScoreWeights()),
+			// пешком 500 м - 6 минут, на машине 4 км - 8 минут и одна активная доставка
+			order:    deliveryService.AssignmentOrder{Distances: map[int64]float64{3: 4000, 4: 500}},
+			expected: 4,
+		},
+		{
+			name:     "weighted by eta only",
+			strategy: deliveryService.NewWeightedStrategy(cfg, deliveryService.ScoreWeights{ETA: 1}),
+			// машина 4 км - 8 минут против 30 минут пешком без позиции
+			order:    deliveryService.AssignmentOrder{Distances: map[int64]float64{3: 4000}},
+			expected: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, pickedID(tt.strategy.Pick(candidates, tt.order)))
+		})
+	}
+}
+
+func TestStrategies_NoCandidates(t *testing.T) {
+	t.Parallel()
+
+	cfg := deliveryService.DefaultTransportConfig()
+	for _, name := range []string{
+		deliveryService.StrategyLeastLoaded,
+		deliveryService.StrategyRoundRobin,
+		deliveryService.StrategyNearest,
+		deliveryService.StrategyFastestTransport,
+		deliveryService.StrategyWeighted,
+	} {
+		strategy, err := deliveryService.NewAssignmentStrategy(name, deliveryService.StrategyConfig{Transport: cfg})
+		require.NoError(t, err)
+		assert.Equal(t, name, strategy.Name())
+		assert.Nil(t, strategy.Pick(nil, deliveryService.AssignmentOrder{}), name)
+	}
+}
+
+func TestRoundRobin_CyclesThroughCandidates(t *testing.T) {
+	t.Parallel()
+
+	strategy := deliveryService.NewRoundRobinStrategy()
+	candidates := []modelCourier.Candidate{
+		candidate(3, modelCourier.TransportCar, 0, 0),
+		candidate(1, modelCourier.TransportCar, 0, 0),
+		candidate(2, modelCourier.TransportCar, 0, 0),
+	}
+
+	picked := make([]int64, 0, 4)
+	for range 4 {
+		picked = append(picked, pickedID(strategy.Pick(candidates, deliveryService.AssignmentOrder{})))
+	}
+	assert.Equal(t, []int64{1, 2, 3, 1}, picked)
+
+	// курьер 2 заполнен - очередь переходит к следующему
+	assert.Equal(t, int64(3), pickedID(strategy.Pick(candidates[:2], deliveryService.AssignmentOrder{})))
+}
+
+func TestParseAssignmentStrategy(t *testing.T) {
+	t.Parallel()
+	cfg := deliveryService.StrategyConfig{Transport: deliveryService.DefaultTransportConfig()}
+
+	strategy, err := deliveryService.ParseAssignmentStrategy("round_robin", cfg)
+	require.NoError(t, err)
+	assert.Equal(t, deliveryService.StrategyRoundRobin, strategy.Name())
+
+	strategy, err = deliveryService.ParseAssignmentStrategy("nearest:80, weighted:20", cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "nearest:80,weighted:20", strategy.Name())
+
+	for _, invalid := range []string{"random", "nearest:0", "nearest:x", "nearest:50,random:50"} {
+		_, err := deliveryService.ParseAssignmentStrategy(invalid, cfg)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestSplitStrategy_StableVariantPerOrder(t *testing.T) {
+	t.Parallel()
+
+	split := deliveryService.NewSplitStrategy(
+		deliveryService.SplitVariant{Strategy: deliveryService.LeastLoaded{}, Weight: 50},
+		deliveryService.SplitVariant{Strategy: deliveryService.NewFastestTransportStrategy(deliveryService.DefaultTransportConfig()), Weight: 50},
+	)
+
+	seen := make(map[string]int)
+	for i := range 200 {
+		orderID := time.Date(2024, 1, 1, 0, 0, i, 0, time.UTC).Format(time.RFC3339)
+		variant := split.Variant(orderID)
+		assert.Equal(t, variant, split.Variant(orderID))
+		seen[variant.Name()]++
+	}
+
+	// оба варианта получают заказы
+	assert.Greater(t, seen[deliveryService.StrategyLeastLoaded], 50)
+	assert.Greater(t, seen[deliveryService.StrategyFastestTransport], 50)
+}
+
+func TestAssignCourier_UsesConfiguredStrategy(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	deliveryRepo := mocks.NewMockdeliveryRepository(ctrl)
+	courierRepo := mocks.NewMockcourierRepository(ctrl)
+	txManager := mocks.NewMocktransactionManager(ctrl)
+	txManager.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		}).
+		AnyTimes()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	service := deliveryService.NewDeliveryService(
+		deliveryRepo,
+		courierRepo,
+		deliveryService.NewTransportFactory(),
+		txManager,
+		deliveryService.NewFixedClock(now),
+		deliveryService.WithAssignmentStrategy(deliveryService.NewFastestTransportStrategy(deliveryService.DefaultTransportConfig())),
+	)
+
+	orderID := "f819526d-6a7c-48eb-b535-43989469d1ca"
+	deliveryRepo.EXPECT().GetByOrderID(gomock.Any(), orderID).Return(nil, modelDelivery.ErrDeliveryNotFound)
+	courierRepo.EXPECT().
+		ListAvailableWithDeliveries(gomock.Any(), gomock.Any()).
+		Return([]modelCourier.Candidate{
+			candidate(1, modelCourier.TransportOnFoot, 0, 0),
+			candidate(2, modelCourier.TransportCar, 2, 10),
+		}, nil)
+	expectReserved(courierRepo, deliveryRepo, candidate(2, modelCourier.TransportCar, 2, 10).Courier)
+	deliveryRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+	deliveryRepo.EXPECT().CreateEvents(gomock.Any(), gomock.Any()).Return(nil)
+	courierRepo.EXPECT().UpdateStatusFrom(gomock.Any(), gomock.Any(), gomock.Any(), modelCourier.CourierStatus(modelCourier.StatusBusy)).Return(true, nil)
+
+	result, err := service.AssignCourier(context.Background(), orderID, modelDelivery.PriorityStandard)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), result.CourierID)
+	assert.Equal(t, now.Add(5*time.Minute), result.Deadline)
+}
+
+// distancesStrategy запоминает расстояния, которые сервис передал стратегии
+type distancesStrategy struct {
+	distances map[int64]float64
+}
+
+func (s *distancesStrategy) Name() string {
+	return "test"
+}
+
+func (s *distancesStrategy) Pick(candidates []modelCourier.Candidate, order deliveryService.AssignmentOrder) *modelCourier.Courier {
+	s.distances = order.Distances
+	return &candidates[0].Courier
+}
+
+type fixedLocator struct {
+	point geo.Point
+}
+
+func (l fixedLocator) Locate(context.Context, string) (*geo.Point, error) {
+	return &l.point, nil
+}
+
+func TestAssignCourier_PassesOnlyFreshDistances(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	deliveryRepo := mocks.NewMockdeliveryRepository(ctrl)
+	courierRepo := mocks.NewMockcourierRepository(ctrl)
+	txManager := mocks.NewMocktransactionManager(ctrl)
+	txManager.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		}).
+		AnyTimes()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	destination := geo.Point{Lat: 55.7580, Lon: 37.6130}
+	strategy := &distancesStrategy{}
+	service := deliveryService.NewDeliveryService(
+		deliveryRepo,
+		courierRepo,
+		deliveryService.NewTransportFactory(),
+		txManager,
+		deliveryService.NewFixedClock(now),
+		deliveryService.WithLocator(fixedLocator{point: destination}),
+		deliveryService.WithAssignmentStrategy(strategy),
+	)
+
+	orderID := "f819526d-6a7c-48eb-b535-43989469d1ca"
+	fresh := candidate(1, modelCourier.TransportCar, 0, 0)
+	fresh.Courier.Location = &modelCourier.Location{Point: destination, UpdatedAt: now.Add(-time.Minute)}
+	stale := candidate(2, modelCourier.TransportCar, 0, 0)
+	stale.Courier.Location = &modelCourier.Location{Point: destination, UpdatedAt: now.Add(-time.Hour)}
+
+	deliveryRepo.EXPECT().GetByOrderID(gomock.Any(), orderID).Return(nil, modelDelivery.ErrDeliveryNotFound)
+	courierRepo.EXPECT().
+		ListAvailableWithDeliveries(gomock.Any(), gomock.Any()).
+		Return([]modelCourier.Candidate{fresh, stale}, nil)
+	expectReserved(courierRepo, deliveryRepo, fresh.Courier)
+	deliveryRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+	deliveryRepo.EXPECT().CreateEvents(gomock.Any(), gomock.Any()).Return(nil)
+	courierRepo.EXPECT().UpdateStatusFrom(gomock.Any(), gomock.Any(), gomock.Any(), modelCourier.CourierStatus(modelCourier.StatusBusy)).Return(true, nil)
+
+	_, err := service.AssignCourier(context.Background(), orderID, modelDelivery.PriorityStandard)
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]float64{1: 0}, strategy.distances)
+}
@@ -0,0 +1,106 @@
+package delivery
+
+import (
+	"context"
+	"fmt"
+	"service-courier/internal/metrics"
+	"service-courier/internal/model/delivery"
+	"time"
+)
+
+// EventStreamConfig - параметры подписки на события доставки
+type EventStreamConfig struct {
+	// PollInterval - как часто подписка проверяет новые события
+	PollInterval time.Duration
+	// BatchSize - сколько событий читается и отдается подписчику за раз
+	BatchSize uint64
+	// MaxStreams - сколько подписок обслуживается одновременно, остальным отказ
+	MaxStreams int64
+}
+
+func DefaultEventStreamConfig() EventStreamConfig {
+	return EventStreamConfig{
+		PollInterval: time.Second,
+		BatchSize:    100,
+		MaxStreams:   1000,
+	}
+}
+
+// LoadEventStreamConfig читает параметры подписки из окружения поверх значений по умолчанию
+func LoadEventStreamConfig() EventStreamConfig {
+	cfg := DefaultEventStreamConfig()
+	cfg.PollInterval = time.Duration(envInt("EVENT_STREAM_POLL_MS", int(cfg.PollInterval.Milliseconds()))) * time.Millisecond
+	cfg.BatchSize = uint64(envInt("EVENT_STREAM_BATCH_SIZE", int(cfg.BatchSize)))
+	cfg.MaxStreams = int64(envInt("EVENT_STREAM_MAX", int(cfg.MaxStreams)))
+	return cfg
+}
+
+func WithEventStream(cfg EventStreamConfig) Option {
+	return func(s *Service) {
+		s.eventStream = cfg
+	}
+}
+
+// WatchEvents отдает в send события доставки заказа или курьера, записанные после filter.AfterID,
+// пока не отменят ctx или не вызовут StopEventStreams. События читаются из истории, поэтому
+// подписчик видит изменения из всех процессов сервиса и может продолжить с последнего полученного id.
+// События еще не завершенных транзакций придерживаются, пока транзакции не закончатся,
+// чтобы курсор не проскочил события, которые закоммитятся позже.
+// send вызывается после каждого опроса, в том числе с пустой пачкой - подписчик может слать heartbeat.
+// Следующая пачка читается только после того, как send вернулся: медленный подписчик
+// не копит события в памяти, а отстает и догоняет по истории.
+func (s *Service) WatchEvents(ctx context.Context, filter delivery.EventFilter, send func([]delivery.Event) error) error {
+	if s.streams.Add(1) > s.eventStream.MaxStreams {
+		s.streams.Add(-1)
+		return delivery.ErrTooManyStreams
+	}
+	defer s.streams.Add(-1)
+
+	metrics.DeliveryEventStreams.Inc()
+	defer metrics.DeliveryEventStreams.Dec()
+
+	filter.Limit = s.eventStream.BatchSize
+
+	ticker := time.NewTicker(s.eventStream.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		events, err := s.deliveryRepo.ListEventsAfter(ctx, filter)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("list delivery events: %w", err)
+		}
+
+		if err := send(events); err != nil {
+			return err
+		}
+		if len(events) > 0 {
+			filter.AfterID = events[len(events)-1].ID
+		}
+
+		// полная пачка - есть отставание, читаем дальше без ожидания
+		if filter.Limit > 0 && uint64(len(events)) == filter.Limit {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.streamsDone:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// StopEventStreams завершает все подписки, чтобы остановка сервера их не ждала
+func (s *Service) StopEventStreams() {
+	s.stopStreams.Do(func() {
+		close(s.streamsDone)
+	})
+}
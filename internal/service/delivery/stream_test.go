@@ -0,0 +1,137 @@
+package delivery_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	modelDelivery "service-courier/internal/model/delivery"
+	deliveryService "service-courier/internal/service/delivery"
+	"service-courier/internal/service/delivery/mocks"
+)
+
+func newStreamService(ctrl *gomock.Controller, cfg deliveryService.EventStreamConfig) (*deliveryService.Service, *mocks.MockdeliveryRepository) {
+	mockDeliveryRepo := mocks.NewMockdeliveryRepository(ctrl)
+	service := deliveryService.NewDeliveryService(
+		mockDeliveryRepo,
+		mocks.NewMockcourierRepository(ctrl),
+		deliveryService.NewTransportFactory(),
+		mocks.NewMocktransactionManager(ctrl),
+		deliveryService.NewFixedClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)),
+		deliveryService.WithEventStream(cfg),
+	)
+	return service, mockDeliveryRepo
+}
+
+func TestWatchEvents_ResumesAfterLastSentEvent(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockDeliveryRepo := newStreamService(ctrl, deliveryService.EventStreamConfig{
+		PollInterval: time.Millisecond,
+		BatchSize:    2,
+		MaxStreams:   1,
+	})
+
+	orderID := "f819526d-6a7c-48eb-b535-43989469d1ca"
+
+	gomock.InOrder(
+		// полная пачка - следующая читается сразу с последнего id
+		mockDeliveryRepo.EXPECT().
+			ListEventsAfter(gomock.Any(), modelDelivery.EventFilter{OrderID: orderID, AfterID: 5, Limit: 2}).
+			Return([]modelDelivery.Event{{ID: 6, OrderID: orderID}, {ID: 8, OrderID: orderID}}, nil),
+		mockDeliveryRepo.EXPECT().
+			ListEventsAfter(gomock.Any(), modelDelivery.EventFilter{OrderID: orderID, AfterID: 8, Limit: 2}).
+			Return([]modelDelivery.Event{}, nil),
+		mockDeliveryRepo.EXPECT().
+			ListEventsAfter(gomock.Any(), modelDelivery.EventFilter{OrderID: orderID, AfterID: 8, Limit: 2}).
+			Return([]modelDelivery.Event{{ID: 9, OrderID: orderID, ToStatus: modelDelivery.StatusCompleted}}, nil),
+	)
+
+	var received []int64
+	errStop := errors.New("stop")
+	err := service.WatchEvents(context.Background(), modelDelivery.EventFilter{OrderID: orderID, AfterID: 5},
+		func(events []modelDelivery.Event) error {
+			for _, e := range events {
+				received = append(received, e.ID)
+			}
+			if len(received) == 3 {
+				return errStop
+			}
+			return nil
+		})
+
+	if !errors.Is(err, errStop) {
+		t.Fatalf("expected send error, got %v", err)
+	}
+	if len(received) != 3 || received[0] != 6 || received[1] != 8 || received[2] != 9 {
+		t.Errorf("expected events 6, 8, 9, got %v", received)
+	}
+}
+
+func TestWatchEvents_RejectsOverLimit(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockDeliveryRepo := newStreamService(ctrl, deliveryService.EventStreamConfig{
+		PollInterval: time.Hour,
+		BatchSize:    10,
+		MaxStreams:   1,
+	})
+
+	polled := make(chan struct{})
+	mockDeliveryRepo.EXPECT().
+		ListEventsAfter(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, modelDelivery.EventFilter) ([]modelDelivery.Event, error) {
+			close(polled)
+			return nil, nil
+		})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- service.WatchEvents(ctx, modelDelivery.EventFilter{CourierID: 10}, func([]modelDelivery.Event) error {
+			return nil
+		})
+	}()
+	<-polled
+
+	err := service.WatchEvents(ctx, modelDelivery.EventFilter{CourierID: 20}, func([]modelDelivery.Event) error {
+		return nil
+	})
+	if !errors.Is(err, modelDelivery.ErrTooManyStreams) {
+		t.Fatalf("expected ErrTooManyStreams, got %v", err)
+	}
+
+	service.StopEventStreams()
+	if err := <-done; err != nil {
+		t.Errorf("expected stopped stream to return nil, got %v", err)
+	}
+}
+
+func TestEventKind(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		event modelDelivery.Event
+		want  string
+	}{
+		{modelDelivery.Event{ToStatus: modelDelivery.StatusAssigned}, "assigned"},
+		{modelDelivery.Event{ToStatus: modelDelivery.StatusDeleted}, "unassigned"},
+		{modelDelivery.Event{ToStatus: modelDelivery.StatusCompleted, Actor: modelDelivery.ActorHTTP}, "completed"},
+		{modelDelivery.Event{ToStatus: modelDelivery.StatusCompleted, Actor: modelDelivery.ActorExpiry}, "expired"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.event.Kind(); got != tt.want {
+			t.Errorf("Kind(%s by %s) = %s, want %s", tt.event.ToStatus, tt.event.Actor, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"service-courier/internal/metrics"
+	"service-courier/internal/model/delivery"
+)
+
+// TransitionDelivery переводит доставку заказа в новый статус по правилам жизненного цикла.
+// При переходе в завершающий статус курьер освобождается, если у него не осталось других активных доставок.
+// Принятие предложенного заказа занимает курьера.
+func (s *Service) TransitionDelivery(ctx context.Context, orderID string, to delivery.DeliveryStatus) (*TransitionResult, error) {
+	var (
+		result   *TransitionResult
+		finished *delivery.Delivery
+		freed    bool
+	)
+
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		deliveryData, err := s.deliveryRepo.GetByOrderID(ctx, orderID)
+		if err != nil {
+			if errors.Is(err, delivery.ErrDeliveryNotFound) {
+				return delivery.ErrDeliveryNotFound
+			}
+			return fmt.Errorf("get delivery: %w", err)
+		}
+
+		if err := delivery.ValidateTransition(deliveryData.Status, to); err != nil {
+			return err
+		}
+
+		if deliveryData.Status == delivery.StatusOffered && s.offers != nil {
+			if to == delivery.StatusAccepted {
+				err = s.acceptOffer(ctx, *deliveryData)
+			} else {
+				err = s.withdrawOffer(ctx, *deliveryData)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := s.deliveryRepo.UpdateStatus(ctx, deliveryData.ID, deliveryData.Status, to); err != nil {
+			return fmt.Errorf("update delivery status: %w", err)
+		}
+
+		if err := s.recordEvents(ctx, s.newEvent(ctx, *deliveryData, to, "")); err != nil {
+			return err
+		}
+
+		if to.IsTerminal() {
+			freed, err = s.releaseIdleCouriers(ctx, deliveryData.CourierID)
+			if err != nil {
+				return err
+			}
+		}
+
+		if to == delivery.StatusDelivered {
+			finished = deliveryData
+		}
+
+		result = &TransitionResult{
+			OrderID:   orderID,
+			CourierID: deliveryData.CourierID,
+			From:      deliveryData.Status,
+			To:        to,
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("transition delivery transaction: %w", err)
+	}
+
+	if freed {
+		s.notifyFreed(ctx)
+	}
+	if finished != nil {
+		observeSLA(*finished, s.clock.Now())
+	}
+	metrics.OpsCounter.Inc()
+
+	return result, nil
+}
@@ -0,0 +1,228 @@
+package delivery_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	modelCourier "service-courier/internal/model/courier"
+	modelDelivery "service-courier/internal/model/delivery"
+	deliveryService "service-courier/internal/service/delivery"
+	"service-courier/internal/service/delivery/mocks"
+)
+
+func TestTransitionDelivery_Accept(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDeliveryRepo := mocks.NewMockdeliveryRepository(ctrl)
+	mockCourierRepo := mocks.NewMockcourierRepository(ctrl)
+	mockTxManager := mocks.NewMocktransactionManager(ctrl)
+
+	fixed := time.Date(2024, 1, 1, 12, 00, 00, 0, time.UTC)
+	service := deliveryService.NewDeliveryService(
+		mockDeliveryRepo,
+		mockCourierRepo,
+		deliveryService.NewTransportFactory(),
+		mockTxManager,
+		deliveryService.NewFixedClock(fixed),
+	)
+
+	orderID := "f819526d-6a7c-48eb-b535-43989469d1ca"
+
+	mockTxManager.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+
+	mockDeliveryRepo.EXPECT().
+		GetByOrderID(gomock.Any(), orderID).
+		Return(&modelDelivery.Delivery{ID: 1, OrderID: orderID, CourierID: 10, Status: modelDelivery.StatusAssigned}, nil)
+
+	mockDeliveryRepo.EXPECT().
+		UpdateStatus(gomock.Any(), int64(1), modelDelivery.DeliveryStatus(modelDelivery.StatusAssigned), modelDelivery.DeliveryStatus(modelDelivery.StatusAccepted)).
+		Return(nil)
+
+	mockDeliveryRepo.EXPECT().
+		CreateEvents(gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	result, err := service.TransitionDelivery(context.Background(), orderID, modelDelivery.StatusAccepted)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result.From != modelDelivery.StatusAssigned || result.To != modelDelivery.StatusAccepted {
+		t.Fatalf("unexpected transition %s -> %s", result.From, result.To)
+	}
+}
+
+func TestTransitionDelivery_DeliveredReleasesCourier(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDeliveryRepo := mocks.NewMockdeliveryRepository(ctrl)
+	mockCourierRepo := mocks.NewMockcourierRepository(ctrl)
+	mockTxManager := mocks.NewMocktransactionManager(ctrl)
+
+	fixed := time.Date(2024, 1, 1, 12, 00, 00, 0, time.UTC)
+	service := deliveryService.NewDeliveryService(
+		mockDeliveryRepo,
+		mockCourierRepo,
+		deliveryService.NewTransportFactory(),
+		mockTxManager,
+		deliveryService.NewFixedClock(fixed),
+	)
+
+	orderID := "f819526d-6a7c-48eb-b535-43989469d1ca"
+
+	mockTxManager.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+
+	mockDeliveryRepo.EXPECT().
+		GetByOrderID(gomock.Any(), orderID).
+		Return(&modelDelivery.Delivery{ID: 1, OrderID: orderID, CourierID: 10, Status: modelDelivery.StatusInTransit}, nil)
+
+	mockDeliveryRepo.EXPECT().
+		UpdateStatus(gomock.Any(), int64(1), gomock.Any(), modelDelivery.DeliveryStatus(modelDelivery.StatusDelivered)).
+		Return(nil)
+
+	mockDeliveryRepo.EXPECT().
+		CreateEvents(gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	mockDeliveryRepo.EXPECT().
+		CountActiveByCourierIDs(gomock.Any(), gomock.Any()).
+		Return(map[int64]int64{}, nil)
+
+	mockCourierRepo.EXPECT().
+		UpdateStatusFromBatch(gomock.Any(), []int64{10}, modelCourier.CourierStatus(modelCourier.StatusBusy), modelCourier.CourierStatus(modelCourier.StatusAvailable)).
+		Return([]int64{10}, nil)
+
+	if _, err := service.TransitionDelivery(context.Background(), orderID, modelDelivery.StatusDelivered); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestTransitionDelivery_InvalidTransition(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDeliveryRepo := mocks.NewMockdeliveryRepository(ctrl)
+	mockCourierRepo := mocks.NewMockcourierRepository(ctrl)
+	mockTxManager := mocks.NewMocktransactionManager(ctrl)
+
+	fixed := time.Date(2024, 1, 1, 12, 00, 00, 0, time.UTC)
+	service := deliveryService.NewDeliveryService(
+		mockDeliveryRepo,
+		mockCourierRepo,
+		deliveryService.NewTransportFactory(),
+		mockTxManager,
+		deliveryService.NewFixedClock(fixed),
+	)
+
+	orderID := "f819526d-6a7c-48eb-b535-43989469d1ca"
+
+	mockTxManager.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+
+	mockDeliveryRepo.EXPECT().
+		GetByOrderID(gomock.Any(), orderID).
+		Return(&modelDelivery.Delivery{ID: 1, OrderID: orderID, CourierID: 10, Status: modelDelivery.StatusAssigned}, nil)
+
+	_, err := service.TransitionDelivery(context.Background(), orderID, modelDelivery.StatusDelivered)
+	if !errors.Is(err, modelDelivery.ErrInvalidTransition) {
+		t.Fatalf("expected ErrInvalidTransition, got %v", err)
+	}
+
+	var transitionErr *modelDelivery.TransitionError
+	if !errors.As(err, &transitionErr) {
+		t.Fatalf("expected TransitionError, got %T", err)
+	}
+	if transitionErr.From != modelDelivery.StatusAssigned || transitionErr.To != modelDelivery.StatusDelivered {
+		t.Fatalf("unexpected transition error %v", transitionErr)
+	}
+}
+
+func TestUnassignCourier_DeliveredRejected(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDeliveryRepo := mocks.NewMockdeliveryRepository(ctrl)
+	mockCourierRepo := mocks.NewMockcourierRepository(ctrl)
+	mockTxManager := mocks.NewMocktransactionManager(ctrl)
+
+	fixed := time.Date(2024, 1, 1, 12, 00, 00, 0, time.UTC)
+	service := deliveryService.NewDeliveryService(
+		mockDeliveryRepo,
+		mockCourierRepo,
+		deliveryService.NewTransportFactory(),
+		mockTxManager,
+		deliveryService.NewFixedClock(fixed),
+	)
+
+	orderID := "f819526d-6a7c-48eb-b535-43989469d1ca"
+
+	mockTxManager.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+
+	mockDeliveryRepo.EXPECT().
+		GetByOrderID(gomock.Any(), orderID).
+		Return(&modelDelivery.Delivery{ID: 1, OrderID: orderID, CourierID: 10, Status: modelDelivery.StatusDelivered}, nil)
+
+	_, err := service.UnassignCourier(context.Background(), orderID)
+	if !errors.Is(err, modelDelivery.ErrInvalidTransition) {
+		t.Fatalf("expected ErrInvalidTransition, got %v", err)
+	}
+}
+
+func TestCompleteDelivery_AlreadyDelivered(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDeliveryRepo := mocks.NewMockdeliveryRepository(ctrl)
+	mockCourierRepo := mocks.NewMockcourierRepository(ctrl)
+	mockTxManager := mocks.NewMocktransactionManager(ctrl)
+
+	fixed := time.Date(2024, 1, 1, 12, 00, 00, 0, time.UTC)
+	service := deliveryService.NewDeliveryService(
+		mockDeliveryRepo,
+		mockCourierRepo,
+		deliveryService.NewTransportFactory(),
+		mockTxManager,
+		deliveryService.NewFixedClock(fixed),
+	)
+
+	orderID := "f819526d-6a7c-48eb-b535-43989469d1ca"
+
+	mockTxManager.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+
+	mockDeliveryRepo.EXPECT().
+		GetByOrderID(gomock.Any(), orderID).
+		Return(&modelDelivery.Delivery{ID: 1, OrderID: orderID, CourierID: 10, Status: modelDelivery.StatusDelivered}, nil)
+
+	if err := service.CompleteDelivery(context.Background(), orderID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
@@ -3,23 +3,54 @@ package delivery
 import "time"
 
 type Transport interface {
+	// DeliveryDuration - время доставки, когда расстояние неизвестно
 	DeliveryDuration() time.Duration
+	// Deadline - дедлайн доставки на заданное расстояние с учетом скорости, буфера и пробок
+	Deadline(assignedAt time.Time, distanceMeters float64) time.Time
+	// Capacity - сколько активных доставок курьер может везти одновременно
+	Capacity() int
 }
 
-type OnFoot struct{}
+// speedTransport - общая модель расчета для всех видов транспорта
+type speedTransport struct {
+	profile TransportProfile
+	traffic TrafficConfig
+}
+
+func (t speedTransport) DeliveryDuration() time.Duration {
+	return t.profile.DefaultDuration
+}
 
-func (OnFoot) DeliveryDuration() time.Duration {
-	return 30 * time.Minute
+func (t speedTransport) Capacity() int {
+	if t.profile.Capacity < 1 {
+		return 1
+	}
+	return t.profile.Capacity
 }
 
-type Scooter struct{}
+func (t speedTransport) Deadline(assignedAt time.Time, distanceMeters float64) time.Time {
+	if distanceMeters < 0 || t.profile.AverageSpeedKmh <= 0 {
+		return assignedAt.Add(t.DeliveryDuration())
+	}
+
+	metersPerSecond := t.profile.AverageSpeedKmh * 1000 / 3600
+	travel := time.Duration(distanceMeters / metersPerSecond * float64(time.Second))
 
-func (Scooter) DeliveryDuration() time.Duration {
-	return 15 * time.Minute
+	if t.profile.AffectedByTraffic {
+		travel = time.Duration(float64(travel) * t.traffic.Multiplier(assignedAt))
+	}
+
+	return assignedAt.Add(t.profile.HandoverBuffer + travel)
+}
+
+type OnFoot struct {
+	speedTransport
 }
 
-type Car struct{}
+type Scooter struct {
+	speedTransport
+}
 
-func (Car) DeliveryDuration() time.Duration {
-	return 5 * time.Minute
+type Car struct {
+	speedTransport
 }
@@ -0,0 +1,201 @@
+package delivery
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TransportProfile - параметры расчета дедлайна для вида транспорта
+type TransportProfile struct {
+	AverageSpeedKmh   float64
+	HandoverBuffer    time.Duration
+	DefaultDuration   time.Duration
+	AffectedByTraffic bool
+	Capacity          int
+}
+
+// TrafficWindow - множитель времени в пути для интервала часов [FromHour, ToHour)
+type TrafficWindow struct {
+	FromHour   int
+	ToHour     int
+	Multiplier float64
+}
+
+type TrafficConfig struct {
+	Windows  []TrafficWindow
+	Location *time.Location
+}
+
+func (c TrafficConfig) Multiplier(at time.Time) float64 {
+	if c.Location != nil {
+		at = at.In(c.Location)
+	}
+	hour := at.Hour()
+
+	for _, w := range c.Windows {
+		if w.FromHour <= w.ToHour && hour >= w.FromHour && hour < w.ToHour {
+			return w.Multiplier
+		}
+		// интервал через полночь, например 22-02
+		if w.FromHour > w.ToHour && (hour >= w.FromHour || hour < w.ToHour) {
+			return w.Multiplier
+		}
+	}
+
+	return 1
+}
+
+type TransportConfig struct {
+	OnFoot  TransportProfile
+	Scooter TransportProfile
+	Car     TransportProfile
+	Traffic TrafficConfig
+}
+
+func DefaultTransportConfig() TransportConfig {
+	return TransportConfig{
+		OnFoot: TransportProfile{
+			AverageSpeedKmh: 5,
+			HandoverBuffer:  5 * time.Minute,
+			DefaultDuration: 30 * time.Minute,
+			Capacity:        1,
+		},
+		Scooter: TransportProfile{
+			AverageSpeedKmh:   15,
+			HandoverBuffer:    5 * time.Minute,
+			DefaultDuration:   15 * time.Minute,
+			AffectedByTraffic: true,
+			Capacity:          2,
+		},
+		Car: TransportProfile{
+			AverageSpeedKmh:   30,
+			HandoverBuffer:    5 * time.Minute,
+			DefaultDuration:   5 * time.Minute,
+			AffectedByTraffic: true,
+			Capacity:          3,
+		},
+		Traffic: TrafficConfig{
+			Location: time.UTC,
+		},
+	}
+}
+
+// LoadTransportConfig читает параметры транспорта из окружения поверх значений по умолчанию
+func LoadTransportConfig() TransportConfig {
+	cfg := DefaultTransportConfig()
+
+	cfg.OnFoot.AverageSpeedKmh = envFloat("TRANSPORT_ON_FOOT_SPEED_KMH", cfg.OnFoot.AverageSpeedKmh)
+	cfg.Scooter.AverageSpeedKmh = envFloat("TRANSPORT_SCOOTER_SPEED_KMH", cfg.Scooter.AverageSpeedKmh)
+	cfg.Car.AverageSpeedKmh = envFloat("TRANSPORT_CAR_SPEED_KMH", cfg.Car.AverageSpeedKmh)
+
+	cfg.OnFoot.Capacity = envInt("TRANSPORT_ON_FOOT_CAPACITY", cfg.OnFoot.Capacity)
+	cfg.Scooter.Capacity = envInt("TRANSPORT_SCOOTER_CAPACITY", cfg.Scooter.Capacity)
+	cfg.Car.Capacity = envInt("TRANSPORT_CAR_CAPACITY", cfg.Car.Capacity)
+
+	buffer := envMinutes("DELIVERY_HANDOVER_BUFFER_MINUTES", cfg.OnFoot.HandoverBuffer)
+	cfg.OnFoot.HandoverBuffer = buffer
+	cfg.Scooter.HandoverBuffer = buffer
+	cfg.Car.HandoverBuffer = buffer
+
+	if tz := os.Getenv("TRAFFIC_TIMEZONE"); tz != "" {
+		location, err := time.LoadLocation(tz)
+		if err != nil {
+			log.Printf("invalid TRAFFIC_TIMEZONE %q, using UTC: %v", tz, err)
+		} else {
+			cfg.Traffic.Location = location
+		}
+	}
+
+	if windows := os.Getenv("TRAFFIC_MULTIPLIERS"); windows != "" {
+		parsed, err := ParseTrafficWindows(windows)
+		if err != nil {
+			log.Printf("invalid TRAFFIC_MULTIPLIERS %q, ignoring: %v", windows, err)
+		} else {
+			cfg.Traffic.Windows = parsed
+		}
+	}
+
+	return cfg
+}
+
+// ParseTrafficWindows разбирает строку вида "8-10:1.5,17-20:1.7"
+func ParseTrafficWindows(value string) ([]TrafficWindow, error) {
+	windows := make([]TrafficWindow, 0)
+
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		hours, multiplier, ok := strings.Cut(item, ":")
+		if !ok {
+			return nil, errInvalidTrafficWindow(item)
+		}
+		from, to, ok := strings.Cut(hours, "-")
+		if !ok {
+			return nil, errInvalidTrafficWindow(item)
+		}
+
+		fromHour, err := strconv.Atoi(strings.TrimSpace(from))
+		if err != nil || fromHour < 0 || fromHour > 23 {
+			return nil, errInvalidTrafficWindow(item)
+		}
+		toHour, err := strconv.Atoi(strings.TrimSpace(to))
+		if err != nil || toHour < 0 || toHour > 24 {
+			return nil, errInvalidTrafficWindow(item)
+		}
+		m, err := strconv.ParseFloat(strings.TrimSpace(multiplier), 64)
+		if err != nil || m <= 0 {
+			return nil, errInvalidTrafficWindow(item)
+		}
+
+		windows = append(windows, TrafficWindow{FromHour: fromHour, ToHour: toHour, Multiplier: m})
+	}
+
+	return windows, nil
+}
+
+func errInvalidTrafficWindow(item string) error {
+	return fmt.Errorf("invalid traffic window: %s", item)
+}
+
+func envFloat(key string, fallback float64) float64 {
+	env := os.Getenv(key)
+	if env == "" {
+		return fallback
+	}
+	v, err := strconv.ParseFloat(env, 64)
+	if err != nil || v <= 0 {
+		return fallback
+	}
+	return v
+}
+
+func envInt(key string, fallback int) int {
+	env := os.Getenv(key)
+	if env == "" {
+		return fallback
+	}
+	v, err := strconv.Atoi(env)
+	if err != nil || v <= 0 {
+		return fallback
+	}
+	return v
+}
+
+func envMinutes(key string, fallback time.Duration) time.Duration {
+	env := os.Getenv(key)
+	if env == "" {
+		return fallback
+	}
+	v, err := strconv.Atoi(env)
+	if err != nil || v < 0 {
+		return fallback
+	}
+	return time.Duration(v) * time.Minute
+}
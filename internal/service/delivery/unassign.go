@@ -5,42 +5,46 @@ import (
 	"errors"
 	"fmt"
 	"service-courier/internal/metrics"
-	"service-courier/internal/model/courier"
 	"service-courier/internal/model/delivery"
 )
 
 func (s *Service) UnassignCourier(ctx context.Context, orderID string) (*UnassignResult, error) {
-	var result *UnassignResult
+	var (
+		result *UnassignResult
+		freed  bool
+	)
 
 	err := s.txManager.Do(ctx, func(ctx context.Context) error {
 		deliveryData, err := s.deliveryRepo.GetByOrderID(ctx, orderID)
 		if err != nil {
 			if errors.Is(err, delivery.ErrDeliveryNotFound) {
-				return delivery.ErrDeliveryNotFound
+				result, err = s.dropPending(ctx, orderID)
+				return err
 			}
 			return fmt.Errorf("get delivery: %w", err)
 		}
 
+		if err := delivery.ValidateTransition(deliveryData.Status, delivery.StatusDeleted); err != nil {
+			return err
+		}
+
+		if err := s.withdrawOffer(ctx, *deliveryData); err != nil {
+			return err
+		}
+
 		courierID := deliveryData.CourierID
 
 		if err := s.deliveryRepo.DeleteByOrderID(ctx, orderID); err != nil {
 			return fmt.Errorf("delete delivery: %w", err)
 		}
 
-		courierData, err := s.courierRepo.GetByID(ctx, courierID)
-		if err != nil {
-			if errors.Is(err, courier.ErrCourierNotFound) {
-				return courier.ErrCourierNotFound
-			}
-			return fmt.Errorf("get courier: %w", err)
+		if err := s.recordEvents(ctx, s.newEvent(ctx, *deliveryData, delivery.StatusDeleted, "courier unassigned")); err != nil {
+			return err
 		}
 
-		courierData.Status = courier.StatusAvailable
-		if err := s.courierRepo.Update(ctx, *courierData); err != nil {
-			if errors.Is(err, courier.ErrCourierNotFound) {
-				return courier.ErrCourierNotFound
-			}
-			return fmt.Errorf("update courier status: %w", err)
+		freed, err = s.releaseIdleCouriers(ctx, courierID)
+		if err != nil {
+			return err
 		}
 
 		result = &UnassignResult{
@@ -56,7 +60,31 @@ func (s *Service) UnassignCourier(ctx context.Context, orderID string) (*Unassig
 		return nil, fmt.Errorf("unassign courier transaction: %w", err)
 	}
 
+	if freed {
+		s.notifyFreed(ctx)
+	}
 	metrics.OpsCounter.Inc()
 
 	return result, nil
 }
+
+// dropPending снимает с очереди заказ, которому курьер еще не назначен.
+// Без этого отмененный заказ получил бы курьера, когда тот освободится.
+func (s *Service) dropPending(ctx context.Context, orderID string) (*UnassignResult, error) {
+	if s.pending == nil {
+		return nil, delivery.ErrDeliveryNotFound
+	}
+
+	removed, err := s.pending.DeleteByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("delete pending assignment: %w", err)
+	}
+	if !removed {
+		return nil, delivery.ErrDeliveryNotFound
+	}
+
+	return &UnassignResult{
+		OrderID: orderID,
+		Status:  delivery.StatusUnassigned,
+	}, nil
+}
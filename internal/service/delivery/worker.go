@@ -3,9 +3,13 @@ package delivery
 import (
 	"context"
 	"log"
+	"service-courier/internal/model/delivery"
 	"time"
 )
 
+// expiredOffersBatch - сколько просроченных предложений закрывается за один проход
+const expiredOffersBatch = 100
+
 type Worker struct {
 	service  *Service
 	interval time.Duration
@@ -19,6 +23,8 @@ func NewWorker(service *Service, interval time.Duration) *Worker {
 }
 
 func (w *Worker) Start(ctx context.Context) {
+	ctx = WithChangeSource(ctx, delivery.ActorExpiry, "deadline expired")
+
 	ticker := time.NewTicker(w.interval)
 	defer ticker.Stop()
 
@@ -27,6 +33,7 @@ func (w *Worker) Start(ctx context.Context) {
 	if err := w.service.ReleaseExpiredCouriers(ctx); err != nil {
 		log.Printf("[Worker] Failed to release expired couriers on startup: %v", err)
 	}
+	w.expireOffers(ctx)
 
 	for {
 		select {
@@ -37,6 +44,15 @@ func (w *Worker) Start(ctx context.Context) {
 			if err := w.service.ReleaseExpiredCouriers(ctx); err != nil {
 				log.Printf("[Worker] Failed to release expired couriers: %v", err)
 			}
+			w.expireOffers(ctx)
 		}
 	}
 }
+
+// expireOffers передает следующим курьерам заказы, на которые не ответили вовремя
+func (w *Worker) expireOffers(ctx context.Context) {
+	ctx = WithChangeSource(ctx, delivery.ActorExpiry, "offer expired")
+	if err := w.service.ExpireOffers(ctx, expiredOffersBatch); err != nil {
+		log.Printf("[Worker] Failed to expire delivery offers: %v", err)
+	}
+}
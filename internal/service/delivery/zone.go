@@ -0,0 +1,41 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"service-courier/internal/model/zone"
+	"service-courier/internal/pkg/geo"
+)
+
+// ZoneResolver определяет зону доставки, в которую попадает точка
+type ZoneResolver interface {
+	Resolve(ctx context.Context, point geo.Point) (*zone.Zone, error)
+}
+
+// WithZones ограничивает выбор курьера зоной доставки заказа.
+// fallbackToNeighbors разрешает брать курьера из соседних зон, если в зоне заказа свободных нет.
+func WithZones(resolver ZoneResolver, fallbackToNeighbors bool) Option {
+	return func(s *Service) {
+		s.zones = resolver
+		s.zoneFallback = fallbackToNeighbors
+	}
+}
+
+// resolveZone находит зону заказа. Без координат зону не определить, и назначать курьера из
+// общего пула нельзя - иначе заказ может уйти курьеру из другого города.
+func (s *Service) resolveZone(ctx context.Context, destination *geo.Point) (*zone.Zone, error) {
+	if destination == nil {
+		return nil, zone.ErrOutsideZones
+	}
+
+	zoneData, err := s.zones.Resolve(ctx, *destination)
+	if err != nil {
+		if errors.Is(err, zone.ErrZoneNotFound) {
+			return nil, zone.ErrOutsideZones
+		}
+		return nil, fmt.Errorf("resolve zone: %w", err)
+	}
+
+	return zoneData, nil
+}
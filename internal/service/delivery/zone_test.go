@@ -0,0 +1,160 @@
+package delivery_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	modelCourier "service-courier/internal/model/courier"
+	modelDelivery "service-courier/internal/model/delivery"
+	"service-courier/internal/model/order"
+	modelZone "service-courier/internal/model/zone"
+	"service-courier/internal/pkg/geo"
+	deliveryService "service-courier/internal/service/delivery"
+	"service-courier/internal/service/delivery/mocks"
+)
+
+const zoneOrderID = "f819526d-6a7c-48eb-b535-43989469d1ca"
+
+type zoneMocks struct {
+	deliveryRepo *mocks.MockdeliveryRepository
+	courierRepo  *mocks.MockcourierRepository
+	orders       *mocks.MockorderProvider
+	geocoder     *mocks.Mockgeocoder
+	zones        *mocks.MockZoneResolver
+}
+
+func newZoneService(t *testing.T, fallback bool) (*deliveryService.Service, zoneMocks) {
+	ctrl := gomock.NewController(t)
+
+	m := zoneMocks{
+		deliveryRepo: mocks.NewMockdeliveryRepository(ctrl),
+		courierRepo:  mocks.NewMockcourierRepository(ctrl),
+		orders:       mocks.NewMockorderProvider(ctrl),
+		geocoder:     mocks.NewMockgeocoder(ctrl),
+		zones:        mocks.NewMockZoneResolver(ctrl),
+	}
+	txManager := mocks.NewMocktransactionManager(ctrl)
+	txManager.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		}).
+		AnyTimes()
+
+	service := deliveryService.NewDeliveryService(
+		m.deliveryRepo,
+		m.courierRepo,
+		deliveryService.NewTransportFactory(),
+		txManager,
+		deliveryService.NewFixedClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)),
+		deliveryService.WithLocator(deliveryService.NewOrderLocator(m.orders, m.geocoder)),
+		deliveryService.WithZones(m.zones, fallback),
+	)
+	return service, m
+}
+
+func (m zoneMocks) expectLocated(destination *geo.Point) {
+	address := order.Address{Street: "Тверская", House: "1"}
+	m.orders.EXPECT().
+		GetOrderByID(gomock.Any(), zoneOrderID).
+		Return(&order.Order{ID: zoneOrderID, Address: address}, nil)
+	m.geocoder.EXPECT().
+		Geocode(gomock.Any(), address).
+		Return(destination, nil)
+	m.deliveryRepo.EXPECT().
+		GetByOrderID(gomock.Any(), zoneOrderID).
+		Return(nil, modelDelivery.ErrDeliveryNotFound)
+}
+
+func (m zoneMocks) expectAssigned(courierID int64) {
+	expectReserved(m.courierRepo, m.deliveryRepo, modelCourier.Courier{ID: courierID, TransportType: modelCourier.TransportCar})
+	m.deliveryRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+	m.deliveryRepo.EXPECT().CreateEvents(gomock.Any(), gomock.Any()).Return(nil)
+	m.courierRepo.EXPECT().UpdateStatusFrom(gomock.Any(), gomock.Any(), gomock.Any(), modelCourier.CourierStatus(modelCourier.StatusBusy)).Return(true, nil)
+}
+
+func zoneFilter(ids ...int64) gomock.Matcher {
+	return gomock.Cond(func(x any) bool {
+		filter, ok := x.(modelCourier.AvailableFilter)
+		return ok && assert.ObjectsAreEqual(ids, filter.ZoneIDs)
+	})
+}
+
+func TestAssignCourier_PicksCourierFromOrderZone(t *testing.T) {
+	t.Parallel()
+	service, m := newZoneService(t, false)
+
+	destination := geo.Point{Lat: 55.7580, Lon: 37.6130}
+	m.expectLocated(&destination)
+	m.zones.EXPECT().
+		Resolve(gomock.Any(), destination).
+		Return(&modelZone.Zone{ID: 3, Neighbors: []int64{4}}, nil)
+	m.courierRepo.EXPECT().
+		ListAvailableWithDeliveries(gomock.Any(), zoneFilter(3)).
+		Return([]modelCourier.Candidate{{Courier: modelCourier.Courier{ID: 7, TransportType: modelCourier.TransportCar}}}, nil)
+	m.expectAssigned(7)
+
+	result, err := service.AssignCourier(context.Background(), zoneOrderID, modelDelivery.PriorityStandard)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), result.CourierID)
+}
+
+func TestAssignCourier_NoFallbackToNeighbors(t *testing.T) {
+	t.Parallel()
+	service, m := newZoneService(t, false)
+
+	destination := geo.Point{Lat: 55.7580, Lon: 37.6130}
+	m.expectLocated(&destination)
+	m.zones.EXPECT().
+		Resolve(gomock.Any(), destination).
+		Return(&modelZone.Zone{ID: 3, Neighbors: []int64{4}}, nil)
+	m.courierRepo.EXPECT().
+		ListAvailableWithDeliveries(gomock.Any(), zoneFilter(3)).
+		Return(nil, nil)
+
+	_, err := service.AssignCourier(context.Background(), zoneOrderID, modelDelivery.PriorityStandard)
+	assert.ErrorIs(t, err, modelCourier.ErrNoAvailableCouriers)
+}
+
+func TestAssignCourier_FallsBackToNeighborZones(t *testing.T) {
+	t.Parallel()
+	service, m := newZoneService(t, true)
+
+	destination := geo.Point{Lat: 55.7580, Lon: 37.6130}
+	m.expectLocated(&destination)
+	m.zones.EXPECT().
+		Resolve(gomock.Any(), destination).
+		Return(&modelZone.Zone{ID: 3, Neighbors: []int64{4, 5}}, nil)
+	gomock.InOrder(
+		m.courierRepo.EXPECT().
+			ListAvailableWithDeliveries(gomock.Any(), zoneFilter(3)).
+			Return(nil, nil),
+		m.courierRepo.EXPECT().
+			ListAvailableWithDeliveries(gomock.Any(), zoneFilter(4, 5)).
+			Return([]modelCourier.Candidate{{Courier: modelCourier.Courier{ID: 8, TransportType: modelCourier.TransportCar}}}, nil),
+	)
+	m.expectAssigned(8)
+
+	result, err := service.AssignCourier(context.Background(), zoneOrderID, modelDelivery.PriorityStandard)
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), result.CourierID)
+}
+
+func TestAssignCourier_OrderOutsideZones(t *testing.T) {
+	t.Parallel()
+	service, m := newZoneService(t, true)
+
+	destination := geo.Point{Lat: 10, Lon: 10}
+	m.expectLocated(&destination)
+	m.zones.EXPECT().
+		Resolve(gomock.Any(), destination).
+		Return(nil, modelZone.ErrZoneNotFound)
+
+	_, err := service.AssignCourier(context.Background(), zoneOrderID, modelDelivery.PriorityStandard)
+	assert.ErrorIs(t, err, modelZone.ErrOutsideZones)
+}
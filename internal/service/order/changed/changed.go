@@ -4,24 +4,82 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	modelDelivery "service-courier/internal/model/delivery"
+	"service-courier/internal/model/inbox"
 	"service-courier/internal/model/order"
+	"service-courier/internal/pkg/geo"
+	"service-courier/internal/service/delivery"
 )
 
 type Usecase struct {
-	delivery deliveryService
+	delivery  deliveryService
+	inbox     inboxRepository
+	txManager transactionManager
 }
 
-func NewUsecase(delivery deliveryService) *Usecase {
+func NewUsecase(delivery deliveryService, inbox inboxRepository, txManager transactionManager) *Usecase {
 	return &Usecase{
-		delivery: delivery,
+		delivery:  delivery,
+		inbox:     inbox,
+		txManager: txManager,
 	}
 }
 
+// ProcessOnce обрабатывает сообщение не более одного раза: отметка в inbox
+// и изменения доставки коммитятся в одной транзакции. Точка доставки ищется до транзакции,
+// чтобы запрос к сервису заказов не держал ее открытой, а очередь ожидания будится после коммита.
+func (u *Usecase) ProcessOnce(ctx context.Context, id inbox.MessageID, o order.Order) error {
+	destination := u.locate(ctx, o)
+	ctx, wake := u.delivery.DeferWakeups(ctx)
+
+	err := u.txManager.Do(ctx, func(ctx context.Context) error {
+		registered, err := u.inbox.Register(ctx, id)
+		if err != nil {
+			return fmt.Errorf("register inbox message: %w", err)
+		}
+
+		if !registered {
+			log.Printf("order.changed usecase: skip duplicate message %s/%d/%d", id.Topic, id.Partition, id.Offset)
+			return nil
+		}
+
+		return u.process(ctx, o, destination)
+	})
+	if err != nil {
+		return err
+	}
+
+	wake()
+	return nil
+}
+
 func (u *Usecase) Process(ctx context.Context, o order.Order) error {
+	return u.process(ctx, o, u.locate(ctx, o))
+}
+
+// locate ищет точку доставки только для нового заказа - при других статусах она не нужна
+func (u *Usecase) locate(ctx context.Context, o order.Order) *geo.Point {
+	if o.Status != order.StatusCreated {
+		return nil
+	}
+	return u.delivery.LocateOrder(ctx, o.ID)
+}
+
+func (u *Usecase) process(ctx context.Context, o order.Order, destination *geo.Point) error {
+	ctx = delivery.WithChangeSource(ctx, modelDelivery.ActorKafka, "order "+o.Status)
+
 	switch o.Status {
 	case order.StatusCreated:
-		if _, err := u.delivery.AssignCourier(ctx, o.ID); err != nil && !errors.Is(err, modelDelivery.ErrOrderAlreadyAssigned) {
+		priority, err := modelDelivery.ParsePriority(o.Priority)
+		if err != nil {
+			log.Printf("order.changed usecase: order %s has unknown priority %q, using standard", o.ID, o.Priority)
+			priority = modelDelivery.PriorityStandard
+		}
+
+		// заказ в очереди ожидания курьера считается обработанным
+		_, err = u.delivery.AssignCourierAt(ctx, o.ID, priority, destination)
+		if err != nil && !errors.Is(err, modelDelivery.ErrOrderAlreadyAssigned) && !errors.Is(err, modelDelivery.ErrAssignmentPending) {
 			return fmt.Errorf("assign courier: %w", err)
 		}
 	case order.StatusCancelled:
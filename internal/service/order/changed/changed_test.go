@@ -0,0 +1,225 @@
+package changed_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	modelDelivery "service-courier/internal/model/delivery"
+	modelInbox "service-courier/internal/model/inbox"
+	modelOrder "service-courier/internal/model/order"
+	"service-courier/internal/pkg/geo"
+	"service-courier/internal/service/delivery"
+	"service-courier/internal/service/order/changed"
+	"service-courier/internal/service/order/changed/mocks"
+)
+
+func newUsecase(ctrl *gomock.Controller) (*changed.Usecase, *mocks.MockdeliveryService, *mocks.MockinboxRepository) {
+	mockDelivery := mocks.NewMockdeliveryService(ctrl)
+	mockInbox := mocks.NewMockinboxRepository(ctrl)
+	mockTxManager := mocks.NewMocktransactionManager(ctrl)
+
+	mockTxManager.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		}).
+		AnyTimes()
+
+	return changed.NewUsecase(mockDelivery, mockInbox, mockTxManager), mockDelivery, mockInbox
+}
+
+// expectDeferredWakeups возвращает флаг, который выставляется, когда usecase будит очередь ожидания
+func expectDeferredWakeups(mockDelivery *mocks.MockdeliveryService) *bool {
+	woken := new(bool)
+	mockDelivery.EXPECT().
+		DeferWakeups(gomock.Any()).
+		DoAndReturn(func(ctx context.Context) (context.Context, func()) {
+			return ctx, func() { *woken = true }
+		})
+	return woken
+}
+
+func TestProcessOnce_NewMessageAssignsCourier(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, mockDelivery, mockInbox := newUsecase(ctrl)
+
+	id := modelInbox.MessageID{Topic: "order.status.changed", Partition: 0, Offset: 7}
+	point := &geo.Point{Lat: 55.75, Lon: 37.61}
+
+	mockDelivery.EXPECT().LocateOrder(gomock.Any(), "order-1").Return(point)
+	woken := expectDeferredWakeups(mockDelivery)
+
+	mockInbox.EXPECT().
+		Register(gomock.Any(), id).
+		Return(true, nil)
+
+	mockDelivery.EXPECT().
+		AssignCourierAt(gomock.Any(), "order-1", modelDelivery.PriorityStandard, point).
+		Return(&delivery.AssignResult{OrderID: "order-1"}, nil)
+
+	err := uc.ProcessOnce(context.Background(), id, modelOrder.Order{ID: "order-1", Status: modelOrder.StatusCreated})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !*woken {
+		t.Error("expected pending queue to be woken after commit")
+	}
+}
+
+func TestProcessOnce_DuplicateSkipped(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, mockDelivery, mockInbox := newUsecase(ctrl)
+
+	mockDelivery.EXPECT().LocateOrder(gomock.Any(), "order-1").Return(nil)
+	expectDeferredWakeups(mockDelivery)
+	mockInbox.EXPECT().
+		Register(gomock.Any(), gomock.Any()).
+		Return(false, nil)
+
+	err := uc.ProcessOnce(context.Background(), modelInbox.MessageID{Topic: "t"}, modelOrder.Order{ID: "order-1", Status: modelOrder.StatusCreated})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestProcessOnce_ProcessErrorIsReturned(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, mockDelivery, mockInbox := newUsecase(ctrl)
+
+	dbErr := errors.New("connection reset")
+
+	woken := expectDeferredWakeups(mockDelivery)
+	mockInbox.EXPECT().
+		Register(gomock.Any(), gomock.Any()).
+		Return(true, nil)
+
+	mockDelivery.EXPECT().
+		CompleteDelivery(gomock.Any(), "order-1").
+		Return(dbErr)
+
+	err := uc.ProcessOnce(context.Background(), modelInbox.MessageID{Topic: "t"}, modelOrder.Order{ID: "order-1", Status: modelOrder.StatusCompleted})
+	if !errors.Is(err, dbErr) {
+		t.Fatalf("expected %v, got %v", dbErr, err)
+	}
+	if *woken {
+		t.Error("expected no wakeup for rolled back transaction")
+	}
+}
+
+func TestProcess_AlreadyAssignedIgnored(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc, mockDelivery, _ := newUsecase(ctrl)
+
+	mockDelivery.EXPECT().LocateOrder(gomock.Any(), "order-1").Return(nil)
+	mockDelivery.EXPECT().
+		AssignCourierAt(gomock.Any(), "order-1", modelDelivery.PriorityStandard, nil).
+		Return(nil, modelDelivery.ErrOrderAlreadyAssigned)
+
+	err := uc.Process(context.Background(), modelOrder.Order{ID: "order-1", Status: modelOrder.StatusCreated})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestProcess_QueuedAssignmentIgnored(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	uc, mockDelivery, _ := newUsecase(ctrl)
+
+	mockDelivery.EXPECT().LocateOrder(gomock.Any(), "order-1").Return(nil)
+	mockDelivery.EXPECT().
+		AssignCourierAt(gomock.Any(), "order-1", modelDelivery.PriorityStandard, nil).
+		Return(nil, modelDelivery.ErrAssignmentPending)
+
+	err := uc.Process(context.Background(), modelOrder.Order{ID: "order-1", Status: modelOrder.StatusCreated})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestProcess_PassesOrderPriority(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	uc, mockDelivery, _ := newUsecase(ctrl)
+
+	mockDelivery.EXPECT().LocateOrder(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	mockDelivery.EXPECT().
+		AssignCourierAt(gomock.Any(), "order-1", modelDelivery.PriorityVIP, nil).
+		Return(&delivery.AssignResult{}, nil)
+	mockDelivery.EXPECT().
+		AssignCourierAt(gomock.Any(), "order-2", modelDelivery.PriorityStandard, nil).
+		Return(&delivery.AssignResult{}, nil)
+
+	err := uc.Process(context.Background(), modelOrder.Order{ID: "order-1", Status: modelOrder.StatusCreated, Priority: "vip"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// неизвестный приоритет не мешает назначению
+	err = uc.Process(context.Background(), modelOrder.Order{ID: "order-2", Status: modelOrder.StatusCreated, Priority: "urgent"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestProcessOnce_LocatesOrderOutsideTransaction(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDelivery := mocks.NewMockdeliveryService(ctrl)
+	mockInbox := mocks.NewMockinboxRepository(ctrl)
+	mockTxManager := mocks.NewMocktransactionManager(ctrl)
+	uc := changed.NewUsecase(mockDelivery, mockInbox, mockTxManager)
+
+	woken := new(bool)
+	gomock.InOrder(
+		mockDelivery.EXPECT().LocateOrder(gomock.Any(), "order-1").Return(nil),
+		mockDelivery.EXPECT().
+			DeferWakeups(gomock.Any()).
+			DoAndReturn(func(ctx context.Context) (context.Context, func()) {
+				return ctx, func() { *woken = true }
+			}),
+		mockTxManager.EXPECT().
+			Do(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+				if err := fn(ctx); err != nil {
+					return err
+				}
+				// до коммита очередь ожидания еще не будили
+				if *woken {
+					t.Error("expected no wakeup before commit")
+				}
+				return nil
+			}),
+	)
+	mockInbox.EXPECT().Register(gomock.Any(), gomock.Any()).Return(true, nil)
+	mockDelivery.EXPECT().
+		AssignCourierAt(gomock.Any(), "order-1", modelDelivery.PriorityStandard, nil).
+		Return(&delivery.AssignResult{OrderID: "order-1"}, nil)
+
+	err := uc.ProcessOnce(context.Background(), modelInbox.MessageID{Topic: "t"}, modelOrder.Order{ID: "order-1", Status: modelOrder.StatusCreated})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !*woken {
+		t.Error("expected pending queue to be woken after commit")
+	}
+}
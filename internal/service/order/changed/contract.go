@@ -1,12 +1,28 @@
+//go:generate mockgen -destination=./mocks/delivery_service_mock.go -package=mocks service-courier/internal/service/order/changed deliveryService
+//go:generate mockgen -destination=./mocks/inbox_repository_mock.go -package=mocks service-courier/internal/service/order/changed inboxRepository
+//go:generate mockgen -destination=./mocks/transaction_manager_mock.go -package=mocks service-courier/internal/service/order/changed transactionManager
 package changed
 
 import (
 	"context"
+	modelDelivery "service-courier/internal/model/delivery"
+	"service-courier/internal/model/inbox"
+	"service-courier/internal/pkg/geo"
 	"service-courier/internal/service/delivery"
 )
 
 type deliveryService interface {
-	AssignCourier(ctx context.Context, orderID string) (*delivery.AssignResult, error)
+	LocateOrder(ctx context.Context, orderID string) *geo.Point
+	AssignCourierAt(ctx context.Context, orderID string, priority modelDelivery.Priority, destination *geo.Point) (*delivery.AssignResult, error)
 	UnassignCourier(ctx context.Context, orderID string) (*delivery.UnassignResult, error)
 	CompleteDelivery(ctx context.Context, orderID string) error
+	DeferWakeups(ctx context.Context) (context.Context, func())
+}
+
+type inboxRepository interface {
+	Register(ctx context.Context, id inbox.MessageID) (bool, error)
+}
+
+type transactionManager interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
 }
@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service-courier/internal/service/order/changed (interfaces: deliveryService)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/delivery_service_mock.go -package=mocks service-courier/internal/service/order/changed deliveryService
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	delivery "service-courier/internal/model/delivery"
+	geo "service-courier/internal/pkg/geo"
+	delivery0 "service-courier/internal/service/delivery"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockdeliveryService is a mock of deliveryService interface.
+type MockdeliveryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockdeliveryServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockdeliveryServiceMockRecorder is the mock recorder for MockdeliveryService.
+type MockdeliveryServiceMockRecorder struct {
+	mock *MockdeliveryService
+}
+
+// NewMockdeliveryService creates a new mock instance.
+func NewMockdeliveryService(ctrl *gomock.Controller) *MockdeliveryService {
+	mock := &MockdeliveryService{ctrl: ctrl}
+	mock.recorder = &MockdeliveryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockdeliveryService) EXPECT() *MockdeliveryServiceMockRecorder {
+	return m.recorder
+}
+
+// AssignCourierAt mocks base method.
+func (m *MockdeliveryService) AssignCourierAt(ctx context.Context, orderID string, priority delivery.Priority, destination *geo.Point) (*delivery0.AssignResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignCourierAt", ctx, orderID, priority, destination)
+	ret0, _ := ret[0].(*delivery0.AssignResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignCourierAt indicates an expected call of AssignCourierAt.
+func (mr *MockdeliveryServiceMockRecorder) AssignCourierAt(ctx, orderID, priority, destination any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignCourierAt", reflect.TypeOf((*MockdeliveryService)(nil).AssignCourierAt), ctx, orderID, priority, destination)
+}
+
+// CompleteDelivery mocks base method.
+func (m *MockdeliveryService) CompleteDelivery(ctx context.Context, orderID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteDelivery", ctx, orderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteDelivery indicates an expected call of CompleteDelivery.
+func (mr *MockdeliveryServiceMockRecorder) CompleteDelivery(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteDelivery", reflect.TypeOf((*MockdeliveryService)(nil).CompleteDelivery), ctx, orderID)
+}
+
+// DeferWakeups mocks base method.
+func (m *MockdeliveryService) DeferWakeups(ctx context.Context) (context.Context, func()) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeferWakeups", ctx)
+	ret0, _ := ret[0].(context.Context)
+	ret1, _ := ret[1].(func())
+	return ret0, ret1
+}
+
+// DeferWakeups indicates an expected call of DeferWakeups.
+func (mr *MockdeliveryServiceMockRecorder) DeferWakeups(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeferWakeups", reflect.TypeOf((*MockdeliveryService)(nil).DeferWakeups), ctx)
+}
+
+// LocateOrder mocks base method.
+func (m *MockdeliveryService) LocateOrder(ctx context.Context, orderID string) *geo.Point {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LocateOrder", ctx, orderID)
+	ret0, _ := ret[0].(*geo.Point)
+	return ret0
+}
+
+// LocateOrder indicates an expected call of LocateOrder.
+func (mr *MockdeliveryServiceMockRecorder) LocateOrder(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LocateOrder", reflect.TypeOf((*MockdeliveryService)(nil).LocateOrder), ctx, orderID)
+}
+
+// UnassignCourier mocks base method.
+func (m *MockdeliveryService) UnassignCourier(ctx context.Context, orderID string) (*delivery0.UnassignResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnassignCourier", ctx, orderID)
+	ret0, _ := ret[0].(*delivery0.UnassignResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnassignCourier indicates an expected call of UnassignCourier.
+func (mr *MockdeliveryServiceMockRecorder) UnassignCourier(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnassignCourier", reflect.TypeOf((*MockdeliveryService)(nil).UnassignCourier), ctx, orderID)
+}
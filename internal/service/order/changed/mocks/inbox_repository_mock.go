@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service-courier/internal/service/order/changed (interfaces: inboxRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/inbox_repository_mock.go -package=mocks service-courier/internal/service/order/changed inboxRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	inbox "service-courier/internal/model/inbox"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockinboxRepository is a mock of inboxRepository interface.
+type MockinboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockinboxRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockinboxRepositoryMockRecorder is the mock recorder for MockinboxRepository.
+type MockinboxRepositoryMockRecorder struct {
+	mock *MockinboxRepository
+}
+
+// NewMockinboxRepository creates a new mock instance.
+func NewMockinboxRepository(ctrl *gomock.Controller) *MockinboxRepository {
+	mock := &MockinboxRepository{ctrl: ctrl}
+	mock.recorder = &MockinboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockinboxRepository) EXPECT() *MockinboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Register mocks base method.
+func (m *MockinboxRepository) Register(ctx context.Context, id inbox.MessageID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Register", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockinboxRepositoryMockRecorder) Register(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockinboxRepository)(nil).Register), ctx, id)
+}
@@ -0,0 +1,23 @@
+//go:generate mockgen -destination=./mocks/reader_mock.go -package=mocks service-courier/internal/service/order/replay reader
+//go:generate mockgen -destination=./mocks/usecase_mock.go -package=mocks service-courier/internal/service/order/replay usecase
+//go:generate mockgen -destination=./mocks/order_gateway_mock.go -package=mocks service-courier/internal/service/order/replay orderGateway
+package replay
+
+import (
+	"context"
+	"service-courier/internal/model/dlq"
+	"service-courier/internal/model/inbox"
+	"service-courier/internal/model/order"
+)
+
+type reader interface {
+	List(ctx context.Context) ([]dlq.Message, error)
+}
+
+type usecase interface {
+	ProcessOnce(ctx context.Context, id inbox.MessageID, order order.Order) error
+}
+
+type orderGateway interface {
+	GetOrderByID(ctx context.Context, id string) (*order.Order, error)
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service-courier/internal/service/order/replay (interfaces: orderGateway)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/order_gateway_mock.go -package=mocks service-courier/internal/service/order/replay orderGateway
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	order "service-courier/internal/model/order"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockorderGateway is a mock of orderGateway interface.
+type MockorderGateway struct {
+	ctrl     *gomock.Controller
+	recorder *MockorderGatewayMockRecorder
+	isgomock struct{}
+}
+
+// MockorderGatewayMockRecorder is the mock recorder for MockorderGateway.
+type MockorderGatewayMockRecorder struct {
+	mock *MockorderGateway
+}
+
+// NewMockorderGateway creates a new mock instance.
+func NewMockorderGateway(ctrl *gomock.Controller) *MockorderGateway {
+	mock := &MockorderGateway{ctrl: ctrl}
+	mock.recorder = &MockorderGatewayMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockorderGateway) EXPECT() *MockorderGatewayMockRecorder {
+	return m.recorder
+}
+
+// GetOrderByID mocks base method.
+func (m *MockorderGateway) GetOrderByID(ctx context.Context, id string) (*order.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderByID", ctx, id)
+	ret0, _ := ret[0].(*order.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderByID indicates an expected call of GetOrderByID.
+func (mr *MockorderGatewayMockRecorder) GetOrderByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderByID", reflect.TypeOf((*MockorderGateway)(nil).GetOrderByID), ctx, id)
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service-courier/internal/service/order/replay (interfaces: reader)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/reader_mock.go -package=mocks service-courier/internal/service/order/replay reader
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	dlq "service-courier/internal/model/dlq"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockreader is a mock of reader interface.
+type Mockreader struct {
+	ctrl     *gomock.Controller
+	recorder *MockreaderMockRecorder
+	isgomock struct{}
+}
+
+// MockreaderMockRecorder is the mock recorder for Mockreader.
+type MockreaderMockRecorder struct {
+	mock *Mockreader
+}
+
+// NewMockreader creates a new mock instance.
+func NewMockreader(ctrl *gomock.Controller) *Mockreader {
+	mock := &Mockreader{ctrl: ctrl}
+	mock.recorder = &MockreaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockreader) EXPECT() *MockreaderMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *Mockreader) List(ctx context.Context) ([]dlq.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]dlq.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockreaderMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*Mockreader)(nil).List), ctx)
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service-courier/internal/service/order/replay (interfaces: usecase)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/usecase_mock.go -package=mocks service-courier/internal/service/order/replay usecase
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	inbox "service-courier/internal/model/inbox"
+	order "service-courier/internal/model/order"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockusecase is a mock of usecase interface.
+type Mockusecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockusecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockusecaseMockRecorder is the mock recorder for Mockusecase.
+type MockusecaseMockRecorder struct {
+	mock *Mockusecase
+}
+
+// NewMockusecase creates a new mock instance.
+func NewMockusecase(ctrl *gomock.Controller) *Mockusecase {
+	mock := &Mockusecase{ctrl: ctrl}
+	mock.recorder = &MockusecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockusecase) EXPECT() *MockusecaseMockRecorder {
+	return m.recorder
+}
+
+// ProcessOnce mocks base method.
+func (m *Mockusecase) ProcessOnce(ctx context.Context, id inbox.MessageID, arg2 order.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessOnce", ctx, id, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessOnce indicates an expected call of ProcessOnce.
+func (mr *MockusecaseMockRecorder) ProcessOnce(ctx, id, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessOnce", reflect.TypeOf((*Mockusecase)(nil).ProcessOnce), ctx, id, arg2)
+}
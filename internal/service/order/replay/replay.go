@@ -0,0 +1,110 @@
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"service-courier/internal/dto/queues/order/changed"
+	"service-courier/internal/model/dlq"
+	"service-courier/internal/model/order"
+)
+
+// Result - итог повторной обработки одного сообщения
+type Result struct {
+	Message dlq.Message
+	Err     error
+}
+
+type Service struct {
+	reader  reader
+	usecase usecase
+	orders  orderGateway
+}
+
+func NewService(r reader, u usecase, orders orderGateway) *Service {
+	return &Service{
+		reader:  r,
+		usecase: u,
+		orders:  orders,
+	}
+}
+
+func (s *Service) List(ctx context.Context) ([]dlq.Message, error) {
+	messages, err := s.reader.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list dead-letter messages: %w", err)
+	}
+	return messages, nil
+}
+
+// Replay повторно обрабатывает выбранные сообщения, а при пустом refs - все.
+// Обработка идет через inbox с исходной позицией сообщения, поэтому повторный replay безопасен.
+// Статус заказа берется текущий из сервиса заказов: пока сообщение лежало в DLQ, заказ мог
+// быть отменен или завершен, и назначать на него курьера уже нельзя.
+func (s *Service) Replay(ctx context.Context, refs []dlq.Ref) ([]Result, error) {
+	messages, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	selected, err := selectMessages(messages, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, 0, len(selected))
+	for _, m := range selected {
+		results = append(results, Result{Message: m, Err: s.replay(ctx, m)})
+	}
+
+	return results, nil
+}
+
+func (s *Service) replay(ctx context.Context, m dlq.Message) error {
+	var msg changed.Message
+	if err := json.Unmarshal(m.Value, &msg); err != nil {
+		return fmt.Errorf("unmarshal message: %w", err)
+	}
+
+	if m.Original.Topic == "" {
+		return fmt.Errorf("message has no original position")
+	}
+
+	current, err := s.orders.GetOrderByID(ctx, msg.OrderID)
+	if err != nil {
+		return fmt.Errorf("get current order: %w", err)
+	}
+
+	priority := current.Priority
+	if priority == "" {
+		priority = msg.Priority
+	}
+
+	return s.usecase.ProcessOnce(ctx, m.Original, order.Order{
+		ID:       msg.OrderID,
+		Status:   current.Status,
+		Priority: priority,
+	})
+}
+
+func selectMessages(messages []dlq.Message, refs []dlq.Ref) ([]dlq.Message, error) {
+	if len(refs) == 0 {
+		return messages, nil
+	}
+
+	byRef := make(map[dlq.Ref]dlq.Message, len(messages))
+	for _, m := range messages {
+		byRef[m.Ref()] = m
+	}
+
+	selected := make([]dlq.Message, 0, len(refs))
+	for _, ref := range refs {
+		m, ok := byRef[ref]
+		if !ok {
+			return nil, fmt.Errorf("partition %d offset %d: %w", ref.Partition, ref.Offset, dlq.ErrMessageNotFound)
+		}
+		selected = append(selected, m)
+	}
+
+	return selected, nil
+}
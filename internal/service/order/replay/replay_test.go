@@ -0,0 +1,179 @@
+package replay_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	modelDlq "service-courier/internal/model/dlq"
+	modelInbox "service-courier/internal/model/inbox"
+	modelOrder "service-courier/internal/model/order"
+	"service-courier/internal/service/order/replay"
+	"service-courier/internal/service/order/replay/mocks"
+)
+
+var deadLettered = []modelDlq.Message{
+	{
+		Partition: 0,
+		Offset:    0,
+		Value:     []byte(`{"order_id":"order-1","status":"created"}`),
+		Error:     "db timeout",
+		Original:  modelInbox.MessageID{Topic: "orders", Partition: 1, Offset: 10},
+	},
+	{
+		Partition: 0,
+		Offset:    1,
+		Value:     []byte(`not json`),
+		Error:     "unmarshal message",
+		Original:  modelInbox.MessageID{Topic: "orders", Partition: 1, Offset: 11},
+	},
+}
+
+func TestReplay_Selected(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReader := mocks.NewMockreader(ctrl)
+	mockUsecase := mocks.NewMockusecase(ctrl)
+	mockOrders := mocks.NewMockorderGateway(ctrl)
+
+	mockReader.EXPECT().
+		List(gomock.Any()).
+		Return(deadLettered, nil)
+
+	mockOrders.EXPECT().
+		GetOrderByID(gomock.Any(), "order-1").
+		Return(&modelOrder.Order{ID: "order-1", Status: modelOrder.StatusCreated}, nil)
+
+	mockUsecase.EXPECT().
+		ProcessOnce(gomock.Any(), modelInbox.MessageID{Topic: "orders", Partition: 1, Offset: 10}, modelOrder.Order{ID: "order-1", Status: modelOrder.StatusCreated}).
+		Return(nil)
+
+	svc := replay.NewService(mockReader, mockUsecase, mockOrders)
+
+	results, err := svc.Replay(context.Background(), []modelDlq.Ref{{Partition: 0, Offset: 0}})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(results) != 1 || results[0].Err != nil {
+		t.Fatalf("unexpected results %+v", results)
+	}
+}
+
+func TestReplay_AllReportsPerMessageErrors(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReader := mocks.NewMockreader(ctrl)
+	mockUsecase := mocks.NewMockusecase(ctrl)
+	mockOrders := mocks.NewMockorderGateway(ctrl)
+
+	mockReader.EXPECT().
+		List(gomock.Any()).
+		Return(deadLettered, nil)
+
+	mockOrders.EXPECT().
+		GetOrderByID(gomock.Any(), "order-1").
+		Return(&modelOrder.Order{ID: "order-1", Status: modelOrder.StatusCreated}, nil)
+
+	mockUsecase.EXPECT().
+		ProcessOnce(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	svc := replay.NewService(mockReader, mockUsecase, mockOrders)
+
+	results, err := svc.Replay(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if results[0].Err != nil {
+		t.Errorf("expected first message replayed, got %v", results[0].Err)
+	}
+	if results[1].Err == nil {
+		t.Errorf("expected unparsable message to fail")
+	}
+}
+
+func TestReplay_UnknownRef(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReader := mocks.NewMockreader(ctrl)
+
+	mockReader.EXPECT().
+		List(gomock.Any()).
+		Return(deadLettered, nil)
+
+	svc := replay.NewService(mockReader, mocks.NewMockusecase(ctrl), mocks.NewMockorderGateway(ctrl))
+
+	_, err := svc.Replay(context.Background(), []modelDlq.Ref{{Partition: 3, Offset: 99}})
+	if !errors.Is(err, modelDlq.ErrMessageNotFound) {
+		t.Fatalf("expected ErrMessageNotFound, got %v", err)
+	}
+}
+
+func TestReplay_UsesCurrentOrderStatus(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReader := mocks.NewMockreader(ctrl)
+	mockUsecase := mocks.NewMockusecase(ctrl)
+	mockOrders := mocks.NewMockorderGateway(ctrl)
+
+	mockReader.EXPECT().
+		List(gomock.Any()).
+		Return(deadLettered, nil)
+
+	// пока сообщение о создании лежало в DLQ, заказ отменили - курьер не назначается
+	mockOrders.EXPECT().
+		GetOrderByID(gomock.Any(), "order-1").
+		Return(&modelOrder.Order{ID: "order-1", Status: modelOrder.StatusCancelled}, nil)
+	mockUsecase.EXPECT().
+		ProcessOnce(gomock.Any(), gomock.Any(), modelOrder.Order{ID: "order-1", Status: modelOrder.StatusCancelled}).
+		Return(nil)
+
+	svc := replay.NewService(mockReader, mockUsecase, mockOrders)
+
+	results, err := svc.Replay(context.Background(), []modelDlq.Ref{{Partition: 0, Offset: 0}})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(results) != 1 || results[0].Err != nil {
+		t.Fatalf("unexpected results %+v", results)
+	}
+}
+
+func TestReplay_OrderNotFound(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockReader := mocks.NewMockreader(ctrl)
+	mockOrders := mocks.NewMockorderGateway(ctrl)
+
+	mockReader.EXPECT().
+		List(gomock.Any()).
+		Return(deadLettered, nil)
+	mockOrders.EXPECT().
+		GetOrderByID(gomock.Any(), "order-1").
+		Return(nil, modelOrder.ErrOrderNotFound)
+
+	svc := replay.NewService(mockReader, mocks.NewMockusecase(ctrl), mockOrders)
+
+	results, err := svc.Replay(context.Background(), []modelDlq.Ref{{Partition: 0, Offset: 0}})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(results) != 1 || !errors.Is(results[0].Err, modelOrder.ErrOrderNotFound) {
+		t.Fatalf("expected ErrOrderNotFound, got %+v", results)
+	}
+}
@@ -0,0 +1,40 @@
+package outbox
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+type Config struct {
+	Interval  time.Duration
+	BatchSize uint64
+	// Retention - сколько хранить опубликованные сообщения, 0 - не удалять
+	Retention time.Duration
+	// CleanupInterval - как часто удалять опубликованные сообщения старше Retention
+	CleanupInterval time.Duration
+}
+
+func LoadConfig() Config {
+	cfg := Config{
+		Interval:        time.Second,
+		BatchSize:       100,
+		Retention:       7 * 24 * time.Hour,
+		CleanupInterval: time.Minute,
+	}
+
+	if ms, err := strconv.Atoi(os.Getenv("OUTBOX_POLL_INTERVAL_MS")); err == nil && ms > 0 {
+		cfg.Interval = time.Duration(ms) * time.Millisecond
+	}
+	if size, err := strconv.ParseUint(os.Getenv("OUTBOX_BATCH_SIZE"), 10, 64); err == nil && size > 0 {
+		cfg.BatchSize = size
+	}
+	if hours, err := strconv.Atoi(os.Getenv("OUTBOX_RETENTION_HOURS")); err == nil && hours >= 0 {
+		cfg.Retention = time.Duration(hours) * time.Hour
+	}
+	if seconds, err := strconv.Atoi(os.Getenv("OUTBOX_CLEANUP_INTERVAL_SECONDS")); err == nil && seconds > 0 {
+		cfg.CleanupInterval = time.Duration(seconds) * time.Second
+	}
+
+	return cfg
+}
@@ -0,0 +1,26 @@
+//go:generate mockgen -destination=./mocks/outbox_repository_mock.go -package=mocks service-courier/internal/service/outbox outboxRepository
+//go:generate mockgen -destination=./mocks/transaction_manager_mock.go -package=mocks service-courier/internal/service/outbox transactionManager
+//go:generate mockgen -destination=./mocks/producer_mock.go -package=mocks service-courier/internal/service/outbox producer
+package outbox
+
+import (
+	"context"
+	"service-courier/internal/model/outbox"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+type outboxRepository interface {
+	FetchPending(ctx context.Context, limit uint64) ([]outbox.Message, error)
+	MarkPublished(ctx context.Context, ids []int64) error
+	DeletePublished(ctx context.Context, olderThan time.Duration, limit uint64) (int64, error)
+}
+
+type transactionManager interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type producer interface {
+	SendMessage(msg *sarama.ProducerMessage) (partition int32, offset int64, err error)
+}
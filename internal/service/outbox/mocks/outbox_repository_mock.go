@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service-courier/internal/service/outbox (interfaces: outboxRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/outbox_repository_mock.go -package=mocks service-courier/internal/service/outbox outboxRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	outbox "service-courier/internal/model/outbox"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockoutboxRepository is a mock of outboxRepository interface.
+type MockoutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockoutboxRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockoutboxRepositoryMockRecorder is the mock recorder for MockoutboxRepository.
+type MockoutboxRepositoryMockRecorder struct {
+	mock *MockoutboxRepository
+}
+
+// NewMockoutboxRepository creates a new mock instance.
+func NewMockoutboxRepository(ctrl *gomock.Controller) *MockoutboxRepository {
+	mock := &MockoutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockoutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockoutboxRepository) EXPECT() *MockoutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeletePublished mocks base method.
+func (m *MockoutboxRepository) DeletePublished(ctx context.Context, olderThan time.Duration, limit uint64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePublished", ctx, olderThan, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePublished indicates an expected call of DeletePublished.
+func (mr *MockoutboxRepositoryMockRecorder) DeletePublished(ctx, olderThan, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePublished", reflect.TypeOf((*MockoutboxRepository)(nil).DeletePublished), ctx, olderThan, limit)
+}
+
+// FetchPending mocks base method.
+func (m *MockoutboxRepository) FetchPending(ctx context.Context, limit uint64) ([]outbox.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchPending", ctx, limit)
+	ret0, _ := ret[0].([]outbox.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchPending indicates an expected call of FetchPending.
+func (mr *MockoutboxRepositoryMockRecorder) FetchPending(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchPending", reflect.TypeOf((*MockoutboxRepository)(nil).FetchPending), ctx, limit)
+}
+
+// MarkPublished mocks base method.
+func (m *MockoutboxRepository) MarkPublished(ctx context.Context, ids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkPublished", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkPublished indicates an expected call of MarkPublished.
+func (mr *MockoutboxRepositoryMockRecorder) MarkPublished(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPublished", reflect.TypeOf((*MockoutboxRepository)(nil).MarkPublished), ctx, ids)
+}
@@ -0,0 +1,139 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"service-courier/internal/metrics"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+// Relay публикует сообщения из outbox в Kafka.
+// Сообщение помечается опубликованным только после подтверждения брокера,
+// поэтому доставка - at-least-once.
+type Relay struct {
+	repo      outboxRepository
+	txManager transactionManager
+	producer  producer
+	config    Config
+}
+
+func NewRelay(repo outboxRepository, txManager transactionManager, producer producer, config Config) *Relay {
+	return &Relay{
+		repo:      repo,
+		txManager: txManager,
+		producer:  producer,
+		config:    config,
+	}
+}
+
+func (r *Relay) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.config.Interval)
+	defer ticker.Stop()
+
+	// без срока хранения канал остается nil и очистка не запускается
+	var cleanup <-chan time.Time
+	if r.config.Retention > 0 && r.config.CleanupInterval > 0 {
+		cleanupTicker := time.NewTicker(r.config.CleanupInterval)
+		defer cleanupTicker.Stop()
+		cleanup = cleanupTicker.C
+	}
+
+	log.Printf("[OutboxRelay] Starting outbox relay (interval: %v, batch: %d, retention: %v)", r.config.Interval, r.config.BatchSize, r.config.Retention)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("[OutboxRelay] Stopping outbox relay...")
+			return
+		case <-ticker.C:
+			r.drain(ctx)
+		case <-cleanup:
+			deleted, err := r.DeletePublished(ctx)
+			if err != nil {
+				log.Printf("[OutboxRelay] Failed to delete published outbox: %v", err)
+			}
+			if deleted > 0 {
+				log.Printf("[OutboxRelay] Deleted %d published outbox messages", deleted)
+			}
+		}
+	}
+}
+
+// DeletePublished удаляет опубликованные сообщения старше Retention пачками по BatchSize,
+// чтобы не держать долгих блокировок, и возвращает число удаленных
+func (r *Relay) DeletePublished(ctx context.Context) (int64, error) {
+	var total int64
+	for ctx.Err() == nil {
+		deleted, err := r.repo.DeletePublished(ctx, r.config.Retention, r.config.BatchSize)
+		if err != nil {
+			return total, fmt.Errorf("delete published: %w", err)
+		}
+		total += deleted
+		if deleted < int64(r.config.BatchSize) {
+			break
+		}
+	}
+	return total, nil
+}
+
+func (r *Relay) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		published, err := r.PublishBatch(ctx)
+		if err != nil {
+			log.Printf("[OutboxRelay] Failed to publish outbox: %v", err)
+			return
+		}
+		if published < int(r.config.BatchSize) {
+			return
+		}
+	}
+}
+
+// PublishBatch публикует очередную пачку сообщений и возвращает число опубликованных.
+// На первой ошибке публикация останавливается, чтобы не нарушить порядок сообщений одного заказа.
+func (r *Relay) PublishBatch(ctx context.Context) (int, error) {
+	var (
+		published  int
+		publishErr error
+	)
+
+	err := r.txManager.Do(ctx, func(ctx context.Context) error {
+		messages, err := r.repo.FetchPending(ctx, r.config.BatchSize)
+		if err != nil {
+			return fmt.Errorf("fetch pending: %w", err)
+		}
+
+		ids := make([]int64, 0, len(messages))
+		for _, m := range messages {
+			_, _, err := r.producer.SendMessage(&sarama.ProducerMessage{
+				Topic: m.Topic,
+				Key:   sarama.StringEncoder(m.Key),
+				Value: sarama.ByteEncoder(m.Payload),
+			})
+			if err != nil {
+				publishErr = fmt.Errorf("send message %d: %w", m.ID, err)
+				break
+			}
+			ids = append(ids, m.ID)
+		}
+
+		// уже отправленные сообщения фиксируем даже при ошибке, чтобы не дублировать их
+		if err := r.repo.MarkPublished(ctx, ids); err != nil {
+			return fmt.Errorf("mark published: %w", err)
+		}
+
+		published = len(ids)
+		return nil
+	})
+
+	if err != nil {
+		return 0, fmt.Errorf("publish outbox transaction: %w", err)
+	}
+
+	metrics.OutboxPublishedTotal.Add(float64(published))
+
+	return published, publishErr
+}
@@ -0,0 +1,204 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"go.uber.org/mock/gomock"
+
+	modelOutbox "service-courier/internal/model/outbox"
+	outboxService "service-courier/internal/service/outbox"
+	"service-courier/internal/service/outbox/mocks"
+)
+
+func newRelay(ctrl *gomock.Controller) (*outboxService.Relay, *mocks.MockoutboxRepository, *mocks.Mockproducer) {
+	mockRepo := mocks.NewMockoutboxRepository(ctrl)
+	mockProducer := mocks.NewMockproducer(ctrl)
+	mockTxManager := mocks.NewMocktransactionManager(ctrl)
+
+	mockTxManager.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		}).
+		AnyTimes()
+
+	relay := outboxService.NewRelay(mockRepo, mockTxManager, mockProducer, outboxService.Config{
+		Interval:  time.Second,
+		BatchSize: 10,
+		Retention: time.Hour,
+	})
+
+	return relay, mockRepo, mockProducer
+}
+
+func TestRelay_PublishBatch_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	relay, mockRepo, mockProducer := newRelay(ctrl)
+
+	mockRepo.EXPECT().
+		FetchPending(gomock.Any(), uint64(10)).
+		Return([]modelOutbox.Message{
+			{ID: 1, Topic: "delivery.status.changed", Key: "order-1", Payload: []byte(`{"status":"assigned"}`)},
+			{ID: 2, Topic: "delivery.status.changed", Key: "order-1", Payload: []byte(`{"status":"accepted"}`)},
+		}, nil)
+
+	var sent []*sarama.ProducerMessage
+	mockProducer.EXPECT().
+		SendMessage(gomock.Any()).
+		DoAndReturn(func(msg *sarama.ProducerMessage) (int32, int64, error) {
+			sent = append(sent, msg)
+			return 0, int64(len(sent)), nil
+		}).
+		Times(2)
+
+	mockRepo.EXPECT().
+		MarkPublished(gomock.Any(), []int64{1, 2}).
+		Return(nil)
+
+	published, err := relay.PublishBatch(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if published != 2 {
+		t.Fatalf("expected 2 published, got %d", published)
+	}
+
+	key, _ := sent[0].Key.Encode()
+	if string(key) != "order-1" {
+		t.Errorf("expected key order-1, got %s", key)
+	}
+	value, _ := sent[1].Value.Encode()
+	if string(value) != `{"status":"accepted"}` {
+		t.Errorf("unexpected payload order: %s", value)
+	}
+}
+
+func TestRelay_PublishBatch_StopsOnSendError(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	relay, mockRepo, mockProducer := newRelay(ctrl)
+
+	sendErr := errors.New("broker unavailable")
+
+	mockRepo.EXPECT().
+		FetchPending(gomock.Any(), gomock.Any()).
+		Return([]modelOutbox.Message{
+			{ID: 1, Topic: "t", Key: "order-1"},
+			{ID: 2, Topic: "t", Key: "order-1"},
+			{ID: 3, Topic: "t", Key: "order-1"},
+		}, nil)
+
+	gomock.InOrder(
+		mockProducer.EXPECT().SendMessage(gomock.Any()).Return(int32(0), int64(1), nil),
+		mockProducer.EXPECT().SendMessage(gomock.Any()).Return(int32(0), int64(0), sendErr),
+	)
+
+	// Отправленное до ошибки сообщение фиксируется, остальные остаются в outbox
+	mockRepo.EXPECT().
+		MarkPublished(gomock.Any(), []int64{1}).
+		Return(nil)
+
+	published, err := relay.PublishBatch(context.Background())
+	if !errors.Is(err, sendErr) {
+		t.Fatalf("expected %v, got %v", sendErr, err)
+	}
+	if published != 1 {
+		t.Fatalf("expected 1 published, got %d", published)
+	}
+}
+
+func TestRelay_PublishBatch_FetchError(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	relay, mockRepo, _ := newRelay(ctrl)
+
+	repoErr := errors.New("db down")
+
+	mockRepo.EXPECT().
+		FetchPending(gomock.Any(), gomock.Any()).
+		Return(nil, repoErr)
+
+	_, err := relay.PublishBatch(context.Background())
+	if !errors.Is(err, repoErr) {
+		t.Fatalf("expected %v, got %v", repoErr, err)
+	}
+}
+
+func TestRelay_PublishBatch_Empty(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	relay, mockRepo, _ := newRelay(ctrl)
+
+	mockRepo.EXPECT().
+		FetchPending(gomock.Any(), gomock.Any()).
+		Return([]modelOutbox.Message{}, nil)
+
+	mockRepo.EXPECT().
+		MarkPublished(gomock.Any(), []int64{}).
+		Return(nil)
+
+	published, err := relay.PublishBatch(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if published != 0 {
+		t.Fatalf("expected 0 published, got %d", published)
+	}
+}
+
+func TestRelay_DeletePublished_RepeatsWhileBatchIsFull(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	relay, mockRepo, _ := newRelay(ctrl)
+
+	gomock.InOrder(
+		mockRepo.EXPECT().
+			DeletePublished(gomock.Any(), time.Hour, uint64(10)).
+			Return(int64(10), nil),
+		mockRepo.EXPECT().
+			DeletePublished(gomock.Any(), time.Hour, uint64(10)).
+			Return(int64(3), nil),
+	)
+
+	deleted, err := relay.DeletePublished(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if deleted != 13 {
+		t.Fatalf("expected 13 deleted, got %d", deleted)
+	}
+}
+
+func TestRelay_DeletePublished_Error(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	relay, mockRepo, _ := newRelay(ctrl)
+
+	repoErr := errors.New("db down")
+
+	mockRepo.EXPECT().
+		DeletePublished(gomock.Any(), time.Hour, uint64(10)).
+		Return(int64(0), repoErr)
+
+	_, err := relay.DeletePublished(context.Background())
+	if !errors.Is(err, repoErr) {
+		t.Fatalf("expected %v, got %v", repoErr, err)
+	}
+}
@@ -0,0 +1,72 @@
+package shift
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"service-courier/internal/model/courier"
+	"time"
+)
+
+// ApplyShifts переводит курьеров в available в начале смены и в paused после ее окончания.
+// Занятый курьер ставится на паузу только после завершения активной доставки:
+// его смена остается незакрытой и проверяется снова на следующем проходе.
+func (s *Service) ApplyShifts(ctx context.Context) error {
+	now := s.clock.Now()
+
+	toStart, err := s.repo.ListToStart(ctx, now)
+	if err != nil {
+		return fmt.Errorf("list shifts to start: %w", err)
+	}
+	for _, shiftData := range toStart {
+		if err := s.startShift(ctx, shiftData, now); err != nil {
+			log.Printf("[ApplyShifts] Failed to start shift %d: %v", shiftData.ID, err)
+		}
+	}
+
+	toFinish, err := s.repo.ListToFinish(ctx, now)
+	if err != nil {
+		return fmt.Errorf("list shifts to finish: %w", err)
+	}
+	for _, shiftData := range toFinish {
+		if err := s.finishShift(ctx, shiftData, now); err != nil {
+			log.Printf("[ApplyShifts] Failed to finish shift %d: %v", shiftData.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) startShift(ctx context.Context, shiftData courier.Shift, now time.Time) error {
+	if _, err := s.courierRepo.UpdateStatusFrom(ctx, shiftData.CourierID, courier.StatusPaused, courier.StatusAvailable); err != nil {
+		return fmt.Errorf("update courier status: %w", err)
+	}
+	return s.repo.MarkStarted(ctx, shiftData.ID, now)
+}
+
+func (s *Service) finishShift(ctx context.Context, shiftData courier.Shift, now time.Time) error {
+	// Следующая смена уже началась - курьер продолжает работать
+	active, err := s.repo.HasActiveShift(ctx, shiftData.CourierID, now)
+	if err != nil {
+		return fmt.Errorf("check active shift: %w", err)
+	}
+	if active {
+		return s.repo.MarkFinished(ctx, shiftData.ID, now)
+	}
+
+	paused, err := s.courierRepo.UpdateStatusFrom(ctx, shiftData.CourierID, courier.StatusAvailable, courier.StatusPaused)
+	if err != nil {
+		return fmt.Errorf("update courier status: %w", err)
+	}
+	if !paused {
+		courierData, err := s.courierRepo.GetByID(ctx, shiftData.CourierID)
+		if err != nil {
+			return fmt.Errorf("get courier: %w", err)
+		}
+		if courierData.Status == courier.StatusBusy {
+			return nil
+		}
+	}
+
+	return s.repo.MarkFinished(ctx, shiftData.ID, now)
+}
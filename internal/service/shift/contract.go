@@ -0,0 +1,39 @@
+//go:generate mockgen -destination=./mocks/shift_repository_mock.go -package=mocks service-courier/internal/service/shift shiftRepository
+//go:generate mockgen -destination=./mocks/courier_repository_mock.go -package=mocks service-courier/internal/service/shift courierRepository
+//go:generate mockgen -destination=./mocks/zone_repository_mock.go -package=mocks service-courier/internal/service/shift zoneRepository
+//go:generate mockgen -destination=./mocks/clock_mock.go -package=mocks service-courier/internal/service/shift clock
+package shift
+
+import (
+	"context"
+	"service-courier/internal/model/courier"
+	"service-courier/internal/model/zone"
+	"time"
+)
+
+type shiftRepository interface {
+	Create(ctx context.Context, shiftData courier.Shift) (int64, error)
+	GetByID(ctx context.Context, id int64) (*courier.Shift, error)
+	List(ctx context.Context, filter courier.ShiftFilter) ([]courier.Shift, error)
+	Update(ctx context.Context, shiftData courier.Shift) error
+	Delete(ctx context.Context, id int64) error
+	HasOverlap(ctx context.Context, courierID int64, startsAt, endsAt time.Time, excludeID int64) (bool, error)
+	HasActiveShift(ctx context.Context, courierID int64, now time.Time) (bool, error)
+	ListToStart(ctx context.Context, now time.Time) ([]courier.Shift, error)
+	ListToFinish(ctx context.Context, now time.Time) ([]courier.Shift, error)
+	MarkStarted(ctx context.Context, id int64, at time.Time) error
+	MarkFinished(ctx context.Context, id int64, at time.Time) error
+}
+
+type courierRepository interface {
+	GetByID(ctx context.Context, id int64) (*courier.Courier, error)
+	UpdateStatusFrom(ctx context.Context, id int64, from, to courier.CourierStatus) (bool, error)
+}
+
+type zoneRepository interface {
+	GetByID(ctx context.Context, id int64) (*zone.Zone, error)
+}
+
+type clock interface {
+	Now() time.Time
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service-courier/internal/service/shift (interfaces: clock)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/clock_mock.go -package=mocks service-courier/internal/service/shift clock
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockclock is a mock of clock interface.
+type Mockclock struct {
+	ctrl     *gomock.Controller
+	recorder *MockclockMockRecorder
+	isgomock struct{}
+}
+
+// MockclockMockRecorder is the mock recorder for Mockclock.
+type MockclockMockRecorder struct {
+	mock *Mockclock
+}
+
+// NewMockclock creates a new mock instance.
+func NewMockclock(ctrl *gomock.Controller) *Mockclock {
+	mock := &Mockclock{ctrl: ctrl}
+	mock.recorder = &MockclockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockclock) EXPECT() *MockclockMockRecorder {
+	return m.recorder
+}
+
+// Now mocks base method.
+func (m *Mockclock) Now() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Now")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// Now indicates an expected call of Now.
+func (mr *MockclockMockRecorder) Now() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Now", reflect.TypeOf((*Mockclock)(nil).Now))
+}
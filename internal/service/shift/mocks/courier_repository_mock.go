@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service-courier/internal/service/shift (interfaces: courierRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/courier_repository_mock.go -package=mocks service-courier/internal/service/shift courierRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	courier "service-courier/internal/model/courier"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockcourierRepository is a mock of courierRepository interface.
+type MockcourierRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockcourierRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockcourierRepositoryMockRecorder is the mock recorder for MockcourierRepository.
+type MockcourierRepositoryMockRecorder struct {
+	mock *MockcourierRepository
+}
+
+// NewMockcourierRepository creates a new mock instance.
+func NewMockcourierRepository(ctrl *gomock.Controller) *MockcourierRepository {
+	mock := &MockcourierRepository{ctrl: ctrl}
+	mock.recorder = &MockcourierRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcourierRepository) EXPECT() *MockcourierRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *MockcourierRepository) GetByID(ctx context.Context, id int64) (*courier.Courier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*courier.Courier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockcourierRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockcourierRepository)(nil).GetByID), ctx, id)
+}
+
+// UpdateStatusFrom mocks base method.
+func (m *MockcourierRepository) UpdateStatusFrom(ctx context.Context, id int64, from, to courier.CourierStatus) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatusFrom", ctx, id, from, to)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatusFrom indicates an expected call of UpdateStatusFrom.
+func (mr *MockcourierRepositoryMockRecorder) UpdateStatusFrom(ctx, id, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatusFrom", reflect.TypeOf((*MockcourierRepository)(nil).UpdateStatusFrom), ctx, id, from, to)
+}
@@ -0,0 +1,204 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service-courier/internal/service/shift (interfaces: shiftRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/shift_repository_mock.go -package=mocks service-courier/internal/service/shift shiftRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	courier "service-courier/internal/model/courier"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockshiftRepository is a mock of shiftRepository interface.
+type MockshiftRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockshiftRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockshiftRepositoryMockRecorder is the mock recorder for MockshiftRepository.
+type MockshiftRepositoryMockRecorder struct {
+	mock *MockshiftRepository
+}
+
+// NewMockshiftRepository creates a new mock instance.
+func NewMockshiftRepository(ctrl *gomock.Controller) *MockshiftRepository {
+	mock := &MockshiftRepository{ctrl: ctrl}
+	mock.recorder = &MockshiftRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockshiftRepository) EXPECT() *MockshiftRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockshiftRepository) Create(ctx context.Context, shiftData courier.Shift) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, shiftData)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockshiftRepositoryMockRecorder) Create(ctx, shiftData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockshiftRepository)(nil).Create), ctx, shiftData)
+}
+
+// Delete mocks base method.
+func (m *MockshiftRepository) Delete(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockshiftRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockshiftRepository)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockshiftRepository) GetByID(ctx context.Context, id int64) (*courier.Shift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*courier.Shift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockshiftRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockshiftRepository)(nil).GetByID), ctx, id)
+}
+
+// HasActiveShift mocks base method.
+func (m *MockshiftRepository) HasActiveShift(ctx context.Context, courierID int64, now time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasActiveShift", ctx, courierID, now)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasActiveShift indicates an expected call of HasActiveShift.
+func (mr *MockshiftRepositoryMockRecorder) HasActiveShift(ctx, courierID, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasActiveShift", reflect.TypeOf((*MockshiftRepository)(nil).HasActiveShift), ctx, courierID, now)
+}
+
+// HasOverlap mocks base method.
+func (m *MockshiftRepository) HasOverlap(ctx context.Context, courierID int64, startsAt, endsAt time.Time, excludeID int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasOverlap", ctx, courierID, startsAt, endsAt, excludeID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasOverlap indicates an expected call of HasOverlap.
+func (mr *MockshiftRepositoryMockRecorder) HasOverlap(ctx, courierID, startsAt, endsAt, excludeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasOverlap", reflect.TypeOf((*MockshiftRepository)(nil).HasOverlap), ctx, courierID, startsAt, endsAt, excludeID)
+}
+
+// List mocks base method.
+func (m *MockshiftRepository) List(ctx context.Context, filter courier.ShiftFilter) ([]courier.Shift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]courier.Shift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockshiftRepositoryMockRecorder) List(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockshiftRepository)(nil).List), ctx, filter)
+}
+
+// ListToFinish mocks base method.
+func (m *MockshiftRepository) ListToFinish(ctx context.Context, now time.Time) ([]courier.Shift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListToFinish", ctx, now)
+	ret0, _ := ret[0].([]courier.Shift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListToFinish indicates an expected call of ListToFinish.
+func (mr *MockshiftRepositoryMockRecorder) ListToFinish(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListToFinish", reflect.TypeOf((*MockshiftRepository)(nil).ListToFinish), ctx, now)
+}
+
+// ListToStart mocks base method.
+func (m *MockshiftRepository) ListToStart(ctx context.Context, now time.Time) ([]courier.Shift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListToStart", ctx, now)
+	ret0, _ := ret[0].([]courier.Shift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListToStart indicates an expected call of ListToStart.
+func (mr *MockshiftRepositoryMockRecorder) ListToStart(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListToStart", reflect.TypeOf((*MockshiftRepository)(nil).ListToStart), ctx, now)
+}
+
+// MarkFinished mocks base method.
+func (m *MockshiftRepository) MarkFinished(ctx context.Context, id int64, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFinished", ctx, id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkFinished indicates an expected call of MarkFinished.
+func (mr *MockshiftRepositoryMockRecorder) MarkFinished(ctx, id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFinished", reflect.TypeOf((*MockshiftRepository)(nil).MarkFinished), ctx, id, at)
+}
+
+// MarkStarted mocks base method.
+func (m *MockshiftRepository) MarkStarted(ctx context.Context, id int64, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkStarted", ctx, id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkStarted indicates an expected call of MarkStarted.
+func (mr *MockshiftRepositoryMockRecorder) MarkStarted(ctx, id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkStarted", reflect.TypeOf((*MockshiftRepository)(nil).MarkStarted), ctx, id, at)
+}
+
+// Update mocks base method.
+func (m *MockshiftRepository) Update(ctx context.Context, shiftData courier.Shift) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, shiftData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockshiftRepositoryMockRecorder) Update(ctx, shiftData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockshiftRepository)(nil).Update), ctx, shiftData)
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service-courier/internal/service/shift (interfaces: zoneRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=./mocks/zone_repository_mock.go -package=mocks service-courier/internal/service/shift zoneRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	zone "service-courier/internal/model/zone"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockzoneRepository is a mock of zoneRepository interface.
+type MockzoneRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockzoneRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockzoneRepositoryMockRecorder is the mock recorder for MockzoneRepository.
+type MockzoneRepositoryMockRecorder struct {
+	mock *MockzoneRepository
+}
+
+// NewMockzoneRepository creates a new mock instance.
+func NewMockzoneRepository(ctrl *gomock.Controller) *MockzoneRepository {
+	mock := &MockzoneRepository{ctrl: ctrl}
+	mock.recorder = &MockzoneRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockzoneRepository) EXPECT() *MockzoneRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *MockzoneRepository) GetByID(ctx context.Context, id int64) (*zone.Zone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*zone.Zone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockzoneRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockzoneRepository)(nil).GetByID), ctx, id)
+}
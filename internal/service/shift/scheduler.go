@@ -0,0 +1,42 @@
+package shift
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+type Scheduler struct {
+	service  *Service
+	interval time.Duration
+}
+
+func NewScheduler(service *Service, interval time.Duration) *Scheduler {
+	return &Scheduler{
+		service:  service,
+		interval: interval,
+	}
+}
+
+func (s *Scheduler) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	log.Printf("[ShiftScheduler] Starting shift scheduler (interval: %v)", s.interval)
+
+	if err := s.service.ApplyShifts(ctx); err != nil {
+		log.Printf("[ShiftScheduler] Failed to apply shifts on startup: %v", err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("[ShiftScheduler] Stopping shift scheduler...")
+			return
+		case <-ticker.C:
+			if err := s.service.ApplyShifts(ctx); err != nil {
+				log.Printf("[ShiftScheduler] Failed to apply shifts: %v", err)
+			}
+		}
+	}
+}
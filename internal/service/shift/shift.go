@@ -0,0 +1,118 @@
+package shift
+
+import (
+	"context"
+	"fmt"
+	"service-courier/internal/metrics"
+	"service-courier/internal/model/courier"
+)
+
+type Service struct {
+	repo        shiftRepository
+	courierRepo courierRepository
+	zoneRepo    zoneRepository
+	clock       clock
+}
+
+func NewShiftService(repo shiftRepository, courierRepo courierRepository, zoneRepo zoneRepository, clock clock) *Service {
+	return &Service{
+		repo:        repo,
+		courierRepo: courierRepo,
+		zoneRepo:    zoneRepo,
+		clock:       clock,
+	}
+}
+
+func (s *Service) GetShift(ctx context.Context, id int64) (*courier.Shift, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *Service) ListShifts(ctx context.Context, filter courier.ShiftFilter) ([]courier.Shift, error) {
+	return s.repo.List(ctx, filter)
+}
+
+func (s *Service) CreateShift(ctx context.Context, shiftData courier.Shift) (int64, error) {
+	metrics.OpsCounter.Inc()
+
+	if !shiftData.EndsAt.After(shiftData.StartsAt) {
+		return 0, courier.ErrInvalidShiftPeriod
+	}
+
+	if _, err := s.courierRepo.GetByID(ctx, shiftData.CourierID); err != nil {
+		return 0, err
+	}
+
+	if err := s.checkZone(ctx, shiftData.ZoneID); err != nil {
+		return 0, err
+	}
+
+	if err := s.checkOverlap(ctx, shiftData); err != nil {
+		return 0, err
+	}
+
+	return s.repo.Create(ctx, shiftData)
+}
+
+// UpdateShift меняет период и зону смены. Курьер смены не меняется.
+func (s *Service) UpdateShift(ctx context.Context, shiftData courier.Shift) error {
+	metrics.OpsCounter.Inc()
+
+	if !shiftData.EndsAt.After(shiftData.StartsAt) {
+		return courier.ErrInvalidShiftPeriod
+	}
+
+	current, err := s.repo.GetByID(ctx, shiftData.ID)
+	if err != nil {
+		return err
+	}
+
+	current.StartsAt = shiftData.StartsAt
+	current.EndsAt = shiftData.EndsAt
+	current.ZoneID = shiftData.ZoneID
+
+	if err := s.checkZone(ctx, current.ZoneID); err != nil {
+		return err
+	}
+
+	if err := s.checkOverlap(ctx, *current); err != nil {
+		return err
+	}
+
+	// Перенесенные в будущее границы планировщик должен применить заново
+	now := s.clock.Now()
+	if current.StartsAt.After(now) {
+		current.StartedAt = nil
+	}
+	if current.EndsAt.After(now) {
+		current.FinishedAt = nil
+	}
+
+	return s.repo.Update(ctx, *current)
+}
+
+func (s *Service) DeleteShift(ctx context.Context, id int64) error {
+	metrics.OpsCounter.Inc()
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *Service) checkOverlap(ctx context.Context, shiftData courier.Shift) error {
+	overlap, err := s.repo.HasOverlap(ctx, shiftData.CourierID, shiftData.StartsAt, shiftData.EndsAt, shiftData.ID)
+	if err != nil {
+		return fmt.Errorf("check overlap: %w", err)
+	}
+	if overlap {
+		return courier.ErrShiftOverlap
+	}
+	return nil
+}
+
+// checkZone проверяет, что зона смены есть в справочнике. Смена без зоны допустима.
+func (s *Service) checkZone(ctx context.Context, zoneID *int64) error {
+	if zoneID == nil {
+		return nil
+	}
+	if _, err := s.zoneRepo.GetByID(ctx, *zoneID); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,265 @@
+package shift_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	model "service-courier/internal/model/courier"
+	modelZone "service-courier/internal/model/zone"
+	deliveryService "service-courier/internal/service/delivery"
+	shiftService "service-courier/internal/service/shift"
+	"service-courier/internal/service/shift/mocks"
+)
+
+var now = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+type serviceMocks struct {
+	repo        *mocks.MockshiftRepository
+	courierRepo *mocks.MockcourierRepository
+	zoneRepo    *mocks.MockzoneRepository
+}
+
+func newService(ctrl *gomock.Controller) (*shiftService.Service, serviceMocks) {
+	m := serviceMocks{
+		repo:        mocks.NewMockshiftRepository(ctrl),
+		courierRepo: mocks.NewMockcourierRepository(ctrl),
+		zoneRepo:    mocks.NewMockzoneRepository(ctrl),
+	}
+	return shiftService.NewShiftService(m.repo, m.courierRepo, m.zoneRepo, deliveryService.NewFixedClock(now)), m
+}
+
+func zoneID(id int64) *int64 {
+	return &id
+}
+
+func TestCreateShift_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	service, m := newService(ctrl)
+
+	shiftData := model.Shift{CourierID: 1, StartsAt: now, EndsAt: now.Add(8 * time.Hour), ZoneID: zoneID(3)}
+
+	m.courierRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&model.Courier{ID: 1}, nil)
+	m.zoneRepo.EXPECT().GetByID(gomock.Any(), int64(3)).Return(&modelZone.Zone{ID: 3}, nil)
+	m.repo.EXPECT().HasOverlap(gomock.Any(), int64(1), shiftData.StartsAt, shiftData.EndsAt, int64(0)).Return(false, nil)
+	m.repo.EXPECT().Create(gomock.Any(), shiftData).Return(int64(10), nil)
+
+	id, err := service.CreateShift(context.Background(), shiftData)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if id != 10 {
+		t.Errorf("expected id 10, got %d", id)
+	}
+}
+
+func TestCreateShift_WithoutZone(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	service, m := newService(ctrl)
+
+	shiftData := model.Shift{CourierID: 1, StartsAt: now, EndsAt: now.Add(8 * time.Hour)}
+
+	// Без зоны справочник не запрашивается
+	m.courierRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&model.Courier{ID: 1}, nil)
+	m.repo.EXPECT().HasOverlap(gomock.Any(), int64(1), gomock.Any(), gomock.Any(), int64(0)).Return(false, nil)
+	m.repo.EXPECT().Create(gomock.Any(), shiftData).Return(int64(10), nil)
+
+	if _, err := service.CreateShift(context.Background(), shiftData); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestCreateShift_InvalidPeriod(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	service, _ := newService(ctrl)
+
+	_, err := service.CreateShift(context.Background(), model.Shift{CourierID: 1, StartsAt: now, EndsAt: now})
+	if !errors.Is(err, model.ErrInvalidShiftPeriod) {
+		t.Errorf("expected ErrInvalidShiftPeriod, got %v", err)
+	}
+}
+
+func TestCreateShift_CourierNotFound(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	service, m := newService(ctrl)
+
+	m.courierRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(nil, model.ErrCourierNotFound)
+
+	_, err := service.CreateShift(context.Background(), model.Shift{CourierID: 1, StartsAt: now, EndsAt: now.Add(time.Hour)})
+	if !errors.Is(err, model.ErrCourierNotFound) {
+		t.Errorf("expected ErrCourierNotFound, got %v", err)
+	}
+}
+
+func TestCreateShift_ZoneNotFound(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	service, m := newService(ctrl)
+
+	m.courierRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&model.Courier{ID: 1}, nil)
+	m.zoneRepo.EXPECT().GetByID(gomock.Any(), int64(3)).Return(nil, modelZone.ErrZoneNotFound)
+
+	_, err := service.CreateShift(context.Background(), model.Shift{CourierID: 1, StartsAt: now, EndsAt: now.Add(time.Hour), ZoneID: zoneID(3)})
+	if !errors.Is(err, modelZone.ErrZoneNotFound) {
+		t.Errorf("expected ErrZoneNotFound, got %v", err)
+	}
+}
+
+func TestCreateShift_Overlap(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	service, m := newService(ctrl)
+
+	m.courierRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(&model.Courier{ID: 1}, nil)
+	m.repo.EXPECT().HasOverlap(gomock.Any(), int64(1), gomock.Any(), gomock.Any(), int64(0)).Return(true, nil)
+
+	_, err := service.CreateShift(context.Background(), model.Shift{CourierID: 1, StartsAt: now, EndsAt: now.Add(time.Hour)})
+	if !errors.Is(err, model.ErrShiftOverlap) {
+		t.Errorf("expected ErrShiftOverlap, got %v", err)
+	}
+}
+
+func TestUpdateShift_ResetsMarksForFutureBounds(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	service, m := newService(ctrl)
+
+	startedAt := now.Add(-2 * time.Hour)
+	finishedAt := now.Add(-time.Hour)
+	current := &model.Shift{
+		ID:         5,
+		CourierID:  1,
+		StartsAt:   now.Add(-2 * time.Hour),
+		EndsAt:     now.Add(-time.Hour),
+		StartedAt:  &startedAt,
+		FinishedAt: &finishedAt,
+	}
+
+	m.repo.EXPECT().GetByID(gomock.Any(), int64(5)).Return(current, nil)
+	m.zoneRepo.EXPECT().GetByID(gomock.Any(), int64(4)).Return(&modelZone.Zone{ID: 4}, nil)
+	m.repo.EXPECT().HasOverlap(gomock.Any(), int64(1), gomock.Any(), gomock.Any(), int64(5)).Return(false, nil)
+	m.repo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, s model.Shift) error {
+		// Смена продлена: начало уже применено, конец нужно применить заново
+		if s.StartedAt == nil || !s.StartedAt.Equal(startedAt) {
+			t.Errorf("expected StartedAt=%v, got %v", startedAt, s.StartedAt)
+		}
+		if s.FinishedAt != nil {
+			t.Errorf("expected FinishedAt to be reset, got %v", s.FinishedAt)
+		}
+		if s.ZoneID == nil || *s.ZoneID != 4 {
+			t.Errorf("expected ZoneID=4, got %v", s.ZoneID)
+		}
+		return nil
+	})
+
+	err := service.UpdateShift(context.Background(), model.Shift{
+		ID:       5,
+		StartsAt: now.Add(-2 * time.Hour),
+		EndsAt:   now.Add(2 * time.Hour),
+		ZoneID:   zoneID(4),
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestUpdateShift_ZoneNotFound(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	service, m := newService(ctrl)
+
+	m.repo.EXPECT().GetByID(gomock.Any(), int64(5)).Return(&model.Shift{ID: 5, CourierID: 1}, nil)
+	m.zoneRepo.EXPECT().GetByID(gomock.Any(), int64(4)).Return(nil, modelZone.ErrZoneNotFound)
+
+	err := service.UpdateShift(context.Background(), model.Shift{
+		ID:       5,
+		StartsAt: now,
+		EndsAt:   now.Add(time.Hour),
+		ZoneID:   zoneID(4),
+	})
+	if !errors.Is(err, modelZone.ErrZoneNotFound) {
+		t.Errorf("expected ErrZoneNotFound, got %v", err)
+	}
+}
+
+func TestApplyShifts_StartsShift(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	service, m := newService(ctrl)
+
+	m.repo.EXPECT().ListToStart(gomock.Any(), now).Return([]model.Shift{{ID: 1, CourierID: 7}}, nil)
+	m.courierRepo.EXPECT().UpdateStatusFrom(gomock.Any(), int64(7), model.CourierStatus(model.StatusPaused), model.CourierStatus(model.StatusAvailable)).Return(true, nil)
+	m.repo.EXPECT().MarkStarted(gomock.Any(), int64(1), now).Return(nil)
+	m.repo.EXPECT().ListToFinish(gomock.Any(), now).Return(nil, nil)
+
+	if err := service.ApplyShifts(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestApplyShifts_PausesAvailableCourier(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	service, m := newService(ctrl)
+
+	m.repo.EXPECT().ListToStart(gomock.Any(), now).Return(nil, nil)
+	m.repo.EXPECT().ListToFinish(gomock.Any(), now).Return([]model.Shift{{ID: 2, CourierID: 7}}, nil)
+	m.repo.EXPECT().HasActiveShift(gomock.Any(), int64(7), now).Return(false, nil)
+	m.courierRepo.EXPECT().UpdateStatusFrom(gomock.Any(), int64(7), model.CourierStatus(model.StatusAvailable), model.CourierStatus(model.StatusPaused)).Return(true, nil)
+	m.repo.EXPECT().MarkFinished(gomock.Any(), int64(2), now).Return(nil)
+
+	if err := service.ApplyShifts(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestApplyShifts_WaitsForActiveDelivery(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	service, m := newService(ctrl)
+
+	m.repo.EXPECT().ListToStart(gomock.Any(), now).Return(nil, nil)
+	m.repo.EXPECT().ListToFinish(gomock.Any(), now).Return([]model.Shift{{ID: 2, CourierID: 7}}, nil)
+	m.repo.EXPECT().HasActiveShift(gomock.Any(), int64(7), now).Return(false, nil)
+	m.courierRepo.EXPECT().UpdateStatusFrom(gomock.Any(), int64(7), model.CourierStatus(model.StatusAvailable), model.CourierStatus(model.StatusPaused)).Return(false, nil)
+	m.courierRepo.EXPECT().GetByID(gomock.Any(), int64(7)).Return(&model.Courier{ID: 7, Status: model.StatusBusy}, nil)
+
+	// Смена не закрывается, пока курьер занят
+	if err := service.ApplyShifts(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestApplyShifts_NextShiftAlreadyStarted(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	service, m := newService(ctrl)
+
+	m.repo.EXPECT().ListToStart(gomock.Any(), now).Return(nil, nil)
+	m.repo.EXPECT().ListToFinish(gomock.Any(), now).Return([]model.Shift{{ID: 2, CourierID: 7}}, nil)
+	m.repo.EXPECT().HasActiveShift(gomock.Any(), int64(7), now).Return(true, nil)
+	m.repo.EXPECT().MarkFinished(gomock.Any(), int64(2), now).Return(nil)
+
+	if err := service.ApplyShifts(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestApplyShifts_ListError(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	service, m := newService(ctrl)
+
+	m.repo.EXPECT().ListToStart(gomock.Any(), now).Return(nil, errors.New("db error"))
+
+	if err := service.ApplyShifts(context.Background()); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+}
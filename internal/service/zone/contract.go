@@ -0,0 +1,31 @@
+//go:generate mockgen -source=contract.go -destination=./mocks/zone_mock.go -package=mocks
+package zone
+
+import (
+	"context"
+	"service-courier/internal/model/courier"
+	"service-courier/internal/model/zone"
+	"service-courier/internal/pkg/geo"
+)
+
+type zoneRepository interface {
+	Create(ctx context.Context, zoneData zone.Zone) (int64, error)
+	UpsertByName(ctx context.Context, zoneData zone.Zone) (int64, error)
+	GetByID(ctx context.Context, id int64) (*zone.Zone, error)
+	GetAll(ctx context.Context) ([]zone.Zone, error)
+	ListByPoint(ctx context.Context, point geo.Point) ([]zone.Zone, error)
+	Update(ctx context.Context, zoneData zone.Zone) error
+	SetNeighbors(ctx context.Context, id int64, neighbors []int64) error
+	Delete(ctx context.Context, id int64) error
+	CountByIDs(ctx context.Context, ids []int64) (int, error)
+	SetCourierZones(ctx context.Context, courierID int64, zoneIDs []int64) error
+	GetCourierZoneIDs(ctx context.Context, courierID int64) ([]int64, error)
+}
+
+type courierRepository interface {
+	GetByID(ctx context.Context, id int64) (*courier.Courier, error)
+}
+
+type transactionManager interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,61 @@
+package zone
+
+import (
+	"context"
+	"fmt"
+	"service-courier/internal/metrics"
+	"service-courier/internal/model/zone"
+)
+
+// ImportZones создает или обновляет зоны по имени в одной транзакции.
+// neighbors задает соседей импортируемых зон по именам: так файл не зависит от идентификаторов
+// конкретной базы. Соседом может быть и зона, которой нет в файле, но которая уже есть в базе.
+func (s *Service) ImportZones(ctx context.Context, zones []zone.Zone, neighbors map[string][]string) ([]int64, error) {
+	metrics.OpsCounter.Inc()
+
+	ids := make([]int64, len(zones))
+
+	err := s.txManager.Do(ctx, func(ctx context.Context) error {
+		for i, zoneData := range zones {
+			id, err := s.repo.UpsertByName(ctx, zoneData)
+			if err != nil {
+				return fmt.Errorf("upsert zone %q: %w", zoneData.Name, err)
+			}
+			ids[i] = id
+		}
+
+		all, err := s.repo.GetAll(ctx)
+		if err != nil {
+			return fmt.Errorf("get zones: %w", err)
+		}
+		byName := make(map[string]int64, len(all))
+		for _, z := range all {
+			byName[z.Name] = z.ID
+		}
+
+		for i, zoneData := range zones {
+			neighborIDs := make([]int64, 0, len(neighbors[zoneData.Name]))
+			for _, name := range neighbors[zoneData.Name] {
+				id, ok := byName[name]
+				if !ok {
+					return fmt.Errorf("zone %q: %w: %s", zoneData.Name, zone.ErrUnknownNeighbor, name)
+				}
+				if id != ids[i] {
+					neighborIDs = append(neighborIDs, id)
+				}
+			}
+
+			if err := s.repo.SetNeighbors(ctx, ids[i], neighborIDs); err != nil {
+				return fmt.Errorf("set neighbors of zone %q: %w", zoneData.Name, err)
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,282 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: contract.go
+//
+// Generated by this command:
+//
+//	mockgen -source=contract.go -destination=./mocks/zone_mock.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	courier "service-courier/internal/model/courier"
+	zone "service-courier/internal/model/zone"
+	geo "service-courier/internal/pkg/geo"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockzoneRepository is a mock of zoneRepository interface.
+type MockzoneRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockzoneRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockzoneRepositoryMockRecorder is the mock recorder for MockzoneRepository.
+type MockzoneRepositoryMockRecorder struct {
+	mock *MockzoneRepository
+}
+
+// NewMockzoneRepository creates a new mock instance.
+func NewMockzoneRepository(ctrl *gomock.Controller) *MockzoneRepository {
+	mock := &MockzoneRepository{ctrl: ctrl}
+	mock.recorder = &MockzoneRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockzoneRepository) EXPECT() *MockzoneRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountByIDs mocks base method.
+func (m *MockzoneRepository) CountByIDs(ctx context.Context, ids []int64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByIDs", ctx, ids)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByIDs indicates an expected call of CountByIDs.
+func (mr *MockzoneRepositoryMockRecorder) CountByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByIDs", reflect.TypeOf((*MockzoneRepository)(nil).CountByIDs), ctx, ids)
+}
+
+// Create mocks base method.
+func (m *MockzoneRepository) Create(ctx context.Context, zoneData zone.Zone) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, zoneData)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockzoneRepositoryMockRecorder) Create(ctx, zoneData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockzoneRepository)(nil).Create), ctx, zoneData)
+}
+
+// Delete mocks base method.
+func (m *MockzoneRepository) Delete(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockzoneRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockzoneRepository)(nil).Delete), ctx, id)
+}
+
+// GetAll mocks base method.
+func (m *MockzoneRepository) GetAll(ctx context.Context) ([]zone.Zone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]zone.Zone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockzoneRepositoryMockRecorder) GetAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockzoneRepository)(nil).GetAll), ctx)
+}
+
+// GetByID mocks base method.
+func (m *MockzoneRepository) GetByID(ctx context.Context, id int64) (*zone.Zone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*zone.Zone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockzoneRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockzoneRepository)(nil).GetByID), ctx, id)
+}
+
+// GetCourierZoneIDs mocks base method.
+func (m *MockzoneRepository) GetCourierZoneIDs(ctx context.Context, courierID int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCourierZoneIDs", ctx, courierID)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCourierZoneIDs indicates an expected call of GetCourierZoneIDs.
+func (mr *MockzoneRepositoryMockRecorder) GetCourierZoneIDs(ctx, courierID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCourierZoneIDs", reflect.TypeOf((*MockzoneRepository)(nil).GetCourierZoneIDs), ctx, courierID)
+}
+
+// ListByPoint mocks base method.
+func (m *MockzoneRepository) ListByPoint(ctx context.Context, point geo.Point) ([]zone.Zone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByPoint", ctx, point)
+	ret0, _ := ret[0].([]zone.Zone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByPoint indicates an expected call of ListByPoint.
+func (mr *MockzoneRepositoryMockRecorder) ListByPoint(ctx, point any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByPoint", reflect.TypeOf((*MockzoneRepository)(nil).ListByPoint), ctx, point)
+}
+
+// SetCourierZones mocks base method.
+func (m *MockzoneRepository) SetCourierZones(ctx context.Context, courierID int64, zoneIDs []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCourierZones", ctx, courierID, zoneIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCourierZones indicates an expected call of SetCourierZones.
+func (mr *MockzoneRepositoryMockRecorder) SetCourierZones(ctx, courierID, zoneIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCourierZones", reflect.TypeOf((*MockzoneRepository)(nil).SetCourierZones), ctx, courierID, zoneIDs)
+}
+
+// SetNeighbors mocks base method.
+func (m *MockzoneRepository) SetNeighbors(ctx context.Context, id int64, neighbors []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNeighbors", ctx, id, neighbors)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNeighbors indicates an expected call of SetNeighbors.
+func (mr *MockzoneRepositoryMockRecorder) SetNeighbors(ctx, id, neighbors any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNeighbors", reflect.TypeOf((*MockzoneRepository)(nil).SetNeighbors), ctx, id, neighbors)
+}
+
+// Update mocks base method.
+func (m *MockzoneRepository) Update(ctx context.Context, zoneData zone.Zone) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, zoneData)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockzoneRepositoryMockRecorder) Update(ctx, zoneData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockzoneRepository)(nil).Update), ctx, zoneData)
+}
+
+// UpsertByName mocks base method.
+func (m *MockzoneRepository) UpsertByName(ctx context.Context, zoneData zone.Zone) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertByName", ctx, zoneData)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertByName indicates an expected call of UpsertByName.
+func (mr *MockzoneRepositoryMockRecorder) UpsertByName(ctx, zoneData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertByName", reflect.TypeOf((*MockzoneRepository)(nil).UpsertByName), ctx, zoneData)
+}
+
+// MockcourierRepository is a mock of courierRepository interface.
+type MockcourierRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockcourierRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockcourierRepositoryMockRecorder is the mock recorder for MockcourierRepository.
+type MockcourierRepositoryMockRecorder struct {
+	mock *MockcourierRepository
+}
+
+// NewMockcourierRepository creates a new mock instance.
+func NewMockcourierRepository(ctrl *gomock.Controller) *MockcourierRepository {
+	mock := &MockcourierRepository{ctrl: ctrl}
+	mock.recorder = &MockcourierRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcourierRepository) EXPECT() *MockcourierRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *MockcourierRepository) GetByID(ctx context.Context, id int64) (*courier.Courier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*courier.Courier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockcourierRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockcourierRepository)(nil).GetByID), ctx, id)
+}
+
+// MocktransactionManager is a mock of transactionManager interface.
+type MocktransactionManager struct {
+	ctrl     *gomock.Controller
+	recorder *MocktransactionManagerMockRecorder
+	isgomock struct{}
+}
+
+// MocktransactionManagerMockRecorder is the mock recorder for MocktransactionManager.
+type MocktransactionManagerMockRecorder struct {
+	mock *MocktransactionManager
+}
+
+// NewMocktransactionManager creates a new mock instance.
+func NewMocktransactionManager(ctrl *gomock.Controller) *MocktransactionManager {
+	mock := &MocktransactionManager{ctrl: ctrl}
+	mock.recorder = &MocktransactionManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktransactionManager) EXPECT() *MocktransactionManagerMockRecorder {
+	return m.recorder
+}
+
+// Do mocks base method.
+func (m *MocktransactionManager) Do(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Do", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Do indicates an expected call of Do.
+func (mr *MocktransactionManagerMockRecorder) Do(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MocktransactionManager)(nil).Do), ctx, fn)
+}
@@ -0,0 +1,139 @@
+package zone
+
+import (
+	"context"
+	"fmt"
+	"service-courier/internal/metrics"
+	"service-courier/internal/model/zone"
+	"service-courier/internal/pkg/geo"
+)
+
+type Service struct {
+	repo        zoneRepository
+	courierRepo courierRepository
+	txManager   transactionManager
+}
+
+func NewZoneService(repo zoneRepository, courierRepo courierRepository, txManager transactionManager) *Service {
+	return &Service{
+		repo:        repo,
+		courierRepo: courierRepo,
+		txManager:   txManager,
+	}
+}
+
+func (s *Service) GetZone(ctx context.Context, id int64) (*zone.Zone, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *Service) ListZones(ctx context.Context) ([]zone.Zone, error) {
+	return s.repo.GetAll(ctx)
+}
+
+func (s *Service) CreateZone(ctx context.Context, zoneData zone.Zone) (int64, error) {
+	metrics.OpsCounter.Inc()
+
+	neighbors, err := s.checkZones(ctx, zoneData.Neighbors, zone.ErrUnknownNeighbor)
+	if err != nil {
+		return 0, err
+	}
+	zoneData.Neighbors = neighbors
+
+	return s.repo.Create(ctx, zoneData)
+}
+
+func (s *Service) UpdateZone(ctx context.Context, zoneData zone.Zone) error {
+	metrics.OpsCounter.Inc()
+
+	neighbors := make([]int64, 0, len(zoneData.Neighbors))
+	for _, id := range zoneData.Neighbors {
+		// зона не может быть соседом самой себе
+		if id != zoneData.ID {
+			neighbors = append(neighbors, id)
+		}
+	}
+
+	neighbors, err := s.checkZones(ctx, neighbors, zone.ErrUnknownNeighbor)
+	if err != nil {
+		return err
+	}
+	zoneData.Neighbors = neighbors
+
+	return s.repo.Update(ctx, zoneData)
+}
+
+func (s *Service) DeleteZone(ctx context.Context, id int64) error {
+	metrics.OpsCounter.Inc()
+
+	return s.txManager.Do(ctx, func(ctx context.Context) error {
+		return s.repo.Delete(ctx, id)
+	})
+}
+
+// Resolve возвращает зону, в многоугольник которой попадает точка.
+// Если зоны пересекаются, побеждает созданная раньше.
+func (s *Service) Resolve(ctx context.Context, point geo.Point) (*zone.Zone, error) {
+	candidates, err := s.repo.ListByPoint(ctx, point)
+	if err != nil {
+		return nil, fmt.Errorf("list zones by point: %w", err)
+	}
+
+	for i := range candidates {
+		if candidates[i].Polygon.Contains(point) {
+			return &candidates[i], nil
+		}
+	}
+
+	return nil, zone.ErrZoneNotFound
+}
+
+// SetCourierZones закрепляет курьера за зонами, заменяя прежний список
+func (s *Service) SetCourierZones(ctx context.Context, courierID int64, zoneIDs []int64) error {
+	metrics.OpsCounter.Inc()
+
+	if _, err := s.courierRepo.GetByID(ctx, courierID); err != nil {
+		return err
+	}
+
+	zoneIDs, err := s.checkZones(ctx, zoneIDs, zone.ErrZoneNotFound)
+	if err != nil {
+		return err
+	}
+
+	return s.txManager.Do(ctx, func(ctx context.Context) error {
+		return s.repo.SetCourierZones(ctx, courierID, zoneIDs)
+	})
+}
+
+func (s *Service) GetCourierZones(ctx context.Context, courierID int64) ([]int64, error) {
+	if _, err := s.courierRepo.GetByID(ctx, courierID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetCourierZoneIDs(ctx, courierID)
+}
+
+// checkZones убирает повторы и проверяет, что все зоны существуют
+func (s *Service) checkZones(ctx context.Context, ids []int64, notFound error) ([]int64, error) {
+	unique := make([]int64, 0, len(ids))
+	seen := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	if len(unique) == 0 {
+		return unique, nil
+	}
+
+	count, err := s.repo.CountByIDs(ctx, unique)
+	if err != nil {
+		return nil, fmt.Errorf("count zones: %w", err)
+	}
+	if count != len(unique) {
+		return nil, notFound
+	}
+
+	return unique, nil
+}
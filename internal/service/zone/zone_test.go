@@ -0,0 +1,196 @@
+package zone_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	modelCourier "service-courier/internal/model/courier"
+	model "service-courier/internal/model/zone"
+	"service-courier/internal/pkg/geo"
+	zoneService "service-courier/internal/service/zone"
+	"service-courier/internal/service/zone/mocks"
+)
+
+var square = geo.Polygon{{
+	{Lat: 0, Lon: 0},
+	{Lat: 0, Lon: 10},
+	{Lat: 10, Lon: 10},
+	{Lat: 10, Lon: 0},
+	{Lat: 0, Lon: 0},
+}}
+
+func newService(ctrl *gomock.Controller) (*zoneService.Service, *mocks.MockzoneRepository, *mocks.MockcourierRepository) {
+	repo := mocks.NewMockzoneRepository(ctrl)
+	courierRepo := mocks.NewMockcourierRepository(ctrl)
+	txManager := mocks.NewMocktransactionManager(ctrl)
+	txManager.EXPECT().
+		Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		}).
+		AnyTimes()
+
+	return zoneService.NewZoneService(repo, courierRepo, txManager), repo, courierRepo
+}
+
+func TestCreateZone_DeduplicatesNeighbors(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	service, repo, _ := newService(ctrl)
+
+	repo.EXPECT().CountByIDs(gomock.Any(), []int64{2, 3}).Return(2, nil)
+	repo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, z model.Zone) (int64, error) {
+			assert.Equal(t, []int64{2, 3}, z.Neighbors)
+			return 1, nil
+		})
+
+	id, err := service.CreateZone(context.Background(), model.Zone{Name: "center", Polygon: square, Neighbors: []int64{2, 3, 2}})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), id)
+}
+
+func TestCreateZone_UnknownNeighbor(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	service, repo, _ := newService(ctrl)
+
+	repo.EXPECT().CountByIDs(gomock.Any(), []int64{2, 3}).Return(1, nil)
+
+	_, err := service.CreateZone(context.Background(), model.Zone{Name: "center", Polygon: square, Neighbors: []int64{2, 3}})
+	assert.ErrorIs(t, err, model.ErrUnknownNeighbor)
+}
+
+func TestUpdateZone_DropsSelfNeighbor(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	service, repo, _ := newService(ctrl)
+
+	repo.EXPECT().
+		Update(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, z model.Zone) error {
+			assert.Empty(t, z.Neighbors)
+			return nil
+		})
+
+	require.NoError(t, service.UpdateZone(context.Background(), model.Zone{ID: 5, Name: "center", Polygon: square, Neighbors: []int64{5}}))
+}
+
+func TestResolve_ChecksExactPolygon(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	service, repo, _ := newService(ctrl)
+
+	// Треугольник, ограничивающий прямоугольник которого содержит точку, а сам он - нет
+	triangle := geo.Polygon{{
+		{Lat: 0, Lon: 0},
+		{Lat: 10, Lon: 0},
+		{Lat: 0, Lon: 10},
+		{Lat: 0, Lon: 0},
+	}}
+	point := geo.Point{Lat: 8, Lon: 8}
+
+	repo.EXPECT().ListByPoint(gomock.Any(), point).Return([]model.Zone{
+		{ID: 1, Name: "triangle", Polygon: triangle},
+		{ID: 2, Name: "square", Polygon: square},
+	}, nil)
+
+	z, err := service.Resolve(context.Background(), point)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), z.ID)
+}
+
+func TestResolve_NotFound(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	service, repo, _ := newService(ctrl)
+
+	repo.EXPECT().ListByPoint(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	_, err := service.Resolve(context.Background(), geo.Point{Lat: 50, Lon: 50})
+	assert.ErrorIs(t, err, model.ErrZoneNotFound)
+}
+
+func TestImportZones_ResolvesNeighborNames(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	service, repo, _ := newService(ctrl)
+
+	zones := []model.Zone{
+		{Name: "center", Polygon: square},
+		{Name: "north", Polygon: square},
+	}
+
+	repo.EXPECT().UpsertByName(gomock.Any(), zones[0]).Return(int64(1), nil)
+	repo.EXPECT().UpsertByName(gomock.Any(), zones[1]).Return(int64(2), nil)
+	repo.EXPECT().GetAll(gomock.Any()).Return([]model.Zone{
+		{ID: 1, Name: "center"},
+		{ID: 2, Name: "north"},
+		{ID: 3, Name: "south"},
+	}, nil)
+	repo.EXPECT().SetNeighbors(gomock.Any(), int64(1), []int64{2, 3}).Return(nil)
+	repo.EXPECT().SetNeighbors(gomock.Any(), int64(2), []int64{1}).Return(nil)
+
+	ids, err := service.ImportZones(context.Background(), zones, map[string][]string{
+		"center": {"north", "south"},
+		"north":  {"center"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, ids)
+}
+
+func TestImportZones_UnknownNeighbor(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	service, repo, _ := newService(ctrl)
+
+	zones := []model.Zone{{Name: "center", Polygon: square}}
+
+	repo.EXPECT().UpsertByName(gomock.Any(), zones[0]).Return(int64(1), nil)
+	repo.EXPECT().GetAll(gomock.Any()).Return([]model.Zone{{ID: 1, Name: "center"}}, nil)
+
+	_, err := service.ImportZones(context.Background(), zones, map[string][]string{
+		"center": {"nowhere"},
+	})
+	assert.ErrorIs(t, err, model.ErrUnknownNeighbor)
+}
+
+func TestSetCourierZones_CourierNotFound(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	service, _, courierRepo := newService(ctrl)
+
+	courierRepo.EXPECT().GetByID(gomock.Any(), int64(7)).Return(nil, modelCourier.ErrCourierNotFound)
+
+	err := service.SetCourierZones(context.Background(), 7, []int64{1})
+	assert.ErrorIs(t, err, modelCourier.ErrCourierNotFound)
+}
+
+func TestSetCourierZones_UnknownZone(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	service, repo, courierRepo := newService(ctrl)
+
+	courierRepo.EXPECT().GetByID(gomock.Any(), int64(7)).Return(&modelCourier.Courier{ID: 7}, nil)
+	repo.EXPECT().CountByIDs(gomock.Any(), []int64{1, 2}).Return(1, nil)
+
+	err := service.SetCourierZones(context.Background(), 7, []int64{1, 2})
+	assert.ErrorIs(t, err, model.ErrZoneNotFound)
+}
+
+func TestSetCourierZones_Success(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	service, repo, courierRepo := newService(ctrl)
+
+	courierRepo.EXPECT().GetByID(gomock.Any(), int64(7)).Return(&modelCourier.Courier{ID: 7}, nil)
+	repo.EXPECT().CountByIDs(gomock.Any(), []int64{1}).Return(1, nil)
+	repo.EXPECT().SetCourierZones(gomock.Any(), int64(7), []int64{1}).Return(nil)
+
+	require.NoError(t, service.SetCourierZones(context.Background(), 7, []int64{1, 1}))
+}
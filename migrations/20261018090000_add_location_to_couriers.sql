@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE couriers
+    ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS location_updated_at TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE couriers
+    DROP COLUMN IF EXISTS location_updated_at,
+    DROP COLUMN IF EXISTS longitude,
+    DROP COLUMN IF EXISTS latitude;
+-- +goose StatementEnd
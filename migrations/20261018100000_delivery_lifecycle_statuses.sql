@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+UPDATE delivery SET status = 'assigned' WHERE status = 'active';
+
+ALTER TABLE delivery
+    ALTER COLUMN status SET DEFAULT 'assigned';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE delivery
+    ALTER COLUMN status SET DEFAULT 'active';
+
+UPDATE delivery SET status = 'active'
+WHERE status IN ('assigned', 'accepted', 'picked_up', 'in_transit', 'failed');
+
+UPDATE delivery SET status = 'completed'
+WHERE status IN ('delivered', 'returned');
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS delivery_events (
+    id                  BIGSERIAL PRIMARY KEY,
+    delivery_id         BIGINT NOT NULL,
+    order_id            VARCHAR(255) NOT NULL,
+    courier_id          BIGINT NOT NULL,
+    from_status         VARCHAR(50),
+    to_status           VARCHAR(50) NOT NULL,
+    actor               VARCHAR(50) NOT NULL,
+    reason              TEXT NOT NULL DEFAULT '',
+    created_at          TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_delivery_events_order_id
+ON delivery_events (order_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_delivery_events_order_id;
+DROP TABLE IF EXISTS delivery_events;
+-- +goose StatementEnd
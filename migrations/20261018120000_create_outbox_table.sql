@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS outbox (
+    id                  BIGSERIAL PRIMARY KEY,
+    topic               VARCHAR(255) NOT NULL,
+    key                 VARCHAR(255) NOT NULL,
+    payload             BYTEA NOT NULL,
+    created_at          TIMESTAMP NOT NULL DEFAULT NOW(),
+    published_at        TIMESTAMP DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_unpublished
+ON outbox (id)
+WHERE published_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_outbox_unpublished;
+DROP TABLE IF EXISTS outbox;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS inbox (
+    topic               VARCHAR(255) NOT NULL,
+    kafka_partition     INTEGER NOT NULL,
+    kafka_offset        BIGINT NOT NULL,
+    processed_at        TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (topic, kafka_partition, kafka_offset)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS inbox;
+-- +goose StatementEnd
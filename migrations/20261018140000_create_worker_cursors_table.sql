@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS worker_cursors (
+    name                VARCHAR(100) PRIMARY KEY,
+    position            TIMESTAMP NOT NULL,
+    updated_at          TIMESTAMP NOT NULL DEFAULT NOW()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS worker_cursors;
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS courier_shifts (
+    id                  BIGSERIAL PRIMARY KEY,
+    courier_id          BIGINT NOT NULL REFERENCES couriers(id) ON DELETE CASCADE,
+    starts_at           TIMESTAMP NOT NULL,
+    ends_at             TIMESTAMP NOT NULL,
+    zone                VARCHAR(100) NOT NULL DEFAULT '',
+    started_at          TIMESTAMP DEFAULT NULL,
+    finished_at         TIMESTAMP DEFAULT NULL,
+    created_at          TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at          TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT courier_shifts_period_check CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_courier_shifts_courier_period
+ON courier_shifts (courier_id, starts_at, ends_at);
+
+CREATE INDEX IF NOT EXISTS idx_courier_shifts_pending_start
+ON courier_shifts (starts_at)
+WHERE started_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_courier_shifts_pending_finish
+ON courier_shifts (ends_at)
+WHERE finished_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_courier_shifts_pending_finish;
+DROP INDEX IF EXISTS idx_courier_shifts_pending_start;
+DROP INDEX IF EXISTS idx_courier_shifts_courier_period;
+DROP TABLE IF EXISTS courier_shifts;
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS zones (
+    id                  BIGSERIAL PRIMARY KEY,
+    name                VARCHAR(100) NOT NULL UNIQUE,
+    city                VARCHAR(100) NOT NULL DEFAULT '',
+    polygon             JSONB NOT NULL,
+    min_lat             DOUBLE PRECISION NOT NULL,
+    min_lon             DOUBLE PRECISION NOT NULL,
+    max_lat             DOUBLE PRECISION NOT NULL,
+    max_lon             DOUBLE PRECISION NOT NULL,
+    neighbor_ids        BIGINT[] NOT NULL DEFAULT '{}',
+    created_at          TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at          TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_zones_bounds
+ON zones (min_lat, max_lat, min_lon, max_lon);
+
+CREATE TABLE IF NOT EXISTS courier_zones (
+    courier_id          BIGINT NOT NULL REFERENCES couriers(id) ON DELETE CASCADE,
+    zone_id             BIGINT NOT NULL REFERENCES zones(id) ON DELETE CASCADE,
+    PRIMARY KEY (courier_id, zone_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_courier_zones_zone
+ON courier_zones (zone_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_courier_zones_zone;
+DROP TABLE IF EXISTS courier_zones;
+DROP INDEX IF EXISTS idx_zones_bounds;
+DROP TABLE IF EXISTS zones;
+-- +goose StatementEnd
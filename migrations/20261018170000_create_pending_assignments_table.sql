@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS pending_assignments (
+    id                  BIGSERIAL PRIMARY KEY,
+    order_id            VARCHAR(255) NOT NULL UNIQUE,
+    priority            INTEGER NOT NULL DEFAULT 0,
+    dest_lat            DOUBLE PRECISION DEFAULT NULL,
+    dest_lon            DOUBLE PRECISION DEFAULT NULL,
+    attempts            INTEGER NOT NULL DEFAULT 0,
+    last_error          TEXT NOT NULL DEFAULT '',
+    created_at          TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at          TIMESTAMP NOT NULL,
+    last_attempt_at     TIMESTAMP DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_pending_assignments_queue
+ON pending_assignments (priority DESC, created_at, id);
+
+CREATE INDEX IF NOT EXISTS idx_pending_assignments_expires_at
+ON pending_assignments (expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_pending_assignments_expires_at;
+DROP INDEX IF EXISTS idx_pending_assignments_queue;
+DROP TABLE IF EXISTS pending_assignments;
+-- +goose StatementEnd
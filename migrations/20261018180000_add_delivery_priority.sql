@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE delivery ADD COLUMN IF NOT EXISTS priority VARCHAR(20) NOT NULL DEFAULT 'standard';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE delivery DROP COLUMN IF EXISTS priority;
+-- +goose StatementEnd
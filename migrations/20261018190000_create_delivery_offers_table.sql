@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS delivery_offers (
+    id                  BIGSERIAL PRIMARY KEY,
+    delivery_id         BIGINT NOT NULL,
+    order_id            VARCHAR(255) NOT NULL,
+    courier_id          BIGINT NOT NULL,
+    outcome             VARCHAR(20) NOT NULL DEFAULT 'pending',
+    offered_at          TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at          TIMESTAMP NOT NULL,
+    responded_at        TIMESTAMP DEFAULT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_delivery_offers_order_id
+ON delivery_offers (order_id);
+
+CREATE INDEX IF NOT EXISTS idx_delivery_offers_courier_id
+ON delivery_offers (courier_id);
+
+CREATE INDEX IF NOT EXISTS idx_delivery_offers_pending_expires_at
+ON delivery_offers (expires_at)
+WHERE outcome = 'pending';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_delivery_offers_pending_expires_at;
+DROP INDEX IF EXISTS idx_delivery_offers_courier_id;
+DROP INDEX IF EXISTS idx_delivery_offers_order_id;
+DROP TABLE IF EXISTS delivery_offers;
+-- +goose StatementEnd
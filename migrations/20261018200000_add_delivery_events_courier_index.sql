@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_delivery_events_courier_id
+ON delivery_events (courier_id, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_delivery_events_courier_id;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_delivery_assigned_at
+ON delivery (assigned_at, id);
+
+CREATE INDEX IF NOT EXISTS idx_delivery_courier_id_assigned_at
+ON delivery (courier_id, assigned_at, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_delivery_courier_id_assigned_at;
+DROP INDEX IF EXISTS idx_delivery_assigned_at;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_couriers_name
+ON couriers (name, id);
+
+CREATE INDEX IF NOT EXISTS idx_couriers_status
+ON couriers (status);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_couriers_status;
+DROP INDEX IF EXISTS idx_couriers_name;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE couriers
+ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE couriers
+DROP COLUMN IF EXISTS version;
+-- +goose StatementEnd
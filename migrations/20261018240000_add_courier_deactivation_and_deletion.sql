@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE couriers
+ADD COLUMN IF NOT EXISTS deactivated_at TIMESTAMP DEFAULT NULL,
+ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP DEFAULT NULL,
+ADD COLUMN IF NOT EXISTS erased_at TIMESTAMP DEFAULT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE couriers
+DROP COLUMN IF EXISTS erased_at,
+DROP COLUMN IF EXISTS deleted_at,
+DROP COLUMN IF EXISTS deactivated_at;
+-- +goose StatementEnd
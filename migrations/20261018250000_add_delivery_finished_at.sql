@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE delivery
+ADD COLUMN IF NOT EXISTS finished_at TIMESTAMP DEFAULT NULL;
+
+-- время завершения уже закрытых доставок берется из истории, для снятых без истории - время снятия
+UPDATE delivery d
+SET finished_at = COALESCE((
+    SELECT MAX(e.created_at)
+    FROM delivery_events e
+    WHERE e.delivery_id = d.id AND e.to_status = d.status
+), d.deleted_at)
+WHERE d.finished_at IS NULL
+  AND d.status IN ('declined', 'delivered', 'returned', 'completed', 'deleted');
+
+CREATE INDEX IF NOT EXISTS idx_delivery_finished_at
+ON delivery (finished_at)
+WHERE finished_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_delivery_finished_at;
+ALTER TABLE delivery
+DROP COLUMN IF EXISTS finished_at;
+-- +goose StatementEnd
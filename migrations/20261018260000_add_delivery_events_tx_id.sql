@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- id событий выдается до коммита, поэтому события становятся видны не по порядку id.
+-- По номеру транзакции подписка отличает события, которые уже не могут появиться позади курсора.
+ALTER TABLE delivery_events
+ADD COLUMN IF NOT EXISTS tx_id XID8 NOT NULL DEFAULT pg_current_xact_id();
+
+CREATE INDEX IF NOT EXISTS idx_delivery_events_tx_id
+ON delivery_events (tx_id, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_delivery_events_tx_id;
+ALTER TABLE delivery_events
+DROP COLUMN IF EXISTS tx_id;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_outbox_published
+ON outbox (published_at)
+WHERE published_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_outbox_published;
+-- +goose StatementEnd
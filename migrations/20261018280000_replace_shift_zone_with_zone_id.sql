@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE courier_shifts
+ADD COLUMN IF NOT EXISTS zone_id BIGINT DEFAULT NULL REFERENCES zones(id) ON DELETE SET NULL;
+
+-- Свободный текст переносится только если совпал с именем существующей зоны
+UPDATE courier_shifts s
+SET zone_id = z.id
+FROM zones z
+WHERE z.name = s.zone;
+
+ALTER TABLE courier_shifts DROP COLUMN IF EXISTS zone;
+
+CREATE INDEX IF NOT EXISTS idx_courier_shifts_zone
+ON courier_shifts (zone_id)
+WHERE zone_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE courier_shifts
+ADD COLUMN IF NOT EXISTS zone VARCHAR(100) NOT NULL DEFAULT '';
+
+UPDATE courier_shifts s
+SET zone = z.name
+FROM zones z
+WHERE z.id = s.zone_id;
+
+DROP INDEX IF EXISTS idx_courier_shifts_zone;
+ALTER TABLE courier_shifts DROP COLUMN IF EXISTS zone_id;
+-- +goose StatementEnd